@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/event/pgeventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	allowListFlagList    = "list"
+	allowListFlagAddress = "address"
+)
+
+var (
+	allowListListFlag = cli.StringFlag{
+		Name:     allowListFlagList,
+		Aliases:  []string{"l"},
+		Usage:    fmt.Sprintf("Allow-list to manage: %s or %s", pool.AllowListSenders, pool.AllowListDeployers),
+		Required: true,
+	}
+	allowListAddressFlag = cli.StringSliceFlag{
+		Name:     allowListFlagAddress,
+		Aliases:  []string{"a"},
+		Usage:    "Addresses to add or remove, can be repeated or comma separated",
+		Required: true,
+	}
+)
+
+var allowListSubcommands = []*cli.Command{
+	{
+		Name:   "show",
+		Usage:  "Shows the addresses of the allow-list",
+		Action: showAllowList,
+		Flags:  []cli.Flag{&configFileFlag, &allowListListFlag},
+	},
+	{
+		Name:   "add",
+		Usage:  "Adds addresses to the allow-list",
+		Action: addToAllowList,
+		Flags:  []cli.Flag{&configFileFlag, &allowListListFlag, &allowListAddressFlag},
+	},
+	{
+		Name:   "remove",
+		Usage:  "Removes addresses from the allow-list",
+		Action: removeFromAllowList,
+		Flags:  []cli.Flag{&configFileFlag, &allowListListFlag, &allowListAddressFlag},
+	},
+}
+
+func showAllowList(cliCtx *cli.Context) error {
+	_, storage, kind, err := loadAllowListArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	addresses, err := storage.GetAllowListAddresses(cliCtx.Context, kind)
+	if err != nil {
+		return err
+	}
+	for _, address := range addresses {
+		fmt.Println(address.String())
+	}
+	return nil
+}
+
+func addToAllowList(cliCtx *cli.Context) error {
+	c, storage, kind, err := loadAllowListArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+	addresses, err := parseAllowListAddresses(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.AddAllowListAddresses(cliCtx.Context, kind, addresses); err != nil {
+		return err
+	}
+	return logAllowListUpdated(cliCtx.Context, c, fmt.Sprintf("added to %s allow-list: %s", kind, strings.Join(cliCtx.StringSlice(allowListFlagAddress), ",")))
+}
+
+func removeFromAllowList(cliCtx *cli.Context) error {
+	c, storage, kind, err := loadAllowListArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+	addresses, err := parseAllowListAddresses(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.DeleteAllowListAddresses(cliCtx.Context, kind, addresses); err != nil {
+		return err
+	}
+	return logAllowListUpdated(cliCtx.Context, c, fmt.Sprintf("removed from %s allow-list: %s", kind, strings.Join(cliCtx.StringSlice(allowListFlagAddress), ",")))
+}
+
+func loadAllowListArgs(cliCtx *cli.Context) (*config.Config, *pgpoolstorage.PostgresPoolStorage, pool.AllowListKind, error) {
+	c, err := config.Load(cliCtx, false)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	setupLog(c.Log)
+
+	kind, err := pool.ParseAllowListKind(cliCtx.String(allowListFlagList))
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	// the memory pool storage lives inside the node process
+	if c.Pool.Storage == pool.StorageMemory {
+		return nil, nil, "", fmt.Errorf("the allow-lists can not be updated from the CLI when the pool storage is %s", pool.StorageMemory)
+	}
+
+	runPoolMigrations(c.Pool.DB)
+	storage, err := pgpoolstorage.NewPostgresPoolStorage(c.Pool.DB)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return c, storage, kind, nil
+}
+
+func parseAllowListAddresses(cliCtx *cli.Context) ([]common.Address, error) {
+	addresses := []common.Address{}
+	for _, addr := range cliCtx.StringSlice(allowListFlagAddress) {
+		for _, a := range strings.Split(addr, ",") {
+			a = strings.TrimSpace(a)
+			if !common.IsHexAddress(a) {
+				return nil, fmt.Errorf("invalid address: %s", a)
+			}
+			addresses = append(addresses, common.HexToAddress(a))
+		}
+	}
+	return addresses, nil
+}
+
+// logAllowListUpdated stores the event of the allow-list update, the pool
+// instances load the changes in the next refresh of the allow-lists
+func logAllowListUpdated(ctx context.Context, c *config.Config, description string) error {
+	var eventStorage event.Storage
+	var err error
+	if c.EventLog.DB.Name != "" {
+		eventStorage, err = pgeventstorage.NewPostgresEventStorage(c.EventLog.DB)
+	} else {
+		eventStorage, err = nileventstorage.NewNilEventStorage()
+	}
+	if err != nil {
+		return err
+	}
+
+	return event.NewEventLog(c.EventLog, eventStorage).LogEvent(ctx, &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Pool,
+		Level:       event.Level_Notice,
+		EventID:     event.EventID_AllowListUpdated,
+		Description: description,
+	})
+}
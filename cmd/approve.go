@@ -36,16 +36,11 @@ func approveTokens(ctx *cli.Context) error {
 	}
 
 	if !ctx.Bool(config.FlagYes) {
-		fmt.Print("*WARNING* Are you sure you want to approve ", amount.String(),
-			" tokens (in wei) for the smc <Name: PoE. Address: "+c.NetworkConfig.L1Config.ZkEVMAddr.String()+">? [y/N]: ")
-		var input string
-		if _, err := fmt.Scanln(&input); err != nil {
+		confirmed, err := askForConfirmation("*WARNING* Are you sure you want to approve " + amount.String() +
+			" tokens (in wei) for the smc <Name: PoE. Address: " + c.NetworkConfig.L1Config.ZkEVMAddr.String() + ">?")
+		if err != nil || !confirmed {
 			return err
 		}
-		input = strings.ToLower(input)
-		if !(input == "y" || input == "yes") {
-			return nil
-		}
 	}
 
 	setupLog(c.Log)
@@ -68,23 +63,39 @@ func approveTokens(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	printL1TxStatus(c.NetworkConfig.L1Config.L1ChainID, tx.Hash())
+	return nil
+}
+
+// askForConfirmation asks the user to confirm the action described by message
+func askForConfirmation(message string) (bool, error) {
+	fmt.Print(message + " [y/N]: ")
+	var input string
+	if _, err := fmt.Scanln(&input); err != nil {
+		return false, err
+	}
+	input = strings.ToLower(input)
+	return input == "y" || input == "yes", nil
+}
+
+// printL1TxStatus prints where to check the status of the L1 tx
+func printL1TxStatus(l1ChainID uint64, txHash common.Hash) {
 	const (
 		mainnet = 1
 		rinkeby = 4
 		goerli  = 5
 		local   = 1337
 	)
-	switch c.NetworkConfig.L1Config.L1ChainID {
+	switch l1ChainID {
 	case mainnet:
-		fmt.Println("Check tx status: https://etherscan.io/tx/" + tx.Hash().String())
+		fmt.Println("Check tx status: https://etherscan.io/tx/" + txHash.String())
 	case rinkeby:
-		fmt.Println("Check tx status: https://rinkeby.etherscan.io/tx/" + tx.Hash().String())
+		fmt.Println("Check tx status: https://rinkeby.etherscan.io/tx/" + txHash.String())
 	case goerli:
-		fmt.Println("Check tx status: https://goerli.etherscan.io/tx/" + tx.Hash().String())
+		fmt.Println("Check tx status: https://goerli.etherscan.io/tx/" + txHash.String())
 	case local:
-		fmt.Println("Local network. Tx Hash: " + tx.Hash().String())
+		fmt.Println("Local network. Tx Hash: " + txHash.String())
 	default:
-		fmt.Println("Unknown network. Tx Hash: " + tx.Hash().String())
+		fmt.Println("Unknown network. Tx Hash: " + txHash.String())
 	}
-	return nil
 }
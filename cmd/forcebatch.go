@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	forceBatchFlagTx        = "tx"
+	forceBatchFlagNumber    = "number"
+	forceBatchFlagFromBlock = "from-block"
+	forceBatchFlagTimeout   = "timeout"
+)
+
+var (
+	forceBatchKeyStorePathFlag = cli.StringFlag{
+		Name:     config.FlagKeyStorePath,
+		Usage:    "the path of the key store file containing the private key of the account going to sign the L1 txs",
+		Required: true,
+	}
+	forceBatchPasswordFlag = cli.StringFlag{
+		Name:     config.FlagPassword,
+		Aliases:  []string{"pw"},
+		Usage:    "the password do decrypt the key store file",
+		Required: true,
+	}
+	forceBatchTxFlag = cli.StringSliceFlag{
+		Name:     forceBatchFlagTx,
+		Usage:    "Signed L2 txs encoded in hex, as returned by eth_signTransaction, can be repeated or comma separated",
+		Required: true,
+	}
+	forceBatchNumberFlag = cli.Uint64Flag{
+		Name:     forceBatchFlagNumber,
+		Aliases:  []string{"n"},
+		Usage:    "Forced batch number",
+		Required: true,
+	}
+	forceBatchFromBlockFlag = cli.Uint64Flag{
+		Name:  forceBatchFlagFromBlock,
+		Usage: "L1 block number from which the ForceBatch events are looked for, by default the genesis block number of the network",
+	}
+	forceBatchTimeoutFlag = cli.DurationFlag{
+		Name:  forceBatchFlagTimeout,
+		Usage: "Maximum time to wait for the L1 txs to be mined",
+		Value: 5 * time.Minute, //nolint:gomnd
+	}
+)
+
+var forceBatchSubcommands = []*cli.Command{
+	{
+		Name:   "send",
+		Usage:  "Sends a forced batch with the L2 txs to the rollup smc, approving the pol fee if needed",
+		Action: sendForcedBatch,
+		Flags: []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &yesFlag, &forceBatchKeyStorePathFlag, &forceBatchPasswordFlag,
+			&forceBatchTxFlag, &forceBatchTimeoutFlag},
+	},
+	{
+		Name:   "status",
+		Usage:  "Shows if a forced batch has been sequenced and when anyone can sequence it",
+		Action: showForcedBatchStatus,
+		Flags:  []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &forceBatchNumberFlag, &forceBatchFromBlockFlag},
+	},
+	{
+		Name:    "sequence",
+		Aliases: []string{"sequenceforcebatch"},
+		Usage:   "Sequences the pending forced batches up to the forced batch number, once its force batch timeout has elapsed",
+		Action:  sequenceForcedBatches,
+		Flags: []cli.Flag{&configFileFlag, &networkFlag, &customNetworkFlag, &yesFlag, &forceBatchKeyStorePathFlag, &forceBatchPasswordFlag,
+			&forceBatchNumberFlag, &forceBatchFromBlockFlag, &forceBatchTimeoutFlag},
+	},
+}
+
+func sendForcedBatch(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	c, ethMan, err := loadForceBatchArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+	auth, err := ethMan.LoadAuthFromKeyStore(cliCtx.String(config.FlagKeyStorePath), cliCtx.String(config.FlagPassword))
+	if err != nil {
+		return err
+	}
+
+	forceBatchAddress, err := ethMan.GetForceBatchAddress()
+	if err != nil {
+		return err
+	}
+	if forceBatchAddress != (common.Address{}) && forceBatchAddress != auth.From {
+		return fmt.Errorf("only %s is allowed to send forced batches", forceBatchAddress.String())
+	}
+
+	l2ChainID, err := ethMan.GetL2ChainID()
+	if err != nil {
+		return err
+	}
+	forcedBatch, err := decodeForcedBatchTxs(cliCtx.StringSlice(forceBatchFlagTx), l2ChainID)
+	if err != nil {
+		return err
+	}
+	txs, err := state.EncodeForcedBatchV2(forcedBatch)
+	if err != nil {
+		return err
+	}
+
+	fee, err := ethMan.GetForcedBatchFee()
+	if err != nil {
+		return err
+	}
+	allowance, err := ethMan.GetPolAllowance(auth.From)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(fee) < 0 {
+		if !cliCtx.Bool(config.FlagYes) {
+			confirmed, err := askForConfirmation("*WARNING* Are you sure you want to approve " + fee.String() +
+				" tokens (in wei) for the smc <Name: PoE. Address: " + c.NetworkConfig.L1Config.ZkEVMAddr.String() + "> to pay the forced batch fee?")
+			if err != nil || !confirmed {
+				return err
+			}
+		}
+		tx, err := ethMan.ApprovePol(ctx, auth.From, fee, c.NetworkConfig.L1Config.ZkEVMAddr)
+		if err != nil {
+			return err
+		}
+		printL1TxStatus(c.NetworkConfig.L1Config.L1ChainID, tx.Hash())
+		if _, err := waitForceBatchTxToBeMined(cliCtx, ethMan, tx); err != nil {
+			return err
+		}
+	}
+
+	if !cliCtx.Bool(config.FlagYes) {
+		confirmed, err := askForConfirmation(fmt.Sprintf("*WARNING* Are you sure you want to force a batch with %d txs paying a fee of %s tokens (in wei)?",
+			len(forcedBatch.Transactions), fee.String()))
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+	tx, err := ethMan.ForceBatch(ctx, auth.From, txs, fee)
+	if err != nil {
+		return err
+	}
+	printL1TxStatus(c.NetworkConfig.L1Config.L1ChainID, tx.Hash())
+	receipt, err := waitForceBatchTxToBeMined(cliCtx, ethMan, tx)
+	if err != nil {
+		return err
+	}
+
+	forcedBatchNumber, err := ethMan.ParseForcedBatchNumber(receipt)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("forced batch number: %d\n", forcedBatchNumber)
+	block, err := ethMan.EthBlockByNumber(ctx, receipt.BlockNumber.Uint64())
+	if err != nil {
+		return err
+	}
+	return printForceBatchTimeout(ethMan, time.Unix(int64(block.Time()), 0))
+}
+
+func showForcedBatchStatus(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	c, ethMan, err := loadForceBatchArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	forcedBatchNumber := cliCtx.Uint64(forceBatchFlagNumber)
+	lastForcedBatch, lastSequencedForcedBatch, err := ethMan.GetLastForcedBatchNumbers()
+	if err != nil {
+		return err
+	}
+	if forcedBatchNumber == 0 || forcedBatchNumber > lastForcedBatch {
+		return fmt.Errorf("forced batch %d not found, last forced batch: %d", forcedBatchNumber, lastForcedBatch)
+	}
+	forcedBatch, err := ethMan.GetForcedBatch(ctx, forcedBatchNumber, forceBatchFromBlock(cliCtx, c))
+	if err != nil {
+		return fmt.Errorf("failed to get forced batch %d: %w", forcedBatchNumber, err)
+	}
+
+	fmt.Printf("forced batch number: %d\n", forcedBatch.ForcedBatchNumber)
+	fmt.Printf("L1 block number: %d\n", forcedBatch.BlockNumber)
+	fmt.Printf("sender: %s\n", forcedBatch.Sequencer.String())
+	fmt.Printf("global exit root: %s\n", forcedBatch.GlobalExitRoot.String())
+	fmt.Printf("forced at: %v\n", forcedBatch.ForcedAt)
+	fmt.Printf("sequenced: %v\n", forcedBatchNumber <= lastSequencedForcedBatch)
+	if forcedBatchNumber <= lastSequencedForcedBatch {
+		return nil
+	}
+	return printForceBatchTimeout(ethMan, forcedBatch.ForcedAt)
+}
+
+func sequenceForcedBatches(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	c, ethMan, err := loadForceBatchArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+	auth, err := ethMan.LoadAuthFromKeyStore(cliCtx.String(config.FlagKeyStorePath), cliCtx.String(config.FlagPassword))
+	if err != nil {
+		return err
+	}
+
+	forcedBatchNumber := cliCtx.Uint64(forceBatchFlagNumber)
+	lastForcedBatch, lastSequencedForcedBatch, err := ethMan.GetLastForcedBatchNumbers()
+	if err != nil {
+		return err
+	}
+	if forcedBatchNumber == 0 || forcedBatchNumber > lastForcedBatch {
+		return fmt.Errorf("forced batch %d not found, last forced batch: %d", forcedBatchNumber, lastForcedBatch)
+	}
+	if forcedBatchNumber <= lastSequencedForcedBatch {
+		return fmt.Errorf("forced batch %d already sequenced, last sequenced forced batch: %d", forcedBatchNumber, lastSequencedForcedBatch)
+	}
+
+	// The forced batches must be sequenced in order, so the previous pending ones are sequenced too
+	fromBlock := forceBatchFromBlock(cliCtx, c)
+	forcedBatches := make([]etherman.ForcedBatch, 0, forcedBatchNumber-lastSequencedForcedBatch)
+	for number := lastSequencedForcedBatch + 1; number <= forcedBatchNumber; number++ {
+		forcedBatch, err := ethMan.GetForcedBatch(ctx, number, fromBlock)
+		if err != nil {
+			return fmt.Errorf("failed to get forced batch %d: %w", number, err)
+		}
+		if err := checkForcedBatchTxs(ctx, ethMan, forcedBatch); err != nil {
+			return err
+		}
+		forcedBatches = append(forcedBatches, *forcedBatch)
+		fromBlock = forcedBatch.BlockNumber
+	}
+
+	timeout, err := ethMan.GetForceBatchTimeout()
+	if err != nil {
+		return err
+	}
+	latestBlockTimestamp, err := ethMan.GetLatestBlockTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+	sequenceableAt := forcedBatches[len(forcedBatches)-1].ForcedAt.Add(time.Duration(timeout) * time.Second)
+	if lastBlockTime := time.Unix(int64(latestBlockTimestamp), 0); lastBlockTime.Before(sequenceableAt) {
+		return fmt.Errorf("force batch timeout not elapsed, forced batch %d can be sequenced by anyone from %v (%v left)",
+			forcedBatchNumber, sequenceableAt, sequenceableAt.Sub(lastBlockTime))
+	}
+
+	if !cliCtx.Bool(config.FlagYes) {
+		confirmed, err := askForConfirmation(fmt.Sprintf("*WARNING* Are you sure you want to sequence the forced batches %d to %d?",
+			lastSequencedForcedBatch+1, forcedBatchNumber))
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+	tx, err := ethMan.SequenceForceBatches(ctx, auth.From, forcedBatches)
+	if err != nil {
+		return err
+	}
+	printL1TxStatus(c.NetworkConfig.L1Config.L1ChainID, tx.Hash())
+	if _, err := waitForceBatchTxToBeMined(cliCtx, ethMan, tx); err != nil {
+		return err
+	}
+	fmt.Printf("forced batches %d to %d sequenced\n", lastSequencedForcedBatch+1, forcedBatchNumber)
+	return nil
+}
+
+func loadForceBatchArgs(cliCtx *cli.Context) (*config.Config, *etherman.Client, error) {
+	c, err := config.Load(cliCtx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	setupLog(c.Log)
+
+	ethMan, err := newEtherman(*c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	forkID, err := ethMan.GetRollupForkID()
+	if err != nil {
+		return nil, nil, err
+	}
+	if forkID < state.FORKID_ETROG {
+		return nil, nil, fmt.Errorf("forced batches are only supported from fork id %d, current fork id: %d", state.FORKID_ETROG, forkID)
+	}
+	return c, ethMan, nil
+}
+
+// decodeForcedBatchTxs decodes the signed L2 txs of a forced batch, checking that they can be executed by the L2 network
+func decodeForcedBatchTxs(encodedTxs []string, l2ChainID uint64) (*state.ForcedBatchRawV2, error) {
+	if len(encodedTxs) == 0 {
+		return nil, errors.New("a forced batch needs at least one tx")
+	}
+	forcedBatch := &state.ForcedBatchRawV2{}
+	for i, encodedTx := range encodedTxs {
+		txBytes, err := hex.DecodeHex(encodedTx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx %d: %w", i, err)
+		}
+		tx := types.Transaction{}
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			return nil, fmt.Errorf("invalid tx %d: %w", i, err)
+		}
+		if tx.Type() != types.LegacyTxType {
+			return nil, fmt.Errorf("invalid tx %d: only legacy txs are supported, tx type: %d", i, tx.Type())
+		}
+		if tx.Protected() && tx.ChainId().Uint64() != l2ChainID {
+			return nil, fmt.Errorf("invalid tx %d: chain id %d, expected L2 chain id %d", i, tx.ChainId().Uint64(), l2ChainID)
+		}
+		forcedBatch.Transactions = append(forcedBatch.Transactions, state.L2TxRaw{
+			Tx:                   tx,
+			EfficiencyPercentage: state.MaxEffectivePercentage,
+		})
+	}
+	return forcedBatch, nil
+}
+
+func forceBatchFromBlock(cliCtx *cli.Context, c *config.Config) uint64 {
+	if cliCtx.IsSet(forceBatchFlagFromBlock) {
+		return cliCtx.Uint64(forceBatchFlagFromBlock)
+	}
+	return c.NetworkConfig.Genesis.BlockNumber
+}
+
+// waitForceBatchTxToBeMined waits for the L1 tx to be mined, failing if it's reverted
+func waitForceBatchTxToBeMined(cliCtx *cli.Context, ethMan *etherman.Client, tx *types.Transaction) (*types.Receipt, error) {
+	ctx := cliCtx.Context
+	fmt.Println("waiting for the tx to be mined...")
+	mined, err := ethMan.WaitTxToBeMined(ctx, tx, cliCtx.Duration(forceBatchFlagTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !mined {
+		return nil, fmt.Errorf("tx %s not mined after %v", tx.Hash().String(), cliCtx.Duration(forceBatchFlagTimeout))
+	}
+	receipt, err := ethMan.GetTxReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		revertMessage, _ := ethMan.GetRevertMessage(ctx, tx)
+		return nil, fmt.Errorf("tx %s reverted: %s", tx.Hash().String(), revertMessage)
+	}
+	return receipt, nil
+}
+
+// printForceBatchTimeout prints when anyone can sequence a forced batch forced at forcedAt
+func printForceBatchTimeout(ethMan *etherman.Client, forcedAt time.Time) error {
+	timeout, err := ethMan.GetForceBatchTimeout()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("can be sequenced by anyone from: %v\n", forcedAt.Add(time.Duration(timeout)*time.Second))
+	return nil
+}
+
+// checkForcedBatchTxs returns an error if the forced batch was sent through a contract and its ForceBatch event has no
+// txs, as they are read from the event instead of from the L1 tx in that case
+func checkForcedBatchTxs(ctx context.Context, ethMan *etherman.Client, forcedBatch *etherman.ForcedBatch) error {
+	if len(forcedBatch.RawTxsData) > 0 {
+		return nil
+	}
+	code, err := ethMan.EthClient.CodeAt(ctx, forcedBatch.Sequencer, nil)
+	if err != nil {
+		return err
+	}
+	if len(code) > 0 {
+		return fmt.Errorf("forced batch %d was sent through the contract %s and its ForceBatch event has no txs, it can't be sequenced with this command",
+			forcedBatch.ForcedBatchNumber, forcedBatch.Sequencer.String())
+	}
+	return nil
+}
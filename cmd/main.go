@@ -72,7 +72,7 @@ var (
 	httpAPIFlag = cli.StringSliceFlag{
 		Name:     config.FlagHTTPAPI,
 		Aliases:  []string{"ha"},
-		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
+		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3, jsonrpc.APIAdmin),
 		Required: false,
 		Value:    cli.NewStringSlice(jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
 	}
@@ -186,6 +186,24 @@ func main() {
 			Action:  restore,
 			Flags:   restoreFlags,
 		},
+		{
+			Name:        "allowlist",
+			Aliases:     []string{},
+			Usage:       "Manage the pool allow-lists used by the permissioned mode",
+			Subcommands: allowListSubcommands,
+		},
+		{
+			Name:        "sequencer",
+			Aliases:     []string{},
+			Usage:       "Control the running sequencer: pause, resume, halt, close the wip L2 block or batch and stop",
+			Subcommands: sequencerSubcommands,
+		},
+		{
+			Name:        "forcebatch",
+			Aliases:     []string{},
+			Usage:       "Force L2 txs through L1 when the trusted sequencer censors them or is down",
+			Subcommands: forceBatchSubcommands,
+		},
 	}
 
 	err := app.Run(os.Args)
@@ -29,6 +29,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
 	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
 	"github.com/0xPolygonHermez/zkevm-node/sequencer"
 	"github.com/0xPolygonHermez/zkevm-node/sequencesender"
@@ -41,6 +42,7 @@ import (
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 )
 
 func start(cliCtx *cli.Context) error {
@@ -123,6 +125,7 @@ func start(cliCtx *cli.Context) error {
 
 	c.Aggregator.ChainID = l2ChainID
 	c.Sequencer.StreamServer.ChainID = l2ChainID
+	c.Sequencer.Preconfirmations.ChainID = l2ChainID
 	log.Infof("Chain ID read from POE SC = %v", l2ChainID)
 	// If the aggregator is restarted before the end of the sync process, this currentForkID could be wrong
 	c.Aggregator.ForkId = currentForkID
@@ -170,10 +173,11 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(c.Pool, c.State.Batch, l2ChainID, st, eventLog)
 			}
 			seq := createSequencer(*c, poolInstance, st, etherman, eventLog)
 			go seq.Start(cliCtx.Context)
+			poolInstance.StartArchivingPeriodically()
 		case SEQUENCE_SENDER:
 			ev.Component = event.Component_Sequence_Sender
 			ev.Description = "Running sequence sender"
@@ -182,7 +186,7 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(c.Pool, c.State.Batch, l2ChainID, st, eventLog)
 			}
 			seqSender := createSequenceSender(*c, poolInstance, ethTxManagerStorage, st, eventLog)
 			go seqSender.Start(cliCtx.Context)
@@ -194,13 +198,15 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(c.Pool, c.State.Batch, l2ChainID, st, eventLog)
 			}
 			if c.RPC.EnableL2SuggestedGasPricePolling {
 				// Needed for rejecting transactions with too low gas price
 				poolInstance.StartPollingMinSuggestedGasPrice(cliCtx.Context)
 			}
 			poolInstance.StartRefreshingBlockedAddressesPeriodically()
+			poolInstance.StartRefreshingAllowListsPeriodically()
+			poolInstance.StartRefreshingReputationsPeriodically()
 			apis := map[string]bool{}
 			for _, a := range cliCtx.StringSlice(config.FlagHTTPAPI) {
 				apis[a] = true
@@ -215,9 +221,10 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(c.Pool, c.State.Batch, l2ChainID, st, eventLog)
 			}
 			go runSynchronizer(*c, etherman, ethTxManagerStorage, st, poolInstance, eventLog)
+			go st.StartLogBloomIndexBackfill(cliCtx.Context)
 		case ETHTXMANAGER:
 			ev.Component = event.Component_EthTxManager
 			ev.Description = "Running eth tx manager service"
@@ -235,7 +242,7 @@ func start(cliCtx *cli.Context) error {
 				log.Fatal(err)
 			}
 			if poolInstance == nil {
-				poolInstance = createPool(c.Pool, c.State.Batch.Constraints, l2ChainID, st, eventLog)
+				poolInstance = createPool(c.Pool, c.State.Batch, l2ChainID, st, eventLog)
 			}
 			go runL2GasPriceSuggester(c.L2GasPriceSuggester, st, poolInstance, etherman)
 		}
@@ -344,18 +351,8 @@ func runSynchronizer(cfg config.Config, etherman *etherman.Client, ethTxManagerS
 func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64, pool *pool.Pool, st *state.State, apis map[string]bool) {
 	var err error
 	storage := jsonrpc.NewStorage()
-	c.RPC.MaxCumulativeGasUsed = c.State.Batch.Constraints.MaxCumulativeGasUsed
+	c.RPC.BatchConfig = c.State.Batch
 	c.RPC.L2Coinbase = c.SequenceSender.L2Coinbase
-	c.RPC.ZKCountersLimits = jsonrpc.ZKCountersLimits{
-		MaxKeccakHashes:     c.State.Batch.Constraints.MaxKeccakHashes,
-		MaxPoseidonHashes:   c.State.Batch.Constraints.MaxPoseidonHashes,
-		MaxPoseidonPaddings: c.State.Batch.Constraints.MaxPoseidonPaddings,
-		MaxMemAligns:        c.State.Batch.Constraints.MaxMemAligns,
-		MaxArithmetics:      c.State.Batch.Constraints.MaxArithmetics,
-		MaxBinaries:         c.State.Batch.Constraints.MaxBinaries,
-		MaxSteps:            c.State.Batch.Constraints.MaxSteps,
-		MaxSHA256Hashes:     c.State.Batch.Constraints.MaxSHA256Hashes,
-	}
 	if !c.IsTrustedSequencer {
 		if c.RPC.SequencerNodeURI == "" {
 			log.Debug("getting trusted sequencer URL from smc")
@@ -410,6 +407,17 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APIAdmin]; ok {
+		// the admin methods change the pool, so they are never served without authentication
+		if !c.RPC.JWT.Enabled || !slices.Contains(c.RPC.JWT.ProtectedNamespaces, jsonrpc.APIAdmin) {
+			log.Fatalf("the %s namespace requires the JWT authentication enabled and %s listed in RPC.JWT.ProtectedNamespaces", jsonrpc.APIAdmin, jsonrpc.APIAdmin)
+		}
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIAdmin,
+			Service: jsonrpc.NewAdminEndpoints(pool),
+		})
+	}
+
 	if err := jsonrpc.NewServer(c.RPC, chainID, pool, st, storage, services).Start(); err != nil {
 		log.Fatal(err)
 	}
@@ -508,6 +516,7 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 		ForkUpgradeNewForkId:         c.ForkUpgradeNewForkId,
 		MaxLogsCount:                 c.RPC.MaxLogsCount,
 		MaxLogsBlockRange:            c.RPC.MaxLogsBlockRange,
+		MaxIndexedLogsBlockRange:     c.RPC.MaxIndexedLogsBlockRange,
 		MaxNativeBlockHashBlockRange: c.RPC.MaxNativeBlockHashBlockRange,
 		AvoidForkIDInMemory:          avoidForkIDInMemory,
 	}
@@ -539,13 +548,23 @@ func newState(ctx context.Context, c *config.Config, etherman *etherman.Client,
 	return st, currentForkID
 }
 
-func createPool(cfgPool pool.Config, constraintsCfg state.BatchConstraintsCfg, l2ChainID uint64, st *state.State, eventLog *event.EventLog) *pool.Pool {
-	runPoolMigrations(cfgPool.DB)
-	poolStorage, err := pgpoolstorage.NewPostgresPoolStorage(cfgPool.DB)
-	if err != nil {
-		log.Fatal(err)
+func createPool(cfgPool pool.Config, batchCfg state.BatchConfig, l2ChainID uint64, st *state.State, eventLog *event.EventLog) *pool.Pool {
+	var poolStorage pool.Storage
+	switch cfgPool.Storage {
+	case pool.StorageMemory:
+		log.Warn("using the memory pool storage, the pool txs will be lost when the node stops")
+		poolStorage = memorypoolstorage.NewMemoryPoolStorage()
+	case pool.StoragePostgres, "":
+		runPoolMigrations(cfgPool.DB)
+		pgStorage, err := pgpoolstorage.NewPostgresPoolStorage(cfgPool.DB)
+		if err != nil {
+			log.Fatal(err)
+		}
+		poolStorage = pgStorage
+	default:
+		log.Fatalf("unknown pool storage: %s", cfgPool.Storage)
 	}
-	poolInstance := pool.NewPool(cfgPool, constraintsCfg, poolStorage, st, l2ChainID, eventLog)
+	poolInstance := pool.NewPool(cfgPool, batchCfg, poolStorage, st, l2ChainID, eventLog)
 	return poolInstance
 }
 
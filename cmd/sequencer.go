@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config"
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/event/pgeventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/pgstatestorage"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	sequencerFlagBatchNumber = "batch"
+)
+
+var (
+	sequencerBatchNumberFlag = cli.Uint64Flag{
+		Name:     sequencerFlagBatchNumber,
+		Aliases:  []string{"b"},
+		Usage:    "Batch number where the sequencer halts, 0 to unset it",
+		Required: true,
+	}
+)
+
+var sequencerSubcommands = []*cli.Command{
+	{
+		Name:   "status",
+		Usage:  "Shows the runtime control requested to the sequencer",
+		Action: showSequencerControl,
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+	{
+		Name:   "pause",
+		Usage:  "Pauses the selection of new txs, the wip L2 block and batch are still closed on time",
+		Action: pauseSequencer,
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+	{
+		Name:   "resume",
+		Usage:  "Resumes the selection of new txs",
+		Action: resumeSequencer,
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+	{
+		Name:   "halt",
+		Usage:  "Sets the batch number where the sequencer halts, it is kept after a restart until it is unset",
+		Action: haltSequencerOnBatchNumber,
+		Flags:  []cli.Flag{&configFileFlag, &sequencerBatchNumberFlag},
+	},
+	{
+		Name:   "close-l2block",
+		Usage:  "Closes the wip L2 block",
+		Action: addSequencerControlRequest(state.CloseL2BlockRequest),
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+	{
+		Name:   "close-batch",
+		Usage:  "Closes the wip batch",
+		Action: addSequencerControlRequest(state.CloseBatchRequest),
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+	{
+		Name:   "stop",
+		Usage:  "Closes the wip batch, stores the pending L2 blocks and halts the sequencer until the halt batch number is unset",
+		Action: addSequencerControlRequest(state.StopRequest),
+		Flags:  []cli.Flag{&configFileFlag},
+	},
+}
+
+func showSequencerControl(cliCtx *cli.Context) error {
+	_, storage, err := loadSequencerControlArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	control, err := storage.GetSequencerControl(cliCtx.Context, nil)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("paused: %v\n", control.Paused)
+	fmt.Printf("halt on batch number: %d\n", control.HaltOnBatchNumber)
+	fmt.Printf("close L2 block requests: %d\n", control.CloseL2BlockRequests)
+	fmt.Printf("close batch requests: %d\n", control.CloseBatchRequests)
+	fmt.Printf("stop requests: %d\n", control.StopRequests)
+	fmt.Printf("updated at: %v\n", control.UpdatedAt)
+	return nil
+}
+
+func pauseSequencer(cliCtx *cli.Context) error {
+	return setSequencerPaused(cliCtx, true)
+}
+
+func resumeSequencer(cliCtx *cli.Context) error {
+	return setSequencerPaused(cliCtx, false)
+}
+
+func setSequencerPaused(cliCtx *cli.Context, paused bool) error {
+	c, storage, err := loadSequencerControlArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.SetSequencerPaused(cliCtx.Context, paused, nil); err != nil {
+		return err
+	}
+	return logSequencerControlRequested(cliCtx.Context, c, fmt.Sprintf("sequencer paused: %v", paused))
+}
+
+func haltSequencerOnBatchNumber(cliCtx *cli.Context) error {
+	c, storage, err := loadSequencerControlArgs(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	batchNumber := cliCtx.Uint64(sequencerFlagBatchNumber)
+	if err := storage.SetSequencerHaltOnBatchNumber(cliCtx.Context, batchNumber, nil); err != nil {
+		return err
+	}
+	return logSequencerControlRequested(cliCtx.Context, c, fmt.Sprintf("sequencer halt on batch number: %d", batchNumber))
+}
+
+func addSequencerControlRequest(request state.SequencerControlRequest) cli.ActionFunc {
+	return func(cliCtx *cli.Context) error {
+		c, storage, err := loadSequencerControlArgs(cliCtx)
+		if err != nil {
+			return err
+		}
+
+		if err := storage.AddSequencerControlRequest(cliCtx.Context, request, nil); err != nil {
+			return err
+		}
+		return logSequencerControlRequested(cliCtx.Context, c, fmt.Sprintf("sequencer %s requested", request))
+	}
+}
+
+func loadSequencerControlArgs(cliCtx *cli.Context) (*config.Config, *pgstatestorage.PostgresStorage, error) {
+	c, err := config.Load(cliCtx, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	setupLog(c.Log)
+
+	stateSqlDB, err := db.NewSQLDB(c.State.DB)
+	if err != nil {
+		return nil, nil, err
+	}
+	storage := pgstatestorage.NewPostgresStorage(state.Config{}, stateSqlDB)
+
+	// The control commands never migrate the state db, which is owned by the running node
+	if _, err := storage.GetSequencerControl(cliCtx.Context, nil); err != nil {
+		stateSqlDB.Close()
+		return nil, nil, fmt.Errorf("the sequencer control table is not available in the state db, upgrade the node to apply the state migrations first, error: %w", err)
+	}
+	return c, storage, nil
+}
+
+// logSequencerControlRequested stores the event of the control request, the running
+// sequencer applies it in the next check of the control requests
+func logSequencerControlRequested(ctx context.Context, c *config.Config, description string) error {
+	var eventStorage event.Storage
+	var err error
+	if c.EventLog.DB.Name != "" {
+		eventStorage, err = pgeventstorage.NewPostgresEventStorage(c.EventLog.DB)
+	} else {
+		eventStorage, err = nileventstorage.NewNilEventStorage()
+	}
+	if err != nil {
+		return err
+	}
+
+	return event.NewEventLog(c.EventLog, eventStorage).LogEvent(ctx, &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequencer,
+		Level:       event.Level_Notice,
+		EventID:     event.EventID_SequencerControlRequested,
+		Description: description,
+	})
+}
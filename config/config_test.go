@@ -13,6 +13,9 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -97,10 +100,58 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.LoadPoolTxsCheckInterval",
 			expectedValue: types.NewDuration(500 * time.Millisecond),
 		},
+		{
+			path:          "Sequencer.LoadPoolTxsNotificationsEnabled",
+			expectedValue: true,
+		},
+		{
+			path:          "Sequencer.LoadPoolTxsFallbackInterval",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
 		{
 			path:          "Sequencer.StateConsistencyCheckInterval",
 			expectedValue: types.NewDuration(5 * time.Second),
 		},
+		{
+			path:          "Sequencer.ControlCheckInterval",
+			expectedValue: types.NewDuration(time.Second),
+		},
+		{
+			path:          "Sequencer.TxOrdering.Policy",
+			expectedValue: sequencer.TxOrderingGasPrice,
+		},
+		{
+			path:          "Sequencer.TxOrdering.PriorityAddresses",
+			expectedValue: []common.Address{},
+		},
+		{
+			path:          "Sequencer.HA.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.HA.NodeID",
+			expectedValue: "",
+		},
+		{
+			path:          "Sequencer.HA.LeaseDuration",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Sequencer.HA.RenewInterval",
+			expectedValue: types.NewDuration(3 * time.Second),
+		},
+		{
+			path:          "Sequencer.HA.StandbyCheckInterval",
+			expectedValue: types.NewDuration(2 * time.Second),
+		},
+		{
+			path:          "Sequencer.Preconfirmations.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.Preconfirmations.PrivateKey",
+			expectedValue: types.KeystoreFileConfig{Path: "/pk/sequencer.keystore", Password: "testonly"},
+		},
 		{
 			path:          "Sequencer.Finalizer.ForcedBatchesTimeout",
 			expectedValue: types.NewDuration(60 * time.Second),
@@ -149,6 +200,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.Finalizer.FlushIdCheckInterval",
 			expectedValue: types.NewDuration(50 * time.Millisecond),
 		},
+		{
+			path:          "Sequencer.Finalizer.L2BlockLimits.MaxTxs",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.L2BlockLimits.MaxGas",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.Finalizer.L2BlockLimits.MaxBatchResourcesPct",
+			expectedValue: uint32(0),
+		},
 		{
 			path:          "Sequencer.Finalizer.Metrics.Interval",
 			expectedValue: types.NewDuration(60 * time.Minute),
@@ -330,10 +393,126 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.GlobalQueue",
 			expectedValue: uint64(1024),
 		},
+		{
+			path:          "Pool.PriceBump",
+			expectedValue: uint64(10),
+		},
 		{
 			path:          "Pool.TxFeeCap",
 			expectedValue: float64(1),
 		},
+		{
+			path:          "Pool.Storage",
+			expectedValue: pool.StoragePostgres,
+		},
+		{
+			path:          "Pool.AllowList.SendersEnabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.AllowList.DeployersEnabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.AllowList.IntervalToRefresh",
+			expectedValue: types.NewDuration(1 * time.Minute),
+		},
+		{
+			path:          "Pool.Reputation.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Reputation.FailuresWindow",
+			expectedValue: types.NewDuration(1 * time.Hour),
+		},
+		{
+			path:          "Pool.Reputation.ThrottleThreshold",
+			expectedValue: uint64(5),
+		},
+		{
+			path:          "Pool.Reputation.ThrottleInterval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Pool.Reputation.BanThreshold",
+			expectedValue: uint64(20),
+		},
+		{
+			path:          "Pool.Reputation.BanDuration",
+			expectedValue: types.NewDuration(1 * time.Hour),
+		},
+		{
+			path:          "Pool.Reputation.IgnoredFailedReasons",
+			expectedValue: []string{"replaced", "evicted", "transaction expired"},
+		},
+		{
+			path:          "Pool.Reputation.IntervalToRefresh",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "Pool.Archive.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.Archive.Interval",
+			expectedValue: types.NewDuration(5 * time.Minute),
+		},
+		{
+			path:          "Pool.Archive.ArchiveInvalidAfter",
+			expectedValue: types.NewDuration(24 * time.Hour),
+		},
+		{
+			path:          "Pool.Archive.SelectedRetention",
+			expectedValue: types.NewDuration(720 * time.Hour),
+		},
+		{
+			path:          "Pool.Archive.FailedRetention",
+			expectedValue: types.NewDuration(168 * time.Hour),
+		},
+		{
+			path:          "Pool.Archive.InvalidRetention",
+			expectedValue: types.NewDuration(168 * time.Hour),
+		},
+		{
+			path:          "Pool.SponsoredClaims.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.SponsoredClaims.BridgeAddress",
+			expectedValue: common.Address{},
+		},
+		{
+			path:          "Pool.SponsoredClaims.MaxGas",
+			expectedValue: uint64(500000),
+		},
+		{
+			path:          "Pool.SponsoredClaims.RecipientRateLimit",
+			expectedValue: uint64(3),
+		},
+		{
+			path:          "Pool.SponsoredClaims.RateLimitInterval",
+			expectedValue: types.NewDuration(time.Hour),
+		},
+		{
+			path:          "Pool.PreExecution.CacheTTL",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
+		{
+			path:          "Pool.PreExecution.CacheMaxSize",
+			expectedValue: 10000,
+		},
+		{
+			path:          "Pool.PreExecution.BatchingEnabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Pool.PreExecution.BatchWindow",
+			expectedValue: types.NewDuration(10 * time.Millisecond),
+		},
+		{
+			path:          "Pool.PreExecution.BatchMaxSize",
+			expectedValue: 20,
+		},
 		{
 			path:          "Pool.EffectiveGasPrice.Enabled",
 			expectedValue: false,
@@ -442,6 +621,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.MaxLogsBlockRange",
 			expectedValue: uint64(10000),
 		},
+		{
+			path:          "RPC.MaxIndexedLogsBlockRange",
+			expectedValue: uint64(100000),
+		},
 		{
 			path:          "RPC.MaxNativeBlockHashBlockRange",
 			expectedValue: uint64(60000),
@@ -450,6 +633,30 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.EnableHttpLog",
 			expectedValue: true,
 		},
+		{
+			path:          "RPC.SequencerRelay.Timeout",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "RPC.SequencerRelay.MaxRetries",
+			expectedValue: uint(2),
+		},
+		{
+			path:          "RPC.SequencerRelay.RetryBackoff",
+			expectedValue: types.NewDuration(100 * time.Millisecond),
+		},
+		{
+			path:          "RPC.SequencerRelay.HealthCheckInterval",
+			expectedValue: types.NewDuration(5 * time.Second),
+		},
+		{
+			path:          "RPC.SequencerRelay.CircuitBreakerFailureThreshold",
+			expectedValue: uint(3),
+		},
+		{
+			path:          "RPC.SequencerRelay.CircuitBreakerOpenDuration",
+			expectedValue: types.NewDuration(30 * time.Second),
+		},
 		{
 			path:          "RPC.WebSockets.Enabled",
 			expectedValue: true,
@@ -466,6 +673,30 @@ func Test_Defaults(t *testing.T) {
 			path:          "RPC.WebSockets.ReadLimit",
 			expectedValue: int64(104857600),
 		},
+		{
+			path:          "RPC.TLS.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.JWT.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.JWT.ProtectedNamespaces",
+			expectedValue: []string{"debug", "txpool", "admin"},
+		},
+		{
+			path:          "RPC.GraphQL.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "RPC.GraphQL.MaxDepth",
+			expectedValue: 10,
+		},
+		{
+			path:          "RPC.GraphQL.MaxBlockRange",
+			expectedValue: uint64(100),
+		},
 		{
 			path:          "Executor.URI",
 			expectedValue: "zkevm-prover:50071",
@@ -542,6 +773,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "Aggregator.BatchProofL1BlockConfirmations",
 			expectedValue: uint64(2),
 		},
+		{
+			path:          "State.Batch.ForkConstraints",
+			expectedValue: []state.ForkBatchConstraintsCfg{},
+		},
 		{
 			path:          "State.Batch.Constraints.MaxTxsPerBatch",
 			expectedValue: uint64(300),
@@ -21,6 +21,7 @@ Outputs = ["stderr"]
 	EnableLog = false	
 	MaxConns = 200
 	[State.Batch]
+	ForkConstraints = []
 		[State.Batch.Constraints]
 		MaxTxsPerBatch = 300
 		MaxBatchBytesSize = 120000
@@ -44,7 +45,41 @@ MinAllowedGasPriceInterval = "5m"
 PollMinAllowedGasPriceInterval = "15s"
 AccountQueue = 64
 GlobalQueue = 1024
+PriceBump = 10
 TxFeeCap = 1.0
+Storage = "postgres"
+    [Pool.AllowList]
+	SendersEnabled = false
+	DeployersEnabled = false
+	IntervalToRefresh = "1m"
+    [Pool.Reputation]
+	Enabled = false
+	FailuresWindow = "1h"
+	ThrottleThreshold = 5
+	ThrottleInterval = "10s"
+	BanThreshold = 20
+	BanDuration = "1h"
+	IgnoredFailedReasons = ["replaced", "evicted", "transaction expired"]
+	IntervalToRefresh = "30s"
+    [Pool.Archive]
+	Enabled = false
+	Interval = "5m"
+	ArchiveInvalidAfter = "24h"
+	SelectedRetention = "720h"
+	FailedRetention = "168h"
+	InvalidRetention = "168h"
+    [Pool.SponsoredClaims]
+	Enabled = false
+	BridgeAddress = "0x0000000000000000000000000000000000000000"
+	MaxGas = 500000
+	RecipientRateLimit = 3
+	RateLimitInterval = "1h"
+    [Pool.PreExecution]
+	CacheTTL = "30s"
+	CacheMaxSize = 10000
+	BatchingEnabled = false
+	BatchWindow = "10ms"
+	BatchMaxSize = 20
     [Pool.EffectiveGasPrice]
 	Enabled = false
 	L1GasPriceFactor = 0.25
@@ -91,13 +126,34 @@ BatchRequestsEnabled = false
 BatchRequestsLimit = 20
 MaxLogsCount = 10000
 MaxLogsBlockRange = 10000
+MaxIndexedLogsBlockRange = 100000
 MaxNativeBlockHashBlockRange = 60000
 EnableHttpLog = true
+	[RPC.SequencerRelay]
+		FallbackURIs = []
+		Timeout = "10s"
+		MaxRetries = 2
+		RetryBackoff = "100ms"
+		HealthCheckInterval = "5s"
+		CircuitBreakerFailureThreshold = 3
+		CircuitBreakerOpenDuration = "30s"
 	[RPC.WebSockets]
 		Enabled = true
 		Host = "0.0.0.0"
 		Port = 8546
 		ReadLimit = 104857600
+	[RPC.TLS]
+		Enabled = false
+		CertFile = ""
+		KeyFile = ""
+	[RPC.JWT]
+		Enabled = false
+		SecretFile = ""
+		ProtectedNamespaces = ["debug", "txpool", "admin"]
+	[RPC.GraphQL]
+		Enabled = false
+		MaxDepth = 10
+		MaxBlockRange = 100
 
 [Synchronizer]
 SyncInterval = "1s"
@@ -140,7 +196,22 @@ DeletePoolTxsCheckInterval = "12h"
 TxLifetimeCheckInterval = "10m"
 TxLifetimeMax = "3h"
 LoadPoolTxsCheckInterval = "500ms"
+LoadPoolTxsNotificationsEnabled = true
+LoadPoolTxsFallbackInterval = "5s"
 StateConsistencyCheckInterval = "5s"
+ControlCheckInterval = "1s"
+	[Sequencer.TxOrdering]
+		Policy = "gasprice"
+		PriorityAddresses = []
+	[Sequencer.HA]
+		Enabled = false
+		NodeID = ""
+		LeaseDuration = "10s"
+		RenewInterval = "3s"
+		StandbyCheckInterval = "2s"
+	[Sequencer.Preconfirmations]
+		Enabled = false
+		PrivateKey = {Path = "/pk/sequencer.keystore", Password = "testonly"}
 	[Sequencer.Finalizer]
 		NewTxsWaitInterval = "100ms"
 		ForcedBatchesTimeout = "60s"
@@ -156,6 +227,10 @@ StateConsistencyCheckInterval = "5s"
 		HaltOnBatchNumber = 0
 		SequentialBatchSanityCheck = false
 		SequentialProcessL2Block = false
+		[Sequencer.Finalizer.L2BlockLimits]
+			MaxTxs = 0
+			MaxGas = 0
+			MaxBatchResourcesPct = 0
 	[Sequencer.Finalizer.Metrics]
 		Interval = "60m"
 		EnableLog = true
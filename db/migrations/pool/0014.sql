@@ -0,0 +1,7 @@
+-- +migrate Up
+CREATE TABLE pool.whitelisted_deployer (
+	addr VARCHAR PRIMARY KEY
+);
+
+-- +migrate Down
+DROP TABLE pool.whitelisted_deployer;
@@ -0,0 +1,36 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the pool.whitelisted_deployer table
+type migrationTest0014 struct{}
+
+func (m migrationTest0014) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	const insertDeployer = `INSERT INTO pool.whitelisted_deployer (addr) VALUES ('0x0011')`
+	_, err := db.Exec(insertDeployer)
+	require.NoError(t, err)
+
+	var count int
+	err = db.QueryRow(`SELECT COUNT(*) FROM pool.whitelisted_deployer`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func (m migrationTest0014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	const insertDeployer = `INSERT INTO pool.whitelisted_deployer (addr) VALUES ('0x0011')`
+	_, err := db.Exec(insertDeployer)
+	require.Error(t, err)
+}
+
+func TestMigration0014(t *testing.T) {
+	runMigrationTest(t, 14, migrationTest0014{})
+}
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION pool.notify_new_tx() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM pg_notify('pool_new_tx', NEW.hash);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER notify_new_tx
+	AFTER INSERT OR UPDATE OF status ON pool.transaction
+	FOR EACH ROW WHEN (NEW.status = 'pending' AND NEW.is_wip IS NOT TRUE)
+	EXECUTE PROCEDURE pool.notify_new_tx();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS notify_new_tx ON pool.transaction;
+DROP FUNCTION IF EXISTS pool.notify_new_tx();
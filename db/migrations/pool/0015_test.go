@@ -0,0 +1,41 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the trigger that notifies the pending txs stored in pool.transaction
+type migrationTest0015 struct{}
+
+const getNotifyNewTxTriggerCount = `SELECT COUNT(*) FROM pg_trigger WHERE tgname = 'notify_new_tx'`
+
+func (m migrationTest0015) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var count int
+	err := db.QueryRow(getNotifyNewTxTriggerCount).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, status)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011', 'pending')`
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	var count int
+	err := db.QueryRow(getNotifyNewTxTriggerCount).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestMigration0015(t *testing.T) {
+	runMigrationTest(t, 15, migrationTest0015{})
+}
@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE pool.reputation (
+    kind            VARCHAR NOT NULL,
+    key             VARCHAR NOT NULL,
+    failures        BIGINT NOT NULL,
+    window_start    TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    banned_until    TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (kind, key)
+);
+
+-- +migrate Down
+DROP TABLE pool.reputation;
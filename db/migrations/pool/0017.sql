@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE pool.transaction_archive (
+    hash                   VARCHAR PRIMARY KEY,
+    encoded                VARCHAR,
+    decoded                jsonb,
+    status                 varchar(15),
+    gas_price              DECIMAL(78, 0),
+    break_even_gas_price   DECIMAL(78, 0),
+    nonce                  DECIMAL(78, 0),
+    cumulative_gas_used    BIGINT,
+    used_keccak_hashes     INTEGER,
+    used_poseidon_hashes   INTEGER,
+    used_poseidon_paddings INTEGER,
+    used_mem_aligns        INTEGER,
+    used_arithmetics       INTEGER,
+    used_binaries          INTEGER,
+    used_steps             INTEGER,
+    used_sha256_hashes     INTEGER,
+    reserved_zkcounters    jsonb,
+    received_at            TIMESTAMP WITH TIME ZONE NOT NULL,
+    from_address           varchar                  NOT NULL,
+    ip                     VARCHAR,
+    failed_reason          VARCHAR,
+    l2_hash                VARCHAR,
+    is_private             BOOLEAN DEFAULT false,
+    archived_at            TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_transaction_archive_status_archived_at ON pool.transaction_archive (status, archived_at);
+CREATE INDEX idx_transaction_archive_l2_hash ON pool.transaction_archive (l2_hash);
+
+-- +migrate Down
+DROP TABLE pool.transaction_archive;
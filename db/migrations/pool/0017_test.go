@@ -0,0 +1,46 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the pool.transaction_archive table
+type migrationTest0017 struct{}
+
+const insertArchivedTx = `
+	INSERT INTO pool.transaction_archive (hash, status, gas_price, received_at, from_address, failed_reason, archived_at)
+	VALUES ('0x0', 'failed', 1000000000, '2023-12-07', '0x0', 'out of counters', '2023-12-08')`
+
+func (m migrationTest0017) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertArchivedTx)
+	require.NoError(t, err)
+
+	var failedReason string
+	var isPrivate bool
+	err = db.QueryRow(`SELECT failed_reason, is_private FROM pool.transaction_archive WHERE hash = '0x0'`).Scan(&failedReason, &isPrivate)
+	require.NoError(t, err)
+	require.Equal(t, "out of counters", failedReason)
+	require.False(t, isPrivate)
+
+	const insertPrivateArchivedTx = `
+		INSERT INTO pool.transaction_archive (hash, received_at, from_address, archived_at, l2_hash, is_private)
+		VALUES ('0x1', '2023-12-07', '0x0', '2023-12-08', '0x11', true)`
+	_, err = db.Exec(insertPrivateArchivedTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0017) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertArchivedTx)
+	require.Error(t, err)
+}
+
+func TestMigration0017(t *testing.T) {
+	runMigrationTest(t, 17, migrationTest0017{})
+}
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE pool.transaction
+    ADD COLUMN is_private BOOLEAN DEFAULT false;
+
+-- +migrate Down
+ALTER TABLE pool.transaction
+    DROP COLUMN is_private;
@@ -0,0 +1,42 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds is_private to the transaction
+type migrationTest0018 struct{}
+
+func (m migrationTest0018) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var isPrivate bool
+	err := db.QueryRow(`SELECT is_private FROM pool.transaction WHERE hash = '0x0001'`).Scan(&isPrivate)
+	require.NoError(t, err)
+	require.False(t, isPrivate)
+
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address, is_private)
+		VALUES ('0x0002', '127.0.0.1', '2023-12-07', '0x0011', true)`
+	_, err = db.Exec(insertTx)
+	require.NoError(t, err)
+}
+
+func (m migrationTest0018) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(`SELECT is_private FROM pool.transaction`)
+	require.Error(t, err)
+}
+
+func TestMigration0018(t *testing.T) {
+	runMigrationTest(t, 18, migrationTest0018{})
+}
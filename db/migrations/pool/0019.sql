@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE pool.preconfirmation
+(
+    hash         VARCHAR PRIMARY KEY REFERENCES pool.transaction (hash) ON DELETE CASCADE,
+    l2_block_num BIGINT NOT NULL,
+    tx_index     BIGINT NOT NULL,
+    status       BIGINT NOT NULL,
+    signature    BYTEA NOT NULL,
+    rescinded    BOOLEAN NOT NULL DEFAULT false,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS preconfirmation_l2_block_num_idx ON pool.preconfirmation (l2_block_num);
+
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION pool.notify_preconfirmation() RETURNS TRIGGER AS $$
+BEGIN
+	PERFORM pg_notify('pool_preconfirmation', NEW.hash);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER notify_preconfirmation
+	AFTER INSERT OR UPDATE ON pool.preconfirmation
+	FOR EACH ROW EXECUTE PROCEDURE pool.notify_preconfirmation();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS notify_preconfirmation ON pool.preconfirmation;
+DROP FUNCTION IF EXISTS pool.notify_preconfirmation();
+DROP TABLE IF EXISTS pool.preconfirmation;
@@ -0,0 +1,63 @@
+package pool_migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// this migration adds the pool.preconfirmation table and the trigger that notifies its changes
+type migrationTest0019 struct{}
+
+const (
+	insertPreconfirmation = `
+		INSERT INTO pool.preconfirmation (hash, l2_block_num, tx_index, status, signature, created_at, updated_at)
+		VALUES ('0x0001', 10, 0, 1, '\x01', '2023-12-07', '2023-12-07')`
+	getNotifyPreconfirmationTriggerCount = `SELECT COUNT(*) FROM pg_trigger WHERE tgname = 'notify_preconfirmation'`
+)
+
+func (m migrationTest0019) InsertData(db *sql.DB) error {
+	const insertTx = `
+		INSERT INTO pool.transaction (hash, ip, received_at, from_address)
+		VALUES ('0x0001', '127.0.0.1', '2023-12-07', '0x0011')`
+
+	_, err := db.Exec(insertTx)
+	return err
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	var count int
+	err := db.QueryRow(getNotifyPreconfirmationTriggerCount).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = db.Exec(insertPreconfirmation)
+	require.NoError(t, err)
+
+	var rescinded bool
+	err = db.QueryRow(`SELECT rescinded FROM pool.preconfirmation WHERE hash = '0x0001'`).Scan(&rescinded)
+	require.NoError(t, err)
+	require.False(t, rescinded)
+
+	// The pre-confirmations are deleted along with their txs
+	_, err = db.Exec(`DELETE FROM pool.transaction WHERE hash = '0x0001'`)
+	require.NoError(t, err)
+	err = db.QueryRow(`SELECT COUNT(*) FROM pool.preconfirmation`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func (m migrationTest0019) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	_, err := db.Exec(insertPreconfirmation)
+	require.Error(t, err)
+
+	var count int
+	err = db.QueryRow(getNotifyPreconfirmationTriggerCount).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
+
+func TestMigration0019(t *testing.T) {
+	runMigrationTest(t, 19, migrationTest0019{})
+}
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.log_bloom_bits
+(
+    section BIGINT    NOT NULL,
+    bit     SMALLINT  NOT NULL,
+    vector  BIT(4096) NOT NULL,
+    PRIMARY KEY (section, bit)
+);
+
+comment on table state.log_bloom_bits is 'index of the l2 blocks logs blooms, grouped by sections of 4096 l2 blocks';
+comment on column state.log_bloom_bits.section is 'l2 block number / 4096';
+comment on column state.log_bloom_bits.bit is 'position of the bit in the logs bloom (0-2047)';
+comment on column state.log_bloom_bits.vector is 'the bit N is set if the bit of the logs bloom is set in the l2 block section * 4096 + N';
+
+-- the logs bloom of the existing l2 blocks are indexed in the background by
+-- chunks to avoid locking the l2 blocks for a long time, while the backfill
+-- is pending the log bloom index is not used for the blocks in the range
+CREATE TABLE IF NOT EXISTS state.log_bloom_backfill
+(
+    next_block BIGINT NOT NULL,
+    end_block  BIGINT NOT NULL
+);
+
+comment on table state.log_bloom_backfill is 'range of l2 blocks existing before the log bloom index that are not indexed yet';
+comment on column state.log_bloom_backfill.next_block is 'next l2 block to index';
+comment on column state.log_bloom_backfill.end_block is 'first l2 block indexed when it was stored';
+
+INSERT INTO state.log_bloom_backfill (next_block, end_block)
+SELECT 0, COALESCE(MAX(block_num) + 1, 0) FROM state.l2block;
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.log_bloom_backfill;
+DROP TABLE IF EXISTS state.log_bloom_bits;
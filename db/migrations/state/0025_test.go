@@ -0,0 +1,78 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTest0025 struct {
+	migrationBase
+}
+
+// logsBloom with only the bit 7 set
+const migrationTest0025LogsBloom = "0x" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000080"
+
+func (m migrationTest0025) InsertData(db *sql.DB) error {
+	const addBlock0 = "INSERT INTO state.block (block_num, received_at, block_hash) VALUES (0, now(), '0x0')"
+	if _, err := db.Exec(addBlock0); err != nil {
+		return err
+	}
+
+	const addBatch0 = `
+		INSERT INTO state.batch (batch_num, global_exit_root, local_exit_root, acc_input_hash, state_root, timestamp, coinbase, raw_txs_data, forced_batch_num, wip) 
+		VALUES (0,'0x0000', '0x0000', '0x0000', '0x0000', now(), '0x0000', null, null, true)`
+	if _, err := db.Exec(addBatch0); err != nil {
+		return err
+	}
+
+	const addL2Block = "INSERT INTO state.l2block (block_num, block_hash, header, uncles, parent_hash, state_root, received_at, batch_num, created_at) VALUES ($1, $2, $3, '{}', '0x0', '0x0', now(), 0, now())"
+	if _, err := db.Exec(addL2Block, 1, "0x1", `{}`); err != nil {
+		return err
+	}
+	if _, err := db.Exec(addL2Block, 4097, "0x2", `{"logsBloom": "`+migrationTest0025LogsBloom+`"}`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m migrationTest0025) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationUp(t, db)
+
+	// the existing blocks are indexed in the background
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM state.log_bloom_bits").Scan(&count))
+	assert.Equal(t, 0, count)
+
+	var nextBlock, endBlock uint64
+	require.NoError(t, db.QueryRow("SELECT next_block, end_block FROM state.log_bloom_backfill").Scan(&nextBlock, &endBlock))
+	assert.Equal(t, uint64(0), nextBlock)
+	assert.Equal(t, uint64(4098), endBlock)
+}
+
+func (m migrationTest0025) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationDown(t, db)
+}
+
+func TestMigration0025(t *testing.T) {
+	m := migrationTest0025{
+		migrationBase: migrationBase{
+			newTables: []tableMetadata{
+				{"state", "log_bloom_bits"},
+				{"state", "log_bloom_backfill"},
+			},
+		},
+	}
+	runMigrationTest(t, 25, m)
+}
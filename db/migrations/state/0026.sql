@@ -0,0 +1,17 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.sequencer_lease
+(
+    id            SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    holder        VARCHAR                  NOT NULL,
+    fencing_token BIGINT                   NOT NULL,
+    expires_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+comment on table state.sequencer_lease is 'leader lease of the sequencers running in high availability mode, it has a single row';
+comment on column state.sequencer_lease.holder is 'node id of the sequencer holding the lease';
+comment on column state.sequencer_lease.fencing_token is 'increased each time the lease is acquired, the writes of a sequencer with an older token are rejected';
+comment on column state.sequencer_lease.expires_at is 'time the lease expires if it is not renewed by its holder';
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.sequencer_lease;
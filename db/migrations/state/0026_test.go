@@ -0,0 +1,43 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type migrationTest0026 struct {
+	migrationBase
+}
+
+func (m migrationTest0026) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0026) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationUp(t, db)
+
+	const addLease = "INSERT INTO state.sequencer_lease (holder, fencing_token, expires_at) VALUES ($1, 1, now())"
+	_, err := db.Exec(addLease, "sequencer-1")
+	assert.NoError(t, err)
+
+	// the lease table only has one row
+	_, err = db.Exec(addLease, "sequencer-2")
+	assert.Error(t, err)
+}
+
+func (m migrationTest0026) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationDown(t, db)
+}
+
+func TestMigration0026(t *testing.T) {
+	m := migrationTest0026{
+		migrationBase: migrationBase{
+			newTables: []tableMetadata{
+				{"state", "sequencer_lease"},
+			},
+		},
+	}
+	runMigrationTest(t, 26, m)
+}
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.sequencer_control
+(
+    id                      SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    paused                  BOOLEAN                  NOT NULL DEFAULT FALSE,
+    halt_on_batch_num       BIGINT                   NOT NULL DEFAULT 0,
+    close_l2block_requests  BIGINT                   NOT NULL DEFAULT 0,
+    close_batch_requests    BIGINT                   NOT NULL DEFAULT 0,
+    stop_requests           BIGINT                   NOT NULL DEFAULT 0,
+    updated_at              TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+comment on table state.sequencer_control is 'runtime control of the sequencer requested by the operator, it has a single row';
+comment on column state.sequencer_control.paused is 'the sequencer does not select new txs while it is paused';
+comment on column state.sequencer_control.halt_on_batch_num is 'batch number where the sequencer halts, 0 if it is not set';
+comment on column state.sequencer_control.close_l2block_requests is 'increased each time the operator requests to close the wip l2 block';
+comment on column state.sequencer_control.close_batch_requests is 'increased each time the operator requests to close the wip batch';
+comment on column state.sequencer_control.stop_requests is 'increased each time the operator requests to drain and stop the sequencer';
+
+INSERT INTO state.sequencer_control (id) VALUES (1) ON CONFLICT DO NOTHING;
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.sequencer_control;
@@ -0,0 +1,43 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTest0027 struct {
+	migrationBase
+}
+
+func (m migrationTest0027) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0027) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationUp(t, db)
+
+	var paused bool
+	var haltOnBatchNum uint64
+	err := db.QueryRow("SELECT paused, halt_on_batch_num FROM state.sequencer_control").Scan(&paused, &haltOnBatchNum)
+	require.NoError(t, err)
+	assert.False(t, paused)
+	assert.Equal(t, uint64(0), haltOnBatchNum)
+}
+
+func (m migrationTest0027) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationDown(t, db)
+}
+
+func TestMigration0027(t *testing.T) {
+	m := migrationTest0027{
+		migrationBase: migrationBase{
+			newTables: []tableMetadata{
+				{"state", "sequencer_control"},
+			},
+		},
+	}
+	runMigrationTest(t, 27, m)
+}
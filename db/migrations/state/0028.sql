@@ -0,0 +1,38 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS state.batch_closing_info
+(
+    batch_num          BIGINT PRIMARY KEY REFERENCES state.batch (batch_num) ON DELETE CASCADE,
+    exhausted_resource VARCHAR,
+    l2_blocks          BIGINT                   NOT NULL,
+    txs                BIGINT                   NOT NULL,
+    skipped_txs        BIGINT                   NOT NULL,
+    node_ooc_txs       BIGINT                   NOT NULL,
+    closed_at          TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+comment on table state.batch_closing_info is 'report of the sequencer about the closing of a batch';
+comment on column state.batch_closing_info.exhausted_resource is 'batch resource that caused the closing of the batch, empty if it was not closed due to a resource';
+comment on column state.batch_closing_info.skipped_txs is 'txs not added to the batch because they did not fit in its remaining resources when they were selected or processed';
+comment on column state.batch_closing_info.node_ooc_txs is 'txs set as invalid while the batch was open because they do not fit in an empty batch (node OOC)';
+
+CREATE TABLE IF NOT EXISTS state.l2block_closing_info
+(
+    block_num      BIGINT PRIMARY KEY REFERENCES state.l2block (block_num) ON DELETE CASCADE,
+    batch_num      BIGINT  NOT NULL REFERENCES state.batch (batch_num) ON DELETE CASCADE,
+    closing_reason VARCHAR NOT NULL,
+    txs            BIGINT  NOT NULL,
+    processed_txs  BIGINT  NOT NULL,
+    used_resources JSONB,
+    times          JSONB
+);
+
+CREATE INDEX IF NOT EXISTS l2block_closing_info_batch_num_idx ON state.l2block_closing_info (batch_num);
+
+comment on table state.l2block_closing_info is 'report of the sequencer about the closing of a l2 block';
+comment on column state.l2block_closing_info.processed_txs is 'txs executed while the l2 block was open, including the ones not added to it';
+comment on column state.l2block_closing_info.times is 'time spent by the sequencer and the executor in the l2 block';
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.l2block_closing_info;
+DROP TABLE IF EXISTS state.batch_closing_info;
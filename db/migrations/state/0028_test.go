@@ -0,0 +1,37 @@
+package migrations_test
+
+import (
+	"database/sql"
+	"testing"
+)
+
+type migrationTest0028 struct {
+	migrationBase
+}
+
+func (m migrationTest0028) InsertData(db *sql.DB) error {
+	return nil
+}
+
+func (m migrationTest0028) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationUp(t, db)
+}
+
+func (m migrationTest0028) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	m.AssertNewAndRemovedItemsAfterMigrationDown(t, db)
+}
+
+func TestMigration0028(t *testing.T) {
+	m := migrationTest0028{
+		migrationBase: migrationBase{
+			newTables: []tableMetadata{
+				{"state", "batch_closing_info"},
+				{"state", "l2block_closing_info"},
+			},
+			newIndexes: []string{
+				"l2block_closing_info_batch_num_idx",
+			},
+		},
+	}
+	runMigrationTest(t, 28, m)
+}
@@ -1199,11 +1199,164 @@ func (etherMan *Client) TrustedSequencer() (common.Address, error) {
 	return etherMan.EtrogZkEVM.TrustedSequencer(&bind.CallOpts{Pending: false})
 }
 
-func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, blocks *[]Block, blocksOrder *map[common.Hash][]Order) error {
-	log.Debug("ForceBatch event detected")
+// GetRollupForkID gets the fork id of the rollup from the rollup manager smc
+func (etherMan *Client) GetRollupForkID() (uint64, error) {
+	rollupData, err := etherMan.EtrogRollupManager.RollupIDToRollupData(&bind.CallOpts{Pending: false}, etherMan.RollupID)
+	if err != nil {
+		return 0, err
+	}
+	return rollupData.ForkID, nil
+}
+
+// GetForcedBatchFee gets the pol fee to send a forced batch
+func (etherMan *Client) GetForcedBatchFee() (*big.Int, error) {
+	return etherMan.EtrogRollupManager.GetForcedBatchFee(&bind.CallOpts{Pending: false})
+}
+
+// GetForceBatchTimeout gets the time, in seconds, after which anyone can sequence a forced batch
+func (etherMan *Client) GetForceBatchTimeout() (uint64, error) {
+	return etherMan.EtrogZkEVM.ForceBatchTimeout(&bind.CallOpts{Pending: false})
+}
+
+// GetForceBatchAddress gets the only address allowed to send forced batches, zero if anyone can send them
+func (etherMan *Client) GetForceBatchAddress() (common.Address, error) {
+	return etherMan.EtrogZkEVM.ForceBatchAddress(&bind.CallOpts{Pending: false})
+}
+
+// GetLastForcedBatchNumbers gets the number of the last forced batch and of the last sequenced forced batch
+func (etherMan *Client) GetLastForcedBatchNumbers() (lastForcedBatch uint64, lastSequencedForcedBatch uint64, err error) {
+	lastForcedBatch, err = etherMan.EtrogZkEVM.LastForceBatch(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return 0, 0, err
+	}
+	lastSequencedForcedBatch, err = etherMan.EtrogZkEVM.LastForceBatchSequenced(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return 0, 0, err
+	}
+	return lastForcedBatch, lastSequencedForcedBatch, nil
+}
+
+// GetForcedBatch gets a forced batch from its ForceBatch event, looking for it from the fromBlock L1 block up to the
+// latest one in intervals of ForkIDChunkSize blocks
+func (etherMan *Client) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, fromBlock uint64) (*ForcedBatch, error) {
+	latestBlock, err := etherMan.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := fromBlock; i <= latestBlock; i = i + etherMan.cfg.ForkIDChunkSize + 1 {
+		final := i + etherMan.cfg.ForkIDChunkSize
+		if final > latestBlock {
+			final = latestBlock
+		}
+		forcedBatch, err := etherMan.getForcedBatchInInterval(ctx, forcedBatchNumber, i, final)
+		if !errors.Is(err, ErrNotFound) {
+			return forcedBatch, err
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// getForcedBatchInInterval gets a forced batch from its ForceBatch event in the L1 blocks from fromBlock to toBlock
+func (etherMan *Client) getForcedBatchInInterval(ctx context.Context, forcedBatchNumber uint64, fromBlock uint64, toBlock uint64) (*ForcedBatch, error) {
+	iter, err := etherMan.EtrogZkEVM.FilterForceBatch(&bind.FilterOpts{Start: fromBlock, End: &toBlock, Context: ctx}, []uint64{forcedBatchNumber})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.Next() {
+		if iter.Event.Raw.Removed {
+			continue
+		}
+		forcedBatch, _, err := etherMan.forcedBatchFromLog(ctx, iter.Event.Raw)
+		if err != nil {
+			return nil, err
+		}
+		return &forcedBatch, nil
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return nil, ErrNotFound
+}
+
+// ForceBatch sends the forced batch with the encoded txs, paying the pol fee, signed by account
+func (etherMan *Client) ForceBatch(ctx context.Context, account common.Address, txs []byte, polFee *big.Int) (*types.Transaction, error) {
+	opts, err := etherMan.getAuthByAddress(account)
+	if err == ErrNotFound {
+		return nil, errors.New("can't find account private key to sign tx")
+	} else if err != nil {
+		return nil, err
+	}
+	if etherMan.GasProviders.MultiGasProvider {
+		opts.GasPrice = etherMan.GetL1GasPrice(ctx)
+	}
+	tx, err := etherMan.EtrogZkEVM.ForceBatch(&opts, txs, polFee)
+	if err != nil {
+		if parsedErr, ok := tryParseError(err); ok {
+			err = parsedErr
+		}
+		return nil, fmt.Errorf("error sending the forced batch. Error: %w", err)
+	}
+	return tx, nil
+}
+
+// SequenceForceBatches sequences the forced batches, signed by account. The force batch timeout must have
+// elapsed since the last of them was forced
+func (etherMan *Client) SequenceForceBatches(ctx context.Context, account common.Address, forcedBatches []ForcedBatch) (*types.Transaction, error) {
+	opts, err := etherMan.getAuthByAddress(account)
+	if err == ErrNotFound {
+		return nil, errors.New("can't find account private key to sign tx")
+	} else if err != nil {
+		return nil, err
+	}
+	if etherMan.GasProviders.MultiGasProvider {
+		opts.GasPrice = etherMan.GetL1GasPrice(ctx)
+	}
+	batches := make([]etrogpolygonzkevm.PolygonRollupBaseEtrogBatchData, 0, len(forcedBatches))
+	for _, forcedBatch := range forcedBatches {
+		batches = append(batches, etrogpolygonzkevm.PolygonRollupBaseEtrogBatchData{
+			Transactions:         forcedBatch.RawTxsData,
+			ForcedGlobalExitRoot: forcedBatch.GlobalExitRoot,
+			ForcedTimestamp:      uint64(forcedBatch.ForcedAt.Unix()),
+			ForcedBlockHashL1:    forcedBatch.ForcedBlockHashL1,
+		})
+	}
+	tx, err := etherMan.EtrogZkEVM.SequenceForceBatches(&opts, batches)
+	if err != nil {
+		if parsedErr, ok := tryParseError(err); ok {
+			err = parsedErr
+		}
+		return nil, fmt.Errorf("error sequencing the forced batches. Error: %w", err)
+	}
+	return tx, nil
+}
+
+// ParseForcedBatchNumber returns the number of the forced batch sent by the tx with the receipt
+func (etherMan *Client) ParseForcedBatchNumber(receipt *types.Receipt) (uint64, error) {
+	for _, vLog := range receipt.Logs {
+		if len(vLog.Topics) == 0 || vLog.Topics[0] != forceBatchSignatureHash {
+			continue
+		}
+		fb, err := etherMan.EtrogZkEVM.ParseForceBatch(*vLog)
+		if err != nil {
+			return 0, err
+		}
+		return fb.ForceBatchNum, nil
+	}
+	return 0, ErrNotFound
+}
+
+// GetPolAllowance gets the pol amount that the rollup smc is allowed to spend from account
+func (etherMan *Client) GetPolAllowance(account common.Address) (*big.Int, error) {
+	return etherMan.Pol.Allowance(&bind.CallOpts{Pending: false}, account, etherMan.l1Cfg.ZkEVMAddr)
+}
+
+// forcedBatchFromLog builds the forced batch of a ForceBatch event, reading the txs from the tx data
+// when the forced batch was sent directly by an account
+func (etherMan *Client) forcedBatchFromLog(ctx context.Context, vLog types.Log) (ForcedBatch, *types.Block, error) {
 	fb, err := etherMan.EtrogZkEVM.ParseForceBatch(vLog)
 	if err != nil {
-		return err
+		return ForcedBatch{}, nil, err
 	}
 	var forcedBatch ForcedBatch
 	forcedBatch.BlockNumber = vLog.BlockNumber
@@ -1213,15 +1366,15 @@ func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, bl
 	// Read the tx for this batch.
 	tx, err := etherMan.EthClient.TransactionInBlock(ctx, vLog.BlockHash, vLog.TxIndex)
 	if err != nil {
-		return err
+		return ForcedBatch{}, nil, err
 	}
 	if tx.Hash() != vLog.TxHash {
-		return fmt.Errorf("error: tx hash mismatch. want: %s have: %s", vLog.TxHash, tx.Hash().String())
+		return ForcedBatch{}, nil, fmt.Errorf("error: tx hash mismatch. want: %s have: %s", vLog.TxHash, tx.Hash().String())
 	}
 
 	msg, err := core.TransactionToMessage(tx, types.NewLondonSigner(tx.ChainId()), big.NewInt(0))
 	if err != nil {
-		return err
+		return ForcedBatch{}, nil, err
 	}
 	if fb.Sequencer == msg.From {
 		txData := tx.Data()
@@ -1229,19 +1382,19 @@ func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, bl
 		// Load contract ABI
 		abi, err := abi.JSON(strings.NewReader(etrogpolygonzkevm.EtrogpolygonzkevmABI))
 		if err != nil {
-			return err
+			return ForcedBatch{}, nil, err
 		}
 
 		// Recover Method from signature and ABI
 		method, err := abi.MethodById(txData[:4])
 		if err != nil {
-			return err
+			return ForcedBatch{}, nil, err
 		}
 
 		// Unpack method inputs
 		data, err := method.Inputs.Unpack(txData[4:])
 		if err != nil {
-			return err
+			return ForcedBatch{}, nil, err
 		}
 		bytedata := data[0].([]byte)
 		forcedBatch.RawTxsData = bytedata
@@ -1251,10 +1404,20 @@ func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, bl
 	forcedBatch.Sequencer = fb.Sequencer
 	fullBlock, err := etherMan.EthClient.BlockByHash(ctx, vLog.BlockHash)
 	if err != nil {
-		return fmt.Errorf("error getting hashParent. BlockNumber: %d. Error: %w", vLog.BlockNumber, err)
+		return ForcedBatch{}, nil, fmt.Errorf("error getting hashParent. BlockNumber: %d. Error: %w", vLog.BlockNumber, err)
 	}
-	t := time.Unix(int64(fullBlock.Time()), 0)
-	forcedBatch.ForcedAt = t
+	forcedBatch.ForcedAt = time.Unix(int64(fullBlock.Time()), 0)
+	forcedBatch.ForcedBlockHashL1 = fullBlock.ParentHash()
+	return forcedBatch, fullBlock, nil
+}
+
+func (etherMan *Client) forcedBatchEvent(ctx context.Context, vLog types.Log, blocks *[]Block, blocksOrder *map[common.Hash][]Order) error {
+	log.Debug("ForceBatch event detected")
+	forcedBatch, fullBlock, err := etherMan.forcedBatchFromLog(ctx, vLog)
+	if err != nil {
+		return err
+	}
+	t := forcedBatch.ForcedAt
 	if len(*blocks) == 0 || ((*blocks)[len(*blocks)-1].BlockHash != vLog.BlockHash || (*blocks)[len(*blocks)-1].BlockNumber != vLog.BlockNumber) {
 		block := prepareBlock(vLog, t, fullBlock)
 		block.ForcedBatches = append(block.ForcedBatches, forcedBatch)
@@ -291,6 +291,63 @@ func TestSequenceForceBatchesEvent(t *testing.T) {
 	assert.Equal(t, 0, order[blocks[1].BlockHash][0].Pos)
 }
 
+func TestForceBatchAndSequenceForceBatches(t *testing.T) {
+	// Set up testing environment
+	etherman, ethBackend, auth, _, _ := newTestingEnv()
+	ctx := context.Background()
+
+	forkID, err := etherman.GetRollupForkID()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(forkID6), forkID)
+
+	fee, err := etherman.GetForcedBatchFee()
+	require.NoError(t, err)
+	allowance, err := etherman.GetPolAllowance(auth.From)
+	require.NoError(t, err)
+	assert.True(t, allowance.Cmp(fee) >= 0)
+
+	rawTxs := "f84901843b9aca00827b0c945fbdb2315678afecb367f032d93f642f64180aa380a46057361d00000000000000000000000000000000000000000000000000000000000000048203e9808073efe1fa2d3e27f26f32208550ea9b0274d49050b816cadab05a771f4275d0242fd5d92b3fb89575c070e6c930587c520ee65a3aa8cfe382fcad20421bf51d621c"
+	data, err := hex.DecodeString(rawTxs)
+	require.NoError(t, err)
+	tx, err := etherman.ForceBatch(ctx, auth.From, data, fee)
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	receipt, err := etherman.GetTxReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	forcedBatchNumber, err := etherman.ParseForcedBatchNumber(receipt)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), forcedBatchNumber)
+	lastForcedBatch, lastSequencedForcedBatch, err := etherman.GetLastForcedBatchNumbers()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastForcedBatch)
+	assert.Equal(t, uint64(0), lastSequencedForcedBatch)
+
+	_, err = etherman.GetForcedBatch(ctx, forcedBatchNumber+1, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+	forcedBatch, err := etherman.GetForcedBatch(ctx, forcedBatchNumber, 0)
+	require.NoError(t, err)
+	assert.Equal(t, receipt.BlockNumber.Uint64(), forcedBatch.BlockNumber)
+	assert.Equal(t, rawTxs, hex.EncodeToString(forcedBatch.RawTxsData))
+	assert.Equal(t, auth.From, forcedBatch.Sequencer)
+	forcedBlock, err := etherman.EthClient.BlockByNumber(ctx, receipt.BlockNumber)
+	require.NoError(t, err)
+	assert.Equal(t, forcedBlock.ParentHash(), forcedBatch.ForcedBlockHashL1)
+	_, err = etherman.GetForcedBatch(ctx, forcedBatchNumber, receipt.BlockNumber.Uint64()+1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = ethBackend.AdjustTime((24*7 + 1) * time.Hour)
+	require.NoError(t, err)
+	ethBackend.Commit()
+	_, err = etherman.SequenceForceBatches(ctx, auth.From, []ForcedBatch{*forcedBatch})
+	require.NoError(t, err)
+	ethBackend.Commit()
+
+	_, lastSequencedForcedBatch, err = etherman.GetLastForcedBatchNumbers()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastSequencedForcedBatch)
+}
+
 func TestSendSequences(t *testing.T) {
 	// Set up testing environment
 	etherman, ethBackend, auth, _, br := newTestingEnv()
@@ -198,6 +198,7 @@ func NewSimulatedEtherman(cfg Config, auth *bind.TransactOpts) (*Client, *simula
 		RollupID:                   rollupID,
 		SCAddresses:                []common.Address{zkevmAddr, mockRollupManagerAddr, exitManagerAddr},
 		auth:                       map[common.Address]bind.TransactOpts{},
+		l1Cfg:                      L1Config{ZkEVMAddr: zkevmAddr, RollupManagerAddr: mockRollupManagerAddr, PolAddr: polAddr},
 		cfg:                        cfg,
 	}
 	err = c.AddOrReplaceAuth(*auth)
@@ -75,6 +75,7 @@ type ForcedBatch struct {
 	GlobalExitRoot    common.Hash
 	RawTxsData        []byte
 	ForcedAt          time.Time
+	ForcedBlockHashL1 common.Hash
 }
 
 // VerifiedBatch represents a VerifiedBatch
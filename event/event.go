@@ -52,6 +52,20 @@ const (
 	EventID_InvalidInfoRoot EventID = "INVALID INFOROOT"
 	// EventID_L2BlockReorg is triggered when a L2 block reorg has happened in the sequencer
 	EventID_L2BlockReorg EventID = "L2 BLOCK REORG"
+	// EventID_SenderNotAllowed is triggered when a tx is rejected because its sender is not in the senders allow-list
+	EventID_SenderNotAllowed EventID = "SENDER NOT ALLOWED"
+	// EventID_DeployerNotAllowed is triggered when a contract deployment is rejected because its sender is not in the deployers allow-list
+	EventID_DeployerNotAllowed EventID = "DEPLOYER NOT ALLOWED"
+	// EventID_AllowListUpdated is triggered when addresses are added to or removed from an allow-list
+	EventID_AllowListUpdated EventID = "ALLOW LIST UPDATED"
+	// EventID_ReputationUpdated is triggered when a sender or IP is banned because of its failed txs or its reputation is reset
+	EventID_ReputationUpdated EventID = "REPUTATION UPDATED"
+	// EventID_SequencerLeadershipAcquired is triggered when a sequencer in high availability mode acquires the leader lease
+	EventID_SequencerLeadershipAcquired EventID = "SEQUENCER LEADERSHIP ACQUIRED"
+	// EventID_SequencerLeadershipLost is triggered when a sequencer in high availability mode loses the leader lease
+	EventID_SequencerLeadershipLost EventID = "SEQUENCER LEADERSHIP LOST"
+	// EventID_SequencerControlRequested is triggered when the operator requests to pause, resume, halt, close the wip L2 block or batch or stop the sequencer
+	EventID_SequencerControlRequested EventID = "SEQUENCER CONTROL REQUESTED"
 	// Source_Node is the source of the event
 	Source_Node Source = "node"
 
@@ -0,0 +1,133 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// jwtIatTolerance is the max difference allowed between the token
+	// issued at claim and the current time, as defined by the Engine API
+	jwtIatTolerance = 60 * time.Second
+	jwtSecretLength = 32
+
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	// ErrInvalidJWTToken is returned when a token is provided but it's not valid
+	ErrInvalidJWTToken = errors.New("invalid JWT token")
+	// ErrMissingIatClaim is returned when a token doesn't have the iat claim
+	ErrMissingIatClaim = errors.New("missing issued at claim")
+	// ErrStaleIatClaim is returned when the token iat claim is out of the allowed range
+	ErrStaleIatClaim = errors.New("stale issued at claim")
+)
+
+// jwtAuthenticator validates the JWT bearer tokens sent by the clients
+// and knows which namespaces require a valid token to be used
+type jwtAuthenticator struct {
+	secret              []byte
+	protectedNamespaces map[string]struct{}
+	parser              *jwt.Parser
+}
+
+// newJWTAuthenticator creates a new instance of jwtAuthenticator loading
+// the secret from the configured file
+func newJWTAuthenticator(cfg JWTConfig) (*jwtAuthenticator, error) {
+	secret, err := loadJWTSecret(cfg.SecretFile)
+	if err != nil {
+		return nil, err
+	}
+
+	protectedNamespaces := make(map[string]struct{}, len(cfg.ProtectedNamespaces))
+	for _, namespace := range cfg.ProtectedNamespaces {
+		protectedNamespaces[namespace] = struct{}{}
+	}
+
+	return &jwtAuthenticator{
+		secret:              secret,
+		protectedNamespaces: protectedNamespaces,
+		// claims are validated manually to allow the iat tolerance in both directions
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation()),
+	}, nil
+}
+
+// loadJWTSecret reads a 32 bytes hex encoded secret from the provided file
+func loadJWTSecret(path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("JWT secret file not provided")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret file: %w", err)
+	}
+
+	hexSecret := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(hexSecret, "0x") {
+		hexSecret = "0x" + hexSecret
+	}
+	secret, err := hexutil.Decode(hexSecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT secret: %w", err)
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("invalid JWT secret length, expected %d bytes, got %d", jwtSecretLength, len(secret))
+	}
+
+	return secret, nil
+}
+
+// isProtected checks if the namespace requires a valid token
+func (a *jwtAuthenticator) isProtected(namespace string) bool {
+	_, found := a.protectedNamespaces[namespace]
+	return found
+}
+
+// authenticate checks the bearer token of the http request, it returns
+// false without error when the request doesn't provide a token and
+// an error when the provided token is not valid
+func (a *jwtAuthenticator) authenticate(req *http.Request) (bool, error) {
+	auth := req.Header.Get(authorizationHeader)
+	if auth == "" {
+		return false, nil
+	}
+
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return false, ErrInvalidJWTToken
+	}
+
+	return true, a.validateToken(strings.TrimPrefix(auth, bearerPrefix))
+}
+
+func (a *jwtAuthenticator) validateToken(strToken string) error {
+	var claims jwt.RegisteredClaims
+	token, err := a.parser.ParseWithClaims(strToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		return a.secret, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJWTToken, err)
+	}
+	if !token.Valid {
+		return ErrInvalidJWTToken
+	}
+	if !claims.VerifyExpiresAt(time.Now(), false) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidJWTToken)
+	}
+	if claims.IssuedAt == nil {
+		return ErrMissingIatClaim
+	}
+	if time.Since(claims.IssuedAt.Time) > jwtIatTolerance || time.Until(claims.IssuedAt.Time) > jwtIatTolerance {
+		return ErrStaleIatClaim
+	}
+
+	return nil
+}
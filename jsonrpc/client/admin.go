@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The admin methods require the client to be created with NewClientWithJWT,
+// the admin namespace must be listed in RPC.JWT.ProtectedNamespaces to be served
+
+// AllowList returns the addresses of the pool allow-list, senders or deployers
+func (c *Client) AllowList(ctx context.Context, kind pool.AllowListKind) ([]common.Address, error) {
+	var result []common.Address
+	err := c.call(ctx, &result, "admin_getAllowList", kind)
+	return result, err
+}
+
+// AddToAllowList adds the addresses to the pool allow-list, senders or deployers
+func (c *Client) AddToAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	return c.call(ctx, nil, "admin_addToAllowList", kind, addresses)
+}
+
+// RemoveFromAllowList removes the addresses from the pool allow-list, senders or deployers
+func (c *Client) RemoveFromAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	return c.call(ctx, nil, "admin_removeFromAllowList", kind, addresses)
+}
+
+// Reputations returns the failures and bans of the senders and IPs tracked by the pool
+func (c *Client) Reputations(ctx context.Context) ([]pool.Reputation, error) {
+	var result []pool.Reputation
+	err := c.call(ctx, &result, "admin_getReputations")
+	return result, err
+}
+
+// ResetReputation deletes the failures and the ban of a sender or IP
+func (c *Client) ResetReputation(ctx context.Context, kind pool.ReputationKind, key string) error {
+	return c.call(ctx, nil, "admin_resetReputation", kind, key)
+}
+
+// ArchivedTransaction returns a processed tx moved from the pool to the archive,
+// ethereum.NotFound is returned when the tx is not archived
+func (c *Client) ArchivedTransaction(ctx context.Context, hash common.Hash) (*types.ArchivedTransaction, error) {
+	var result *types.ArchivedTransaction
+	if err := c.call(ctx, &result, "admin_getArchivedTransaction", hash.String()); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
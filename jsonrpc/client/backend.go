@@ -0,0 +1,12 @@
+package client
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Client implements the backends required by the go-ethereum contract
+// bindings, so it can be used to deploy and interact with abigen contracts
+var (
+	_ bind.ContractBackend = (*Client)(nil)
+	_ bind.DeployBackend   = (*Client)(nil)
+)
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// BatchElem is a single request of a batch sent through BatchCallContext.
+// After the call, Result holds the decoded response result and Error
+// holds the error returned by the node for this specific request, if any.
+type BatchElem struct {
+	Method     string
+	Parameters []interface{}
+	// Result must be a pointer to the value the response result is decoded into,
+	// nil discards the result
+	Result interface{}
+	Error  error
+}
+
+// BatchCallContext sends all the provided requests in a single batch request
+// and decodes each response into its request element.
+//
+// The returned error is only related to the batch request itself, errors
+// related to each request are set to the Error field of the element.
+func (c *Client) BatchCallContext(ctx context.Context, elems []BatchElem) error {
+	if len(elems) == 0 {
+		return nil
+	}
+
+	calls := make([]BatchCall, 0, len(elems))
+	for _, elem := range elems {
+		parameters := elem.Parameters
+		if parameters == nil {
+			parameters = []interface{}{}
+		}
+		calls = append(calls, BatchCall{Method: elem.Method, Parameters: parameters})
+	}
+
+	header, err := c.authHeader()
+	if err != nil {
+		return err
+	}
+
+	responses, err := jsonRPCBatchCall(ctx, c.url, header, calls...)
+	if err != nil {
+		return err
+	}
+
+	received := make([]bool, len(elems))
+	for _, response := range responses {
+		// request ids are the index of the call in the batch
+		id, ok := response.ID.(float64)
+		if !ok || id < 0 || int(id) >= len(elems) || received[int(id)] {
+			return fmt.Errorf("unexpected batch response id: %v", response.ID)
+		}
+		i := int(id)
+		received[i] = true
+
+		if response.Error != nil {
+			elems[i].Error = response.Error.RPCError()
+			continue
+		}
+		if elems[i].Result != nil && len(response.Result) > 0 {
+			elems[i].Error = json.Unmarshal(response.Result, elems[i].Result)
+		}
+	}
+
+	for i := range elems {
+		if !received[i] {
+			elems[i].Error = fmt.Errorf("missing response for batch request %d", i)
+		}
+	}
+
+	return nil
+}
@@ -2,19 +2,24 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/golang-jwt/jwt/v4"
 )
 
 const jsonRPCVersion = "2.0"
 
 // Client defines typed wrappers for the zkEVM RPC API.
 type Client struct {
-	url string
+	url       string
+	wsURL     string
+	jwtSecret []byte
 }
 
 // NewClient creates an instance of client
@@ -24,10 +29,68 @@ func NewClient(url string) *Client {
 	}
 }
 
+// NewClientWithWebSocket creates an instance of client that is also able
+// to create subscriptions through the provided web socket URL
+func NewClientWithWebSocket(url, wsURL string) *Client {
+	return &Client{
+		url:   url,
+		wsURL: wsURL,
+	}
+}
+
+// NewClientWithJWT creates an instance of client that authenticates its requests
+// with JWT bearer tokens signed with the provided secret, which is required to
+// call the methods of the protected namespaces like admin
+func NewClientWithJWT(url string, jwtSecret []byte) *Client {
+	return &Client{
+		url:       url,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// call executes the provided method and decodes the response result into result.
+// A null result leaves result untouched.
+func (c *Client) call(ctx context.Context, result interface{}, method string, parameters ...interface{}) error {
+	if parameters == nil {
+		parameters = []interface{}{}
+	}
+
+	header, err := c.authHeader()
+	if err != nil {
+		return err
+	}
+
+	response, err := jsonRPCCall(ctx, c.url, header, method, parameters...)
+	if err != nil {
+		return err
+	}
+
+	if response.Error != nil {
+		return response.Error.RPCError()
+	}
+
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
 // JSONRPCCall executes a 2.0 JSON RPC HTTP Post Request to the provided URL with
 // the provided method and parameters, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response, error) {
+	return JSONRPCCallWithContext(context.Background(), url, method, parameters...)
+}
+
+// JSONRPCCallWithContext executes a 2.0 JSON RPC HTTP Post Request to the provided URL with
+// the provided method and parameters, the request is canceled when the context is done.
+func JSONRPCCallWithContext(ctx context.Context, url, method string, parameters ...interface{}) (types.Response, error) {
+	return jsonRPCCall(ctx, url, nil, method, parameters...)
+}
+
+// jsonRPCCall executes a 2.0 JSON RPC HTTP Post Request adding the provided headers
+func jsonRPCCall(ctx context.Context, url string, header http.Header, method string, parameters ...interface{}) (types.Response, error) {
 	params, err := json.Marshal(parameters)
 	if err != nil {
 		return types.Response{}, err
@@ -40,7 +103,7 @@ func JSONRPCCall(url, method string, parameters ...interface{}) (types.Response,
 		Params:  params,
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, request)
+	httpRes, err := sendJSONRPC_HTTPRequest(ctx, url, header, request)
 	if err != nil {
 		return types.Response{}, err
 	}
@@ -73,6 +136,17 @@ type BatchCall struct {
 // the provided method and parameters groups, which is compatible with the Ethereum
 // JSON RPC Server.
 func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error) {
+	return JSONRPCBatchCallWithContext(context.Background(), url, calls...)
+}
+
+// JSONRPCBatchCallWithContext executes a 2.0 JSON RPC HTTP Post Batch Request to the provided URL with
+// the provided method and parameters groups, the request is canceled when the context is done.
+func JSONRPCBatchCallWithContext(ctx context.Context, url string, calls ...BatchCall) ([]types.Response, error) {
+	return jsonRPCBatchCall(ctx, url, nil, calls...)
+}
+
+// jsonRPCBatchCall executes a 2.0 JSON RPC HTTP Post Batch Request adding the provided headers
+func jsonRPCBatchCall(ctx context.Context, url string, header http.Header, calls ...BatchCall) ([]types.Response, error) {
 	requests := []types.Request{}
 
 	for i, call := range calls {
@@ -91,7 +165,7 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 		requests = append(requests, req)
 	}
 
-	httpRes, err := sendJSONRPC_HTTPRequest(url, requests)
+	httpRes, err := sendJSONRPC_HTTPRequest(ctx, url, header, requests)
 	if err != nil {
 		return nil, err
 	}
@@ -116,19 +190,43 @@ func JSONRPCBatchCall(url string, calls ...BatchCall) ([]types.Response, error)
 	return res, nil
 }
 
-func sendJSONRPC_HTTPRequest(url string, payload interface{}) (*http.Response, error) {
+// authHeader returns the header with a fresh JWT bearer token when the client has a
+// JWT secret, the server only accepts tokens issued in the last minute
+func (c *Client) authHeader() (http.Header, error) {
+	if c.jwtSecret == nil {
+		return nil, nil
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}).SignedString(c.jwtSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign JWT token: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+	return header, nil
+}
+
+func sendJSONRPC_HTTPRequest(ctx context.Context, url string, header http.Header, payload interface{}) (*http.Response, error) {
 	reqBody, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
 	reqBodyReader := bytes.NewReader(reqBody)
-	httpReq, err := http.NewRequest(http.MethodPost, url, reqBodyReader)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, reqBodyReader)
 	if err != nil {
 		return nil, err
 	}
 
 	httpReq.Header.Add("Content-type", "application/json")
+	for key, values := range header {
+		for _, value := range values {
+			httpReq.Header.Add(key, value)
+		}
+	}
 
 	httpRes, err := http.DefaultClient.Do(httpReq)
 	if err != nil {
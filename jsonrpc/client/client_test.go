@@ -0,0 +1,306 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcHandler returns the result or the error of a single request
+type rpcHandler func(req types.Request) (interface{}, *types.ErrorObject)
+
+func newTestServer(t *testing.T, handler rpcHandler) *httptest.Server {
+	handleRequest := func(req types.Request) map[string]interface{} {
+		res := map[string]interface{}{"jsonrpc": jsonRPCVersion, "id": req.ID}
+		result, rpcErr := handler(req)
+		if rpcErr != nil {
+			res["error"] = rpcErr
+		} else {
+			res["result"] = result
+		}
+		return res
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		var res interface{}
+		if strings.HasPrefix(string(body), "[") {
+			var reqs []types.Request
+			require.NoError(t, json.Unmarshal(body, &reqs))
+			responses := []map[string]interface{}{}
+			// answer in reverse order to make sure responses are matched by id
+			for i := len(reqs) - 1; i >= 0; i-- {
+				responses = append(responses, handleRequest(reqs[i]))
+			}
+			res = responses
+		} else {
+			var req types.Request
+			require.NoError(t, json.Unmarshal(body, &req))
+			res = handleRequest(req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+}
+
+func TestClientCall(t *testing.T) {
+	receiptHash := common.HexToHash("0x1")
+	server := newTestServer(t, func(req types.Request) (interface{}, *types.ErrorObject) {
+		switch req.Method {
+		case "eth_chainId":
+			return "0x3e9", nil
+		case "eth_getTransactionReceipt":
+			return nil, nil
+		case "eth_getCode":
+			assert.JSONEq(t, `["0x0000000000000000000000000000000000000123","pending"]`, string(req.Params))
+			return "0x6001", nil
+		case "eth_call":
+			var params []json.RawMessage
+			require.NoError(t, json.Unmarshal(req.Params, &params))
+			require.Len(t, params, 2)
+			assert.JSONEq(t, `{"from":"0x0000000000000000000000000000000000000000","to":"0x0000000000000000000000000000000000000456","value":"0x64","input":"0x1234"}`, string(params[0]))
+			assert.JSONEq(t, `"latest"`, string(params[1]))
+			return "0xff", nil
+		default:
+			return nil, &types.ErrorObject{Code: types.DefaultErrorCode, Message: "method not found"}
+		}
+	})
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	ctx := context.Background()
+
+	chainID, err := c.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1001), chainID.Uint64())
+
+	_, err = c.TransactionReceipt(ctx, receiptHash)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+
+	code, err := c.PendingCodeAt(ctx, common.HexToAddress("0x123"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x01}, code)
+
+	to := common.HexToAddress("0x456")
+	result, err := c.CallContract(ctx, ethereum.CallMsg{To: &to, Value: big.NewInt(100), Data: []byte{0x12, 0x34}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff}, result)
+
+	_, err = c.BlockNumber(ctx)
+	require.Error(t, err)
+	rpcErr, ok := err.(types.RPCError)
+	require.True(t, ok)
+	assert.Equal(t, types.DefaultErrorCode, rpcErr.ErrorCode())
+	assert.Equal(t, "method not found", rpcErr.Error())
+}
+
+func TestClientBatchCallContext(t *testing.T) {
+	server := newTestServer(t, func(req types.Request) (interface{}, *types.ErrorObject) {
+		switch req.Method {
+		case "eth_blockNumber":
+			return "0xa", nil
+		case "zkevm_batchNumber":
+			return "0x5", nil
+		default:
+			return nil, &types.ErrorObject{Code: types.InvalidParamsErrorCode, Message: "invalid params"}
+		}
+	})
+	defer server.Close()
+
+	var blockNumber, batchNumber types.ArgUint64
+	elems := []BatchElem{
+		{Method: "eth_blockNumber", Result: &blockNumber},
+		{Method: "zkevm_batchNumber", Result: &batchNumber},
+		{Method: "eth_getBalance", Parameters: []interface{}{"0x1"}},
+	}
+	err := NewClient(server.URL).BatchCallContext(context.Background(), elems)
+	require.NoError(t, err)
+
+	assert.NoError(t, elems[0].Error)
+	assert.Equal(t, types.ArgUint64(10), blockNumber)
+	assert.NoError(t, elems[1].Error)
+	assert.Equal(t, types.ArgUint64(5), batchNumber)
+	assert.EqualError(t, elems[2].Error, "invalid params")
+}
+
+func TestClientTraceTransactionWithCallTracer(t *testing.T) {
+	txHash := common.HexToHash("0x1")
+	server := newTestServer(t, func(req types.Request) (interface{}, *types.ErrorObject) {
+		assert.Equal(t, "debug_traceTransaction", req.Method)
+		assert.JSONEq(t, `["`+txHash.String()+`",{"disableStorage":false,"disableStack":false,"enableMemory":false,"enableReturnData":false,"tracer":"callTracer","tracerConfig":{"onlyTopCall":true,"withLog":false}}]`, string(req.Params))
+		return json.RawMessage(`{"type":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x1","gas":"0x5208","gasUsed":"0x5208","input":"0x"}`), nil
+	})
+	defer server.Close()
+
+	callFrame, err := NewClient(server.URL).TraceTransactionWithCallTracer(context.Background(), txHash, CallTracerConfig{OnlyTopCall: true})
+	require.NoError(t, err)
+	assert.Equal(t, "CALL", callFrame.Type)
+	assert.Equal(t, common.HexToAddress("0x1"), callFrame.From)
+	assert.Equal(t, uint64(21000), uint64(callFrame.GasUsed))
+	assert.Equal(t, int64(1), callFrame.Value.ToInt().Int64())
+}
+
+func TestClientSubscribeFilterLogs(t *testing.T) {
+	const subscriptionID = "0x1"
+	address := common.HexToAddress("0x123")
+	unsubscribed := make(chan struct{})
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var req types.Request
+		require.NoError(t, conn.ReadJSON(&req))
+		assert.Equal(t, "eth_subscribe", req.Method)
+		assert.JSONEq(t, `["logs",{"address":["`+address.String()+`"]}]`, string(req.Params))
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{"jsonrpc": jsonRPCVersion, "id": req.ID, "result": subscriptionID}))
+
+		l := types.NewLog(ethTypes.Log{Address: address, Topics: []common.Hash{}, BlockNumber: 7})
+		data, err := json.Marshal(l)
+		require.NoError(t, err)
+		require.NoError(t, conn.WriteJSON(types.SubscriptionResponse{
+			JSONRPC: jsonRPCVersion,
+			Method:  "eth_subscription",
+			Params:  types.SubscriptionResponseParams{Subscription: subscriptionID, Result: data},
+		}))
+
+		require.NoError(t, conn.ReadJSON(&req))
+		assert.Equal(t, "eth_unsubscribe", req.Method)
+		close(unsubscribed)
+	}))
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	c := NewClientWithWebSocket(server.URL, wsURL)
+
+	logs := make(chan ethTypes.Log)
+	sub, err := c.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{Addresses: []common.Address{address}}, logs)
+	require.NoError(t, err)
+
+	l := <-logs
+	assert.Equal(t, address, l.Address)
+	assert.Equal(t, uint64(7), l.BlockNumber)
+
+	sub.Unsubscribe()
+	<-unsubscribed
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+
+	_, err = NewClient(server.URL).SubscribeNewHeads(context.Background(), make(chan *types.Block))
+	assert.ErrorIs(t, err, ErrWebSocketURLNotSet)
+}
+
+func TestClientPrivateTxAndPreconfirmation(t *testing.T) {
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1)})
+	rawTx, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	pendingTxHash := common.HexToHash("0x2")
+
+	server := newTestServer(t, func(req types.Request) (interface{}, *types.ErrorObject) {
+		switch req.Method {
+		case "zkevm_sendPrivateRawTransaction":
+			assert.JSONEq(t, `["`+types.ArgBytes(rawTx).Hex()+`"]`, string(req.Params))
+			return tx.Hash().String(), nil
+		case "eth_getTransactionPreconfirmation":
+			if string(req.Params) == `["`+pendingTxHash.String()+`"]` {
+				return nil, nil
+			}
+			return json.RawMessage(`{"transactionHash":"` + tx.Hash().String() + `","blockNumber":"0xa","transactionIndex":"0x1","status":"0x1","signature":"0x1234","rescinded":false}`), nil
+		default:
+			return nil, &types.ErrorObject{Code: types.DefaultErrorCode, Message: "method not found"}
+		}
+	})
+	defer server.Close()
+
+	c := NewClient(server.URL)
+	ctx := context.Background()
+
+	txHash, err := c.SendPrivateRawTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), txHash)
+
+	preconfirmation, err := c.TransactionPreconfirmation(ctx, txHash)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), preconfirmation.TxHash)
+	assert.Equal(t, types.ArgUint64(10), preconfirmation.BlockNumber)
+	assert.Equal(t, types.ArgUint64(1), preconfirmation.TxIndex)
+	assert.Equal(t, types.ArgBytes{0x12, 0x34}, preconfirmation.Signature)
+
+	_, err = c.TransactionPreconfirmation(ctx, pendingTxHash)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestClientAdminWithJWT(t *testing.T) {
+	secret := common.Hex2Bytes("1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	addresses := []common.Address{common.HexToAddress("0x1")}
+	archivedTxHash := common.HexToHash("0x2")
+
+	server := newTestServer(t, func(req types.Request) (interface{}, *types.ErrorObject) {
+		switch req.Method {
+		case "admin_getAllowList":
+			assert.JSONEq(t, `["senders"]`, string(req.Params))
+			return addresses, nil
+		case "admin_addToAllowList":
+			assert.JSONEq(t, `["deployers",["`+addresses[0].String()+`"]]`, string(req.Params))
+			return true, nil
+		case "admin_resetReputation":
+			assert.JSONEq(t, `["ip","127.0.0.1"]`, string(req.Params))
+			return true, nil
+		case "admin_getArchivedTransaction":
+			return nil, nil
+		default:
+			return nil, &types.ErrorObject{Code: types.DefaultErrorCode, Message: "method not found"}
+		}
+	})
+	defer server.Close()
+
+	var authorization string
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		server.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer authServer.Close()
+
+	c := NewClientWithJWT(authServer.URL, secret)
+	ctx := context.Background()
+
+	allowList, err := c.AllowList(ctx, pool.AllowListSenders)
+	require.NoError(t, err)
+	assert.Equal(t, addresses, allowList)
+	require.True(t, strings.HasPrefix(authorization, "Bearer "))
+	var claims jwt.RegisteredClaims
+	_, err = jwt.ParseWithClaims(strings.TrimPrefix(authorization, "Bearer "), &claims, func(token *jwt.Token) (interface{}, error) {
+		return secret, nil
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, claims.IssuedAt)
+
+	require.NoError(t, c.AddToAllowList(ctx, pool.AllowListDeployers, addresses))
+	require.NoError(t, c.ResetReputation(ctx, pool.ReputationIP, "127.0.0.1"))
+	_, err = c.ArchivedTransaction(ctx, archivedTxHash)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+
+	// the clients without JWT secret don't send the header
+	_, err = NewClient(authServer.URL).AllowList(ctx, pool.AllowListSenders)
+	require.NoError(t, err)
+	assert.Empty(t, authorization)
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers/structlogger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// CallTracer is the name of the native call tracer
+	CallTracer = "callTracer"
+	// FlatCallTracer is the name of the native flat call tracer
+	FlatCallTracer = "flatCallTracer"
+	// PrestateTracer is the name of the native prestate tracer
+	PrestateTracer = "prestateTracer"
+	// FourByteTracer is the name of the native 4byte tracer
+	FourByteTracer = "4byteTracer"
+	// NoopTracer is the name of the native noop tracer
+	NoopTracer = "noopTracer"
+	// MuxTracer is the name of the native mux tracer
+	MuxTracer = "muxTracer"
+)
+
+// TraceConfig is the configuration sent to the debug trace endpoints,
+// when Tracer is nil the struct logger is used
+type TraceConfig struct {
+	DisableStorage   bool            `json:"disableStorage"`
+	DisableStack     bool            `json:"disableStack"`
+	EnableMemory     bool            `json:"enableMemory"`
+	EnableReturnData bool            `json:"enableReturnData"`
+	Tracer           *string         `json:"tracer,omitempty"`
+	TracerConfig     json.RawMessage `json:"tracerConfig,omitempty"`
+}
+
+// TransactionTrace is the trace of a single transaction returned when
+// tracing a block or a batch, TxHash is only provided when tracing batches
+type TransactionTrace struct {
+	TxHash *common.Hash    `json:"txHash,omitempty"`
+	Result json.RawMessage `json:"result"`
+}
+
+// Decode decodes the trace result into v, which must match the tracer used
+func (t TransactionTrace) Decode(v interface{}) error {
+	return json.Unmarshal(t.Result, v)
+}
+
+// StructLogTrace is the result of the default struct logger
+type StructLogTrace = structlogger.TraceResponse
+
+// CallFrame is the result of the call tracer
+type CallFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+	Logs         []CallLog       `json:"logs,omitempty"`
+}
+
+// CallLog is a log collected by the call tracer when WithLog is enabled
+type CallLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// CallTracerConfig is the configuration of the call tracer
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"`
+	WithLog     bool `json:"withLog"`
+}
+
+// FlatCallFrame is an element of the result of the flat call tracer
+type FlatCallFrame struct {
+	Action              FlatCallAction  `json:"action"`
+	BlockHash           *common.Hash    `json:"blockHash"`
+	BlockNumber         uint64          `json:"blockNumber"`
+	Error               string          `json:"error,omitempty"`
+	Result              *FlatCallResult `json:"result,omitempty"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     *common.Hash    `json:"transactionHash"`
+	TransactionPosition uint64          `json:"transactionPosition"`
+	Type                string          `json:"type"`
+}
+
+// FlatCallAction is the action of a flat call frame
+type FlatCallAction struct {
+	Author         *common.Address `json:"author,omitempty"`
+	RewardType     string          `json:"rewardType,omitempty"`
+	SelfDestructed *common.Address `json:"address,omitempty"`
+	Balance        *hexutil.Big    `json:"balance,omitempty"`
+	CallType       string          `json:"callType,omitempty"`
+	CreationMethod string          `json:"creationMethod,omitempty"`
+	From           *common.Address `json:"from,omitempty"`
+	Gas            *hexutil.Uint64 `json:"gas,omitempty"`
+	Init           *hexutil.Bytes  `json:"init,omitempty"`
+	Input          *hexutil.Bytes  `json:"input,omitempty"`
+	RefundAddress  *common.Address `json:"refundAddress,omitempty"`
+	To             *common.Address `json:"to,omitempty"`
+	Value          *hexutil.Big    `json:"value,omitempty"`
+}
+
+// FlatCallResult is the result of a flat call frame
+type FlatCallResult struct {
+	Address *common.Address `json:"address,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+}
+
+// PrestateAccount is the state of an account collected by the prestate tracer
+type PrestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// PrestateDiff is the result of the prestate tracer when the diff mode is enabled
+type PrestateDiff struct {
+	Post map[common.Address]*PrestateAccount `json:"post"`
+	Pre  map[common.Address]*PrestateAccount `json:"pre"`
+}
+
+// TraceTransaction returns the raw trace of the transaction produced by the configured tracer
+func (c *Client) TraceTransaction(ctx context.Context, hash common.Hash, cfg *TraceConfig) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.call(ctx, &result, "debug_traceTransaction", hash.String(), cfg)
+	return result, err
+}
+
+// TraceBlockByNumber returns the raw traces of all the transactions of the block.
+// If number is nil, the latest known block is used.
+func (c *Client) TraceBlockByNumber(ctx context.Context, number *big.Int, cfg *TraceConfig) ([]TransactionTrace, error) {
+	var result []TransactionTrace
+	err := c.call(ctx, &result, "debug_traceBlockByNumber", toBlockNumArg(number), cfg)
+	return result, err
+}
+
+// TraceBlockByHash returns the raw traces of all the transactions of the block
+func (c *Client) TraceBlockByHash(ctx context.Context, hash common.Hash, cfg *TraceConfig) ([]TransactionTrace, error) {
+	var result []TransactionTrace
+	err := c.call(ctx, &result, "debug_traceBlockByHash", hash.String(), cfg)
+	return result, err
+}
+
+// TraceBatchByNumber returns the raw traces of all the transactions of the batch.
+// If number is nil, the latest known batch is used.
+func (c *Client) TraceBatchByNumber(ctx context.Context, number *big.Int, cfg *TraceConfig) ([]TransactionTrace, error) {
+	bn := types.LatestBatchNumber
+	if number != nil {
+		bn = types.BatchNumber(number.Int64())
+	}
+
+	var result []TransactionTrace
+	err := c.call(ctx, &result, "debug_traceBatchByNumber", bn.StringOrHex(), cfg)
+	return result, err
+}
+
+// TraceTransactionWithStructLogger traces the transaction with the default struct logger
+func (c *Client) TraceTransactionWithStructLogger(ctx context.Context, hash common.Hash, cfg *TraceConfig) (*StructLogTrace, error) {
+	if cfg != nil && cfg.Tracer != nil {
+		cfgCopy := *cfg
+		cfgCopy.Tracer = nil
+		cfg = &cfgCopy
+	}
+
+	var result *StructLogTrace
+	err := c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+// TraceTransactionWithCallTracer traces the transaction with the call tracer
+func (c *Client) TraceTransactionWithCallTracer(ctx context.Context, hash common.Hash, tracerCfg CallTracerConfig) (*CallFrame, error) {
+	cfg, err := newNativeTraceConfig(CallTracer, tracerCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *CallFrame
+	err = c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+// TraceTransactionWithFlatCallTracer traces the transaction with the flat call tracer
+func (c *Client) TraceTransactionWithFlatCallTracer(ctx context.Context, hash common.Hash) ([]FlatCallFrame, error) {
+	cfg, err := newNativeTraceConfig(FlatCallTracer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []FlatCallFrame
+	err = c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+// TraceTransactionWithPrestateTracer traces the transaction with the prestate tracer
+// and returns the state of the accounts touched by the transaction before its execution
+func (c *Client) TraceTransactionWithPrestateTracer(ctx context.Context, hash common.Hash) (map[common.Address]*PrestateAccount, error) {
+	cfg, err := newNativeTraceConfig(PrestateTracer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[common.Address]*PrestateAccount
+	err = c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+// TraceTransactionWithPrestateDiffTracer traces the transaction with the prestate tracer
+// in diff mode and returns the state of the touched accounts before and after the execution
+func (c *Client) TraceTransactionWithPrestateDiffTracer(ctx context.Context, hash common.Hash) (*PrestateDiff, error) {
+	cfg, err := newNativeTraceConfig(PrestateTracer, map[string]bool{"diffMode": true})
+	if err != nil {
+		return nil, err
+	}
+
+	var result *PrestateDiff
+	err = c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+// TraceTransactionWithFourByteTracer traces the transaction with the 4byte tracer and
+// returns the number of times each selector-calldata size pair was called
+func (c *Client) TraceTransactionWithFourByteTracer(ctx context.Context, hash common.Hash) (map[string]int, error) {
+	cfg, err := newNativeTraceConfig(FourByteTracer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]int
+	err = c.traceTransactionInto(ctx, &result, hash, cfg)
+	return result, err
+}
+
+func (c *Client) traceTransactionInto(ctx context.Context, result interface{}, hash common.Hash, cfg *TraceConfig) error {
+	return c.call(ctx, result, "debug_traceTransaction", hash.String(), cfg)
+}
+
+// newNativeTraceConfig creates the trace config to use the provided native tracer
+func newNativeTraceConfig(tracer string, tracerCfg interface{}) (*TraceConfig, error) {
+	cfg := &TraceConfig{Tracer: &tracer}
+	if tracerCfg != nil {
+		rawTracerCfg, err := json.Marshal(tracerCfg)
+		if err != nil {
+			return nil, err
+		}
+		cfg.TracerConfig = rawTracerCfg
+	}
+	return cfg, nil
+}
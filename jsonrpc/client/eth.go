@@ -3,78 +3,540 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 
-	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// BlockNumber returns the latest block number
-func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
-	response, err := JSONRPCCall(c.url, "eth_blockNumber")
-	if err != nil {
-		return 0, err
+// ChainID returns the chain id of the network
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var result types.ArgBig
+	if err := c.call(ctx, &result, "eth_chainId"); err != nil {
+		return nil, err
 	}
+	return (*big.Int)(&result), nil
+}
 
-	if response.Error != nil {
-		return 0, response.Error.RPCError()
+// NetworkID returns the network id of the network
+func (c *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	var result string
+	if err := c.call(ctx, &result, "net_version"); err != nil {
+		return nil, err
+	}
+	networkID, ok := new(big.Int).SetString(result, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid net_version result %q", result)
 	}
+	return networkID, nil
+}
 
+// ClientVersion returns the version of the node
+func (c *Client) ClientVersion(ctx context.Context) (string, error) {
 	var result string
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
-		return 0, err
+	err := c.call(ctx, &result, "web3_clientVersion")
+	return result, err
+}
+
+// Sha3 returns the keccak-256 hash of the provided data computed by the node
+func (c *Client) Sha3(ctx context.Context, data []byte) (common.Hash, error) {
+	var result common.Hash
+	err := c.call(ctx, &result, "web3_sha3", types.ArgBytes(data))
+	return result, err
+}
+
+// ProtocolVersion returns the ethereum protocol version supported by the node
+func (c *Client) ProtocolVersion(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, &result, "eth_protocolVersion")
+	return result, err
+}
+
+// Coinbase returns the address of the sequencer
+func (c *Client) Coinbase(ctx context.Context) (common.Address, error) {
+	var result common.Address
+	err := c.call(ctx, &result, "eth_coinbase")
+	return result, err
+}
+
+// Compilers returns the compilers available in the node
+func (c *Client) Compilers(ctx context.Context) ([]string, error) {
+	var result []string
+	err := c.call(ctx, &result, "eth_getCompilers")
+	return result, err
+}
+
+// SyncProgress returns the synchronization status of the node,
+// nil is returned when the node is not synchronizing
+func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var result json.RawMessage
+	if err := c.call(ctx, &result, "eth_syncing"); err != nil {
+		return nil, err
 	}
 
-	bigBlockNumber := hex.DecodeBig(result)
-	blockNumber := bigBlockNumber.Uint64()
+	var syncing bool
+	if err := json.Unmarshal(result, &syncing); err == nil {
+		return nil, nil
+	}
 
-	return blockNumber, nil
+	var progress struct {
+		StartingBlock types.ArgUint64 `json:"startingBlock"`
+		CurrentBlock  types.ArgUint64 `json:"currentBlock"`
+		HighestBlock  types.ArgUint64 `json:"highestBlock"`
+	}
+	if err := json.Unmarshal(result, &progress); err != nil {
+		return nil, err
+	}
+
+	return &ethereum.SyncProgress{
+		StartingBlock: uint64(progress.StartingBlock),
+		CurrentBlock:  uint64(progress.CurrentBlock),
+		HighestBlock:  uint64(progress.HighestBlock),
+	}, nil
+}
+
+// BlockNumber returns the latest block number
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	if err := c.call(ctx, &result, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
 }
 
 // BlockByNumber returns a block from the current canonical chain. If number is nil, the
 // latest known block is returned.
 func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
-	bn := types.LatestBlockNumber
-	if number != nil {
-		bn = types.BlockNumber(number.Int64())
-	}
+	var result *types.Block
+	err := c.call(ctx, &result, "eth_getBlockByNumber", toBlockNumArg(number), true, true)
+	return result, err
+}
 
-	response, err := JSONRPCCall(c.url, "eth_getBlockByNumber", bn.StringOrHex(), true, true)
-	if err != nil {
+// BlockByHash returns a block from the current canonical chain.
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, &result, "eth_getBlockByHash", hash.String(), true, true)
+	return result, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If number is
+// nil, the latest known header is returned.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	var result *types.Block
+	if err := c.call(ctx, &result, "eth_getBlockByNumber", toBlockNumArg(number), false); err != nil {
 		return nil, err
 	}
-
-	if response.Error != nil {
-		return nil, response.Error.RPCError()
+	if result == nil {
+		return nil, ethereum.NotFound
 	}
+	return toHeader(result), nil
+}
 
+// HeaderByHash returns the block header with the given hash.
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*ethTypes.Header, error) {
 	var result *types.Block
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
+	if err := c.call(ctx, &result, "eth_getBlockByHash", hash.String(), false); err != nil {
 		return nil, err
 	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return toHeader(result), nil
+}
+
+// TransactionCount returns the number of transactions in the given block.
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getBlockTransactionCountByHash", blockHash.String())
+	return uint(result), err
+}
+
+// TransactionCountByNumber returns the number of transactions in the given block.
+// If number is nil, the latest known block is used.
+func (c *Client) TransactionCountByNumber(ctx context.Context, number *big.Int) (uint, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getBlockTransactionCountByNumber", toBlockNumArg(number))
+	return uint(result), err
+}
+
+// UncleCountByBlockHash returns the number of uncles in the given block, always 0 for L2 blocks
+func (c *Client) UncleCountByBlockHash(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getUncleCountByBlockHash", blockHash.String())
+	return uint(result), err
+}
+
+// UncleCountByBlockNumber returns the number of uncles in the given block, always 0 for L2 blocks
+func (c *Client) UncleCountByBlockNumber(ctx context.Context, number *big.Int) (uint, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getUncleCountByBlockNumber", toBlockNumArg(number))
+	return uint(result), err
+}
+
+// UncleByBlockHashAndIndex returns an uncle of the given block, always nil for L2 blocks
+func (c *Client) UncleByBlockHashAndIndex(ctx context.Context, blockHash common.Hash, index uint) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, &result, "eth_getUncleByBlockHashAndIndex", blockHash.String(), types.ArgUint64(index))
+	return result, err
+}
+
+// UncleByBlockNumberAndIndex returns an uncle of the given block, always nil for L2 blocks
+func (c *Client) UncleByBlockNumberAndIndex(ctx context.Context, number *big.Int, index uint) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, &result, "eth_getUncleByBlockNumberAndIndex", toBlockNumArg(number), types.ArgUint64(index))
+	return result, err
+}
 
+// TransactionByHash returns the transaction with the given hash,
+// ethereum.NotFound is returned when the transaction is not known by the node
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, error) {
+	var result *types.Transaction
+	if err := c.call(ctx, &result, "eth_getTransactionByHash", hash.String()); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
 	return result, nil
 }
 
-// BlockByHash returns a block from the current canonical chain.
-func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
-	response, err := JSONRPCCall(c.url, "eth_getBlockByHash", hash.String(), true, true)
-	if err != nil {
+// TransactionInBlock returns a single transaction at index in the given block.
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	var result *types.Transaction
+	if err := c.call(ctx, &result, "eth_getTransactionByBlockHashAndIndex", blockHash.String(), types.ArgUint64(index)); err != nil {
 		return nil, err
 	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
 
-	if response.Error != nil {
-		return nil, response.Error.RPCError()
+// TransactionInBlockByNumber returns a single transaction at index in the given block.
+// If number is nil, the latest known block is used.
+func (c *Client) TransactionInBlockByNumber(ctx context.Context, number *big.Int, index uint) (*types.Transaction, error) {
+	var result *types.Transaction
+	if err := c.call(ctx, &result, "eth_getTransactionByBlockNumberAndIndex", toBlockNumArg(number), types.ArgUint64(index)); err != nil {
+		return nil, err
 	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
 
-	var result *types.Block
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
+// TransactionReceipt returns the receipt of a mined transaction, ethereum.NotFound
+// is returned while the transaction is not mined yet.
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethTypes.Receipt, error) {
+	var result *types.Receipt
+	if err := c.call(ctx, &result, "eth_getTransactionReceipt", txHash.String()); err != nil {
 		return nil, err
 	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return toReceipt(result), nil
+}
 
+// TransactionPreconfirmation returns the pre-confirmation issued by the sequencer for
+// the transaction, ethereum.NotFound is returned while the transaction is not pre-confirmed
+func (c *Client) TransactionPreconfirmation(ctx context.Context, txHash common.Hash) (*types.Preconfirmation, error) {
+	var result *types.Preconfirmation
+	if err := c.call(ctx, &result, "eth_getTransactionPreconfirmation", txHash.String()); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
 	return result, nil
 }
+
+// BalanceAt returns the wei balance of the given account.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result types.ArgBig
+	if err := c.call(ctx, &result, "eth_getBalance", account, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// StorageAt returns the value of key in the contract storage of the given account.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var result types.ArgBytes
+	err := c.call(ctx, &result, "eth_getStorageAt", account, key, toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// CodeAt returns the contract code of the given account.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result types.ArgBytes
+	err := c.call(ctx, &result, "eth_getCode", account, toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result types.ArgBytes
+	err := c.call(ctx, &result, "eth_getCode", account, types.Pending)
+	return result, err
+}
+
+// NonceAt returns the account nonce of the given account.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getTransactionCount", account, toBlockNumArg(blockNumber))
+	return uint64(result), err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state,
+// which includes the transactions already added to the pool.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_getTransactionCount", account, types.Pending)
+	return uint64(result), err
+}
+
+// CallContract executes a message call transaction, which is directly executed in the VM
+// of the node, but never mined into the blockchain.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result types.ArgBytes
+	err := c.call(ctx, &result, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// PendingCallContract executes a message call transaction using the pending state.
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var result types.ArgBytes
+	err := c.call(ctx, &result, "eth_call", toCallArg(msg), types.Pending)
+	return result, err
+}
+
+// EstimateGas tries to estimate the gas needed to execute the provided message
+// on top of the latest known block.
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "eth_estimateGas", toCallArg(msg))
+	return uint64(result), err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a timely
+// execution of a transaction.
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result types.ArgBig
+	if err := c.call(ctx, &result, "eth_gasPrice"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap. The network
+// doesn't support dynamic fee transactions, so the tip is always zero.
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// SendTransaction injects a signed transaction into the pool for execution.
+func (c *Client) SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error {
+	_, err := c.SendRawTransaction(ctx, tx)
+	return err
+}
+
+// SendRawTransaction injects a signed transaction into the pool for execution
+// and returns the hash of the transaction computed by the node.
+func (c *Client) SendRawTransaction(ctx context.Context, tx *ethTypes.Transaction) (common.Hash, error) {
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	var result common.Hash
+	err = c.call(ctx, &result, "eth_sendRawTransaction", types.ArgBytes(rawTx))
+	return result, err
+}
+
+// FilterLogs executes a filter query.
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	var result []ethTypes.Log
+	err := c.call(ctx, &result, "eth_getLogs", toFilterArg(q))
+	return result, err
+}
+
+// NewFilter creates a log filter in the node and returns its id
+func (c *Client) NewFilter(ctx context.Context, q ethereum.FilterQuery) (string, error) {
+	var result string
+	err := c.call(ctx, &result, "eth_newFilter", toFilterArg(q))
+	return result, err
+}
+
+// NewBlockFilter creates a filter in the node to be notified about new blocks and returns its id
+func (c *Client) NewBlockFilter(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, &result, "eth_newBlockFilter")
+	return result, err
+}
+
+// NewPendingTransactionFilter creates a filter in the node to be notified about
+// new pending transactions and returns its id
+func (c *Client) NewPendingTransactionFilter(ctx context.Context) (string, error) {
+	var result string
+	err := c.call(ctx, &result, "eth_newPendingTransactionFilter")
+	return result, err
+}
+
+// FilterHashChanges returns the hashes collected by a block or pending transaction
+// filter since the last time the filter was polled
+func (c *Client) FilterHashChanges(ctx context.Context, filterID string) ([]common.Hash, error) {
+	var result []common.Hash
+	err := c.call(ctx, &result, "eth_getFilterChanges", filterID)
+	return result, err
+}
+
+// FilterLogChanges returns the logs collected by a log filter since the
+// last time the filter was polled
+func (c *Client) FilterLogChanges(ctx context.Context, filterID string) ([]ethTypes.Log, error) {
+	var result []ethTypes.Log
+	err := c.call(ctx, &result, "eth_getFilterChanges", filterID)
+	return result, err
+}
+
+// FilterLogsByID returns all the logs matching the log filter
+func (c *Client) FilterLogsByID(ctx context.Context, filterID string) ([]ethTypes.Log, error) {
+	var result []ethTypes.Log
+	err := c.call(ctx, &result, "eth_getFilterLogs", filterID)
+	return result, err
+}
+
+// UninstallFilter removes the filter from the node
+func (c *Client) UninstallFilter(ctx context.Context, filterID string) (bool, error) {
+	var result bool
+	err := c.call(ctx, &result, "eth_uninstallFilter", filterID)
+	return result, err
+}
+
+// TxPoolContent returns the content of the pool as served by txpool_content
+func (c *Client) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
+	var result *TxPoolContent
+	err := c.call(ctx, &result, "txpool_content")
+	return result, err
+}
+
+// TxPoolContent is the content of the pool grouped by sender and nonce
+type TxPoolContent struct {
+	Pending map[common.Address]map[uint64]*types.Transaction `json:"pending"`
+	Queued  map[common.Address]map[uint64]*types.Transaction `json:"queued"`
+}
+
+// toBlockNumArg converts a block number into the RPC parameter,
+// nil is converted to latest and negative values to the block tags
+func toBlockNumArg(number *big.Int) string {
+	bn := types.LatestBlockNumber
+	if number != nil {
+		bn = types.BlockNumber(number.Int64())
+	}
+	return bn.StringOrHex()
+}
+
+// toCallArg converts a call message into the RPC transaction parameter
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["input"] = types.ArgBytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = types.ArgBig(*msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = types.ArgUint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = types.ArgBig(*msg.GasPrice)
+	}
+	return arg
+}
+
+// toFilterArg converts a filter query into the RPC log filter parameter
+func toFilterArg(q ethereum.FilterQuery) interface{} {
+	arg := map[string]interface{}{}
+	if len(q.Addresses) > 0 {
+		arg["address"] = q.Addresses
+	}
+	if len(q.Topics) > 0 {
+		arg["topics"] = q.Topics
+	}
+	if q.BlockHash != nil {
+		arg["blockHash"] = *q.BlockHash
+		return arg
+	}
+	if q.FromBlock != nil {
+		arg["fromBlock"] = toBlockNumArg(q.FromBlock)
+	}
+	if q.ToBlock != nil {
+		arg["toBlock"] = toBlockNumArg(q.ToBlock)
+	}
+	return arg
+}
+
+// toHeader builds a geth header from the RPC block
+func toHeader(b *types.Block) *ethTypes.Header {
+	header := &ethTypes.Header{
+		ParentHash:  b.ParentHash,
+		UncleHash:   b.Sha3Uncles,
+		Root:        b.StateRoot,
+		TxHash:      b.TxRoot,
+		ReceiptHash: b.ReceiptsRoot,
+		Bloom:       b.LogsBloom,
+		Difficulty:  new(big.Int).SetUint64(uint64(b.Difficulty)),
+		Number:      new(big.Int).SetUint64(uint64(b.Number)),
+		GasLimit:    uint64(b.GasLimit),
+		GasUsed:     uint64(b.GasUsed),
+		Time:        uint64(b.Timestamp),
+		Extra:       b.ExtraData,
+		MixDigest:   b.MixHash,
+	}
+	if b.Miner != nil {
+		header.Coinbase = *b.Miner
+	}
+	if b.Nonce != nil {
+		header.Nonce = ethTypes.BlockNonce(common.LeftPadBytes(*b.Nonce, len(ethTypes.BlockNonce{})))
+	}
+	return header
+}
+
+// toReceipt builds a geth receipt from the RPC receipt
+func toReceipt(r *types.Receipt) *ethTypes.Receipt {
+	receipt := &ethTypes.Receipt{
+		Type:              uint8(r.Type),
+		Status:            uint64(r.Status),
+		CumulativeGasUsed: uint64(r.CumulativeGasUsed),
+		Bloom:             r.LogsBloom,
+		Logs:              r.Logs,
+		TxHash:            r.TxHash,
+		GasUsed:           uint64(r.GasUsed),
+		BlockHash:         r.BlockHash,
+		BlockNumber:       new(big.Int).SetUint64(uint64(r.BlockNumber)),
+		TransactionIndex:  uint(r.TxIndex),
+	}
+	if r.Root != nil {
+		receipt.PostState = r.Root.Bytes()
+	}
+	if r.ContractAddress != nil {
+		receipt.ContractAddress = *r.ContractAddress
+	}
+	if r.EffectiveGasPrice != nil {
+		receipt.EffectiveGasPrice = (*big.Int)(r.EffectiveGasPrice)
+	}
+	return receipt
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+)
+
+// ErrWebSocketURLNotSet is returned when a subscription is requested
+// to a client created without a web socket URL
+var ErrWebSocketURLNotSet = errors.New("web socket URL not set, use NewClientWithWebSocket to create the client")
+
+// Subscription is an active subscription created through the web socket
+// connection, it implements ethereum.Subscription
+type Subscription struct {
+	ID string
+
+	conn      *websocket.Conn
+	err       chan error
+	quit      chan struct{}
+	unsubOnce sync.Once
+}
+
+// Unsubscribe uninstalls the subscription in the node and closes the web socket
+// connection, the Err channel is closed afterwards. It can be called more than once.
+func (s *Subscription) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		close(s.quit)
+		_ = s.writeRequest("eth_unsubscribe", s.ID)
+		_ = s.conn.Close()
+	})
+}
+
+// Err returns the subscription error channel. The channel receives a value
+// if there is an issue with the subscription and it is closed when Unsubscribe is called.
+func (s *Subscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *Subscription) writeRequest(method string, parameters ...interface{}) error {
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+
+	request := types.Request{
+		JSONRPC: jsonRPCVersion,
+		ID:      float64(1),
+		Method:  method,
+		Params:  params,
+	}
+	return s.conn.WriteJSON(request)
+}
+
+// listen reads the notifications of the subscription and forwards their result
+// to the handler until the subscription is unsubscribed or the connection fails
+func (s *Subscription) listen(handler func(result json.RawMessage) error) {
+	defer close(s.err)
+
+	for {
+		_, message, err := s.conn.ReadMessage()
+		if err != nil {
+			s.sendErr(err)
+			return
+		}
+
+		var notification types.SubscriptionResponse
+		if err := json.Unmarshal(message, &notification); err != nil || notification.Params.Subscription != s.ID {
+			// responses to requests like eth_unsubscribe are ignored
+			continue
+		}
+
+		if err := handler(notification.Params.Result); err != nil {
+			s.sendErr(err)
+			return
+		}
+	}
+}
+
+// sendErr forwards the error unless the subscription was unsubscribed, in that
+// case the error is caused by the connection being closed and is discarded
+func (s *Subscription) sendErr(err error) {
+	select {
+	case <-s.quit:
+		return
+	default:
+	}
+	select {
+	case <-s.quit:
+	case s.err <- err:
+	}
+}
+
+// SubscribeNewHeads subscribes to notifications about new blocks added to the chain
+func (c *Client) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Block) (*Subscription, error) {
+	return c.subscribe(ctx, func(sub *Subscription, result json.RawMessage) error {
+		var block *types.Block
+		if err := json.Unmarshal(result, &block); err != nil {
+			return err
+		}
+		select {
+		case ch <- block:
+		case <-sub.quit:
+		}
+		return nil
+	}, "newHeads")
+}
+
+// SubscribeLogs subscribes to the logs matching the provided filter query
+func (c *Client) SubscribeLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (*Subscription, error) {
+	return c.subscribe(ctx, func(sub *Subscription, result json.RawMessage) error {
+		var l ethTypes.Log
+		if err := json.Unmarshal(result, &l); err != nil {
+			return err
+		}
+		select {
+		case ch <- l:
+		case <-sub.quit:
+		}
+		return nil
+	}, "logs", toFilterArg(q))
+}
+
+// SubscribeFilterLogs subscribes to the logs matching the provided filter query,
+// it is the ethereum.LogFilterer flavour of SubscribeLogs
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error) {
+	sub, err := c.SubscribeLogs(ctx, q, ch)
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// subscribe opens a dedicated web socket connection, creates the subscription
+// in the node and starts forwarding its notifications to the handler
+func (c *Client) subscribe(ctx context.Context, handler func(sub *Subscription, result json.RawMessage) error, parameters ...interface{}) (*Subscription, error) {
+	if c.wsURL == "" {
+		return nil, ErrWebSocketURLNotSet
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.wsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		conn: conn,
+		err:  make(chan error, 1),
+		quit: make(chan struct{}),
+	}
+
+	if err := sub.writeRequest("eth_subscribe", parameters...); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	var response types.Response
+	if err := conn.ReadJSON(&response); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if response.Error != nil {
+		_ = conn.Close()
+		return nil, response.Error.RPCError()
+	}
+	if err := json.Unmarshal(response.Result, &sub.ID); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	go sub.listen(func(result json.RawMessage) error {
+		return handler(sub, result)
+	})
+
+	return sub, nil
+}
@@ -2,98 +2,212 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"math/big"
 
-	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // BatchNumber returns the latest batch number
 func (c *Client) BatchNumber(ctx context.Context) (uint64, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_batchNumber")
-	if err != nil {
-		return 0, err
-	}
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "zkevm_batchNumber")
+	return uint64(result), err
+}
 
-	if response.Error != nil {
-		return 0, response.Error.RPCError()
-	}
+// VirtualBatchNumber returns the latest virtualized batch number
+func (c *Client) VirtualBatchNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "zkevm_virtualBatchNumber")
+	return uint64(result), err
+}
 
-	var result string
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
-		return 0, err
-	}
+// VerifiedBatchNumber returns the latest verified batch number
+func (c *Client) VerifiedBatchNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "zkevm_verifiedBatchNumber")
+	return uint64(result), err
+}
+
+// ConsolidatedBlockNumber returns the last block number related to the last verified batch
+func (c *Client) ConsolidatedBlockNumber(ctx context.Context) (uint64, error) {
+	var result types.ArgUint64
+	err := c.call(ctx, &result, "zkevm_consolidatedBlockNumber")
+	return uint64(result), err
+}
 
-	bigBatchNumber := hex.DecodeBig(result)
-	batchNumber := bigBatchNumber.Uint64()
+// IsBlockConsolidated returns true if the block is part of a verified batch
+func (c *Client) IsBlockConsolidated(ctx context.Context, blockNumber uint64) (bool, error) {
+	var result bool
+	err := c.call(ctx, &result, "zkevm_isBlockConsolidated", types.ArgUint64(blockNumber))
+	return result, err
+}
 
-	return batchNumber, nil
+// IsBlockVirtualized returns true if the block is part of a virtualized batch
+func (c *Client) IsBlockVirtualized(ctx context.Context, blockNumber uint64) (bool, error) {
+	var result bool
+	err := c.call(ctx, &result, "zkevm_isBlockVirtualized", types.ArgUint64(blockNumber))
+	return result, err
+}
+
+// BatchNumberByBlockNumber returns the number of the batch that contains the block,
+// ethereum.NotFound is returned when the block is not known by the node
+func (c *Client) BatchNumberByBlockNumber(ctx context.Context, blockNumber uint64) (uint64, error) {
+	var result *types.ArgUint64
+	if err := c.call(ctx, &result, "zkevm_batchNumberByBlockNumber", types.ArgUint64(blockNumber)); err != nil {
+		return 0, err
+	}
+	if result == nil {
+		return 0, ethereum.NotFound
+	}
+	return uint64(*result), nil
 }
 
 // BatchByNumber returns a batch from the current canonical chain. If number is nil, the
 // latest known batch is returned.
 func (c *Client) BatchByNumber(ctx context.Context, number *big.Int) (*types.Batch, error) {
+	return c.batchByNumber(ctx, number, true)
+}
+
+// BatchByNumberWithTxHashes returns a batch from the current canonical chain including only
+// the hashes of its transactions. If number is nil, the latest known batch is returned.
+func (c *Client) BatchByNumberWithTxHashes(ctx context.Context, number *big.Int) (*types.Batch, error) {
+	return c.batchByNumber(ctx, number, false)
+}
+
+func (c *Client) batchByNumber(ctx context.Context, number *big.Int, fullTx bool) (*types.Batch, error) {
 	bn := types.LatestBatchNumber
 	if number != nil {
 		bn = types.BatchNumber(number.Int64())
 	}
-	response, err := JSONRPCCall(c.url, "zkevm_getBatchByNumber", bn.StringOrHex(), true)
-	if err != nil {
-		return nil, err
-	}
 
-	if response.Error != nil {
-		return nil, response.Error.RPCError()
+	var result *types.Batch
+	err := c.call(ctx, &result, "zkevm_getBatchByNumber", bn.StringOrHex(), fullTx)
+	return result, err
+}
+
+// BatchClosingInfo returns the report of the sequencer about the closing of a batch and its
+// L2 blocks. If number is nil, the latest known batch is used.
+func (c *Client) BatchClosingInfo(ctx context.Context, number *big.Int) (*types.BatchClosingInfo, error) {
+	bn := types.LatestBatchNumber
+	if number != nil {
+		bn = types.BatchNumber(number.Int64())
 	}
 
-	var result *types.Batch
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
-		return nil, err
+	var result *types.BatchClosingInfo
+	err := c.call(ctx, &result, "zkevm_getBatchClosingInfo", bn.StringOrHex())
+	return result, err
+}
+
+// FullBlockByNumber returns a block from the current canonical chain including the
+// receipts of its transactions. If number is nil, the latest known block is returned.
+func (c *Client) FullBlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, &result, "zkevm_getFullBlockByNumber", toBlockNumArg(number), true)
+	return result, err
+}
+
+// FullBlockByHash returns a block from the current canonical chain including the
+// receipts of its transactions.
+func (c *Client) FullBlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, &result, "zkevm_getFullBlockByHash", hash.String(), true)
+	return result, err
+}
+
+// NativeBlockHashesInRange returns the native block hashes, the state roots,
+// of the blocks in the provided range
+func (c *Client) NativeBlockHashesInRange(ctx context.Context, fromBlock, toBlock *big.Int) ([]common.Hash, error) {
+	filter := map[string]interface{}{
+		"fromBlock": toBlockNumArg(fromBlock),
+		"toBlock":   toBlockNumArg(toBlock),
 	}
 
-	return result, nil
+	var result []common.Hash
+	err := c.call(ctx, &result, "zkevm_getNativeBlockHashesInRange", filter)
+	return result, err
 }
 
-// ExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
-func (c *Client) ExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (*types.ExitRoots, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_getExitRootsByGER", globalExitRoot.String())
-	if err != nil {
+// TransactionByL2Hash returns the transaction with the given l2 hash,
+// ethereum.NotFound is returned when the transaction is not known by the node
+func (c *Client) TransactionByL2Hash(ctx context.Context, l2Hash common.Hash) (*types.Transaction, error) {
+	var result *types.Transaction
+	if err := c.call(ctx, &result, "zkevm_getTransactionByL2Hash", l2Hash.String()); err != nil {
 		return nil, err
 	}
-
-	if response.Error != nil {
-		return nil, response.Error.RPCError()
+	if result == nil {
+		return nil, ethereum.NotFound
 	}
+	return result, nil
+}
 
-	var result *types.ExitRoots
-	err = json.Unmarshal(response.Result, &result)
-	if err != nil {
+// TransactionReceiptByL2Hash returns the receipt of the transaction with the given l2 hash,
+// ethereum.NotFound is returned while the transaction is not mined yet
+func (c *Client) TransactionReceiptByL2Hash(ctx context.Context, l2Hash common.Hash) (*types.Receipt, error) {
+	var result *types.Receipt
+	if err := c.call(ctx, &result, "zkevm_getTransactionReceiptByL2Hash", l2Hash.String()); err != nil {
 		return nil, err
 	}
-
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
 	return result, nil
 }
 
+// ExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
+func (c *Client) ExitRootsByGER(ctx context.Context, globalExitRoot common.Hash) (*types.ExitRoots, error) {
+	var result *types.ExitRoots
+	err := c.call(ctx, &result, "zkevm_getExitRootsByGER", globalExitRoot.String())
+	return result, err
+}
+
 // GetLatestGlobalExitRoot returns the latest global exit root
 func (c *Client) GetLatestGlobalExitRoot(ctx context.Context) (common.Hash, error) {
-	response, err := JSONRPCCall(c.url, "zkevm_getLatestGlobalExitRoot")
-	if err != nil {
-		return common.Hash{}, err
+	var result common.Hash
+	err := c.call(ctx, &result, "zkevm_getLatestGlobalExitRoot")
+	return result, err
+}
+
+// EstimateGasPrice returns the gas price the node expects for the provided message.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) EstimateGasPrice(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*big.Int, error) {
+	var result types.ArgBig
+	if err := c.call(ctx, &result, "zkevm_estimateGasPrice", toCallArg(msg), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
 	}
+	return (*big.Int)(&result), nil
+}
 
-	if response.Error != nil {
-		return common.Hash{}, response.Error.RPCError()
+// EstimateFee returns the fee the node expects for the provided message.
+// If blockNumber is nil, the latest known block is used.
+func (c *Client) EstimateFee(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*big.Int, error) {
+	var result types.ArgBig
+	if err := c.call(ctx, &result, "zkevm_estimateFee", toCallArg(msg), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
 	}
+	return (*big.Int)(&result), nil
+}
+
+// EstimateCounters returns the zk counters used to execute the provided message
+// along with the limits of the batch. If blockNumber is nil, the latest known block is used.
+func (c *Client) EstimateCounters(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*types.ZKCountersResponse, error) {
+	var result *types.ZKCountersResponse
+	err := c.call(ctx, &result, "zkevm_estimateCounters", toCallArg(msg), toBlockNumArg(blockNumber))
+	return result, err
+}
 
-	var result string
-	err = json.Unmarshal(response.Result, &result)
+// SendPrivateRawTransaction injects a signed transaction into the pool hidden from the
+// public views of the pool until it's included in a block and returns the hash of the
+// transaction computed by the node.
+func (c *Client) SendPrivateRawTransaction(ctx context.Context, tx *ethTypes.Transaction) (common.Hash, error) {
+	rawTx, err := tx.MarshalBinary()
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	return common.HexToHash(result), nil
+	var result common.Hash
+	err = c.call(ctx, &result, "zkevm_sendPrivateRawTransaction", types.ArgBytes(rawTx))
+	return result, err
 }
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -29,8 +30,8 @@ type Config struct {
 	// to relay transactions to the Sequencer node
 	SequencerNodeURI string `mapstructure:"SequencerNodeURI"`
 
-	// MaxCumulativeGasUsed is the max gas allowed per batch
-	MaxCumulativeGasUsed uint64
+	// SequencerRelay configures how the requests are relayed to the Sequencer nodes
+	SequencerRelay SequencerRelayConfig `mapstructure:"SequencerRelay"`
 
 	// WebSockets configuration
 	WebSockets WebSocketsConfig `mapstructure:"WebSockets"`
@@ -55,6 +56,11 @@ type Config struct {
 	// logs in a single call to the state, if zero it means no limit
 	MaxLogsBlockRange uint64 `mapstructure:"MaxLogsBlockRange"`
 
+	// MaxIndexedLogsBlockRange is a configuration to set the max range for block number when querying TXs
+	// logs filtered by address or topics, which use the logs bloom index and can support wider ranges,
+	// if zero it means no limit
+	MaxIndexedLogsBlockRange uint64 `mapstructure:"MaxIndexedLogsBlockRange"`
+
 	// MaxNativeBlockHashBlockRange is a configuration to set the max range for block number when querying
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64 `mapstructure:"MaxNativeBlockHashBlockRange"`
@@ -63,20 +69,49 @@ type Config struct {
 	// requests to be captured by the server.
 	EnableHttpLog bool `mapstructure:"EnableHttpLog"`
 
-	// ZKCountersLimits defines the ZK Counter limits
-	ZKCountersLimits ZKCountersLimits
+	// BatchConfig defines the batch constraints, the max gas allowed per batch and the ZK Counter limits
+	// are the ones of the fork the next batch will be executed under
+	BatchConfig state.BatchConfig
+
+	// TLS configuration for the HTTP and WS servers
+	TLS TLSConfig `mapstructure:"TLS"`
+
+	// JWT configuration used to authenticate requests to protected namespaces
+	JWT JWTConfig `mapstructure:"JWT"`
+
+	// GraphQL configuration of the GraphQL endpoint served by the HTTP server
+	GraphQL GraphQLConfig `mapstructure:"GraphQL"`
 }
 
-// ZKCountersLimits defines the ZK Counter limits
-type ZKCountersLimits struct {
-	MaxKeccakHashes     uint32
-	MaxPoseidonHashes   uint32
-	MaxPoseidonPaddings uint32
-	MaxMemAligns        uint32
-	MaxArithmetics      uint32
-	MaxBinaries         uint32
-	MaxSteps            uint32
-	MaxSHA256Hashes     uint32
+// SequencerRelayConfig has parameters to relay requests from Non-Sequencer
+// nodes to the Sequencer nodes
+type SequencerRelayConfig struct {
+	// FallbackURIs are additional Sequencer node URIs used when SequencerNodeURI
+	// is not available or is slower
+	FallbackURIs []string `mapstructure:"FallbackURIs"`
+
+	// Timeout is the max time to wait for the response of a Sequencer node
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// MaxRetries is the number of times the request is retried on all the
+	// Sequencer nodes when none of them responds
+	MaxRetries uint `mapstructure:"MaxRetries"`
+
+	// RetryBackoff is the time to wait before the first retry, it is doubled
+	// for every following retry
+	RetryBackoff types.Duration `mapstructure:"RetryBackoff"`
+
+	// HealthCheckInterval is the interval to check the health and latency of
+	// the Sequencer nodes, if zero the health checks are disabled
+	HealthCheckInterval types.Duration `mapstructure:"HealthCheckInterval"`
+
+	// CircuitBreakerFailureThreshold is the number of consecutive failures after
+	// which a Sequencer node stops receiving requests, if zero the circuit breaker is disabled
+	CircuitBreakerFailureThreshold uint `mapstructure:"CircuitBreakerFailureThreshold"`
+
+	// CircuitBreakerOpenDuration is the time a Sequencer node stops receiving requests
+	// after reaching the CircuitBreakerFailureThreshold
+	CircuitBreakerOpenDuration types.Duration `mapstructure:"CircuitBreakerOpenDuration"`
 }
 
 // WebSocketsConfig has parameters to config the rpc websocket support
@@ -93,3 +128,47 @@ type WebSocketsConfig struct {
 	// ReadLimit defines the maximum size of a message read from the client (in bytes)
 	ReadLimit int64 `mapstructure:"ReadLimit"`
 }
+
+// TLSConfig has parameters to serve the HTTP and WS endpoints over TLS
+type TLSConfig struct {
+	// Enabled defines if the HTTP and WS servers are served over TLS
+	Enabled bool `mapstructure:"Enabled"`
+
+	// CertFile is the path to the PEM encoded certificate file, it is
+	// reloaded automatically when the file changes
+	CertFile string `mapstructure:"CertFile"`
+
+	// KeyFile is the path to the PEM encoded private key file, it is
+	// reloaded automatically when the file changes
+	KeyFile string `mapstructure:"KeyFile"`
+}
+
+// JWTConfig has parameters to authenticate requests using JWT bearer
+// tokens signed with HS256, the same scheme used by the Engine API
+type JWTConfig struct {
+	// Enabled defines if the JWT authentication is enabled or disabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// SecretFile is the path to the file containing the 32 bytes hex encoded secret
+	// used to validate the tokens
+	SecretFile string `mapstructure:"SecretFile"`
+
+	// ProtectedNamespaces defines the namespaces that require a valid token, for
+	// example ["debug", "txpool"]; the methods of the other namespaces remain public.
+	// The GraphQL endpoint requires a valid token when the eth namespace is protected
+	ProtectedNamespaces []string `mapstructure:"ProtectedNamespaces"`
+}
+
+// GraphQLConfig has parameters to config the GraphQL endpoint, it is served
+// by the HTTP server in the /graphql path
+type GraphQLConfig struct {
+	// Enabled defines if the GraphQL endpoint is enabled or disabled
+	Enabled bool `mapstructure:"Enabled"`
+
+	// MaxDepth defines the max depth of the nested fields of a query, if zero it means no limit
+	MaxDepth int `mapstructure:"MaxDepth"`
+
+	// MaxBlockRange defines the max range of blocks and batches that can be queried
+	// in a single blocks or batches query, if zero it means no limit
+	MaxBlockRange uint64 `mapstructure:"MaxBlockRange"`
+}
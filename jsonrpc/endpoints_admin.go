@@ -0,0 +1,161 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AdminEndpoints contains implementations for the "admin" RPC endpoints,
+// used by the operators to manage the node. This namespace is not exposed
+// by default and requires the JWT authentication, it must be listed in
+// RPC.JWT.ProtectedNamespaces
+type AdminEndpoints struct {
+	pool types.PoolInterface
+}
+
+// NewAdminEndpoints returns AdminEndpoints
+func NewAdminEndpoints(p types.PoolInterface) *AdminEndpoints {
+	return &AdminEndpoints{pool: p}
+}
+
+// GetAllowList returns the addresses of the provided pool allow-list, senders or deployers
+func (a *AdminEndpoints) GetAllowList(kind string) (interface{}, types.Error) {
+	allowListKind, err := pool.ParseAllowListKind(kind)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+
+	addresses, err := a.pool.GetAllowList(context.Background(), allowListKind)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get allow-list", err, true)
+	}
+	if addresses == nil {
+		addresses = []common.Address{}
+	}
+
+	return addresses, nil
+}
+
+// AddToAllowList adds the addresses to the provided pool allow-list, senders or deployers
+func (a *AdminEndpoints) AddToAllowList(httpRequest *http.Request, kind string, addresses []common.Address) (interface{}, types.Error) {
+	allowListKind, rpcErr := validateAllowListParams(kind, addresses)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if err := a.pool.AddToAllowList(context.Background(), allowListKind, addresses, getRequestIP(httpRequest)); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to add addresses to the allow-list", err, true)
+	}
+
+	return true, nil
+}
+
+// RemoveFromAllowList removes the addresses from the provided pool allow-list, senders or deployers
+func (a *AdminEndpoints) RemoveFromAllowList(httpRequest *http.Request, kind string, addresses []common.Address) (interface{}, types.Error) {
+	allowListKind, rpcErr := validateAllowListParams(kind, addresses)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if err := a.pool.RemoveFromAllowList(context.Background(), allowListKind, addresses, getRequestIP(httpRequest)); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to remove addresses from the allow-list", err, true)
+	}
+
+	return true, nil
+}
+
+// GetReputations returns the failures and bans of the senders and IPs tracked by the pool
+func (a *AdminEndpoints) GetReputations() (interface{}, types.Error) {
+	reputations, err := a.pool.GetReputations(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get reputations", err, true)
+	}
+	if reputations == nil {
+		reputations = []pool.Reputation{}
+	}
+
+	return reputations, nil
+}
+
+// ResetReputation deletes the failures and the ban of a sender or IP, kind is sender or ip
+func (a *AdminEndpoints) ResetReputation(httpRequest *http.Request, kind string, key string) (interface{}, types.Error) {
+	reputationKind, err := pool.ParseReputationKind(kind)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+	if strings.TrimSpace(key) == "" {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "the sender or IP is required", nil, false)
+	}
+
+	if err := a.pool.ResetReputation(context.Background(), reputationKind, strings.TrimSpace(key), getRequestIP(httpRequest)); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to reset the reputation", err, true)
+	}
+
+	return true, nil
+}
+
+// GetArchivedTransaction returns a processed tx moved from the pool to the archive by its hash
+func (a *AdminEndpoints) GetArchivedTransaction(hash types.ArgHash) (interface{}, types.Error) {
+	archivedTx, err := a.pool.GetArchivedTransactionByHash(context.Background(), hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get archived tx", err, true)
+	}
+
+	res, err := types.NewArchivedTransaction(*archivedTx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build the archived tx response", err, true)
+	}
+
+	return res, nil
+}
+
+func validateAllowListParams(kind string, addresses []common.Address) (pool.AllowListKind, types.Error) {
+	allowListKind, err := pool.ParseAllowListKind(kind)
+	if err != nil {
+		return "", types.NewRPCError(types.InvalidParamsErrorCode, err.Error())
+	}
+	if len(addresses) == 0 {
+		return "", types.NewRPCError(types.InvalidParamsErrorCode, "at least one address is required")
+	}
+	return allowListKind, nil
+}
+
+// getRequestIP returns the client IP provided by the proxy through the
+// X-Forwarded-For header, or an empty string if it is not available
+func getRequestIP(httpRequest *http.Request) string {
+	if httpRequest == nil {
+		return ""
+	}
+	ips := httpRequest.Header.Get("X-Forwarded-For")
+	if ips == "" {
+		return ""
+	}
+	return strings.Split(ips, ",")[0]
+}
+
+// poolErrorCode returns the error code for the errors returned by
+// the pool while adding a tx
+func poolErrorCode(err error) int {
+	switch {
+	case errors.Is(err, pool.ErrSenderNotAllowed):
+		return types.SenderNotAllowedErrorCode
+	case errors.Is(err, pool.ErrDeployerNotAllowed):
+		return types.DeployerNotAllowedErrorCode
+	case errors.Is(err, pool.ErrReputationBanned):
+		return types.ReputationBannedErrorCode
+	case errors.Is(err, pool.ErrReputationThrottled):
+		return types.ReputationThrottledErrorCode
+	case errors.Is(err, pool.ErrSponsoredClaimRateLimited):
+		return types.SponsoredClaimRateLimitedErrorCode
+	default:
+		return types.DefaultErrorCode
+	}
+}
@@ -0,0 +1,248 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllowList(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	addresses := []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}
+	m.Pool.
+		On("GetAllowList", context.Background(), pool.AllowListSenders).
+		Return(addresses, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("admin_getAllowList", "senders")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result []common.Address
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, addresses, result)
+
+	res, err = s.JSONRPCCall("admin_getAllowList", "receivers")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	assert.Equal(t, pool.ErrInvalidAllowListKind.Error(), res.Error.Message)
+}
+
+func TestAddToAllowList(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	addresses := []common.Address{common.HexToAddress("0x1")}
+
+	type testCase struct {
+		Name           string
+		Kind           string
+		Addresses      []common.Address
+		ExpectedResult bool
+		ExpectedError  types.Error
+		SetupMocks     func(m *mocksWrapper)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "add deployers successfully",
+			Kind:           "deployers",
+			Addresses:      addresses,
+			ExpectedResult: true,
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddToAllowList", context.Background(), pool.AllowListDeployers, addresses, "").
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name:          "invalid allow-list",
+			Kind:          "receivers",
+			Addresses:     addresses,
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, pool.ErrInvalidAllowListKind.Error()),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "missing addresses",
+			Kind:          "senders",
+			Addresses:     []common.Address{},
+			ExpectedError: types.NewRPCError(types.InvalidParamsErrorCode, "at least one address is required"),
+			SetupMocks:    func(m *mocksWrapper) {},
+		},
+		{
+			Name:          "failed to add addresses",
+			Kind:          "senders",
+			Addresses:     addresses,
+			ExpectedError: types.NewRPCError(types.DefaultErrorCode, "failed to add addresses to the allow-list"),
+			SetupMocks: func(m *mocksWrapper) {
+				m.Pool.
+					On("AddToAllowList", context.Background(), pool.AllowListSenders, addresses, "").
+					Return(errors.New("failed to add")).
+					Once()
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.SetupMocks(m)
+
+			res, err := s.JSONRPCCall("admin_addToAllowList", tc.Kind, tc.Addresses)
+			require.NoError(t, err)
+
+			if tc.ExpectedError != nil {
+				require.NotNil(t, res.Error)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+				return
+			}
+
+			require.Nil(t, res.Error)
+			var result bool
+			err = json.Unmarshal(res.Result, &result)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+		})
+	}
+}
+
+func TestRemoveFromAllowList(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	addresses := []common.Address{common.HexToAddress("0x1")}
+	m.Pool.
+		On("RemoveFromAllowList", context.Background(), pool.AllowListSenders, addresses, "").
+		Return(nil).
+		Once()
+
+	res, err := s.JSONRPCCall("admin_removeFromAllowList", "senders", addresses)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result bool
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.True(t, result)
+}
+
+func TestGetReputations(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	bannedUntil := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reputations := []pool.Reputation{
+		{Kind: pool.ReputationIP, Key: "127.0.0.1", Failures: 3, WindowStart: bannedUntil.Add(-time.Hour), LastFailureAt: bannedUntil.Add(-time.Minute), BannedUntil: &bannedUntil},
+	}
+	m.Pool.
+		On("GetReputations", context.Background()).
+		Return(reputations, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("admin_getReputations")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result []pool.Reputation
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, reputations, result)
+}
+
+func TestResetReputation(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.Pool.
+		On("ResetReputation", context.Background(), pool.ReputationIP, "127.0.0.1", "").
+		Return(nil).
+		Once()
+
+	res, err := s.JSONRPCCall("admin_resetReputation", "ip", "127.0.0.1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result bool
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	res, err = s.JSONRPCCall("admin_resetReputation", "receiver", "127.0.0.1")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	assert.Equal(t, pool.ErrInvalidReputationKind.Error(), res.Error.Message)
+
+	res, err = s.JSONRPCCall("admin_resetReputation", "sender", " ")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
+
+func TestGetArchivedTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receivedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failedReason := "out of counters"
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(2), 21000, big.NewInt(10), nil)
+	archivedTx := &pool.ArchivedTransaction{
+		Transaction: pool.Transaction{
+			Transaction:  *tx,
+			Status:       pool.TxStatusFailed,
+			ZKCounters:   state.ZKCounters{GasUsed: 21000, Steps: 100},
+			ReceivedAt:   receivedAt,
+			IP:           "127.0.0.1",
+			FailedReason: &failedReason,
+		},
+		From:       common.HexToAddress("0x2"),
+		ArchivedAt: receivedAt.Add(time.Hour),
+	}
+	m.Pool.
+		On("GetArchivedTransactionByHash", context.Background(), tx.Hash()).
+		Return(archivedTx, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("admin_getArchivedTransaction", tx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.ArchivedTransaction
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), result.Hash)
+	assert.Equal(t, archivedTx.From, result.From)
+	assert.Equal(t, "failed", result.Status)
+	require.NotNil(t, result.FailedReason)
+	assert.Equal(t, failedReason, *result.FailedReason)
+	assert.Equal(t, types.ArgUint64(100), result.ZKCounters.UsedSteps)
+	assert.Nil(t, result.BreakEvenGasPrice)
+	assert.True(t, archivedTx.ArchivedAt.Equal(result.ArchivedAt))
+
+	m.Pool.
+		On("GetArchivedTransactionByHash", context.Background(), common.HexToHash("0x3")).
+		Return(nil, pool.ErrNotFound).
+		Once()
+
+	res, err = s.JSONRPCCall("admin_getArchivedTransaction", common.HexToHash("0x3").String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
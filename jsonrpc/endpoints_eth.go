@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
-	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
@@ -31,17 +30,20 @@ const (
 
 // EthEndpoints contains implementations for the "eth" RPC endpoints
 type EthEndpoints struct {
-	cfg      Config
-	chainID  uint64
-	pool     types.PoolInterface
-	state    types.StateInterface
-	etherman types.EthermanInterface
-	storage  storageInterface
+	cfg            Config
+	chainID        uint64
+	pool           types.PoolInterface
+	state          types.StateInterface
+	etherman       types.EthermanInterface
+	storage        storageInterface
+	sequencerRelay *sequencerRelay
+
+	notifyPreconfirmationsOnce sync.Once
 }
 
 // NewEthEndpoints creates an new instance of Eth
 func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface, etherman types.EthermanInterface, storage storageInterface) *EthEndpoints {
-	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage}
+	e := &EthEndpoints{cfg: cfg, chainID: chainID, pool: p, state: s, etherman: etherman, storage: storage, sequencerRelay: getSequencerRelay(cfg)}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
 
 	return e
@@ -134,7 +136,7 @@ func (e *EthEndpoints) Coinbase() (interface{}, types.Error) { //nolint:revive
 }
 
 func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_coinbase")
+	res, err := e.sequencerRelay.call("eth_coinbase")
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get coinbase from sequencer node", err, true)
 	}
@@ -213,7 +215,7 @@ func (e *EthEndpoints) GasPrice() (interface{}, types.Error) {
 }
 
 func (e *EthEndpoints) getPriceFromSequencerNode() (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_gasPrice")
+	res, err := e.sequencerRelay.call("eth_gasPrice")
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get gas price from sequencer node", err, true)
 	}
@@ -231,7 +233,7 @@ func (e *EthEndpoints) getPriceFromSequencerNode() (interface{}, types.Error) {
 }
 
 func (e *EthEndpoints) getHighestL2BlockFromTrustedNode() (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_blockNumber")
+	res, err := e.sequencerRelay.call("eth_blockNumber")
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get gas price from sequencer node", err, true)
 	}
@@ -524,7 +526,7 @@ func (e *EthEndpoints) internalGetLogs(ctx context.Context, dbTx pgx.Tx, filter
 		errMsg := fmt.Sprintf(state.ErrMaxLogsCountLimitExceeded.Error(), e.cfg.MaxLogsCount)
 		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
 	} else if errors.Is(err, state.ErrMaxLogsBlockRangeLimitExceeded) {
-		errMsg := fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), e.cfg.MaxLogsBlockRange)
+		errMsg := maxLogsBlockRangeErrorMessage(ctx, e.state, fromBlockNumber, toBlockNumber, filter, dbTx)
 		return RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get logs from state", err, true)
@@ -677,11 +679,17 @@ func (e *EthEndpoints) GetTransactionByHash(hash types.ArgHash, includeExtraInfo
 	}
 	poolTx, err := e.pool.GetTransactionByHash(ctx, hash.Hash())
 	if errors.Is(err, pool.ErrNotFound) {
+		// the archived txs are not pending anymore, so they are only returned with the
+		// extra info to not make the standard clients wait for a tx that left the pool
+		if includeExtraInfo != nil && *includeExtraInfo {
+			return archivedTransactionResponse(e.pool.GetArchivedTransactionByHash(ctx, hash.Hash()))
+		}
 		return nil, nil
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by hash from pool", err, true)
 	}
-	if poolTx.Status == pool.TxStatusPending {
+	// the private txs are revealed only after they are included in a block
+	if poolTx.Status == pool.TxStatusPending && !poolTx.IsPrivate {
 		tx = &poolTx.Transaction
 		res, err := types.NewTransaction(*tx, nil, false, nil)
 		if err != nil {
@@ -692,12 +700,32 @@ func (e *EthEndpoints) GetTransactionByHash(hash types.ArgHash, includeExtraInfo
 	return nil, nil
 }
 
+// archivedTransactionResponse returns the tx moved from the pool to the archive
+// with its final status, the private txs and the IP of the sender are not revealed
+func archivedTransactionResponse(archivedTx *pool.ArchivedTransaction, err error) (interface{}, types.Error) {
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction from the pool archive", err, true)
+	}
+	if archivedTx.IsPrivate {
+		return nil, nil
+	}
+
+	res, err := types.NewArchivedTransaction(*archivedTx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build transaction response", err, true)
+	}
+	res.IP = ""
+	return res, nil
+}
+
 func (e *EthEndpoints) getTransactionByHashFromSequencerNode(hash common.Hash, includeExtraInfo *bool) (interface{}, types.Error) {
 	extraInfo := false
 	if includeExtraInfo != nil {
 		extraInfo = *includeExtraInfo
 	}
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_getTransactionByHash", hash.String(), extraInfo)
+	res, err := e.sequencerRelay.call("eth_getTransactionByHash", hash.String(), extraInfo)
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx from sequencer node", err, true)
 	}
@@ -714,6 +742,46 @@ func (e *EthEndpoints) getTransactionByHashFromSequencerNode(hash common.Hash, i
 	return tx, nil
 }
 
+// GetTransactionPreconfirmation returns the pre-confirmation signed by the sequencer for a tx
+// executed in a L2 block that may not be closed yet, nil if the tx has not been pre-confirmed.
+// As the pool lookups by hash, the pre-confirmations of the private txs are not returned.
+// The Go clients read it with client.Client.TransactionPreconfirmation
+func (e *EthEndpoints) GetTransactionPreconfirmation(hash types.ArgHash) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return e.getTransactionPreconfirmationFromSequencerNode(hash.Hash())
+	}
+
+	preconfirmation, err := e.pool.GetPreconfirmationByHash(context.Background(), hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction pre-confirmation from pool", err, true)
+	}
+	if preconfirmation.IsPrivate {
+		return nil, nil
+	}
+
+	return types.NewPreconfirmation(*preconfirmation), nil
+}
+
+func (e *EthEndpoints) getTransactionPreconfirmationFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := e.sequencerRelay.call("eth_getTransactionPreconfirmation", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx pre-confirmation from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var preconfirmation *types.Preconfirmation
+	err = json.Unmarshal(res.Result, &preconfirmation)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx pre-confirmation from sequencer node", err, true)
+	}
+	return preconfirmation, nil
+}
+
 // GetTransactionCount returns account nonce
 func (e *EthEndpoints) GetTransactionCount(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	ctx := context.Background()
@@ -757,7 +825,7 @@ func (e *EthEndpoints) GetTransactionCount(address types.ArgAddress, blockArg *t
 }
 
 func (e *EthEndpoints) getTransactionCountFromSequencerNode(address common.Address, number *types.BlockNumber) (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_getTransactionCount", address.String(), number.StringOrHex())
+	res, err := e.sequencerRelay.call("eth_getTransactionCount", address.String(), number.StringOrHex())
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get nonce from sequencer node", err, true)
 	}
@@ -816,7 +884,7 @@ func (e *EthEndpoints) GetBlockTransactionCountByNumber(number *types.BlockNumbe
 }
 
 func (e *EthEndpoints) getBlockTransactionCountByNumberFromSequencerNode(number *types.BlockNumber) (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_getBlockTransactionCountByNumber", number.StringOrHex())
+	res, err := e.sequencerRelay.call("eth_getBlockTransactionCountByNumber", number.StringOrHex())
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx count by block number from sequencer node", err, true)
 	}
@@ -920,6 +988,24 @@ func (e *EthEndpoints) newPendingTransactionFilter(wsConn *concurrentWsConn) (in
 	// return id, nil
 }
 
+// newPreconfirmationFilter creates a subscription to the pre-confirmations added or rescinded by the sequencer
+func (e *EthEndpoints) newPreconfirmationFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
+	if e.cfg.SequencerNodeURI != "" {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "pre-confirmations subscription is only supported by the sequencer node")
+	}
+
+	e.notifyPreconfirmationsOnce.Do(func() {
+		go e.notifyPreconfirmations()
+	})
+
+	id, err := e.storage.NewPreconfirmationFilter(wsConn)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new pre-confirmation filter", err, true)
+	}
+
+	return id, nil
+}
+
 // SendRawTransaction has two different ways to handle new transactions:
 // - for Sequencer nodes it tries to add the tx to the pool
 // - for Non-Sequencer nodes it relays the Tx to the Sequencer node
@@ -943,7 +1029,7 @@ func (e *EthEndpoints) SendRawTransaction(httpRequest *http.Request, input strin
 }
 
 func (e *EthEndpoints) relayTxToSequencerNode(input string) (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, "eth_sendRawTransaction", input)
+	res, err := e.sequencerRelay.call("eth_sendRawTransaction", input)
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay tx to the sequencer node", err, true)
 	}
@@ -966,7 +1052,7 @@ func (e *EthEndpoints) tryToAddTxToPool(input, ip string) (interface{}, types.Er
 	if err := e.pool.AddTx(context.Background(), *tx, ip); err != nil {
 		// it's not needed to log the error here, because we check and log if needed
 		// for each specific case during the "pool.AddTx" internal steps
-		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+		return RPCErrorResponse(poolErrorCode(err), err.Error(), nil, false)
 	}
 	log.Infof("TX added to the pool: %v", tx.Hash().Hex())
 
@@ -1098,6 +1184,8 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 		return e.newFilter(ctx, wsConn, lf, nil)
 	case "pendingTransactions", "newPendingTransactions":
 		return e.newPendingTransactionFilter(wsConn)
+	case "preconfirmations":
+		return e.newPreconfirmationFilter(wsConn)
 	case "syncing":
 		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
 	default:
@@ -1200,6 +1288,26 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 	log.Debugf("[notifyNewLogs] new l2 block event for block %v took %v to send all the messages for log filters", event.Block.NumberU64(), time.Since(start))
 }
 
+// notifyPreconfirmations sends the pre-confirmations added or rescinded in the pool to the ws connections
+// subscribed to them, except the ones of the private txs
+func (e *EthEndpoints) notifyPreconfirmations() {
+	for preconfirmation := range e.pool.SubscribePreconfirmations(context.Background()) {
+		if preconfirmation.IsPrivate {
+			continue
+		}
+
+		data, err := json.Marshal(types.NewPreconfirmation(preconfirmation))
+		if err != nil {
+			log.Errorf("failed to marshal pre-confirmation response to subscription: %v", err)
+			continue
+		}
+
+		for _, filter := range e.storage.GetAllPreconfirmationFiltersWithWSConn() {
+			filter.EnqueueSubscriptionDataToBeSent(data)
+		}
+	}
+}
+
 // shouldSkipLogFilter checks if the log filter can be skipped while notifying new logs.
 // it checks the log filter information against the block in the event to decide if the
 // information in the event is required by the filter or can be ignored to save resources.
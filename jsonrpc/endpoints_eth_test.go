@@ -331,7 +331,7 @@ func TestCall(t *testing.T) {
 			expectedResult: []byte("hello world"),
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
-				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.MaxCumulativeGasUsed})
+				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.BatchConfig.Constraints.MaxCumulativeGasUsed})
 				m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
 				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), nil).Return(blockHeader, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
@@ -368,7 +368,7 @@ func TestCall(t *testing.T) {
 			expectedResult: []byte("hello world"),
 			expectedError:  nil,
 			setupMocks: func(c Config, m *mocksWrapper, testCase *testCase) {
-				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.MaxCumulativeGasUsed})
+				blockHeader := state.NewL2Header(&ethTypes.Header{GasLimit: s.Config.BatchConfig.Constraints.MaxCumulativeGasUsed})
 				m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
 				m.State.On("GetL2BlockHeaderByNumber", context.Background(), blockNumOne.Uint64(), nil).Return(blockHeader, nil).Once()
 				txArgs := testCase.params[0].(types.TxArgs)
@@ -2312,6 +2312,77 @@ func TestGetTransactionByHash(t *testing.T) {
 	}
 }
 
+func TestGetTransactionFromArchive(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+	require.NoError(t, err)
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTransaction(1, common.HexToAddress("0x111"), big.NewInt(2), 21000, big.NewInt(4), nil))
+	require.NoError(t, err)
+
+	failedReason := "out of counters"
+	archivedTx := &pool.ArchivedTransaction{
+		Transaction: pool.Transaction{Transaction: *signedTx, Status: pool.TxStatusFailed, FailedReason: &failedReason, IP: "127.0.0.1"},
+		From:        auth.From,
+		ArchivedAt:  time.Now(),
+	}
+	privateArchivedTx := *archivedTx
+	privateArchivedTx.IsPrivate = true
+
+	m.State.On("GetTransactionByHash", context.Background(), signedTx.Hash(), nil).Return(nil, state.ErrNotFound).Times(3)
+	m.Pool.On("GetTransactionByHash", context.Background(), signedTx.Hash()).Return(nil, pool.ErrNotFound).Times(3)
+	m.Pool.On("GetArchivedTransactionByHash", context.Background(), signedTx.Hash()).Return(archivedTx, nil).Once()
+	m.Pool.On("GetArchivedTransactionByHash", context.Background(), signedTx.Hash()).Return(&privateArchivedTx, nil).Once()
+
+	// the archived txs are returned with the extra info
+	res, err := s.JSONRPCCall("eth_getTransactionByHash", signedTx.Hash().String(), true)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var result types.ArchivedTransaction
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, signedTx.Hash(), result.Hash)
+	assert.Equal(t, auth.From, result.From)
+	assert.Equal(t, pool.TxStatusFailed.String(), result.Status)
+	require.NotNil(t, result.FailedReason)
+	assert.Equal(t, failedReason, *result.FailedReason)
+	assert.Empty(t, result.IP)
+
+	// the private txs are not revealed
+	res, err = s.JSONRPCCall("eth_getTransactionByHash", signedTx.Hash().String(), true)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	// the standard clients don't get the txs that left the pool
+	res, err = s.JSONRPCCall("eth_getTransactionByHash", signedTx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	m.State.On("GetTransactionByL2Hash", context.Background(), signedTx.Hash(), nil).Return(nil, state.ErrNotFound).Once()
+	m.Pool.On("GetTransactionByL2Hash", context.Background(), signedTx.Hash()).Return(nil, pool.ErrNotFound).Once()
+	m.Pool.On("GetArchivedTransactionByL2Hash", context.Background(), signedTx.Hash()).Return(archivedTx, nil).Once()
+
+	res, err = s.JSONRPCCall("zkevm_getTransactionByL2Hash", signedTx.Hash().String(), true)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, signedTx.Hash(), result.Hash)
+	assert.Equal(t, pool.TxStatusFailed.String(), result.Status)
+
+	// like eth_getTransactionByHash, the archived txs are only returned with the extra info
+	m.State.On("GetTransactionByL2Hash", context.Background(), signedTx.Hash(), nil).Return(nil, state.ErrNotFound).Once()
+	m.Pool.On("GetTransactionByL2Hash", context.Background(), signedTx.Hash()).Return(nil, pool.ErrNotFound).Once()
+
+	res, err = s.JSONRPCCall("zkevm_getTransactionByL2Hash", signedTx.Hash().String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
+
 func TestGetBlockTransactionCountByHash(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -2840,6 +2911,63 @@ func TestGetTransactionReceipt(t *testing.T) {
 	}
 }
 
+func TestGetTransactionPreconfirmation(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	preconfirmation := &pool.Preconfirmation{
+		TxHash:        common.HexToHash("0x1"),
+		L2BlockNumber: 10,
+		Index:         2,
+		Status:        ethTypes.ReceiptStatusSuccessful,
+		Signature:     []byte{1, 2, 3},
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+	m.Pool.
+		On("GetPreconfirmationByHash", context.Background(), preconfirmation.TxHash).
+		Return(preconfirmation, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("eth_getTransactionPreconfirmation", preconfirmation.TxHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.Preconfirmation
+	err = json.Unmarshal(res.Result, &result)
+	require.NoError(t, err)
+	assert.Equal(t, preconfirmation.TxHash, result.TxHash)
+	assert.Equal(t, types.ArgUint64(10), result.BlockNumber)
+	assert.Equal(t, types.ArgUint64(2), result.TxIndex)
+	assert.Equal(t, types.ArgUint64(1), result.Status)
+	assert.Equal(t, types.ArgBytes{1, 2, 3}, result.Signature)
+	assert.False(t, result.Rescinded)
+	assert.True(t, createdAt.Equal(result.CreatedAt))
+
+	// The pre-confirmations of the private txs are hidden
+	privatePreconfirmation := &pool.Preconfirmation{TxHash: common.HexToHash("0x2"), IsPrivate: true}
+	m.Pool.
+		On("GetPreconfirmationByHash", context.Background(), privatePreconfirmation.TxHash).
+		Return(privatePreconfirmation, nil).
+		Once()
+
+	res, err = s.JSONRPCCall("eth_getTransactionPreconfirmation", privatePreconfirmation.TxHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	m.Pool.
+		On("GetPreconfirmationByHash", context.Background(), common.HexToHash("0x3")).
+		Return(nil, pool.ErrNotFound).
+		Once()
+
+	res, err = s.JSONRPCCall("eth_getTransactionPreconfirmation", common.HexToHash("0x3").String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
+
 func TestSendRawTransactionViaGeth(t *testing.T) {
 	s, m, c := newSequencerMockedServer(t)
 	defer s.Stop()
@@ -3051,6 +3179,21 @@ func TestSendRawTransactionViaGethForNonSequencerNode(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name:          "Send TX rejected by the sender allow-list",
+			Tx:            ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{}),
+			ExpectedError: types.NewRPCError(types.SenderNotAllowedErrorCode, pool.ErrSenderNotAllowed.Error()),
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				txMatchByHash := mock.MatchedBy(func(tx ethTypes.Transaction) bool {
+					return tx.Hash().Hex() == tc.Tx.Hash().Hex()
+				})
+
+				m.Pool.
+					On("AddTx", context.Background(), txMatchByHash, "").
+					Return(pool.ErrSenderNotAllowed).
+					Once()
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -3533,6 +3676,20 @@ func TestGetLogs(t *testing.T) {
 		},
 		{
 			Name: "Get logs fails due to max block range limit exceeded",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tc.Filter = ethereum.FilterQuery{
+					FromBlock: big.NewInt(1), ToBlock: big.NewInt(100002),
+					Addresses: []common.Address{common.HexToAddress("0x111")},
+					Topics:    [][]common.Hash{{common.HexToHash("0x222")}},
+				}
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.InvalidParamsErrorCode, "logs are limited to a 100000 block range")
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+			},
+		},
+		{
+			Name: "Get logs fails due to max block range limit exceeded while the blocks are not indexed",
 			Prepare: func(t *testing.T, tc *testCase) {
 				tc.Filter = ethereum.FilterQuery{
 					FromBlock: big.NewInt(1), ToBlock: big.NewInt(10002),
@@ -3543,6 +3700,33 @@ func TestGetLogs(t *testing.T) {
 				tc.ExpectedError = types.NewRPCError(types.InvalidParamsErrorCode, "logs are limited to a 10000 block range")
 			},
 			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.State.
+					On("GetLogsMaxBlockRange", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, nil).
+					Return(uint64(10000), nil).
+					Once()
+			},
+		},
+		{
+			Name: "Get logs with a block range allowed once the blocks are indexed",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tc.Filter = ethereum.FilterQuery{
+					FromBlock: big.NewInt(1), ToBlock: big.NewInt(10002),
+					Addresses: []common.Address{common.HexToAddress("0x111")},
+					Topics:    [][]common.Hash{{common.HexToHash("0x222")}},
+				}
+				tc.ExpectedResult = []ethTypes.Log{}
+				tc.ExpectedError = nil
+			},
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				var since *time.Time
+				m.State.
+					On("GetLogsMaxBlockRange", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, nil).
+					Return(uint64(100000), nil).
+					Once()
+				m.State.
+					On("GetLogs", context.Background(), tc.Filter.FromBlock.Uint64(), tc.Filter.ToBlock.Uint64(), tc.Filter.Addresses, tc.Filter.Topics, tc.Filter.BlockHash, since, nil).
+					Return([]*ethTypes.Log{}, nil).
+					Once()
 			},
 		},
 		{
@@ -6,10 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
-	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
@@ -23,19 +23,21 @@ import (
 
 // ZKEVMEndpoints contains implementations for the "zkevm" RPC endpoints
 type ZKEVMEndpoints struct {
-	cfg      Config
-	pool     types.PoolInterface
-	state    types.StateInterface
-	etherman types.EthermanInterface
+	cfg            Config
+	pool           types.PoolInterface
+	state          types.StateInterface
+	etherman       types.EthermanInterface
+	sequencerRelay *sequencerRelay
 }
 
 // NewZKEVMEndpoints returns ZKEVMEndpoints
 func NewZKEVMEndpoints(cfg Config, pool types.PoolInterface, state types.StateInterface, etherman types.EthermanInterface) *ZKEVMEndpoints {
 	return &ZKEVMEndpoints{
-		cfg:      cfg,
-		pool:     pool,
-		state:    state,
-		etherman: etherman,
+		cfg:            cfg,
+		pool:           pool,
+		state:          state,
+		etherman:       etherman,
+		sequencerRelay: getSequencerRelay(cfg),
 	}
 }
 
@@ -303,8 +305,9 @@ func (z *ZKEVMEndpoints) GetNativeBlockHashesInRange(filter NativeBlockHashBlock
 	return nativeBlockHashes, nil
 }
 
-// GetTransactionByL2Hash returns a transaction by his l2 hash
-func (z *ZKEVMEndpoints) GetTransactionByL2Hash(hash types.ArgHash) (interface{}, types.Error) {
+// GetTransactionByL2Hash returns a transaction by his l2 hash, the txs archived
+// from the pool are only returned with the extra info, like eth_getTransactionByHash
+func (z *ZKEVMEndpoints) GetTransactionByL2Hash(hash types.ArgHash, includeExtraInfo *bool) (interface{}, types.Error) {
 	ctx := context.Background()
 	// try to get tx from state
 	tx, err := z.state.GetTransactionByL2Hash(ctx, hash.Hash(), nil)
@@ -334,15 +337,19 @@ func (z *ZKEVMEndpoints) GetTransactionByL2Hash(hash types.ArgHash) (interface{}
 
 	// if the tx does not exist in the state, look for it in the pool
 	if z.cfg.SequencerNodeURI != "" {
-		return z.getTransactionByL2HashFromSequencerNode(hash.Hash())
+		return z.getTransactionByL2HashFromSequencerNode(hash.Hash(), includeExtraInfo)
 	}
 	poolTx, err := z.pool.GetTransactionByL2Hash(ctx, hash.Hash())
 	if errors.Is(err, pool.ErrNotFound) {
+		if includeExtraInfo != nil && *includeExtraInfo {
+			return archivedTransactionResponse(z.pool.GetArchivedTransactionByL2Hash(ctx, hash.Hash()))
+		}
 		return nil, nil
 	} else if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by l2 hash from pool", err, true)
 	}
-	if poolTx.Status == pool.TxStatusPending {
+	// the private txs are revealed only after they are included in a block
+	if poolTx.Status == pool.TxStatusPending && !poolTx.IsPrivate {
 		tx = &poolTx.Transaction
 		res, err := types.NewTransaction(*tx, nil, false, nil)
 		if err != nil {
@@ -383,8 +390,12 @@ func (z *ZKEVMEndpoints) GetTransactionReceiptByL2Hash(hash types.ArgHash) (inte
 	return receipt, nil
 }
 
-func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
-	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTransactionByL2Hash", hash.String())
+func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Hash, includeExtraInfo *bool) (interface{}, types.Error) {
+	extraInfo := false
+	if includeExtraInfo != nil {
+		extraInfo = *includeExtraInfo
+	}
+	res, err := z.sequencerRelay.call("zkevm_getTransactionByL2Hash", hash.String(), extraInfo)
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx from sequencer node by l2 hash", err, true)
 	}
@@ -401,6 +412,37 @@ func (z *ZKEVMEndpoints) getTransactionByL2HashFromSequencerNode(hash common.Has
 	return tx, nil
 }
 
+// SendPrivateRawTransaction adds a tx to the pool hidden from the public views of the
+// pool, like the pending tx filters or the pending txs returned by hash, until it is
+// included in a block. Non-Sequencer nodes relay it privately to the Sequencer node.
+// The Go clients send it with client.Client.SendPrivateRawTransaction
+func (z *ZKEVMEndpoints) SendPrivateRawTransaction(httpRequest *http.Request, input string) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		res, err := z.sequencerRelay.call("zkevm_sendPrivateRawTransaction", input)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to relay private tx to the sequencer node", err, true)
+		}
+		if res.Error != nil {
+			return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+		}
+		return res.Result, nil
+	}
+
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+	log.Infof("adding private TX to the pool: %v", tx.Hash().Hex())
+	if err := z.pool.AddPrivateTx(context.Background(), *tx, getRequestIP(httpRequest)); err != nil {
+		// it's not needed to log the error here, because we check and log if needed
+		// for each specific case during the "pool.AddTx" internal steps
+		return RPCErrorResponse(poolErrorCode(err), err.Error(), nil, false)
+	}
+	log.Infof("private TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
+
 // GetExitRootsByGER returns the exit roots accordingly to the provided Global Exit Root
 func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interface{}, types.Error) {
 	ctx := context.Background()
@@ -419,6 +461,24 @@ func (z *ZKEVMEndpoints) GetExitRootsByGER(globalExitRoot common.Hash) (interfac
 	}, nil
 }
 
+// GetBatchClosingInfo returns the report of the sequencer about the closing of a batch and its L2 blocks
+func (z *ZKEVMEndpoints) GetBatchClosingInfo(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	ctx := context.Background()
+	numericBatchNumber, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	closingInfo, err := z.state.GetBatchClosingInfo(ctx, numericBatchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch closing info from state by number %v", numericBatchNumber), err, true)
+	}
+
+	return types.NewBatchClosingInfo(*closingInfo), nil
+}
+
 // EstimateGasPrice returns an estimate gas price for the transaction.
 func (z *ZKEVMEndpoints) EstimateGasPrice(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
 	ctx := context.Background()
@@ -461,8 +521,13 @@ func (z *ZKEVMEndpoints) internalEstimateGasPriceAndFee(ctx context.Context, arg
 		}
 	}
 
+	batchConstraints, err := z.cfg.BatchConfig.NextBatchConstraints(ctx, z.state)
+	if err != nil {
+		return nil, nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the batch constraints")
+	}
+
 	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
-	sender, tx, err := arg.ToTransaction(ctx, z.state, z.cfg.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, nil)
+	sender, tx, err := arg.ToTransaction(ctx, z.state, batchConstraints.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, nil)
 	if err != nil {
 		return nil, nil, types.NewRPCError(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction")
 	}
@@ -544,8 +609,13 @@ func (z *ZKEVMEndpoints) EstimateCounters(arg *types.TxArgs, blockArg *types.Blo
 		}
 	}
 
+	batchConstraints, err := z.cfg.BatchConfig.NextBatchConstraints(ctx, z.state)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get the batch constraints", err, true)
+	}
+
 	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
-	sender, tx, err := arg.ToTransaction(ctx, z.state, z.cfg.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, nil)
+	sender, tx, err := arg.ToTransaction(ctx, z.state, batchConstraints.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, nil)
 	if err != nil {
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 	}
@@ -578,14 +648,14 @@ func (z *ZKEVMEndpoints) EstimateCounters(arg *types.TxArgs, blockArg *types.Blo
 
 	limits := types.ZKCountersLimits{
 		MaxGasUsed:          types.ArgUint64(state.MaxTxGasLimit),
-		MaxKeccakHashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxKeccakHashes),
-		MaxPoseidonHashes:   types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonHashes),
-		MaxPoseidonPaddings: types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonPaddings),
-		MaxMemAligns:        types.ArgUint64(z.cfg.ZKCountersLimits.MaxMemAligns),
-		MaxArithmetics:      types.ArgUint64(z.cfg.ZKCountersLimits.MaxArithmetics),
-		MaxBinaries:         types.ArgUint64(z.cfg.ZKCountersLimits.MaxBinaries),
-		MaxSteps:            types.ArgUint64(z.cfg.ZKCountersLimits.MaxSteps),
-		MaxSHA256Hashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxSHA256Hashes),
+		MaxKeccakHashes:     types.ArgUint64(batchConstraints.MaxKeccakHashes),
+		MaxPoseidonHashes:   types.ArgUint64(batchConstraints.MaxPoseidonHashes),
+		MaxPoseidonPaddings: types.ArgUint64(batchConstraints.MaxPoseidonPaddings),
+		MaxMemAligns:        types.ArgUint64(batchConstraints.MaxMemAligns),
+		MaxArithmetics:      types.ArgUint64(batchConstraints.MaxArithmetics),
+		MaxBinaries:         types.ArgUint64(batchConstraints.MaxBinaries),
+		MaxSteps:            types.ArgUint64(batchConstraints.MaxSteps),
+		MaxSHA256Hashes:     types.ArgUint64(batchConstraints.MaxSHA256Hashes),
 	}
 	return types.NewZKCountersResponse(processBatchResponse.UsedZkCounters, limits, revert, oocErr), nil
 }
@@ -1662,6 +1662,24 @@ func TestGetTransactionByL2Hash(t *testing.T) {
 					Once()
 			},
 		},
+		{
+			Name:            "Private TX in the pool is not found",
+			Hash:            common.HexToHash("0x123"),
+			ExpectedPending: false,
+			ExpectedResult:  nil,
+			ExpectedError:   nil,
+			SetupMocks: func(m *mocksWrapper, tc testCase) {
+				m.State.
+					On("GetTransactionByL2Hash", context.Background(), tc.Hash, nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+
+				m.Pool.
+					On("GetTransactionByL2Hash", context.Background(), tc.Hash).
+					Return(&pool.Transaction{Transaction: *signedTx, Status: pool.TxStatusPending, IsPrivate: true}, nil).
+					Once()
+			},
+		},
 		{
 			Name:            "TX Not Found",
 			Hash:            common.HexToHash("0x123"),
@@ -2180,3 +2198,199 @@ func TestGetLatestGlobalExitRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestSendPrivateRawTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	type testCase struct {
+		Name           string
+		Input          string
+		ExpectedResult *common.Hash
+		ExpectedError  types.Error
+		Prepare        func(t *testing.T, tc *testCase)
+		SetupMocks     func(t *testing.T, m *mocksWrapper, tc testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name: "Send private TX successfully",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = state.Ptr(tx.Hash())
+				tc.ExpectedError = nil
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(nil).
+					Once()
+			},
+		},
+		{
+			Name: "Send private TX failed to add to the pool",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+
+				txBinary, err := tx.MarshalBinary()
+				require.NoError(t, err)
+
+				tc.Input = hex.EncodeToHex(txBinary)
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.DefaultErrorCode, "failed to add TX to the pool")
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {
+				m.Pool.
+					On("AddPrivateTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+					Return(errors.New("failed to add TX to the pool")).
+					Once()
+			},
+		},
+		{
+			Name: "Send invalid private tx input",
+			Prepare: func(t *testing.T, tc *testCase) {
+				tc.Input = "0x1234"
+				tc.ExpectedResult = nil
+				tc.ExpectedError = types.NewRPCError(types.InvalidParamsErrorCode, "invalid tx input")
+			},
+			SetupMocks: func(t *testing.T, m *mocksWrapper, tc testCase) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			tc.Prepare(t, &tc)
+			tc.SetupMocks(t, m, tc)
+
+			res, err := s.JSONRPCCall("zkevm_sendPrivateRawTransaction", tc.Input)
+			require.NoError(t, err)
+
+			assert.Equal(t, float64(1), res.ID)
+			assert.Equal(t, "2.0", res.JSONRPC)
+
+			if res.Result != nil || tc.ExpectedResult != nil {
+				var result common.Hash
+				err = json.Unmarshal(res.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.ExpectedResult, result)
+			}
+			if res.Error != nil || tc.ExpectedError != nil {
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), res.Error.Code)
+				assert.Equal(t, tc.ExpectedError.Error(), res.Error.Message)
+			}
+		})
+	}
+}
+
+func TestGetBatchClosingInfo(t *testing.T) {
+	closedAt := time.Unix(1700000000, 0).UTC()
+	closingInfo := &state.BatchClosingInfo{
+		BatchNumber:       10,
+		ClosingReason:     state.ResourceMarginExhaustedClosingReason,
+		ExhaustedResource: "Steps",
+		UsedResources:     state.BatchResources{Bytes: 1000, ZKCounters: state.ZKCounters{GasUsed: 210000, Steps: 7000000}},
+		Txs:               10,
+		SkippedTxs:        2,
+		NodeOOCTxs:        1,
+		OpenedAt:          closedAt.Add(-time.Minute),
+		ClosedAt:          closedAt,
+		L2BlocksClosingInfo: []state.L2BlockClosingInfo{
+			{
+				L2BlockNumber: 20,
+				BatchNumber:   10,
+				ClosingReason: "max_txs",
+				Txs:           10,
+				ProcessedTxs:  12,
+				UsedResources: state.BatchResources{Bytes: 1000, ZKCounters: state.ZKCounters{GasUsed: 210000, Steps: 7000000}},
+				Times:         state.L2BlockProcessTimes{Total: 3 * time.Second, ExecutorTxs: 1500 * time.Microsecond},
+			},
+		},
+	}
+
+	type testCase struct {
+		Name           string
+		BatchNumber    *big.Int
+		ExpectedResult *types.BatchClosingInfo
+		ExpectedError  types.Error
+		SetupMocks     func(*mocksWrapper, *testCase)
+	}
+
+	testCases := []testCase{
+		{
+			Name:           "batch closing info not found",
+			BatchNumber:    big.NewInt(10),
+			ExpectedResult: nil,
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.State.
+					On("GetBatchClosingInfo", context.Background(), uint64(10), nil).
+					Return(nil, state.ErrNotFound).
+					Once()
+			},
+		},
+		{
+			Name:           "failed to load batch closing info from state",
+			BatchNumber:    big.NewInt(10),
+			ExpectedResult: nil,
+			ExpectedError:  types.NewRPCError(types.DefaultErrorCode, "couldn't load batch closing info from state by number 10"),
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.State.
+					On("GetBatchClosingInfo", context.Background(), uint64(10), nil).
+					Return(nil, fmt.Errorf("failed to load batch closing info")).
+					Once()
+			},
+		},
+		{
+			Name:           "get batch closing info successfully",
+			BatchNumber:    big.NewInt(10),
+			ExpectedResult: state.Ptr(types.NewBatchClosingInfo(*closingInfo)),
+			ExpectedError:  nil,
+			SetupMocks: func(m *mocksWrapper, tc *testCase) {
+				m.State.
+					On("GetBatchClosingInfo", context.Background(), uint64(10), nil).
+					Return(closingInfo, nil).
+					Once()
+			},
+		},
+	}
+
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	zkEVMClient := client.NewClient(s.ServerURL)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tc := testCase
+			testCase.SetupMocks(m, &tc)
+
+			result, err := zkEVMClient.BatchClosingInfo(context.Background(), tc.BatchNumber)
+
+			if tc.ExpectedResult != nil {
+				require.NotNil(t, result)
+				assert.Equal(t, tc.ExpectedResult.ClosingReason, result.ClosingReason)
+				assert.Equal(t, tc.ExpectedResult.ExhaustedResource, result.ExhaustedResource)
+				assert.Equal(t, tc.ExpectedResult.UsedZKCounters, result.UsedZKCounters)
+				assert.Equal(t, tc.ExpectedResult.SkippedTxs, result.SkippedTxs)
+				assert.Equal(t, tc.ExpectedResult.ClosedAt.Unix(), result.ClosedAt.Unix())
+				require.Len(t, result.L2Blocks, 1)
+				assert.Equal(t, tc.ExpectedResult.L2Blocks[0], result.L2Blocks[0])
+				assert.Equal(t, types.ArgUint64(1500), result.L2Blocks[0].Times.ExecutorTxs)
+			} else if err == nil {
+				assert.Nil(t, result)
+			}
+
+			if err != nil || tc.ExpectedError != nil {
+				rpcErr := err.(types.RPCError)
+				assert.Equal(t, tc.ExpectedError.ErrorCode(), rpcErr.ErrorCode())
+				assert.Equal(t, tc.ExpectedError.Error(), rpcErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,1217 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// graphQLBatchStatusTrusted is the status of the batches only known by the trusted sequencer
+	graphQLBatchStatusTrusted = "TRUSTED"
+	// graphQLBatchStatusVirtual is the status of the batches sequenced to L1
+	graphQLBatchStatusVirtual = "VIRTUAL"
+	// graphQLBatchStatusVerified is the status of the batches verified in L1
+	graphQLBatchStatusVerified = "VERIFIED"
+)
+
+// graphQLErrorResponse logs the internal error and returns the error
+// message to be returned by the GraphQL resolvers
+func graphQLErrorResponse(message string, err error) error {
+	if err != nil {
+		log.Debugf("%v: %v", message, err.Error())
+	} else {
+		log.Debug(message)
+	}
+	return errors.New(message)
+}
+
+// graphQLResolver is the root resolver of the GraphQL schema, it resolves
+// the queries using the same state and pool used by the JSON RPC endpoints
+type graphQLResolver struct {
+	cfg            Config
+	chainID        uint64
+	pool           types.PoolInterface
+	state          types.StateInterface
+	sequencerRelay *sequencerRelay
+}
+
+// newGraphQLResolver creates the root resolver of the GraphQL schema
+func newGraphQLResolver(cfg Config, chainID uint64, p types.PoolInterface, s types.StateInterface) *graphQLResolver {
+	return &graphQLResolver{
+		cfg:            cfg,
+		chainID:        chainID,
+		pool:           p,
+		state:          s,
+		sequencerRelay: getSequencerRelay(cfg),
+	}
+}
+
+// Block fetches an L2 block by number or by hash, or the latest block
+func (r *graphQLResolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*graphQLBlock, error) {
+	var block *state.L2Block
+	var err error
+	if args.Hash != nil {
+		block, err = r.state.GetL2BlockByHash(ctx, *args.Hash, nil)
+	} else if args.Number != nil {
+		block, err = r.state.GetL2BlockByNumber(ctx, uint64(*args.Number), nil)
+	} else {
+		block, err = r.state.GetLastL2Block(ctx, nil)
+	}
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to load block from state", err)
+	}
+	return &graphQLBlock{r: r, block: block}, nil
+}
+
+// Blocks returns the blocks in the range, both included
+func (r *graphQLResolver) Blocks(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*graphQLBlock, error) {
+	fromBlock, toBlock, err := r.getRange(ctx, args.From, args.To, r.state.GetLastL2BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*graphQLBlock, 0, toBlock-fromBlock+1)
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		block, err := r.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+		if errors.Is(err, state.ErrNotFound) {
+			break
+		} else if err != nil {
+			return nil, graphQLErrorResponse(fmt.Sprintf("failed to load block %v from state", blockNumber), err)
+		}
+		blocks = append(blocks, &graphQLBlock{r: r, block: block})
+	}
+	return blocks, nil
+}
+
+// Transaction fetches a transaction by hash
+func (r *graphQLResolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*graphQLTransaction, error) {
+	return r.loadTransaction(ctx, args.Hash)
+}
+
+// Logs returns the logs matching the filter
+func (r *graphQLResolver) Logs(ctx context.Context, args struct{ Filter graphQLFilterCriteria }) ([]*graphQLLog, error) {
+	lastBlockNumber, err := r.state.GetLastL2BlockNumber(ctx, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse("failed to get the last block number from state", err)
+	}
+
+	fromBlock, toBlock := lastBlockNumber, lastBlockNumber
+	if args.Filter.FromBlock != nil {
+		fromBlock = uint64(*args.Filter.FromBlock)
+	}
+	if args.Filter.ToBlock != nil {
+		toBlock = uint64(*args.Filter.ToBlock)
+	}
+	if toBlock < fromBlock {
+		return nil, graphQLErrorResponse("invalid block range", nil)
+	}
+
+	filter := LogFilter{}
+	if args.Filter.Addresses != nil {
+		filter.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		filter.Topics = *args.Filter.Topics
+	}
+
+	return r.getLogs(ctx, fromBlock, toBlock, filter)
+}
+
+func (r *graphQLResolver) getLogs(ctx context.Context, fromBlock, toBlock uint64, filter LogFilter) ([]*graphQLLog, error) {
+	logs, err := r.state.GetLogs(ctx, fromBlock, toBlock, filter.Addresses, filter.Topics, filter.BlockHash, nil, nil)
+	if errors.Is(err, state.ErrMaxLogsCountLimitExceeded) {
+		return nil, graphQLErrorResponse(fmt.Sprintf(state.ErrMaxLogsCountLimitExceeded.Error(), r.cfg.MaxLogsCount), nil)
+	} else if errors.Is(err, state.ErrMaxLogsBlockRangeLimitExceeded) {
+		return nil, graphQLErrorResponse(maxLogsBlockRangeErrorMessage(ctx, r.state, fromBlock, toBlock, filter, nil), nil)
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to get logs from state", err)
+	}
+
+	result := make([]*graphQLLog, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, &graphQLLog{r: r, log: l})
+	}
+	return result, nil
+}
+
+// GasPrice returns the L2 gas price suggested by the node
+func (r *graphQLResolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	if r.sequencerRelay != nil {
+		res, err := r.sequencerRelay.call("eth_gasPrice")
+		if err != nil {
+			return hexutil.Big{}, graphQLErrorResponse("failed to get gas price from sequencer node", err)
+		}
+		if res.Error != nil {
+			return hexutil.Big{}, graphQLErrorResponse(res.Error.Message, nil)
+		}
+		var gasPrice types.ArgUint64
+		if err := json.Unmarshal(res.Result, &gasPrice); err != nil {
+			return hexutil.Big{}, graphQLErrorResponse("failed to read gas price from sequencer node", err)
+		}
+		return hexutil.Big(*new(big.Int).SetUint64(uint64(gasPrice))), nil
+	}
+
+	gasPrices, err := r.pool.GetGasPrices(ctx)
+	if err != nil {
+		return hexutil.Big{}, graphQLErrorResponse("failed to get gas price from pool", err)
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(gasPrices.L2GasPrice)), nil
+}
+
+// Syncing returns the synchronization state, or null if the node is synced
+func (r *graphQLResolver) Syncing(ctx context.Context) (*graphQLSyncState, error) {
+	syncInfo, err := r.state.GetSyncingInfo(ctx, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse("failed to get syncing info from state", err)
+	}
+	if !syncInfo.IsSynchronizing {
+		return nil, nil
+	}
+	return &graphQLSyncState{info: syncInfo}, nil
+}
+
+// ChainID returns the L2 chain id
+func (r *graphQLResolver) ChainID() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(r.chainID))
+}
+
+// Batch fetches a batch by number, or the latest batch
+func (r *graphQLResolver) Batch(ctx context.Context, args struct{ Number *hexutil.Uint64 }) (*graphQLBatch, error) {
+	var batchNumber uint64
+	if args.Number != nil {
+		batchNumber = uint64(*args.Number)
+	} else {
+		lastBatchNumber, err := r.state.GetLastBatchNumber(ctx, nil)
+		if err != nil {
+			return nil, graphQLErrorResponse("failed to get the last batch number from state", err)
+		}
+		batchNumber = lastBatchNumber
+	}
+	return r.loadBatch(ctx, batchNumber)
+}
+
+// Batches returns the batches in the range, both included
+func (r *graphQLResolver) Batches(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*graphQLBatch, error) {
+	fromBatch, toBatch, err := r.getRange(ctx, args.From, args.To, r.state.GetLastBatchNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([]*graphQLBatch, 0, toBatch-fromBatch+1)
+	for batchNumber := fromBatch; batchNumber <= toBatch; batchNumber++ {
+		batch, err := r.loadBatch(ctx, batchNumber)
+		if err != nil {
+			return nil, err
+		} else if batch == nil {
+			break
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+// getRange returns the numeric range for the blocks and batches queries, the
+// missing values default to the last number and the range is limited to
+// the configured MaxBlockRange
+func (r *graphQLResolver) getRange(ctx context.Context, from, to *hexutil.Uint64, getLast func(context.Context, pgx.Tx) (uint64, error)) (uint64, uint64, error) {
+	var fromNumber, toNumber uint64
+	if from == nil || to == nil {
+		last, err := getLast(ctx, nil)
+		if err != nil {
+			return 0, 0, graphQLErrorResponse("failed to get the last number from state", err)
+		}
+		fromNumber, toNumber = last, last
+	}
+	if from != nil {
+		fromNumber = uint64(*from)
+	}
+	if to != nil {
+		toNumber = uint64(*to)
+	}
+
+	if toNumber < fromNumber {
+		return 0, 0, graphQLErrorResponse("invalid range", nil)
+	}
+	maxRange := r.cfg.GraphQL.MaxBlockRange
+	if maxRange > 0 && toNumber-fromNumber+1 > maxRange {
+		return 0, 0, graphQLErrorResponse(fmt.Sprintf("queries are limited to a %v block range", maxRange), nil)
+	}
+	return fromNumber, toNumber, nil
+}
+
+func (r *graphQLResolver) loadBlockByNumber(ctx context.Context, blockNumber uint64) (*graphQLBlock, error) {
+	block, err := r.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("failed to load block %v from state", blockNumber), err)
+	}
+	return &graphQLBlock{r: r, block: block}, nil
+}
+
+func (r *graphQLResolver) loadTransaction(ctx context.Context, hash common.Hash) (*graphQLTransaction, error) {
+	tx, err := r.state.GetTransactionByHash(ctx, hash, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to load transaction by hash from state", err)
+	}
+	return &graphQLTransaction{r: r, tx: tx}, nil
+}
+
+func (r *graphQLResolver) loadBatch(ctx context.Context, batchNumber uint64) (*graphQLBatch, error) {
+	batch, err := r.state.GetBatchByNumber(ctx, batchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load batch from state by number %v", batchNumber), err)
+	}
+
+	batchTimestamp, err := r.state.GetBatchTimestamp(ctx, batchNumber, nil, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load batch timestamp from state by number %v", batchNumber), err)
+	}
+	if batchTimestamp == nil {
+		batch.Timestamp = time.Time{}
+	} else {
+		batch.Timestamp = *batchTimestamp
+	}
+
+	virtualBatch, err := r.state.GetVirtualBatch(ctx, batchNumber, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load virtual batch from state by number %v", batchNumber), err)
+	}
+
+	verifiedBatch, err := r.state.GetVerifiedBatch(ctx, batchNumber, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load verified batch from state by number %v", batchNumber), err)
+	}
+
+	return &graphQLBatch{r: r, batch: batch, virtualBatch: virtualBatch, verifiedBatch: verifiedBatch}, nil
+}
+
+// graphQLAccount resolves an account at the state of a block, the state root
+// is loaded when it is needed if it's not known
+type graphQLAccount struct {
+	r       *graphQLResolver
+	address common.Address
+	// blockNumber is the number of the block of the state, nil means the latest block
+	blockNumber *uint64
+
+	root  *common.Hash
+	mutex sync.Mutex
+}
+
+func (r *graphQLResolver) newAccount(address common.Address, blockNumber *hexutil.Uint64, defaultBlockNumber *uint64) *graphQLAccount {
+	if blockNumber != nil {
+		return &graphQLAccount{r: r, address: address, blockNumber: state.Ptr(uint64(*blockNumber))}
+	}
+	return &graphQLAccount{r: r, address: address, blockNumber: defaultBlockNumber}
+}
+
+func (a *graphQLAccount) getRoot(ctx context.Context) (common.Hash, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.root != nil {
+		return *a.root, nil
+	}
+
+	var root common.Hash
+	if a.blockNumber == nil {
+		block, err := a.r.state.GetLastL2Block(ctx, nil)
+		if err != nil {
+			return common.Hash{}, graphQLErrorResponse("failed to load the last block from state", err)
+		}
+		root = block.Root()
+	} else {
+		header, err := a.r.state.GetL2BlockHeaderByNumber(ctx, *a.blockNumber, nil)
+		if err != nil {
+			return common.Hash{}, graphQLErrorResponse(fmt.Sprintf("failed to load block %v header from state", *a.blockNumber), err)
+		}
+		root = header.Root
+	}
+	a.root = &root
+	return root, nil
+}
+
+// Address returns the account address
+func (a *graphQLAccount) Address() common.Address {
+	return a.address
+}
+
+// Balance returns the account balance
+func (a *graphQLAccount) Balance(ctx context.Context) (hexutil.Big, error) {
+	root, err := a.getRoot(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	balance, err := a.r.state.GetBalance(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return hexutil.Big{}, nil
+	} else if err != nil {
+		return hexutil.Big{}, graphQLErrorResponse("failed to get balance from state", err)
+	}
+	return hexutil.Big(*balance), nil
+}
+
+// TransactionCount returns the account nonce
+func (a *graphQLAccount) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	root, err := a.getRoot(ctx)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := a.r.state.GetNonce(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, graphQLErrorResponse("failed to count transactions", err)
+	}
+	return hexutil.Uint64(nonce), nil
+}
+
+// Code returns the account code
+func (a *graphQLAccount) Code(ctx context.Context) (hexutil.Bytes, error) {
+	root, err := a.getRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	code, err := a.r.state.GetCode(ctx, a.address, root)
+	if errors.Is(err, state.ErrNotFound) {
+		return hexutil.Bytes{}, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to get code", err)
+	}
+	return code, nil
+}
+
+// Storage returns the value stored in the slot of the account storage
+func (a *graphQLAccount) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	root, err := a.getRoot(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	value, err := a.r.state.GetStorageAt(ctx, a.address, args.Slot.Big(), root)
+	if errors.Is(err, state.ErrNotFound) {
+		return common.Hash{}, nil
+	} else if err != nil {
+		return common.Hash{}, graphQLErrorResponse("failed to get storage value from state", err)
+	}
+	return common.BigToHash(value), nil
+}
+
+// graphQLBlock resolves an L2 block
+type graphQLBlock struct {
+	r     *graphQLResolver
+	block *state.L2Block
+}
+
+// Number returns the block number
+func (b *graphQLBlock) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.NumberU64())
+}
+
+// Hash returns the block hash
+func (b *graphQLBlock) Hash() common.Hash {
+	return b.block.Hash()
+}
+
+// Parent returns the parent block
+func (b *graphQLBlock) Parent(ctx context.Context) (*graphQLBlock, error) {
+	if b.block.NumberU64() == 0 {
+		return nil, nil
+	}
+	return b.r.loadBlockByNumber(ctx, b.block.NumberU64()-1)
+}
+
+// Nonce returns the block nonce
+func (b *graphQLBlock) Nonce() hexutil.Bytes {
+	nonce := b.block.Header().Nonce
+	return nonce[:]
+}
+
+// TransactionsRoot returns the root of the transactions trie
+func (b *graphQLBlock) TransactionsRoot() common.Hash {
+	return b.block.TxHash()
+}
+
+// TransactionCount returns the number of transactions of the block
+func (b *graphQLBlock) TransactionCount() *hexutil.Uint64 {
+	count := hexutil.Uint64(len(b.block.Transactions()))
+	return &count
+}
+
+// StateRoot returns the state root after the block
+func (b *graphQLBlock) StateRoot() common.Hash {
+	return b.block.Root()
+}
+
+// ReceiptsRoot returns the root of the receipts trie
+func (b *graphQLBlock) ReceiptsRoot() common.Hash {
+	return b.block.ReceiptHash()
+}
+
+// Miner returns the account that receives the fees of the block
+func (b *graphQLBlock) Miner(args struct{ Block *hexutil.Uint64 }) *graphQLAccount {
+	return b.r.newAccount(b.block.Coinbase(), args.Block, state.Ptr(b.block.NumberU64()))
+}
+
+// ExtraData returns the block extra data
+func (b *graphQLBlock) ExtraData() hexutil.Bytes {
+	return b.block.Extra()
+}
+
+// GasLimit returns the block gas limit
+func (b *graphQLBlock) GasLimit() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.GasLimit())
+}
+
+// GasUsed returns the gas used by the block transactions
+func (b *graphQLBlock) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.GasUsed())
+}
+
+// Timestamp returns the block timestamp
+func (b *graphQLBlock) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.block.Time())
+}
+
+// LogsBloom returns the bloom of the block logs
+func (b *graphQLBlock) LogsBloom() hexutil.Bytes {
+	return b.block.Bloom().Bytes()
+}
+
+// MixHash returns the block mix hash
+func (b *graphQLBlock) MixHash() common.Hash {
+	return b.block.MixDigest()
+}
+
+// Difficulty returns the block difficulty
+func (b *graphQLBlock) Difficulty() hexutil.Big {
+	return hexutil.Big(*b.block.Difficulty())
+}
+
+// OmmerCount returns the number of uncles, L2 blocks have no uncles
+func (b *graphQLBlock) OmmerCount() *hexutil.Uint64 {
+	count := hexutil.Uint64(0)
+	return &count
+}
+
+// Ommers returns the uncles, L2 blocks have no uncles
+func (b *graphQLBlock) Ommers() *[]*graphQLBlock {
+	return &[]*graphQLBlock{}
+}
+
+// OmmerHash returns the hash of the uncles list
+func (b *graphQLBlock) OmmerHash() common.Hash {
+	return b.block.UncleHash()
+}
+
+// Transactions returns the block transactions
+func (b *graphQLBlock) Transactions() *[]*graphQLTransaction {
+	txs := b.block.Transactions()
+	result := make([]*graphQLTransaction, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, &graphQLTransaction{r: b.r, tx: tx})
+	}
+	return &result
+}
+
+// TransactionAt returns the transaction at the index of the block
+func (b *graphQLBlock) TransactionAt(args struct{ Index hexutil.Uint64 }) *graphQLTransaction {
+	txs := b.block.Transactions()
+	if uint64(args.Index) >= uint64(len(txs)) {
+		return nil
+	}
+	return &graphQLTransaction{r: b.r, tx: txs[args.Index]}
+}
+
+// Logs returns the block logs matching the filter
+func (b *graphQLBlock) Logs(ctx context.Context, args struct{ Filter graphQLBlockFilterCriteria }) ([]*graphQLLog, error) {
+	blockHash := b.block.Hash()
+	filter := LogFilter{BlockHash: &blockHash}
+	if args.Filter.Addresses != nil {
+		filter.Addresses = *args.Filter.Addresses
+	}
+	if args.Filter.Topics != nil {
+		filter.Topics = *args.Filter.Topics
+	}
+	return b.r.getLogs(ctx, 0, 0, filter)
+}
+
+// Account returns an account at the state of the block
+func (b *graphQLBlock) Account(args struct{ Address common.Address }) *graphQLAccount {
+	root := b.block.Root()
+	return &graphQLAccount{r: b.r, address: args.Address, blockNumber: state.Ptr(b.block.NumberU64()), root: &root}
+}
+
+// Call executes a call at the state of the block
+func (b *graphQLBlock) Call(ctx context.Context, args struct{ Data graphQLCallData }) (*graphQLCallResult, error) {
+	txArgs := args.Data.toTxArgs()
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if txArgs.Gas == nil || uint64(*txArgs.Gas) <= 0 {
+		gas := types.ArgUint64(b.block.GasLimit())
+		txArgs.Gas = &gas
+	}
+
+	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+	sender, tx, err := txArgs.ToTransaction(ctx, b.r.state, state.MaxTxGasLimit, b.block.Root(), defaultSenderAddress, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse("failed to convert arguments into an unsigned transaction", err)
+	}
+
+	blockNumber := b.block.NumberU64()
+	result, err := b.r.state.ProcessUnsignedTransaction(ctx, tx, sender, &blockNumber, true, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error()), nil)
+	}
+
+	status := hexutil.Uint64(ethTypes.ReceiptStatusSuccessful)
+	if result.Failed() {
+		status = hexutil.Uint64(ethTypes.ReceiptStatusFailed)
+	}
+
+	return &graphQLCallResult{
+		data:    result.ReturnValue,
+		gasUsed: hexutil.Uint64(result.GasUsed),
+		status:  status,
+	}, nil
+}
+
+// EstimateGas estimates the gas required to execute a transaction at the state of the block
+func (b *graphQLBlock) EstimateGas(ctx context.Context, args struct{ Data graphQLCallData }) (hexutil.Uint64, error) {
+	txArgs := args.Data.toTxArgs()
+
+	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+	sender, tx, err := txArgs.ToTransaction(ctx, b.r.state, state.MaxTxGasLimit, b.block.Root(), defaultSenderAddress, nil)
+	if err != nil {
+		return 0, graphQLErrorResponse("failed to convert arguments into an unsigned transaction", err)
+	}
+
+	blockNumber := b.block.NumberU64()
+	gasEstimation, _, err := b.r.state.EstimateGas(tx, sender, &blockNumber, nil)
+	if err != nil {
+		return 0, graphQLErrorResponse(err.Error(), nil)
+	}
+	return hexutil.Uint64(gasEstimation), nil
+}
+
+// GlobalExitRoot returns the global exit root used by the block
+func (b *graphQLBlock) GlobalExitRoot() common.Hash {
+	return b.block.GlobalExitRoot()
+}
+
+// BlockInfoRoot returns the root of the block info tree of the block
+func (b *graphQLBlock) BlockInfoRoot() common.Hash {
+	return b.block.BlockInfoRoot()
+}
+
+// L1InfoRoot returns the L1 info root used to sequence the batch of the block
+func (b *graphQLBlock) L1InfoRoot(ctx context.Context) (*common.Hash, error) {
+	batchNumber, err := b.BatchNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	virtualBatch, err := b.r.state.GetVirtualBatch(ctx, uint64(batchNumber), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load virtual batch from state by number %v", batchNumber), err)
+	}
+	return virtualBatch.L1InfoRoot, nil
+}
+
+// BatchNumber returns the number of the batch of the block
+func (b *graphQLBlock) BatchNumber(ctx context.Context) (hexutil.Uint64, error) {
+	batchNumber, err := b.r.state.BatchNumberByL2BlockNumber(ctx, b.block.NumberU64(), nil)
+	if err != nil {
+		return 0, graphQLErrorResponse(fmt.Sprintf("failed to get the batch number of the block %v", b.block.NumberU64()), err)
+	}
+	return hexutil.Uint64(batchNumber), nil
+}
+
+// Batch returns the batch of the block
+func (b *graphQLBlock) Batch(ctx context.Context) (*graphQLBatch, error) {
+	batchNumber, err := b.BatchNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := b.r.loadBatch(ctx, uint64(batchNumber))
+	if err != nil {
+		return nil, err
+	} else if batch == nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("batch %v of the block %v not found", batchNumber, b.block.NumberU64()), nil)
+	}
+	return batch, nil
+}
+
+// graphQLTransaction resolves a transaction, the receipt is loaded
+// when it is needed
+type graphQLTransaction struct {
+	r  *graphQLResolver
+	tx *ethTypes.Transaction
+
+	receipt *ethTypes.Receipt
+	mutex   sync.Mutex
+}
+
+func (t *graphQLTransaction) getReceipt(ctx context.Context) (*ethTypes.Receipt, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.receipt != nil {
+		return t.receipt, nil
+	}
+
+	receipt, err := t.r.state.GetTransactionReceipt(ctx, t.tx.Hash(), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to load transaction receipt from state", err)
+	}
+	t.receipt = receipt
+	return receipt, nil
+}
+
+// getBlockNumber returns the number of the block of the transaction
+func (t *graphQLTransaction) getBlockNumber(ctx context.Context) (*uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return state.Ptr(receipt.BlockNumber.Uint64()), nil
+}
+
+// Hash returns the transaction hash
+func (t *graphQLTransaction) Hash() common.Hash {
+	return t.tx.Hash()
+}
+
+// L2Hash returns the transaction hash computed by the zkEVM
+func (t *graphQLTransaction) L2Hash(ctx context.Context) (*common.Hash, error) {
+	l2Hash, err := t.r.state.GetL2TxHashByTxHash(ctx, t.tx.Hash(), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, graphQLErrorResponse("failed to get l2 transaction hash", err)
+	}
+	return l2Hash, nil
+}
+
+// Nonce returns the transaction nonce
+func (t *graphQLTransaction) Nonce() hexutil.Uint64 {
+	return hexutil.Uint64(t.tx.Nonce())
+}
+
+// Index returns the index of the transaction in the block
+func (t *graphQLTransaction) Index(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	index := hexutil.Uint64(receipt.TransactionIndex)
+	return &index, nil
+}
+
+// From returns the account that sent the transaction
+func (t *graphQLTransaction) From(ctx context.Context, args struct{ Block *hexutil.Uint64 }) (*graphQLAccount, error) {
+	sender, err := state.GetSender(*t.tx)
+	if err != nil {
+		return nil, graphQLErrorResponse("failed to get the transaction sender", err)
+	}
+	blockNumber, err := t.getBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.r.newAccount(sender, args.Block, blockNumber), nil
+}
+
+// To returns the account that received the transaction
+func (t *graphQLTransaction) To(ctx context.Context, args struct{ Block *hexutil.Uint64 }) (*graphQLAccount, error) {
+	if t.tx.To() == nil {
+		return nil, nil
+	}
+	blockNumber, err := t.getBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t.r.newAccount(*t.tx.To(), args.Block, blockNumber), nil
+}
+
+// Value returns the value sent by the transaction
+func (t *graphQLTransaction) Value() hexutil.Big {
+	return hexutil.Big(*t.tx.Value())
+}
+
+// GasPrice returns the transaction gas price
+func (t *graphQLTransaction) GasPrice() hexutil.Big {
+	return hexutil.Big(*t.tx.GasPrice())
+}
+
+// EffectiveGasPrice returns the gas price paid by the transaction
+func (t *graphQLTransaction) EffectiveGasPrice(ctx context.Context) (*hexutil.Big, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || receipt.EffectiveGasPrice == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(receipt.EffectiveGasPrice), nil
+}
+
+// Gas returns the transaction gas limit
+func (t *graphQLTransaction) Gas() hexutil.Uint64 {
+	return hexutil.Uint64(t.tx.Gas())
+}
+
+// InputData returns the transaction data
+func (t *graphQLTransaction) InputData() hexutil.Bytes {
+	return t.tx.Data()
+}
+
+// Block returns the block of the transaction
+func (t *graphQLTransaction) Block(ctx context.Context) (*graphQLBlock, error) {
+	blockNumber, err := t.getBlockNumber(ctx)
+	if err != nil || blockNumber == nil {
+		return nil, err
+	}
+	return t.r.loadBlockByNumber(ctx, *blockNumber)
+}
+
+// Status returns the execution status of the transaction
+func (t *graphQLTransaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(receipt.Status)
+	return &status, nil
+}
+
+// GasUsed returns the gas used by the transaction
+func (t *graphQLTransaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	gasUsed := hexutil.Uint64(receipt.GasUsed)
+	return &gasUsed, nil
+}
+
+// CumulativeGasUsed returns the gas used by the block up to the transaction
+func (t *graphQLTransaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	cumulativeGasUsed := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &cumulativeGasUsed, nil
+}
+
+// CreatedContract returns the contract created by the transaction
+func (t *graphQLTransaction) CreatedContract(ctx context.Context, args struct{ Block *hexutil.Uint64 }) (*graphQLAccount, error) {
+	if t.tx.To() != nil {
+		return nil, nil
+	}
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	return t.r.newAccount(receipt.ContractAddress, args.Block, state.Ptr(receipt.BlockNumber.Uint64())), nil
+}
+
+// Logs returns the logs emitted by the transaction
+func (t *graphQLTransaction) Logs(ctx context.Context) (*[]*graphQLLog, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	logs := make([]*graphQLLog, 0, len(receipt.Logs))
+	for _, l := range receipt.Logs {
+		logs = append(logs, &graphQLLog{r: t.r, log: l})
+	}
+	return &logs, nil
+}
+
+// R returns the R value of the transaction signature
+func (t *graphQLTransaction) R() hexutil.Big {
+	_, r, _ := t.tx.RawSignatureValues()
+	return hexutil.Big(*r)
+}
+
+// S returns the S value of the transaction signature
+func (t *graphQLTransaction) S() hexutil.Big {
+	_, _, s := t.tx.RawSignatureValues()
+	return hexutil.Big(*s)
+}
+
+// V returns the V value of the transaction signature
+func (t *graphQLTransaction) V() hexutil.Big {
+	v, _, _ := t.tx.RawSignatureValues()
+	return hexutil.Big(*v)
+}
+
+// Type returns the transaction type
+func (t *graphQLTransaction) Type() *hexutil.Uint64 {
+	txType := hexutil.Uint64(t.tx.Type())
+	return &txType
+}
+
+// Raw returns the canonical encoding of the transaction
+func (t *graphQLTransaction) Raw() (hexutil.Bytes, error) {
+	raw, err := t.tx.MarshalBinary()
+	if err != nil {
+		return nil, graphQLErrorResponse("failed to encode the transaction", err)
+	}
+	return raw, nil
+}
+
+// graphQLLog resolves a log
+type graphQLLog struct {
+	r   *graphQLResolver
+	log *ethTypes.Log
+}
+
+// Index returns the index of the log in the block
+func (l *graphQLLog) Index() hexutil.Uint64 {
+	return hexutil.Uint64(l.log.Index)
+}
+
+// Account returns the account that emitted the log
+func (l *graphQLLog) Account(args struct{ Block *hexutil.Uint64 }) *graphQLAccount {
+	return l.r.newAccount(l.log.Address, args.Block, state.Ptr(l.log.BlockNumber))
+}
+
+// Topics returns the log topics
+func (l *graphQLLog) Topics() []common.Hash {
+	return l.log.Topics
+}
+
+// Data returns the log data
+func (l *graphQLLog) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+// Transaction returns the transaction that emitted the log
+func (l *graphQLLog) Transaction(ctx context.Context) (*graphQLTransaction, error) {
+	tx, err := l.r.loadTransaction(ctx, l.log.TxHash)
+	if err != nil {
+		return nil, err
+	} else if tx == nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("transaction %v of the log not found", l.log.TxHash.String()), nil)
+	}
+	return tx, nil
+}
+
+// graphQLBatch resolves a batch, the exit roots are loaded when they are needed
+type graphQLBatch struct {
+	r             *graphQLResolver
+	batch         *state.Batch
+	virtualBatch  *state.VirtualBatch
+	verifiedBatch *state.VerifiedBatch
+
+	exitRoot *state.GlobalExitRoot
+	mutex    sync.Mutex
+}
+
+func (b *graphQLBatch) getExitRoot(ctx context.Context) (*state.GlobalExitRoot, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.exitRoot != nil {
+		return b.exitRoot, nil
+	}
+
+	exitRoot, err := b.r.state.GetExitRootByGlobalExitRoot(ctx, b.batch.GlobalExitRoot, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		exitRoot = &state.GlobalExitRoot{}
+	} else if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load full GER from state by number %v", b.batch.BatchNumber), err)
+	}
+	b.exitRoot = exitRoot
+	return exitRoot, nil
+}
+
+// Number returns the batch number
+func (b *graphQLBatch) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.batch.BatchNumber)
+}
+
+// Status returns the batch status
+func (b *graphQLBatch) Status() string {
+	if b.verifiedBatch != nil {
+		return graphQLBatchStatusVerified
+	} else if b.virtualBatch != nil {
+		return graphQLBatchStatusVirtual
+	}
+	return graphQLBatchStatusTrusted
+}
+
+// Closed returns if the batch is closed
+func (b *graphQLBatch) Closed() bool {
+	return !b.batch.WIP
+}
+
+// Coinbase returns the address that receives the fees of the batch
+func (b *graphQLBatch) Coinbase() common.Address {
+	return b.batch.Coinbase
+}
+
+// StateRoot returns the state root after the batch
+func (b *graphQLBatch) StateRoot() common.Hash {
+	return b.batch.StateRoot
+}
+
+// LocalExitRoot returns the local exit root after the batch
+func (b *graphQLBatch) LocalExitRoot() common.Hash {
+	return b.batch.LocalExitRoot
+}
+
+// AccInputHash returns the accumulated input hash of the batch
+func (b *graphQLBatch) AccInputHash() common.Hash {
+	return b.batch.AccInputHash
+}
+
+// GlobalExitRoot returns the global exit root of the batch
+func (b *graphQLBatch) GlobalExitRoot() common.Hash {
+	return b.batch.GlobalExitRoot
+}
+
+// MainnetExitRoot returns the mainnet exit root of the global exit root
+func (b *graphQLBatch) MainnetExitRoot(ctx context.Context) (common.Hash, error) {
+	exitRoot, err := b.getExitRoot(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return exitRoot.MainnetExitRoot, nil
+}
+
+// RollupExitRoot returns the rollup exit root of the global exit root
+func (b *graphQLBatch) RollupExitRoot(ctx context.Context) (common.Hash, error) {
+	exitRoot, err := b.getExitRoot(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return exitRoot.RollupExitRoot, nil
+}
+
+// Timestamp returns the batch timestamp
+func (b *graphQLBatch) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.batch.Timestamp.Unix())
+}
+
+// ForcedBatchNumber returns the forced batch number if the batch was forced
+func (b *graphQLBatch) ForcedBatchNumber() *hexutil.Uint64 {
+	if b.batch.ForcedBatchNum == nil {
+		return nil
+	}
+	forcedBatchNumber := hexutil.Uint64(*b.batch.ForcedBatchNum)
+	return &forcedBatchNumber
+}
+
+// BatchL2Data returns the encoded batch data
+func (b *graphQLBatch) BatchL2Data() hexutil.Bytes {
+	return b.batch.BatchL2Data
+}
+
+// Virtual returns the L1 sequencing of the batch
+func (b *graphQLBatch) Virtual() *graphQLVirtualBatch {
+	if b.virtualBatch == nil {
+		return nil
+	}
+	return &graphQLVirtualBatch{virtualBatch: b.virtualBatch}
+}
+
+// Verified returns the L1 verification of the batch
+func (b *graphQLBatch) Verified() *graphQLVerifiedBatch {
+	if b.verifiedBatch == nil {
+		return nil
+	}
+	return &graphQLVerifiedBatch{verifiedBatch: b.verifiedBatch}
+}
+
+// Blocks returns the L2 blocks of the batch
+func (b *graphQLBatch) Blocks(ctx context.Context) ([]*graphQLBlock, error) {
+	blocks, err := b.r.state.GetL2BlocksByBatchNumber(ctx, b.batch.BatchNumber, nil)
+	if err != nil {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load blocks associated to the batch %v", b.batch.BatchNumber), err)
+	}
+	result := make([]*graphQLBlock, 0, len(blocks))
+	for i := range blocks {
+		result = append(result, &graphQLBlock{r: b.r, block: &blocks[i]})
+	}
+	return result, nil
+}
+
+// Transactions returns the transactions of the batch
+func (b *graphQLBatch) Transactions(ctx context.Context) ([]*graphQLTransaction, error) {
+	txs, _, err := b.r.state.GetTransactionsByBatchNumber(ctx, b.batch.BatchNumber, nil)
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return nil, graphQLErrorResponse(fmt.Sprintf("couldn't load batch txs from state by number %v", b.batch.BatchNumber), err)
+	}
+	result := make([]*graphQLTransaction, 0, len(txs))
+	for i := range txs {
+		result = append(result, &graphQLTransaction{r: b.r, tx: &txs[i]})
+	}
+	return result, nil
+}
+
+// graphQLVirtualBatch resolves the L1 sequencing of a batch
+type graphQLVirtualBatch struct {
+	virtualBatch *state.VirtualBatch
+}
+
+// L1TxHash returns the hash of the L1 transaction that sequenced the batch
+func (v *graphQLVirtualBatch) L1TxHash() common.Hash {
+	return v.virtualBatch.TxHash
+}
+
+// L1BlockNumber returns the number of the L1 block that sequenced the batch
+func (v *graphQLVirtualBatch) L1BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(v.virtualBatch.BlockNumber)
+}
+
+// Sequencer returns the address that sequenced the batch
+func (v *graphQLVirtualBatch) Sequencer() common.Address {
+	return v.virtualBatch.SequencerAddr
+}
+
+// L1InfoRoot returns the L1 info root used to sequence the batch
+func (v *graphQLVirtualBatch) L1InfoRoot() *common.Hash {
+	return v.virtualBatch.L1InfoRoot
+}
+
+// graphQLVerifiedBatch resolves the L1 verification of a batch
+type graphQLVerifiedBatch struct {
+	verifiedBatch *state.VerifiedBatch
+}
+
+// L1TxHash returns the hash of the L1 transaction that verified the batch
+func (v *graphQLVerifiedBatch) L1TxHash() common.Hash {
+	return v.verifiedBatch.TxHash
+}
+
+// L1BlockNumber returns the number of the L1 block that verified the batch
+func (v *graphQLVerifiedBatch) L1BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(v.verifiedBatch.BlockNumber)
+}
+
+// Aggregator returns the address that verified the batch
+func (v *graphQLVerifiedBatch) Aggregator() common.Address {
+	return v.verifiedBatch.Aggregator
+}
+
+// StateRoot returns the state root verified for the batch
+func (v *graphQLVerifiedBatch) StateRoot() common.Hash {
+	return v.verifiedBatch.StateRoot
+}
+
+// graphQLSyncState resolves the synchronization state
+type graphQLSyncState struct {
+	info state.SyncingInfo
+}
+
+// StartingBlock returns the block where the synchronization started
+func (s *graphQLSyncState) StartingBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.InitialSyncingBlock)
+}
+
+// CurrentBlock returns the last synchronized block
+func (s *graphQLSyncState) CurrentBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.CurrentBlockNumber)
+}
+
+// HighestBlock returns the highest known block
+func (s *graphQLSyncState) HighestBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.info.EstimatedHighestBlock)
+}
+
+// graphQLCallResult resolves the result of a call
+type graphQLCallResult struct {
+	data    hexutil.Bytes
+	gasUsed hexutil.Uint64
+	status  hexutil.Uint64
+}
+
+// Data returns the data returned by the call
+func (c *graphQLCallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+// GasUsed returns the gas used by the call
+func (c *graphQLCallResult) GasUsed() hexutil.Uint64 {
+	return c.gasUsed
+}
+
+// Status returns the execution status of the call
+func (c *graphQLCallResult) Status() hexutil.Uint64 {
+	return c.status
+}
+
+// graphQLCallData is the input of the call and estimateGas queries
+type graphQLCallData struct {
+	From     *common.Address
+	To       *common.Address
+	Gas      *hexutil.Uint64
+	GasPrice *hexutil.Big
+	Value    *hexutil.Big
+	Data     *hexutil.Bytes
+}
+
+func (d graphQLCallData) toTxArgs() *types.TxArgs {
+	args := &types.TxArgs{
+		From: d.From,
+		To:   d.To,
+		Data: (*types.ArgBytes)(d.Data),
+	}
+	if d.Gas != nil {
+		gas := types.ArgUint64(*d.Gas)
+		args.Gas = &gas
+	}
+	if d.GasPrice != nil {
+		gasPrice := types.ArgBytes(d.GasPrice.ToInt().Bytes())
+		args.GasPrice = &gasPrice
+	}
+	if d.Value != nil {
+		value := types.ArgBytes(d.Value.ToInt().Bytes())
+		args.Value = &value
+	}
+	return args
+}
+
+// graphQLFilterCriteria is the input of the logs query
+type graphQLFilterCriteria struct {
+	FromBlock *hexutil.Uint64
+	ToBlock   *hexutil.Uint64
+	Addresses *[]common.Address
+	Topics    *[][]common.Hash
+}
+
+// graphQLBlockFilterCriteria is the input of the block logs query
+type graphQLBlockFilterCriteria struct {
+	Addresses *[]common.Address
+	Topics    *[][]common.Hash
+}
@@ -0,0 +1,347 @@
+package jsonrpc
+
+// graphQLSchema is the GraphQL schema served by the GraphQL endpoint, it follows
+// the EIP-1767 schema for the L2 blocks, transactions, logs and accounts and
+// extends it with the zkEVM batches and exit roots.
+//
+// https://eips.ethereum.org/EIPS/eip-1767
+const graphQLSchema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'. Byte strings must have an even number of hexadecimal nybbles.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer. Input is accepted as either a JSON number or as a
+    # 0x-prefixed hexadecimal string. Output values are all 0x-prefixed hexadecimal.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    # Account is an account at a particular L2 block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in wei.
+        balance: BigInt!
+        # TransactionCount is the number of transactions sent from this account,
+        # or in the case of a contract, the number of contracts created. Otherwise
+        # known as the nonce.
+        transactionCount: Long!
+        # Code contains the smart contract code for this account, if the account
+        # is a contract.
+        code: Bytes!
+        # Storage provides access to the storage of a contract account, indexed
+        # by its 32 byte slot identifier.
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Long!
+        # Account is the account which generated this log - this will always
+        # be a contract account.
+        account(block: Long): Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+        # Transaction is the transaction that generated this log entry.
+        transaction: Transaction!
+    }
+
+    # Transaction is an L2 transaction.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # L2Hash is the hash of this transaction computed by the zkEVM, which
+        # can be different from the hash of the transaction.
+        l2Hash: Bytes32
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction in the parent block.
+        index: Long
+        # From is the account that sent this transaction - this will always be
+        # an externally owned account.
+        from(block: Long): Account!
+        # To is the account the transaction was sent to. This is null for
+        # contract-creating transactions.
+        to(block: Long): Account
+        # Value is the value, in wei, sent along with this transaction.
+        value: BigInt!
+        # GasPrice is the price offered to the sequencer for gas, in wei per unit.
+        gasPrice: BigInt!
+        # EffectiveGasPrice is the actual value per gas deducted from the sender's
+        # account, in wei per unit.
+        effectiveGasPrice: BigInt
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the L2 block this transaction was included in.
+        block: Block
+        # Status is the return status of the transaction. This will be 1 if the
+        # transaction succeeded, or 0 if it failed (due to a revert, or due to
+        # running out of gas).
+        status: Long
+        # GasUsed is the amount of gas that was used processing this transaction.
+        gasUsed: Long
+        # CumulativeGasUsed is the total gas used in the block up to and including
+        # this transaction.
+        cumulativeGasUsed: Long
+        # CreatedContract is the account that was created by a contract creation
+        # transaction. If the transaction was not a contract creation transaction
+        # this field will be null.
+        createdContract(block: Long): Account
+        # Logs is a list of log entries emitted by this transaction.
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+        # Type is the EIP-2718 type of the transaction.
+        type: Long
+        # Raw is the canonical encoding of the transaction.
+        raw: Bytes!
+    }
+
+    # BlockFilterCriteria encapsulates log filter criteria for a filter applied
+    # to a single block.
+    input BlockFilterCriteria {
+        # Addresses is list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is an L2 block.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # Nonce is the block nonce.
+        nonce: Bytes!
+        # TransactionsRoot is the keccak256 hash of the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of transactions in this block.
+        transactionCount: Long
+        # StateRoot is the state root after this block was processed.
+        stateRoot: Bytes32!
+        # ReceiptsRoot is the keccak256 hash of the trie of transaction receipts in this block.
+        receiptsRoot: Bytes32!
+        # Miner is the account that receives the fees of this block.
+        miner(block: Long): Account!
+        # ExtraData is an arbitrary data field supplied by the sequencer.
+        extraData: Bytes!
+        # GasLimit is the maximum amount of gas that was available to transactions in this block.
+        gasLimit: Long!
+        # GasUsed is the amount of gas that was used executing transactions in this block.
+        gasUsed: Long!
+        # Timestamp is the unix timestamp of this block.
+        timestamp: Long!
+        # LogsBloom is a bloom filter that can be used to check if a block may
+        # contain log entries matching a filter.
+        logsBloom: Bytes!
+        # MixHash is the mix hash field of the block header.
+        mixHash: Bytes32!
+        # Difficulty is always zero for L2 blocks.
+        difficulty: BigInt!
+        # OmmerCount is always zero for L2 blocks.
+        ommerCount: Long
+        # Ommers is always empty for L2 blocks.
+        ommers: [Block]
+        # OmmerHash is the keccak256 hash of the empty ommers list.
+        ommerHash: Bytes32!
+        # Transactions is a list of transactions associated with this block.
+        transactions: [Transaction!]
+        # TransactionAt returns the transaction at the specified index. If the
+        # index is out of bounds, this field will be null.
+        transactionAt(index: Long!): Transaction
+        # Logs returns a filtered set of logs from this block.
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        # Account fetches an account at the current block's state.
+        account(address: Address!): Account!
+        # Call executes a local call operation at the current block's state.
+        call(data: CallData!): CallResult
+        # EstimateGas estimates the amount of gas that will be required for
+        # successful execution of a transaction at the current block's state.
+        estimateGas(data: CallData!): Long!
+        # GlobalExitRoot is the global exit root used by this block.
+        globalExitRoot: Bytes32!
+        # BlockInfoRoot is the root of the block info tree of this block.
+        blockInfoRoot: Bytes32!
+        # L1InfoRoot is the L1 info root used to sequence the batch of this block,
+        # this field will be null while the batch is not virtualized.
+        l1InfoRoot: Bytes32
+        # BatchNumber is the number of the batch this block belongs to.
+        batchNumber: Long!
+        # Batch is the batch this block belongs to.
+        batch: Batch!
+    }
+
+    # CallData represents the data associated with a local contract call.
+    # All fields are optional.
+    input CallData {
+        # From is the address making the call.
+        from: Address
+        # To is the address the call is sent to.
+        to: Address
+        # Gas is the amount of gas sent with the call.
+        gas: Long
+        # GasPrice is the price, in wei, offered for each unit of gas.
+        gasPrice: BigInt
+        # Value is the value, in wei, sent along with the call.
+        value: BigInt
+        # Data is the data sent to the callee.
+        data: Bytes
+    }
+
+    # CallResult is the result of a local call operation.
+    type CallResult {
+        # Data is the return data of the called contract.
+        data: Bytes!
+        # GasUsed is the amount of gas used by the call, after any refunds.
+        gasUsed: Long!
+        # Status is the result of the call - 1 for success or 0 for failure.
+        status: Long!
+    }
+
+    # FilterCriteria encapsulates log filter criteria for searching log entries.
+    input FilterCriteria {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # Addresses is a list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        topics: [[Bytes32!]!]
+    }
+
+    # SyncState contains the current synchronisation state of the node.
+    type SyncState {
+        # StartingBlock is the block number at which synchronisation started.
+        startingBlock: Long!
+        # CurrentBlock is the point at which synchronisation has presently reached.
+        currentBlock: Long!
+        # HighestBlock is the latest known block number.
+        highestBlock: Long!
+    }
+
+    # BatchStatus is the status of a batch in the zkEVM.
+    enum BatchStatus {
+        # TRUSTED batches are only known by the trusted sequencer.
+        TRUSTED
+        # VIRTUAL batches are sequenced to L1.
+        VIRTUAL
+        # VERIFIED batches have a validity proof verified in L1.
+        VERIFIED
+    }
+
+    # VirtualBatch contains the information of the L1 sequencing of a batch.
+    type VirtualBatch {
+        # L1TxHash is the hash of the L1 transaction that sequenced the batch.
+        l1TxHash: Bytes32!
+        # L1BlockNumber is the number of the L1 block that sequenced the batch.
+        l1BlockNumber: Long!
+        # Sequencer is the address that sequenced the batch.
+        sequencer: Address!
+        # L1InfoRoot is the L1 info root used to sequence the batch.
+        l1InfoRoot: Bytes32
+    }
+
+    # VerifiedBatch contains the information of the L1 verification of a batch.
+    type VerifiedBatch {
+        # L1TxHash is the hash of the L1 transaction that verified the batch.
+        l1TxHash: Bytes32!
+        # L1BlockNumber is the number of the L1 block that verified the batch.
+        l1BlockNumber: Long!
+        # Aggregator is the address that verified the batch.
+        aggregator: Address!
+        # StateRoot is the state root verified for the batch.
+        stateRoot: Bytes32!
+    }
+
+    # Batch is a zkEVM batch, a group of L2 blocks sequenced and verified together.
+    type Batch {
+        # Number is the number of this batch.
+        number: Long!
+        # Status is the status of this batch.
+        status: BatchStatus!
+        # Closed is false while the sequencer is still adding blocks to this batch.
+        closed: Boolean!
+        # Coinbase is the address that receives the fees of this batch.
+        coinbase: Address!
+        # StateRoot is the state root after this batch was processed.
+        stateRoot: Bytes32!
+        # LocalExitRoot is the local exit root after this batch was processed.
+        localExitRoot: Bytes32!
+        # AccInputHash is the accumulated input hash of this batch.
+        accInputHash: Bytes32!
+        # GlobalExitRoot is the global exit root of this batch.
+        globalExitRoot: Bytes32!
+        # MainnetExitRoot is the mainnet exit root of the global exit root.
+        mainnetExitRoot: Bytes32!
+        # RollupExitRoot is the rollup exit root of the global exit root.
+        rollupExitRoot: Bytes32!
+        # Timestamp is the unix timestamp of this batch.
+        timestamp: Long!
+        # ForcedBatchNumber is the number of the forced batch, if this batch was forced.
+        forcedBatchNumber: Long
+        # BatchL2Data is the encoded data of this batch.
+        batchL2Data: Bytes!
+        # Virtual contains the L1 sequencing of this batch, null if it is not virtualized yet.
+        virtual: VirtualBatch
+        # Verified contains the L1 verification of this batch, null if it is not verified yet.
+        verified: VerifiedBatch
+        # Blocks is the list of L2 blocks of this batch.
+        blocks: [Block!]!
+        # Transactions is the list of transactions of this batch.
+        transactions: [Transaction!]!
+    }
+
+    type Query {
+        # Block fetches an L2 block by number or by hash. If neither is
+        # supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns all the blocks between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known block.
+        blocks(from: Long, to: Long): [Block!]!
+        # Transaction returns a transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # Logs returns log entries matching the provided filter.
+        logs(filter: FilterCriteria!): [Log!]!
+        # GasPrice returns the L2 gas price suggested by the node.
+        gasPrice: BigInt!
+        # Syncing returns information on the current synchronisation state.
+        syncing: SyncState
+        # ChainID returns the current chain ID for transaction replay protection.
+        chainID: BigInt!
+        # Batch fetches a batch by number. If the number is not supplied, the
+        # most recent known batch is returned.
+        batch(number: Long): Batch
+        # Batches returns all the batches between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known batch.
+        batches(from: Long, to: Long): [Batch!]!
+    }
+`
@@ -0,0 +1,158 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newGraphQLTestSchema(t *testing.T, cfg Config) (*graphql.Schema, *mocks.StateMock, *mocks.PoolMock) {
+	st := mocks.NewStateMock(t)
+	pool := mocks.NewPoolMock(t)
+	resolver := newGraphQLResolver(cfg, chainID, pool, st)
+	schema, err := graphql.ParseSchema(graphQLSchema, resolver)
+	require.NoError(t, err)
+	return schema, st, pool
+}
+
+func TestGraphQLBlockWithBatch(t *testing.T) {
+	schema, st, _ := newGraphQLTestSchema(t, getSequencerDefaultConfig())
+
+	ger := common.HexToHash("0x1")
+	blockInfoRoot := common.HexToHash("0x2")
+	l1InfoRoot := common.HexToHash("0x3")
+	l2Header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10), Time: 100})
+	l2Header.GlobalExitRoot = ger
+	l2Header.BlockInfoRoot = blockInfoRoot
+	block := state.NewL2BlockWithHeader(l2Header)
+
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(10), nil).Return(block, nil).Once()
+	st.On("BatchNumberByL2BlockNumber", mock.Anything, uint64(10), nil).Return(uint64(5), nil)
+	st.On("GetBatchByNumber", mock.Anything, uint64(5), nil).Return(&state.Batch{BatchNumber: 5, GlobalExitRoot: ger}, nil).Once()
+	st.On("GetBatchTimestamp", mock.Anything, uint64(5), (*uint64)(nil), nil).Return(nil, nil).Once()
+	st.On("GetVirtualBatch", mock.Anything, uint64(5), nil).Return(&state.VirtualBatch{BatchNumber: 5, L1InfoRoot: &l1InfoRoot}, nil)
+	st.On("GetVerifiedBatch", mock.Anything, uint64(5), nil).Return(nil, state.ErrNotFound).Once()
+
+	query := `{
+		block(number: 10) {
+			number
+			timestamp
+			globalExitRoot
+			blockInfoRoot
+			l1InfoRoot
+			batchNumber
+			batch { number status closed virtual { l1InfoRoot } verified { l1TxHash } }
+		}
+	}`
+	res := schema.Exec(context.Background(), query, "", nil)
+	require.Empty(t, res.Errors)
+
+	var result struct {
+		Block struct {
+			Number         string
+			Timestamp      string
+			GlobalExitRoot common.Hash
+			BlockInfoRoot  common.Hash
+			L1InfoRoot     *common.Hash
+			BatchNumber    string
+			Batch          struct {
+				Number  string
+				Status  string
+				Closed  bool
+				Virtual *struct {
+					L1InfoRoot *common.Hash
+				}
+				Verified *struct {
+					L1TxHash common.Hash
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &result))
+
+	assert.Equal(t, "0xa", result.Block.Number)
+	assert.Equal(t, "0x64", result.Block.Timestamp)
+	assert.Equal(t, ger, result.Block.GlobalExitRoot)
+	assert.Equal(t, blockInfoRoot, result.Block.BlockInfoRoot)
+	assert.Equal(t, &l1InfoRoot, result.Block.L1InfoRoot)
+	assert.Equal(t, "0x5", result.Block.BatchNumber)
+	assert.Equal(t, "0x5", result.Block.Batch.Number)
+	assert.Equal(t, graphQLBatchStatusVirtual, result.Block.Batch.Status)
+	assert.True(t, result.Block.Batch.Closed)
+	require.NotNil(t, result.Block.Batch.Virtual)
+	assert.Equal(t, &l1InfoRoot, result.Block.Batch.Virtual.L1InfoRoot)
+	assert.Nil(t, result.Block.Batch.Verified)
+}
+
+func TestGraphQLAccount(t *testing.T) {
+	schema, st, _ := newGraphQLTestSchema(t, getSequencerDefaultConfig())
+
+	address := common.HexToAddress("0x123")
+	root := common.HexToHash("0x456")
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(1), Root: root}))
+
+	st.On("GetL2BlockByNumber", mock.Anything, uint64(1), nil).Return(block, nil).Once()
+	st.On("GetBalance", mock.Anything, address, root).Return(big.NewInt(1000), nil).Once()
+	st.On("GetNonce", mock.Anything, address, root).Return(uint64(3), nil).Once()
+	st.On("GetCode", mock.Anything, address, root).Return(nil, state.ErrNotFound).Once()
+
+	query := `query($address: Address!) {
+		block(number: 1) {
+			account(address: $address) { address balance transactionCount code }
+		}
+	}`
+	res := schema.Exec(context.Background(), query, "", map[string]interface{}{"address": address.String()})
+	require.Empty(t, res.Errors)
+
+	var result struct {
+		Block struct {
+			Account struct {
+				Address          common.Address
+				Balance          string
+				TransactionCount string
+				Code             string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &result))
+
+	assert.Equal(t, address, result.Block.Account.Address)
+	assert.Equal(t, "0x3e8", result.Block.Account.Balance)
+	assert.Equal(t, "0x3", result.Block.Account.TransactionCount)
+	assert.Equal(t, "0x", result.Block.Account.Code)
+}
+
+func TestGraphQLBlocksRangeLimit(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	cfg.GraphQL.MaxBlockRange = 10
+	schema, _, _ := newGraphQLTestSchema(t, cfg)
+
+	res := schema.Exec(context.Background(), `{ blocks(from: 1, to: 11) { number } }`, "", nil)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "queries are limited to a 10 block range", res.Errors[0].Message)
+
+	res = schema.Exec(context.Background(), `{ batches(from: 2, to: 1) { number } }`, "", nil)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "invalid range", res.Errors[0].Message)
+}
+
+func TestGraphQLGasPriceError(t *testing.T) {
+	schema, _, p := newGraphQLTestSchema(t, getSequencerDefaultConfig())
+	p.On("GetGasPrices", mock.Anything).Return(pool.GasPrices{}, errors.New("failed to get gas prices")).Once()
+
+	res := schema.Exec(context.Background(), `{ gasPrice }`, "", nil)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "failed to get gas price from pool", res.Errors[0].Message)
+}
@@ -36,6 +36,8 @@ type handleRequest struct {
 	types.Request
 	wsConn      *concurrentWsConn
 	HttpRequest *http.Request
+
+	authenticated bool
 }
 
 // Handler manage services to handle jsonrpc requests
@@ -61,7 +63,8 @@ type handleRequest struct {
 //
 // check the `eth.go` file for more example on how the methods are implemented
 type Handler struct {
-	serviceMap map[string]*serviceData
+	serviceMap    map[string]*serviceData
+	authenticator *jwtAuthenticator
 }
 
 func newJSONRpcHandler() *Handler {
@@ -82,6 +85,10 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 		return types.NewResponse(req.Request, nil, err)
 	}
 
+	if err := h.checkAuthorization(req); err != nil {
+		return types.NewResponse(req.Request, nil, err)
+	}
+
 	inArgsOffset := 0
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
@@ -141,7 +148,7 @@ func (h *Handler) Handle(req handleRequest) types.Response {
 }
 
 // HandleWs handle websocket requests
-func (h *Handler) HandleWs(reqBody []byte, wsConn *concurrentWsConn, httpReq *http.Request) ([]byte, error) {
+func (h *Handler) HandleWs(reqBody []byte, wsConn *concurrentWsConn, httpReq *http.Request, authenticated bool) ([]byte, error) {
 	log.Debugf("WS message received: %v", string(reqBody))
 	var req types.Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
@@ -149,9 +156,10 @@ func (h *Handler) HandleWs(reqBody []byte, wsConn *concurrentWsConn, httpReq *ht
 	}
 
 	handleReq := handleRequest{
-		Request:     req,
-		wsConn:      wsConn,
-		HttpRequest: httpReq,
+		Request:       req,
+		wsConn:        wsConn,
+		HttpRequest:   httpReq,
+		authenticated: authenticated,
 	}
 
 	return h.Handle(handleReq).Bytes()
@@ -241,6 +249,20 @@ func (h *Handler) getFnHandler(req types.Request) (*serviceData, *funcData, type
 	return service, fd, nil
 }
 
+// checkAuthorization returns an error when the method belongs to a
+// protected namespace and the request was not authenticated
+func (h *Handler) checkAuthorization(req handleRequest) types.Error {
+	if h.authenticator == nil || req.authenticated {
+		return nil
+	}
+
+	serviceName, _, _ := strings.Cut(req.Method, "_")
+	if h.authenticator.isProtected(serviceName) {
+		return types.NewRPCError(types.UnauthorizedErrorCode, fmt.Sprintf("the method %s requires authentication", req.Method))
+	}
+	return nil
+}
+
 func validateFunc(funcName string, fv reflect.Value, isMethod bool) (inNum int, reqt []reflect.Type, err error) {
 	if funcName == "" {
 		err = fmt.Errorf("getBlockNumByArg cannot be empty")
@@ -14,13 +14,23 @@ const (
 	requestDurationName = requestPrefix + "duration"
 	connName            = requestPrefix + "connection"
 
+	relayPrefix       = prefix + "relay_"
+	relayHandledName  = relayPrefix + "handled"
+	relayDurationName = relayPrefix + "duration"
+
 	requestHandledTypeLabelName = "type"
+	relayHandledTypeLabelName   = "type"
+	relayMethodLabelName        = "method"
 )
 
 // RequestHandledLabel represents the possible values for the
 // `jsonrpc_request_handled` metric `type` label.
 type RequestHandledLabel string
 
+// RelayHandledLabel represents the possible values for the
+// `jsonrpc_relay_handled` metric `type` label.
+type RelayHandledLabel string
+
 // ConnLabel represents the possible values for the
 // `jsonrpc_request_connection` metric `type` label.
 type ConnLabel string
@@ -34,6 +44,15 @@ const (
 	RequestHandledLabelSingle RequestHandledLabel = "single"
 	// RequestHandledLabelBatch represents an request of type batch
 	RequestHandledLabelBatch RequestHandledLabel = "batch"
+	// RequestHandledLabelGraphQL represents an request of type graphql
+	RequestHandledLabelGraphQL RequestHandledLabel = "graphql"
+
+	// RelayHandledLabelSuccess represents a request relayed successfully to a sequencer node
+	RelayHandledLabelSuccess RelayHandledLabel = "success"
+	// RelayHandledLabelRetry represents a retry of a request to the sequencer nodes
+	RelayHandledLabelRetry RelayHandledLabel = "retry"
+	// RelayHandledLabelFailed represents a request that could not be relayed to any sequencer node
+	RelayHandledLabelFailed RelayHandledLabel = "failed"
 
 	// HTTPConnLabel represents a HTTP connection
 	HTTPConnLabel ConnLabel = "HTTP"
@@ -44,8 +63,9 @@ const (
 // Register the metrics for the jsonrpc package.
 func Register() {
 	var (
-		counterVecs []metrics.CounterVecOpts
-		histograms  []prometheus.HistogramOpts
+		counterVecs   []metrics.CounterVecOpts
+		histograms    []prometheus.HistogramOpts
+		histogramVecs []metrics.HistogramVecOpts
 	)
 
 	counterVecs = []metrics.CounterVecOpts{
@@ -56,6 +76,13 @@ func Register() {
 			},
 			Labels: []string{requestHandledTypeLabelName},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: relayHandledName,
+				Help: "[JSONRPC] number of requests relayed to the sequencer nodes",
+			},
+			Labels: []string{relayHandledTypeLabelName},
+		},
 	}
 
 	start := 0.1
@@ -69,8 +96,20 @@ func Register() {
 		},
 	}
 
+	histogramVecs = []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    relayDurationName,
+				Help:    "[JSONRPC] Histogram for the runtime of requests relayed to the sequencer nodes",
+				Buckets: prometheus.LinearBuckets(start, width, count),
+			},
+			Labels: []string{relayMethodLabelName},
+		},
+	}
+
 	metrics.RegisterCounterVecs(counterVecs...)
 	metrics.RegisterHistograms(histograms...)
+	metrics.RegisterHistogramVecs(histogramVecs...)
 }
 
 // CountConn increments the connection counter vector by one for the
@@ -90,3 +129,15 @@ func RequestHandled(label RequestHandledLabel) {
 func RequestDuration(start time.Time) {
 	metrics.HistogramObserve(requestDurationName, time.Since(start).Seconds())
 }
+
+// RelayHandled increments the relayed requests counter vector by one for the
+// given label.
+func RelayHandled(label RelayHandledLabel) {
+	metrics.CounterVecInc(relayHandledName, string(label))
+}
+
+// RelayDuration observes (histogram) the duration of a request relayed to
+// the sequencer nodes from the provided starting time.
+func RelayDuration(method string, start time.Time) {
+	metrics.HistogramVecObserve(relayDurationName, method, time.Since(start).Seconds())
+}
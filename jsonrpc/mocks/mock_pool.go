@@ -22,6 +22,42 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddPrivateTx provides a mock function with given fields: ctx, tx, ip
+func (_m *PoolMock) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error {
+	ret := _m.Called(ctx, tx, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPrivateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddToAllowList provides a mock function with given fields: ctx, kind, addresses, ip
+func (_m *PoolMock) AddToAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address, ip string) error {
+	ret := _m.Called(ctx, kind, addresses, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddToAllowList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.AllowListKind, []common.Address, string) error); ok {
+		r0 = rf(ctx, kind, addresses, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddTx provides a mock function with given fields: ctx, tx, ip
 func (_m *PoolMock) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
 	ret := _m.Called(ctx, tx, ip)
@@ -144,6 +180,96 @@ func (_m *PoolMock) EffectiveGasPriceEnabled() bool {
 	return r0
 }
 
+// GetAllowList provides a mock function with given fields: ctx, kind
+func (_m *PoolMock) GetAllowList(ctx context.Context, kind pool.AllowListKind) ([]common.Address, error) {
+	ret := _m.Called(ctx, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllowList")
+	}
+
+	var r0 []common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.AllowListKind) ([]common.Address, error)); ok {
+		return rf(ctx, kind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pool.AllowListKind) []common.Address); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pool.AllowListKind) error); ok {
+		r1 = rf(ctx, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArchivedTransactionByHash provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedTransactionByHash")
+	}
+
+	var r0 *pool.ArchivedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*pool.ArchivedTransaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *pool.ArchivedTransaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.ArchivedTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArchivedTransactionByL2Hash provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArchivedTransactionByL2Hash")
+	}
+
+	var r0 *pool.ArchivedTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*pool.ArchivedTransaction, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *pool.ArchivedTransaction); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.ArchivedTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGasPrices provides a mock function with given fields: ctx
 func (_m *PoolMock) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
 	ret := _m.Called(ctx)
@@ -260,6 +386,66 @@ func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Tra
 	return r0, r1
 }
 
+// GetPreconfirmationByHash provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) GetPreconfirmationByHash(ctx context.Context, hash common.Hash) (*pool.Preconfirmation, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreconfirmationByHash")
+	}
+
+	var r0 *pool.Preconfirmation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*pool.Preconfirmation, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *pool.Preconfirmation); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Preconfirmation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReputations provides a mock function with given fields: ctx
+func (_m *PoolMock) GetReputations(ctx context.Context) ([]pool.Reputation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReputations")
+	}
+
+	var r0 []pool.Reputation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pool.Reputation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []pool.Reputation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Reputation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
 	ret := _m.Called(ctx, hash)
@@ -320,6 +506,62 @@ func (_m *PoolMock) GetTransactionByL2Hash(ctx context.Context, hash common.Hash
 	return r0, r1
 }
 
+// RemoveFromAllowList provides a mock function with given fields: ctx, kind, addresses, ip
+func (_m *PoolMock) RemoveFromAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address, ip string) error {
+	ret := _m.Called(ctx, kind, addresses, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveFromAllowList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.AllowListKind, []common.Address, string) error); ok {
+		r0 = rf(ctx, kind, addresses, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetReputation provides a mock function with given fields: ctx, kind, key, ip
+func (_m *PoolMock) ResetReputation(ctx context.Context, kind pool.ReputationKind, key string, ip string) error {
+	ret := _m.Called(ctx, kind, key, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetReputation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.ReputationKind, string, string) error); ok {
+		r0 = rf(ctx, kind, key, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscribePreconfirmations provides a mock function with given fields: ctx
+func (_m *PoolMock) SubscribePreconfirmations(ctx context.Context) <-chan pool.Preconfirmation {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribePreconfirmations")
+	}
+
+	var r0 <-chan pool.Preconfirmation
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan pool.Preconfirmation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan pool.Preconfirmation)
+		}
+	}
+
+	return r0
+}
+
 // NewPoolMock creates a new instance of PoolMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPoolMock(t interface {
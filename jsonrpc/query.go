@@ -23,6 +23,8 @@ const (
 	FilterTypeBlock = "block"
 	// FilterTypePendingTx represent a filter of type pending Tx.
 	FilterTypePendingTx = "pendingTx"
+	// FilterTypePreconfirmation represents a filter of type tx pre-confirmation.
+	FilterTypePreconfirmation = "preconfirmation"
 )
 
 // Filter represents a filter.
@@ -337,7 +339,48 @@ func (f *LogFilter) Match(log *types.Log) bool {
 // GetNumericBlockNumbers load the numeric block numbers from state accordingly
 // to the provided from and to block number
 func (f *LogFilter) GetNumericBlockNumbers(ctx context.Context, cfg Config, s types.StateInterface, e types.EthermanInterface, dbTx pgx.Tx) (uint64, uint64, types.Error) {
-	return getNumericBlockNumbers(ctx, s, e, f.FromBlock, f.ToBlock, cfg.MaxLogsBlockRange, state.ErrMaxLogsBlockRangeLimitExceeded, dbTx)
+	fromBlock, toBlock, rpcErr := getNumericBlockNumbers(ctx, s, e, f.FromBlock, f.ToBlock, f.MaxBlockRange(cfg), state.ErrMaxLogsBlockRangeLimitExceeded, dbTx)
+	if rpcErr != nil {
+		return 0, 0, rpcErr
+	}
+
+	// the wider range of the filters by address or topics is only allowed once
+	// the logs bloom of the blocks are indexed, which the state knows
+	if cfg.MaxLogsBlockRange > 0 && toBlock-fromBlock > cfg.MaxLogsBlockRange {
+		maxBlockRange, err := s.GetLogsMaxBlockRange(ctx, fromBlock, toBlock, f.Addresses, f.Topics, dbTx)
+		if err != nil {
+			_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get the max logs block range from state", err, true)
+			return 0, 0, rpcErr
+		}
+		if maxBlockRange > 0 && toBlock-fromBlock > maxBlockRange {
+			errMsg := fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), maxBlockRange)
+			_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+			return 0, 0, rpcErr
+		}
+	}
+
+	return fromBlock, toBlock, nil
+}
+
+// MaxBlockRange returns the highest max block range allowed for the filter, the
+// filters by address or topics can use the logs bloom index and allow a wider
+// range once the blocks are indexed, see LogFilter.GetNumericBlockNumbers
+func (f *LogFilter) MaxBlockRange(cfg Config) uint64 {
+	if state.CanUseLogBloomIndex(f.Addresses, f.Topics) {
+		return cfg.MaxIndexedLogsBlockRange
+	}
+	return cfg.MaxLogsBlockRange
+}
+
+// maxLogsBlockRangeErrorMessage returns the message of the error returned by
+// the state when the block range of a logs query exceeds the max allowed
+func maxLogsBlockRangeErrorMessage(ctx context.Context, s types.StateInterface, fromBlock, toBlock uint64, f LogFilter, dbTx pgx.Tx) string {
+	maxBlockRange, err := s.GetLogsMaxBlockRange(ctx, fromBlock, toBlock, f.Addresses, f.Topics, dbTx)
+	if err != nil {
+		log.Errorf("failed to get the max logs block range from state: %v", err)
+		return state.ErrMaxLogsBlockRangeLimitExceeded.Error()
+	}
+	return fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), maxBlockRange)
 }
 
 // ShouldFilterByBlockHash if the filter should consider the block hash value
@@ -0,0 +1,290 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// latencySampleWeight is the weight of a new latency sample in the
+	// moving average of the latency of a sequencer endpoint
+	latencySampleWeight = 0.2
+
+	healthCheckMethod = "eth_blockNumber"
+)
+
+// nonIdempotentMethods are the methods that must not be sent again once the
+// request may have been delivered to a sequencer endpoint, since the tx would
+// be sent twice
+var nonIdempotentMethods = map[string]bool{
+	"eth_sendRawTransaction":          true,
+	"zkevm_sendPrivateRawTransaction": true,
+}
+
+var (
+	// ErrNoSequencerNodeAvailable is returned when all the sequencer endpoints
+	// have the circuit breaker open
+	ErrNoSequencerNodeAvailable = errors.New("no sequencer node available")
+
+	sequencerRelays      = map[string]*sequencerRelay{}
+	sequencerRelaysMutex sync.Mutex
+)
+
+// sequencerEndpoint keeps the health information of a trusted sequencer endpoint
+type sequencerEndpoint struct {
+	uri      string
+	priority int
+
+	// latency is the moving average of the latency of the successful
+	// requests, zero means it's not known yet
+	latency             time.Duration
+	consecutiveFailures uint
+	// openUntil is the time until the circuit breaker is open, while it is
+	// open no requests are sent to the endpoint
+	openUntil time.Time
+}
+
+// sequencerRelay sends the requests that must be handled by the trusted
+// sequencer to the healthiest and fastest of the configured endpoints,
+// retrying with backoff and failing over to the other endpoints on errors
+type sequencerRelay struct {
+	cfg       SequencerRelayConfig
+	endpoints []*sequencerEndpoint
+	mutex     sync.RWMutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// getSequencerRelay returns the relay for the sequencer endpoints in the
+// config, the relay is shared by all the endpoints configured with the
+// same sequencer URIs and relay config so the health information and the
+// health checks are not duplicated. Returns nil if there is no sequencer
+// configured.
+func getSequencerRelay(cfg Config) *sequencerRelay {
+	if cfg.SequencerNodeURI == "" {
+		return nil
+	}
+
+	uris := append([]string{cfg.SequencerNodeURI}, cfg.SequencerRelay.FallbackURIs...)
+	key := fmt.Sprintf("%s|%+v", strings.Join(uris, ","), cfg.SequencerRelay)
+
+	sequencerRelaysMutex.Lock()
+	defer sequencerRelaysMutex.Unlock()
+
+	if relay, found := sequencerRelays[key]; found {
+		return relay
+	}
+
+	relay := newSequencerRelay(uris, cfg.SequencerRelay)
+	if cfg.SequencerRelay.HealthCheckInterval.Duration > 0 {
+		go relay.startHealthChecks()
+	}
+	sequencerRelays[key] = relay
+	return relay
+}
+
+// stopSequencerRelays stops the health checks of all the sequencer relays,
+// the relays created after calling it start their own health checks
+func stopSequencerRelays() {
+	sequencerRelaysMutex.Lock()
+	defer sequencerRelaysMutex.Unlock()
+
+	for key, relay := range sequencerRelays {
+		relay.stop()
+		delete(sequencerRelays, key)
+	}
+}
+
+// newSequencerRelay creates a new instance of sequencerRelay, the order
+// of the uris defines their priority when the latency is the same
+func newSequencerRelay(uris []string, cfg SequencerRelayConfig) *sequencerRelay {
+	endpoints := make([]*sequencerEndpoint, 0, len(uris))
+	for i, uri := range uris {
+		endpoints = append(endpoints, &sequencerEndpoint{uri: uri, priority: i})
+	}
+
+	return &sequencerRelay{
+		cfg:       cfg,
+		endpoints: endpoints,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// stop stops the health checks of the relay
+func (r *sequencerRelay) stop() {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+}
+
+// call sends the request to the sequencer endpoints until one of them
+// responds, the json rpc errors returned by the sequencer are not
+// considered failures and are returned in the response. The non idempotent
+// methods are only sent again when the previous request wasn't delivered
+func (r *sequencerRelay) call(method string, parameters ...interface{}) (types.Response, error) {
+	start := time.Now()
+	defer metrics.RelayDuration(method, start)
+
+	var lastErr error = ErrNoSequencerNodeAvailable
+	backoff := r.cfg.RetryBackoff.Duration
+	for attempt := uint(0); attempt <= r.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			metrics.RelayHandled(metrics.RelayHandledLabelRetry)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		for _, endpoint := range r.candidates() {
+			res, err := r.callEndpoint(endpoint, method, parameters...)
+			if err != nil {
+				log.Warnf("failed to relay %s to sequencer node %s: %v", method, endpoint.uri, err)
+				if nonIdempotentMethods[method] && mayHaveBeenDelivered(err) {
+					metrics.RelayHandled(metrics.RelayHandledLabelFailed)
+					return types.Response{}, err
+				}
+				lastErr = err
+				continue
+			}
+			metrics.RelayHandled(metrics.RelayHandledLabelSuccess)
+			return res, nil
+		}
+	}
+
+	metrics.RelayHandled(metrics.RelayHandledLabelFailed)
+	return types.Response{}, lastErr
+}
+
+func (r *sequencerRelay) callEndpoint(endpoint *sequencerEndpoint, method string, parameters ...interface{}) (types.Response, error) {
+	res, latency, err := r.sendToEndpoint(endpoint, method, parameters...)
+	if err != nil {
+		r.recordFailure(endpoint)
+		return types.Response{}, err
+	}
+	r.recordSuccess(endpoint, latency)
+	return res, nil
+}
+
+func (r *sequencerRelay) sendToEndpoint(endpoint *sequencerEndpoint, method string, parameters ...interface{}) (types.Response, time.Duration, error) {
+	ctx := context.Background()
+	if r.cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	start := time.Now()
+	res, err := client.JSONRPCCallWithContext(ctx, endpoint.uri, method, parameters...)
+	return res, time.Since(start), err
+}
+
+// mayHaveBeenDelivered returns false only when the error ensures that the
+// request didn't reach the endpoint, that is when the connection failed
+func mayHaveBeenDelivered(err error) bool {
+	var opErr *net.OpError
+	return !errors.As(err, &opErr) || opErr.Op != "dial"
+}
+
+// candidates returns the endpoints that can receive requests sorted by
+// latency, the endpoints with unknown latency are sorted by priority
+// after the ones with known latency
+func (r *sequencerRelay) candidates() []*sequencerEndpoint {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	now := time.Now()
+	candidates := make([]*sequencerEndpoint, 0, len(r.endpoints))
+	for _, endpoint := range r.endpoints {
+		if now.Before(endpoint.openUntil) {
+			continue
+		}
+		candidates = append(candidates, endpoint)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.latency == 0 || b.latency == 0 {
+			if a.latency == b.latency {
+				return a.priority < b.priority
+			}
+			return b.latency == 0
+		}
+		return a.latency < b.latency
+	})
+
+	return candidates
+}
+
+func (r *sequencerRelay) recordSuccess(endpoint *sequencerEndpoint, latency time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if endpoint.consecutiveFailures >= r.cfg.CircuitBreakerFailureThreshold && r.cfg.CircuitBreakerFailureThreshold > 0 {
+		log.Infof("sequencer node %s is available again", endpoint.uri)
+	}
+	endpoint.consecutiveFailures = 0
+	endpoint.openUntil = time.Time{}
+	if endpoint.latency == 0 {
+		endpoint.latency = latency
+	} else {
+		endpoint.latency = time.Duration((1-latencySampleWeight)*float64(endpoint.latency) + latencySampleWeight*float64(latency))
+	}
+}
+
+func (r *sequencerRelay) recordFailure(endpoint *sequencerEndpoint) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	endpoint.consecutiveFailures++
+	if r.cfg.CircuitBreakerFailureThreshold > 0 && endpoint.consecutiveFailures >= r.cfg.CircuitBreakerFailureThreshold {
+		endpoint.openUntil = time.Now().Add(r.cfg.CircuitBreakerOpenDuration.Duration)
+		log.Warnf("sequencer node %s failed %d consecutive times, it will not receive requests until %v",
+			endpoint.uri, endpoint.consecutiveFailures, endpoint.openUntil)
+	}
+}
+
+// startHealthChecks checks periodically all the sequencer endpoints, including
+// the ones with the circuit breaker open, to keep their latency updated and
+// make them available again as soon as they recover
+func (r *sequencerRelay) startHealthChecks() {
+	ticker := time.NewTicker(r.cfg.HealthCheckInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			r.checkEndpoints()
+		}
+	}
+}
+
+func (r *sequencerRelay) checkEndpoints() {
+	r.mutex.RLock()
+	endpoints := make([]*sequencerEndpoint, len(r.endpoints))
+	copy(endpoints, r.endpoints)
+	r.mutex.RUnlock()
+
+	for _, endpoint := range endpoints {
+		res, latency, err := r.sendToEndpoint(endpoint, healthCheckMethod)
+		if err == nil && res.Error != nil {
+			err = errors.New(res.Error.Message)
+		}
+		if err != nil {
+			log.Debugf("health check failed for sequencer node %s: %v", endpoint.uri, err)
+			r.recordFailure(endpoint)
+			continue
+		}
+		r.recordSuccess(endpoint, latency)
+	}
+}
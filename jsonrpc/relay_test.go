@@ -0,0 +1,154 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRelayTestServer(t *testing.T, healthy *atomic.Bool, calls *atomic.Uint64) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSequencerRelayFailover(t *testing.T) {
+	var primaryHealthy, fallbackHealthy atomic.Bool
+	var primaryCalls, fallbackCalls atomic.Uint64
+	primary := newRelayTestServer(t, &primaryHealthy, &primaryCalls)
+	fallback := newRelayTestServer(t, &fallbackHealthy, &fallbackCalls)
+	fallbackHealthy.Store(true)
+
+	relay := newSequencerRelay([]string{primary.URL, fallback.URL}, SequencerRelayConfig{
+		Timeout:                        types.NewDuration(time.Second),
+		CircuitBreakerFailureThreshold: 2,
+		CircuitBreakerOpenDuration:     types.NewDuration(time.Hour),
+	})
+
+	// the primary fails and the request is relayed to the fallback
+	res, err := relay.call("eth_gasPrice")
+	require.NoError(t, err)
+	assert.Equal(t, `"0x1"`, string(res.Result))
+	assert.Equal(t, uint64(1), primaryCalls.Load())
+	assert.Equal(t, uint64(1), fallbackCalls.Load())
+
+	// the fallback is faster now, so it's selected first
+	_, err = relay.call("eth_gasPrice")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), primaryCalls.Load())
+	assert.Equal(t, uint64(2), fallbackCalls.Load())
+
+	// the fallback fails too, the primary reaches the failure threshold
+	// and stops receiving requests
+	fallbackHealthy.Store(false)
+	_, err = relay.call("eth_gasPrice")
+	require.Error(t, err)
+	assert.Equal(t, uint64(2), primaryCalls.Load())
+	_, err = relay.call("eth_gasPrice")
+	require.Error(t, err)
+	assert.Equal(t, uint64(2), primaryCalls.Load())
+
+	// both circuits are open
+	_, err = relay.call("eth_gasPrice")
+	assert.ErrorIs(t, err, ErrNoSequencerNodeAvailable)
+}
+
+func TestSequencerRelayRetries(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Uint64
+	srv := newRelayTestServer(t, &healthy, &calls)
+
+	relay := newSequencerRelay([]string{srv.URL}, SequencerRelayConfig{
+		Timeout:      types.NewDuration(time.Second),
+		MaxRetries:   2,
+		RetryBackoff: types.NewDuration(time.Millisecond),
+	})
+
+	_, err := relay.call("eth_gasPrice")
+	require.Error(t, err)
+	assert.Equal(t, uint64(3), calls.Load())
+
+	healthy.Store(true)
+	_, err = relay.call("eth_gasPrice")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), calls.Load())
+}
+
+func TestSequencerRelayNonIdempotentMethods(t *testing.T) {
+	var primaryHealthy, fallbackHealthy atomic.Bool
+	var primaryCalls, fallbackCalls atomic.Uint64
+	primary := newRelayTestServer(t, &primaryHealthy, &primaryCalls)
+	fallback := newRelayTestServer(t, &fallbackHealthy, &fallbackCalls)
+	fallbackHealthy.Store(true)
+
+	relay := newSequencerRelay([]string{primary.URL, fallback.URL}, SequencerRelayConfig{
+		Timeout:      types.NewDuration(time.Second),
+		MaxRetries:   2,
+		RetryBackoff: types.NewDuration(time.Millisecond),
+	})
+
+	// the primary received the tx, so it's not sent again
+	_, err := relay.call("eth_sendRawTransaction", "0x00")
+	require.Error(t, err)
+	assert.Equal(t, uint64(1), primaryCalls.Load())
+	assert.Equal(t, uint64(0), fallbackCalls.Load())
+
+	// the primary is down, so the tx is sent to the fallback
+	primary.Close()
+	_, err = relay.call("eth_sendRawTransaction", "0x00")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), fallbackCalls.Load())
+}
+
+func TestSequencerRelayHealthChecks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"not synced"}}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	relay := newSequencerRelay([]string{srv.URL}, SequencerRelayConfig{
+		Timeout:                        types.NewDuration(time.Second),
+		CircuitBreakerFailureThreshold: 1,
+		CircuitBreakerOpenDuration:     types.NewDuration(time.Hour),
+	})
+
+	// a json rpc error is not a healthy response
+	relay.checkEndpoints()
+	assert.Empty(t, relay.candidates())
+}
+
+func TestGetSequencerRelay(t *testing.T) {
+	t.Cleanup(stopSequencerRelays)
+
+	cfg := Config{SequencerNodeURI: "http://localhost:1", SequencerRelay: SequencerRelayConfig{HealthCheckInterval: types.NewDuration(time.Hour)}}
+	relay := getSequencerRelay(cfg)
+	assert.Same(t, relay, getSequencerRelay(cfg))
+
+	// a different relay config doesn't share the relay
+	otherCfg := cfg
+	otherCfg.SequencerRelay.MaxRetries = 3
+	assert.NotSame(t, relay, getSequencerRelay(otherCfg))
+
+	stopSequencerRelays()
+	select {
+	case <-relay.stopCh:
+	default:
+		t.Fatal("health checks not stopped")
+	}
+	assert.NotSame(t, relay, getSequencerRelay(cfg))
+}
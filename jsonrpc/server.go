@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +19,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/didip/tollbooth/v6"
 	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
 )
 
 const (
@@ -33,6 +35,8 @@ const (
 	APITxPool = "txpool"
 	// APIWeb3 represents the web3 API prefix.
 	APIWeb3 = "web3"
+	// APIAdmin represents the admin API prefix.
+	APIAdmin = "admin"
 
 	wsBufferSizeLimitInBytes = 1024
 	maxRequestContentLength  = 1024 * 1024 * 5
@@ -50,6 +54,12 @@ type Server struct {
 	srv        *http.Server
 	wsSrv      *http.Server
 	wsUpgrader websocket.Upgrader
+
+	authenticator *jwtAuthenticator
+	certReloader  *certReloader
+
+	graphQLResolver *graphQLResolver
+	graphQLSchema   *graphql.Schema
 }
 
 // Service defines a struct that will provide public methods to be exposed
@@ -89,6 +99,11 @@ func NewServer(
 		handler: handler,
 		chainID: chainID,
 	}
+
+	if cfg.GraphQL.Enabled {
+		srv.graphQLResolver = newGraphQLResolver(cfg, chainID, p, s)
+	}
+
 	return srv
 }
 
@@ -96,6 +111,38 @@ func NewServer(
 func (s *Server) Start() error {
 	metrics.Register()
 
+	if s.config.JWT.Enabled {
+		authenticator, err := newJWTAuthenticator(s.config.JWT)
+		if err != nil {
+			log.Errorf("failed to load JWT configuration: %v", err)
+			return err
+		}
+		s.authenticator = authenticator
+		s.handler.authenticator = authenticator
+	}
+
+	if s.config.TLS.Enabled {
+		certReloader, err := newCertReloader(s.config.TLS.CertFile, s.config.TLS.KeyFile)
+		if err != nil {
+			log.Errorf("failed to load TLS configuration: %v", err)
+			return err
+		}
+		s.certReloader = certReloader
+	}
+
+	if s.graphQLResolver != nil {
+		opts := []graphql.SchemaOpt{}
+		if s.config.GraphQL.MaxDepth > 0 {
+			opts = append(opts, graphql.MaxDepth(s.config.GraphQL.MaxDepth))
+		}
+		schema, err := graphql.ParseSchema(graphQLSchema, s.graphQLResolver, opts...)
+		if err != nil {
+			log.Errorf("failed to parse GraphQL schema: %v", err)
+			return err
+		}
+		s.graphQLSchema = schema
+	}
+
 	if s.config.WebSockets.Enabled {
 		go s.startWS()
 	}
@@ -116,11 +163,15 @@ func (s *Server) startHTTP() error {
 		log.Errorf("failed to create tcp listener: %v", err)
 		return err
 	}
+	lis = s.wrapListener(lis)
 
 	mux := http.NewServeMux()
 
 	lmt := tollbooth.NewLimiter(s.config.MaxRequestsPerIPAndSecond, nil)
 	mux.Handle("/", tollbooth.LimitFuncHandler(lmt, s.handle))
+	if s.graphQLSchema != nil {
+		mux.Handle("/graphql", tollbooth.LimitFuncHandler(lmt, s.handleGraphQL))
+	}
 
 	s.srv = &http.Server{
 		Handler:           mux,
@@ -156,6 +207,7 @@ func (s *Server) startWS() {
 		log.Errorf("failed to create tcp listener: %v", err)
 		return
 	}
+	lis = s.wrapListener(lis)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", s.handleWs)
@@ -181,8 +233,27 @@ func (s *Server) startWS() {
 	}
 }
 
+// wrapListener wraps the listener with TLS when it is enabled
+func (s *Server) wrapListener(lis net.Listener) net.Listener {
+	if s.certReloader == nil {
+		return lis
+	}
+	return tls.NewListener(lis, s.certReloader.tlsConfig())
+}
+
+// authenticate checks the JWT token provided by the request when the
+// JWT authentication is enabled
+func (s *Server) authenticate(req *http.Request) (bool, error) {
+	if s.authenticator == nil {
+		return false, nil
+	}
+	return s.authenticator.authenticate(req)
+}
+
 // Stop shutdown the rpc server
 func (s *Server) Stop() error {
+	stopSequencerRelays()
+
 	if s.srv != nil {
 		if err := s.srv.Shutdown(context.Background()); err != nil {
 			return err
@@ -231,6 +302,12 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	authenticated, err := s.authenticate(req)
+	if err != nil {
+		handleInvalidRequest(w, err, http.StatusUnauthorized)
+		return
+	}
+
 	body := io.LimitReader(req.Body, maxRequestContentLength)
 	data, err := io.ReadAll(body)
 	if err != nil {
@@ -249,14 +326,81 @@ func (s *Server) handle(w http.ResponseWriter, req *http.Request) {
 	start := time.Now()
 	var respLen int
 	if single {
-		respLen = s.handleSingleRequest(req, w, data)
+		respLen = s.handleSingleRequest(req, w, data, authenticated)
 	} else {
-		respLen = s.handleBatchRequest(req, w, data)
+		respLen = s.handleBatchRequest(req, w, data, authenticated)
 	}
 	metrics.RequestDuration(start)
 	s.combinedLog(req, start, http.StatusOK, respLen)
 }
 
+// graphQLRequest is the body of the requests to the GraphQL endpoint
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+
+	if req.Method == http.MethodOptions {
+		return
+	}
+
+	if code, err := validateRequest(req); err != nil {
+		handleInvalidRequest(w, err, code)
+		return
+	}
+
+	// GraphQL serves the same data as the eth namespace, so it's protected
+	// by the JWT authentication when the eth namespace is protected
+	authenticated, err := s.authenticate(req)
+	if err != nil {
+		handleInvalidRequest(w, err, http.StatusUnauthorized)
+		return
+	}
+	if s.authenticator != nil && !authenticated && s.authenticator.isProtected(APIEth) {
+		handleInvalidRequest(w, errors.New("the GraphQL endpoint requires authentication"), http.StatusUnauthorized)
+		return
+	}
+
+	body := io.LimitReader(req.Body, maxRequestContentLength)
+	var graphQLReq graphQLRequest
+	if err := json.NewDecoder(body).Decode(&graphQLReq); err != nil {
+		handleInvalidRequest(w, err, http.StatusBadRequest)
+		return
+	}
+
+	s.increaseHttpConnCounter()
+	defer metrics.RequestHandled(metrics.RequestHandledLabelGraphQL)
+
+	start := time.Now()
+	response := s.graphQLSchema.Exec(req.Context(), graphQLReq.Query, graphQLReq.OperationName, graphQLReq.Variables)
+	respBytes, err := json.Marshal(response)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	// the errors are returned with a bad request status like the EIP-1767 reference implementation
+	httpStatus := http.StatusOK
+	if len(response.Errors) > 0 {
+		httpStatus = http.StatusBadRequest
+	}
+	w.WriteHeader(httpStatus)
+	_, err = w.Write(respBytes)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	metrics.RequestDuration(start)
+	s.combinedLog(req, start, httpStatus, len(respBytes))
+}
+
 // validateRequest returns a non-zero response code and error message if the
 // request is invalid.
 func validateRequest(req *http.Request) (int, error) {
@@ -293,14 +437,14 @@ func (s *Server) isSingleRequest(data []byte) (bool, error) {
 	return x[0] != '[', nil
 }
 
-func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseWriter, data []byte) int {
+func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseWriter, data []byte, authenticated bool) int {
 	defer metrics.RequestHandled(metrics.RequestHandledLabelSingle)
 	request, err := s.parseRequest(data)
 	if err != nil {
 		handleInvalidRequest(w, err, http.StatusBadRequest)
 		return 0
 	}
-	req := handleRequest{Request: request, HttpRequest: httpRequest}
+	req := handleRequest{Request: request, HttpRequest: httpRequest, authenticated: authenticated}
 	response := s.handler.Handle(req)
 
 	respBytes, err := json.Marshal(response)
@@ -317,7 +461,7 @@ func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseW
 	return len(respBytes)
 }
 
-func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWriter, data []byte) int {
+func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWriter, data []byte, authenticated bool) int {
 	// Checking if batch requests are enabled
 	if !s.config.BatchRequestsEnabled {
 		handleInvalidRequest(w, types.ErrBatchRequestsDisabled, http.StatusBadRequest)
@@ -342,7 +486,7 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 	responses := make([]types.Response, 0, len(requests))
 
 	for _, request := range requests {
-		req := handleRequest{Request: request, HttpRequest: httpRequest}
+		req := handleRequest{Request: request, HttpRequest: httpRequest, authenticated: authenticated}
 		response := s.handler.Handle(req)
 		responses = append(responses, response)
 	}
@@ -380,6 +524,13 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 	// CORS rule - Allow requests from anywhere
 	s.wsUpgrader.CheckOrigin = func(r *http.Request) bool { return true }
 
+	// The token is checked only once when the connection is established
+	authenticated, err := s.authenticate(req)
+	if err != nil {
+		handleInvalidRequest(w, err, http.StatusUnauthorized)
+		return
+	}
+
 	// Upgrade the connection to a WS one
 	innerWsConn, err := s.wsUpgrader.Upgrade(w, req, nil)
 	if err != nil {
@@ -427,7 +578,7 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 		}
 
 		if msgType == websocket.TextMessage || msgType == websocket.BinaryMessage {
-			resp, err := s.handler.HandleWs(message, wsConn, req)
+			resp, err := s.handler.HandleWs(message, wsConn, req, authenticated)
 			if err != nil {
 				log.Error(fmt.Sprintf("Unable to handle WS request, %s", err.Error()))
 				_ = wsConn.WriteMessage(msgType, []byte(fmt.Sprintf("WS Handle error: %s", err.Error())))
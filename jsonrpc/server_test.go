@@ -3,10 +3,13 @@ package jsonrpc
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -20,6 +23,7 @@ import (
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -56,6 +60,7 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		APIZKEVM:  true,
 		APITxPool: true,
 		APIWeb3:   true,
+		APIAdmin:  true,
 	}
 
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
@@ -104,6 +109,13 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 			Service: &Web3Endpoints{},
 		})
 	}
+
+	if _, ok := apis[APIAdmin]; ok {
+		services = append(services, Service{
+			Name:    APIAdmin,
+			Service: NewAdminEndpoints(pool),
+		})
+	}
 	server := NewServer(cfg, chainID, pool, st, storage, services)
 
 	go func() {
@@ -151,10 +163,11 @@ func getSequencerDefaultConfig() Config {
 		Host:                         "0.0.0.0",
 		Port:                         9123,
 		MaxRequestsPerIPAndSecond:    maxRequestsPerIPAndSecond,
-		MaxCumulativeGasUsed:         300000,
+		BatchConfig:                  state.BatchConfig{Constraints: state.BatchConstraintsCfg{MaxCumulativeGasUsed: 300000}},
 		BatchRequestsEnabled:         true,
 		MaxLogsCount:                 10000,
 		MaxLogsBlockRange:            10000,
+		MaxIndexedLogsBlockRange:     100000,
 		MaxNativeBlockHashBlockRange: 60000,
 		WebSockets: WebSocketsConfig{
 			Enabled:   true,
@@ -628,3 +641,131 @@ func TestMaxRequestPerIPPerSec(t *testing.T) {
 	// connection abruptly
 	time.Sleep(time.Second)
 }
+
+func TestJWTAuthentication(t *testing.T) {
+	secret := common.HexToHash("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(secretFile, []byte(secret.Hex()), 0600))
+
+	cfg := getSequencerDefaultConfig()
+	cfg.JWT = JWTConfig{
+		Enabled:             true,
+		SecretFile:          secretFile,
+		ProtectedNamespaces: []string{APIWeb3},
+	}
+	s, _, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	newToken := func(key []byte, iat time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(iat)})
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	type testCase struct {
+		Name               string
+		Method             string
+		Token              string
+		ExpectedStatusCode int
+		ExpectedError      types.Error
+	}
+
+	testCases := []testCase{
+		{
+			Name:               "public method without token",
+			Method:             "eth_chainId",
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "protected method without token",
+			Method:             "web3_clientVersion",
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedError:      types.NewRPCError(types.UnauthorizedErrorCode, "the method web3_clientVersion requires authentication"),
+		},
+		{
+			Name:               "protected method with valid token",
+			Method:             "web3_clientVersion",
+			Token:              newToken(secret.Bytes(), time.Now()),
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name:               "protected method with stale token",
+			Method:             "web3_clientVersion",
+			Token:              newToken(secret.Bytes(), time.Now().Add(-2*jwtIatTolerance)),
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name:               "public method with token signed with a wrong secret",
+			Method:             "eth_chainId",
+			Token:              newToken(common.HexToHash("0x1").Bytes(), time.Now()),
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[]}`, testCase.Method)
+			req, err := http.NewRequest(http.MethodPost, s.ServerURL, bytes.NewReader([]byte(body)))
+			require.NoError(t, err)
+			req.Header.Add("Content-type", "application/json")
+			if testCase.Token != "" {
+				req.Header.Add("Authorization", "Bearer "+testCase.Token)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			require.Equal(t, testCase.ExpectedStatusCode, res.StatusCode)
+			if res.StatusCode != http.StatusOK {
+				return
+			}
+
+			resBody, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			var response types.Response
+			require.NoError(t, json.Unmarshal(resBody, &response))
+			if testCase.ExpectedError == nil {
+				assert.Nil(t, response.Error)
+				return
+			}
+			require.NotNil(t, response.Error)
+			assert.Equal(t, testCase.ExpectedError.ErrorCode(), response.Error.Code)
+			assert.Equal(t, testCase.ExpectedError.Error(), response.Error.Message)
+		})
+	}
+}
+
+func TestGraphQLJWTAuthentication(t *testing.T) {
+	secret := common.HexToHash("0x0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(secretFile, []byte(secret.Hex()), 0600))
+
+	cfg := getSequencerDefaultConfig()
+	cfg.GraphQL.Enabled = true
+	cfg.JWT = JWTConfig{
+		Enabled:             true,
+		SecretFile:          secretFile,
+		ProtectedNamespaces: []string{APIEth},
+	}
+	s, _, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Now())})
+	signed, err := token.SignedString(secret.Bytes())
+	require.NoError(t, err)
+
+	for token, expectedStatusCode := range map[string]int{"": http.StatusUnauthorized, "invalid": http.StatusUnauthorized, signed: http.StatusOK} {
+		req, err := http.NewRequest(http.MethodPost, s.ServerURL+"/graphql", bytes.NewReader([]byte(`{"query":"{ chainID }"}`)))
+		require.NoError(t, err)
+		req.Header.Add("Content-type", "application/json")
+		if token != "" {
+			req.Header.Add("Authorization", "Bearer "+token)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, expectedStatusCode, res.StatusCode)
+	}
+}
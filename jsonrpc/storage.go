@@ -20,28 +20,32 @@ var ErrFilterInvalidPayload = errors.New("invalid argument 0: cannot specify bot
 // Storage uses memory to store the data
 // related to the json rpc server
 type Storage struct {
-	allFilters                 map[string]*Filter
-	allFiltersWithWSConn       map[*concurrentWsConn]map[string]*Filter
-	blockFiltersWithWSConn     map[string]*Filter
-	logFiltersWithWSConn       map[string]*Filter
-	pendingTxFiltersWithWSConn map[string]*Filter
-
-	blockMutex     *sync.Mutex
-	logMutex       *sync.Mutex
-	pendingTxMutex *sync.Mutex
+	allFilters                       map[string]*Filter
+	allFiltersWithWSConn             map[*concurrentWsConn]map[string]*Filter
+	blockFiltersWithWSConn           map[string]*Filter
+	logFiltersWithWSConn             map[string]*Filter
+	pendingTxFiltersWithWSConn       map[string]*Filter
+	preconfirmationFiltersWithWSConn map[string]*Filter
+
+	blockMutex           *sync.Mutex
+	logMutex             *sync.Mutex
+	pendingTxMutex       *sync.Mutex
+	preconfirmationMutex *sync.Mutex
 }
 
 // NewStorage creates and initializes an instance of Storage
 func NewStorage() *Storage {
 	return &Storage{
-		allFilters:                 make(map[string]*Filter),
-		allFiltersWithWSConn:       make(map[*concurrentWsConn]map[string]*Filter),
-		blockFiltersWithWSConn:     make(map[string]*Filter),
-		logFiltersWithWSConn:       make(map[string]*Filter),
-		pendingTxFiltersWithWSConn: make(map[string]*Filter),
-		blockMutex:                 &sync.Mutex{},
-		logMutex:                   &sync.Mutex{},
-		pendingTxMutex:             &sync.Mutex{},
+		allFilters:                       make(map[string]*Filter),
+		allFiltersWithWSConn:             make(map[*concurrentWsConn]map[string]*Filter),
+		blockFiltersWithWSConn:           make(map[string]*Filter),
+		logFiltersWithWSConn:             make(map[string]*Filter),
+		pendingTxFiltersWithWSConn:       make(map[string]*Filter),
+		preconfirmationFiltersWithWSConn: make(map[string]*Filter),
+		blockMutex:                       &sync.Mutex{},
+		logMutex:                         &sync.Mutex{},
+		pendingTxMutex:                   &sync.Mutex{},
+		preconfirmationMutex:             &sync.Mutex{},
 	}
 }
 
@@ -64,6 +68,11 @@ func (s *Storage) NewPendingTransactionFilter(wsConn *concurrentWsConn) (string,
 	return s.createFilter(FilterTypePendingTx, nil, wsConn)
 }
 
+// NewPreconfirmationFilter persists a new tx pre-confirmation filter
+func (s *Storage) NewPreconfirmationFilter(wsConn *concurrentWsConn) (string, error) {
+	return s.createFilter(FilterTypePreconfirmation, nil, wsConn)
+}
+
 // create persists the filter to the memory and provides the filter id
 func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *concurrentWsConn) (string, error) {
 	lastPoll := time.Now().UTC()
@@ -75,9 +84,11 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.preconfirmationMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.preconfirmationMutex.Unlock()
 
 	f := &Filter{
 		ID:            id,
@@ -104,6 +115,8 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else if t == FilterTypePreconfirmation {
+			s.preconfirmationFiltersWithWSConn[id] = f
 		}
 	}
 	return id, nil
@@ -152,14 +165,30 @@ func (s *Storage) GetAllLogFiltersWithWSConn() []*Filter {
 	return filters
 }
 
+// GetAllPreconfirmationFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are filtering by tx pre-confirmations
+func (s *Storage) GetAllPreconfirmationFiltersWithWSConn() []*Filter {
+	s.preconfirmationMutex.Lock()
+	defer s.preconfirmationMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.preconfirmationFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
+
 // GetFilter gets a filter by its id
 func (s *Storage) GetFilter(filterID string) (*Filter, error) {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.preconfirmationMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.preconfirmationMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -174,9 +203,11 @@ func (s *Storage) UpdateFilterLastPoll(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.preconfirmationMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.preconfirmationMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -192,9 +223,11 @@ func (s *Storage) UninstallFilter(filterID string) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.preconfirmationMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.preconfirmationMutex.Unlock()
 
 	filter, found := s.allFilters[filterID]
 	if !found {
@@ -210,9 +243,11 @@ func (s *Storage) UninstallFilterByWSConn(wsConn *concurrentWsConn) error {
 	s.blockMutex.Lock()
 	s.logMutex.Lock()
 	s.pendingTxMutex.Lock()
+	s.preconfirmationMutex.Lock()
 	defer s.blockMutex.Unlock()
 	defer s.logMutex.Unlock()
 	defer s.pendingTxMutex.Unlock()
+	defer s.preconfirmationMutex.Unlock()
 
 	filters, found := s.allFiltersWithWSConn[wsConn]
 	if !found {
@@ -234,6 +269,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else if filter.Type == FilterTypePreconfirmation {
+		delete(s.preconfirmationFiltersWithWSConn, filter.ID)
 	}
 
 	if filter.WsConn != nil {
@@ -0,0 +1,105 @@
+package jsonrpc
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+// certReloader keeps the TLS certificate loaded in memory and reloads
+// it from the disk when the certificate or key files are modified
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+	mutex       sync.RWMutex
+}
+
+// newCertReloader creates a new instance of certReloader loading the
+// certificate from the provided files
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// tlsConfig returns a TLS config that always serves the latest certificate
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+	}
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	changed, err := r.filesChanged()
+	if err != nil {
+		log.Warnf("failed to check TLS certificate files, using the loaded certificate: %v", err)
+	} else if changed {
+		if err := r.reload(); err != nil {
+			log.Errorf("failed to reload TLS certificate, using the loaded certificate: %v", err)
+		} else {
+			log.Infof("TLS certificate reloaded")
+		}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.cert, nil
+}
+
+func (r *certReloader) filesChanged() (bool, error) {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return false, err
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return !certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime), nil
+}
+
+func (r *certReloader) reload() error {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.cert = &cert
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	return nil
+}
+
+func (r *certReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -15,6 +15,18 @@ const (
 	InvalidParamsErrorCode = -32602
 	// ParserErrorCode error code for parsing errors
 	ParserErrorCode = -32700
+	// UnauthorizedErrorCode error code for requests to protected methods without a valid token
+	UnauthorizedErrorCode = -32001
+	// SenderNotAllowedErrorCode error code for txs sent by accounts out of the pool senders allow-list
+	SenderNotAllowedErrorCode = -32002
+	// DeployerNotAllowedErrorCode error code for contract deployments sent by accounts out of the pool deployers allow-list
+	DeployerNotAllowedErrorCode = -32003
+	// ReputationBannedErrorCode error code for txs whose sender or IP are banned because of their failed txs
+	ReputationBannedErrorCode = -32004
+	// ReputationThrottledErrorCode error code for txs whose sender or IP are throttled because of their failed txs
+	ReputationThrottledErrorCode = -32005
+	// SponsoredClaimRateLimitedErrorCode error code for sponsored bridge claims whose recipient reached the rate limit
+	SponsoredClaimRateLimitedErrorCode = -32006
 )
 
 var (
@@ -16,6 +16,7 @@ import (
 // PoolInterface contains the methods required to interact with the tx pool.
 type PoolInterface interface {
 	AddTx(ctx context.Context, tx types.Transaction, ip string) error
+	AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error
 	GetGasPrices(ctx context.Context) (pool.GasPrices, error)
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
@@ -26,6 +27,15 @@ type PoolInterface interface {
 	CalculateEffectiveGasPrice(rawTx []byte, txGasPrice *big.Int, txGasUsed uint64, l1GasPrice uint64, l2GasPrice uint64) (*big.Int, error)
 	CalculateEffectiveGasPricePercentage(gasPrice *big.Int, effectiveGasPrice *big.Int) (uint8, error)
 	EffectiveGasPriceEnabled() bool
+	GetAllowList(ctx context.Context, kind pool.AllowListKind) ([]common.Address, error)
+	AddToAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address, ip string) error
+	RemoveFromAllowList(ctx context.Context, kind pool.AllowListKind, addresses []common.Address, ip string) error
+	GetReputations(ctx context.Context) ([]pool.Reputation, error)
+	ResetReputation(ctx context.Context, kind pool.ReputationKind, key string, ip string) error
+	GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error)
+	GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error)
+	GetPreconfirmationByHash(ctx context.Context, hash common.Hash) (*pool.Preconfirmation, error)
+	SubscribePreconfirmations(ctx context.Context) <-chan pool.Preconfirmation
 }
 
 // StateInterface gathers the methods required to interact with the state.
@@ -48,6 +58,7 @@ type StateInterface interface {
 	GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error)
 	GetLastL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*types.Log, error)
+	GetLogsMaxBlockRange(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) (uint64, error)
 	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
 	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
 	GetSyncingInfo(ctx context.Context, dbTx pgx.Tx) (state.SyncingInfo, error)
@@ -63,11 +74,13 @@ type StateInterface interface {
 	GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	GetLastVerifiedBatch(ctx context.Context, dbTx pgx.Tx) (*state.VerifiedBatch, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetForkIDByBatchNumber(batchNumber uint64) uint64
 	GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error)
 	GetTransactionsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (txs []types.Transaction, effectivePercentages []uint8, err error)
 	GetVirtualBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VirtualBatch, error)
 	GetVerifiedBatch(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.VerifiedBatch, error)
 	GetExitRootByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (*state.GlobalExitRoot, error)
+	GetBatchClosingInfo(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.BatchClosingInfo, error)
 	GetL2BlocksByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.L2Block, error)
 	GetNativeBlockHashesInRange(ctx context.Context, fromBlockNumber uint64, toBlockNumber uint64, dbTx pgx.Tx) ([]common.Hash, error)
 	GetLastClosedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
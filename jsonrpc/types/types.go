@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -720,6 +722,175 @@ type ZKCounters struct {
 	UsedSHA256Hashes     ArgUint64 `json:"usedSHA256Hashes"`
 }
 
+// NewZKCounters creates an instance of ZKCounters from the state counters
+func NewZKCounters(zkCounters state.ZKCounters) ZKCounters {
+	return ZKCounters{
+		GasUsed:              ArgUint64(zkCounters.GasUsed),
+		UsedKeccakHashes:     ArgUint64(zkCounters.KeccakHashes),
+		UsedPoseidonHashes:   ArgUint64(zkCounters.PoseidonHashes),
+		UsedPoseidonPaddings: ArgUint64(zkCounters.PoseidonPaddings),
+		UsedMemAligns:        ArgUint64(zkCounters.MemAligns),
+		UsedArithmetics:      ArgUint64(zkCounters.Arithmetics),
+		UsedBinaries:         ArgUint64(zkCounters.Binaries),
+		UsedSteps:            ArgUint64(zkCounters.Steps),
+		UsedSHA256Hashes:     ArgUint64(zkCounters.Sha256Hashes_V2),
+	}
+}
+
+// BatchClosingInfo is the report of the sequencer about the closing of a batch
+type BatchClosingInfo struct {
+	BatchNumber            ArgUint64            `json:"batchNumber"`
+	ClosingReason          string               `json:"closingReason"`
+	ExhaustedResource      string               `json:"exhaustedResource"`
+	UsedZKCounters         ZKCounters           `json:"usedZkCounters"`
+	UsedBytes              ArgUint64            `json:"usedBytes"`
+	HighReservedZKCounters ZKCounters           `json:"highReservedZkCounters"`
+	Txs                    ArgUint64            `json:"txs"`
+	SkippedTxs             ArgUint64            `json:"skippedTxs"`
+	NodeOOCTxs             ArgUint64            `json:"nodeOOCTxs"`
+	OpenedAt               time.Time            `json:"openedAt"`
+	ClosedAt               time.Time            `json:"closedAt"`
+	L2Blocks               []L2BlockClosingInfo `json:"l2Blocks"`
+}
+
+// L2BlockClosingInfo is the report of the sequencer about the closing of a L2 block
+type L2BlockClosingInfo struct {
+	BlockNumber    ArgUint64           `json:"blockNumber"`
+	ClosingReason  string              `json:"closingReason"`
+	Txs            ArgUint64           `json:"txs"`
+	ProcessedTxs   ArgUint64           `json:"processedTxs"`
+	UsedZKCounters ZKCounters          `json:"usedZkCounters"`
+	UsedBytes      ArgUint64           `json:"usedBytes"`
+	Times          L2BlockProcessTimes `json:"times"`
+}
+
+// L2BlockProcessTimes is the time in microseconds spent by the sequencer and the executor in a L2 block
+type L2BlockProcessTimes struct {
+	Total               ArgUint64 `json:"total"`
+	Idle                ArgUint64 `json:"idle"`
+	WaitL2Block         ArgUint64 `json:"waitL2Block"`
+	SequencerNewL2Block ArgUint64 `json:"sequencerNewL2Block"`
+	SequencerTxs        ArgUint64 `json:"sequencerTxs"`
+	SequencerL2Block    ArgUint64 `json:"sequencerL2Block"`
+	ExecutorNewL2Block  ArgUint64 `json:"executorNewL2Block"`
+	ExecutorTxs         ArgUint64 `json:"executorTxs"`
+	ExecutorL2Block     ArgUint64 `json:"executorL2Block"`
+}
+
+// NewBatchClosingInfo creates an instance of BatchClosingInfo to be returned
+// by the RPC to the caller
+func NewBatchClosingInfo(closingInfo state.BatchClosingInfo) BatchClosingInfo {
+	microseconds := func(d time.Duration) ArgUint64 {
+		return ArgUint64(d.Microseconds())
+	}
+
+	res := BatchClosingInfo{
+		BatchNumber:            ArgUint64(closingInfo.BatchNumber),
+		ClosingReason:          string(closingInfo.ClosingReason),
+		ExhaustedResource:      closingInfo.ExhaustedResource,
+		UsedZKCounters:         NewZKCounters(closingInfo.UsedResources.ZKCounters),
+		UsedBytes:              ArgUint64(closingInfo.UsedResources.Bytes),
+		HighReservedZKCounters: NewZKCounters(closingInfo.HighReservedZKCounters),
+		Txs:                    ArgUint64(closingInfo.Txs),
+		SkippedTxs:             ArgUint64(closingInfo.SkippedTxs),
+		NodeOOCTxs:             ArgUint64(closingInfo.NodeOOCTxs),
+		OpenedAt:               closingInfo.OpenedAt,
+		ClosedAt:               closingInfo.ClosedAt,
+		L2Blocks:               make([]L2BlockClosingInfo, 0, len(closingInfo.L2BlocksClosingInfo)),
+	}
+
+	for _, l2Block := range closingInfo.L2BlocksClosingInfo {
+		res.L2Blocks = append(res.L2Blocks, L2BlockClosingInfo{
+			BlockNumber:    ArgUint64(l2Block.L2BlockNumber),
+			ClosingReason:  l2Block.ClosingReason,
+			Txs:            ArgUint64(l2Block.Txs),
+			ProcessedTxs:   ArgUint64(l2Block.ProcessedTxs),
+			UsedZKCounters: NewZKCounters(l2Block.UsedResources.ZKCounters),
+			UsedBytes:      ArgUint64(l2Block.UsedResources.Bytes),
+			Times: L2BlockProcessTimes{
+				Total:               microseconds(l2Block.Times.Total),
+				Idle:                microseconds(l2Block.Times.Idle),
+				WaitL2Block:         microseconds(l2Block.Times.WaitL2Block),
+				SequencerNewL2Block: microseconds(l2Block.Times.SequencerNewL2Block),
+				SequencerTxs:        microseconds(l2Block.Times.SequencerTxs),
+				SequencerL2Block:    microseconds(l2Block.Times.SequencerL2Block),
+				ExecutorNewL2Block:  microseconds(l2Block.Times.ExecutorNewL2Block),
+				ExecutorTxs:         microseconds(l2Block.Times.ExecutorTxs),
+				ExecutorL2Block:     microseconds(l2Block.Times.ExecutorL2Block),
+			},
+		})
+	}
+
+	return res
+}
+
+// Preconfirmation is the commitment signed by the sequencer to include a tx in a L2 block
+type Preconfirmation struct {
+	TxHash      common.Hash `json:"transactionHash"`
+	BlockNumber ArgUint64   `json:"blockNumber"`
+	TxIndex     ArgUint64   `json:"transactionIndex"`
+	Status      ArgUint64   `json:"status"`
+	Signature   ArgBytes    `json:"signature"`
+	Rescinded   bool        `json:"rescinded"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+// NewPreconfirmation creates an instance of Preconfirmation to be returned
+// by the RPC to the caller
+func NewPreconfirmation(preconfirmation pool.Preconfirmation) Preconfirmation {
+	return Preconfirmation{
+		TxHash:      preconfirmation.TxHash,
+		BlockNumber: ArgUint64(preconfirmation.L2BlockNumber),
+		TxIndex:     ArgUint64(preconfirmation.Index),
+		Status:      ArgUint64(preconfirmation.Status),
+		Signature:   ArgBytes(preconfirmation.Signature),
+		Rescinded:   preconfirmation.Rescinded,
+		CreatedAt:   preconfirmation.CreatedAt,
+		UpdatedAt:   preconfirmation.UpdatedAt,
+	}
+}
+
+// ArchivedTransaction is a processed pool tx moved to the archive
+type ArchivedTransaction struct {
+	Transaction
+	Status             string     `json:"status"`
+	FailedReason       *string    `json:"failedReason"`
+	BreakEvenGasPrice  *ArgBig    `json:"breakEvenGasPrice"`
+	ZKCounters         ZKCounters `json:"zkCounters"`
+	ReservedZKCounters ZKCounters `json:"reservedZkCounters"`
+	IP                 string     `json:"ip,omitempty"`
+	ReceivedAt         time.Time  `json:"receivedAt"`
+	ArchivedAt         time.Time  `json:"archivedAt"`
+}
+
+// NewArchivedTransaction creates an instance of ArchivedTransaction to be returned
+// by the RPC to the caller
+func NewArchivedTransaction(archivedTx pool.ArchivedTransaction) (*ArchivedTransaction, error) {
+	tx, err := NewTransaction(archivedTx.Transaction.Transaction, nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	tx.From = archivedTx.From
+
+	res := &ArchivedTransaction{
+		Transaction:        *tx,
+		Status:             archivedTx.Status.String(),
+		FailedReason:       archivedTx.FailedReason,
+		ZKCounters:         NewZKCounters(archivedTx.ZKCounters),
+		ReservedZKCounters: NewZKCounters(archivedTx.ReservedZKCounters),
+		IP:                 archivedTx.IP,
+		ReceivedAt:         archivedTx.ReceivedAt,
+		ArchivedAt:         archivedTx.ArchivedAt,
+	}
+	if archivedTx.BreakEvenGasPrice != nil {
+		breakEvenGasPrice := ArgBig(*archivedTx.BreakEvenGasPrice)
+		res.BreakEvenGasPrice = &breakEvenGasPrice
+	}
+
+	return res, nil
+}
+
 // ZKCountersLimits used to return the zk counter limits to the user
 type ZKCountersLimits struct {
 	MaxGasUsed          ArgUint64 `json:"maxGasUsed"`
@@ -757,17 +928,7 @@ func NewZKCountersResponse(zkCounters state.ZKCounters, limits ZKCountersLimits,
 		oocErrMsg = &s
 	}
 	return ZKCountersResponse{
-		CountersUsed: ZKCounters{
-			GasUsed:              ArgUint64(zkCounters.GasUsed),
-			UsedKeccakHashes:     ArgUint64(zkCounters.KeccakHashes),
-			UsedPoseidonHashes:   ArgUint64(zkCounters.PoseidonHashes),
-			UsedPoseidonPaddings: ArgUint64(zkCounters.PoseidonPaddings),
-			UsedMemAligns:        ArgUint64(zkCounters.MemAligns),
-			UsedArithmetics:      ArgUint64(zkCounters.Arithmetics),
-			UsedBinaries:         ArgUint64(zkCounters.Binaries),
-			UsedSteps:            ArgUint64(zkCounters.Steps),
-			UsedSHA256Hashes:     ArgUint64(zkCounters.Sha256Hashes_V2),
-		},
+		CountersUsed:   NewZKCounters(zkCounters),
 		CountersLimits: limits,
 		Revert:         revert,
 		OOCError:       oocErrMsg,
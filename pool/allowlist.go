@@ -0,0 +1,182 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AllowListKind identifies one of the allow-lists of the pool
+type AllowListKind string
+
+const (
+	// AllowListSenders is the allow-list of the accounts allowed to send transactions
+	AllowListSenders AllowListKind = "senders"
+	// AllowListDeployers is the allow-list of the accounts allowed to deploy contracts
+	AllowListDeployers AllowListKind = "deployers"
+)
+
+// ParseAllowListKind converts a string like "senders" or "deployers" to an AllowListKind
+func ParseAllowListKind(s string) (AllowListKind, error) {
+	kind := AllowListKind(strings.ToLower(strings.TrimSpace(s)))
+	switch kind {
+	case AllowListSenders, AllowListDeployers:
+		return kind, nil
+	default:
+		return "", ErrInvalidAllowListKind
+	}
+}
+
+// StartRefreshingAllowListsPeriodically will make this instance of the pool
+// to check periodically(accordingly to the configuration) for updates regarding
+// the allow-lists and update the in memory allow-lists. It does nothing
+// if none of the allow-lists is enabled
+func (p *Pool) StartRefreshingAllowListsPeriodically() {
+	if !p.cfg.AllowList.SendersEnabled && !p.cfg.AllowList.DeployersEnabled {
+		return
+	}
+
+	p.refreshAllowLists()
+	go func(p *Pool) {
+		for {
+			time.Sleep(p.cfg.AllowList.IntervalToRefresh.Duration)
+			p.refreshAllowLists()
+		}
+	}(p)
+}
+
+// refreshAllowLists refreshes the enabled allow-lists for the provided instance of pool
+func (p *Pool) refreshAllowLists() {
+	if p.cfg.AllowList.SendersEnabled {
+		p.refreshAllowList(AllowListSenders)
+	}
+	if p.cfg.AllowList.DeployersEnabled {
+		p.refreshAllowList(AllowListDeployers)
+	}
+}
+
+// refreshAllowList refreshes the in memory addresses of the provided allow-list
+func (p *Pool) refreshAllowList(kind AllowListKind) {
+	addresses, err := p.storage.GetAllowListAddresses(context.Background(), kind)
+	if err != nil {
+		log.Errorf("failed to load %s allow-list: %v", kind, err)
+		return
+	}
+
+	allowList := p.allowList(kind)
+
+	allowedAddressesMap := map[string]struct{}{}
+	for _, address := range addresses {
+		allowedAddressesMap[address.String()] = struct{}{}
+		allowList.Store(address.String(), 1)
+	}
+
+	removedAddresses := []string{}
+	allowList.Range(func(key, value any) bool {
+		addrHex := key.(string)
+		if _, found := allowedAddressesMap[addrHex]; !found {
+			removedAddresses = append(removedAddresses, addrHex)
+		}
+		return true
+	})
+
+	for _, removedAddress := range removedAddresses {
+		allowList.Delete(removedAddress)
+	}
+}
+
+// allowList returns the in memory addresses of the provided allow-list
+func (p *Pool) allowList(kind AllowListKind) *sync.Map {
+	if kind == AllowListDeployers {
+		return &p.allowedDeployers
+	}
+	return &p.allowedSenders
+}
+
+// checkAllowLists checks the tx sender against the enabled allow-lists
+func (p *Pool) checkAllowLists(ctx context.Context, poolTx Transaction, from common.Address) error {
+	if p.cfg.AllowList.SendersEnabled {
+		if _, allowed := p.allowedSenders.Load(from.String()); !allowed {
+			log.Infof("%v: %v", ErrSenderNotAllowed.Error(), from.String())
+			p.logAllowListEvent(ctx, poolTx.IP, event.Level_Warning, event.EventID_SenderNotAllowed,
+				fmt.Sprintf("tx %s rejected, sender %s", poolTx.Hash().String(), from.String()))
+			return ErrSenderNotAllowed
+		}
+	}
+
+	if p.cfg.AllowList.DeployersEnabled && poolTx.To() == nil {
+		if _, allowed := p.allowedDeployers.Load(from.String()); !allowed {
+			log.Infof("%v: %v", ErrDeployerNotAllowed.Error(), from.String())
+			p.logAllowListEvent(ctx, poolTx.IP, event.Level_Warning, event.EventID_DeployerNotAllowed,
+				fmt.Sprintf("tx %s rejected, deployer %s", poolTx.Hash().String(), from.String()))
+			return ErrDeployerNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// GetAllowList returns the addresses stored in the provided allow-list
+func (p *Pool) GetAllowList(ctx context.Context, kind AllowListKind) ([]common.Address, error) {
+	return p.storage.GetAllowListAddresses(ctx, kind)
+}
+
+// AddToAllowList adds the addresses to the provided allow-list and refreshes
+// the in memory list of this instance, other instances of the pool sharing the
+// same storage are updated in the next refresh
+func (p *Pool) AddToAllowList(ctx context.Context, kind AllowListKind, addresses []common.Address, ip string) error {
+	if err := p.storage.AddAllowListAddresses(ctx, kind, addresses); err != nil {
+		return err
+	}
+	p.refreshAllowList(kind)
+	p.logAllowListEvent(ctx, ip, event.Level_Notice, event.EventID_AllowListUpdated,
+		fmt.Sprintf("added to %s allow-list: %s", kind, addressesToString(addresses)))
+	return nil
+}
+
+// RemoveFromAllowList removes the addresses from the provided allow-list and refreshes
+// the in memory list of this instance, other instances of the pool sharing the
+// same storage are updated in the next refresh
+func (p *Pool) RemoveFromAllowList(ctx context.Context, kind AllowListKind, addresses []common.Address, ip string) error {
+	if err := p.storage.DeleteAllowListAddresses(ctx, kind, addresses); err != nil {
+		return err
+	}
+	p.refreshAllowList(kind)
+	p.logAllowListEvent(ctx, ip, event.Level_Notice, event.EventID_AllowListUpdated,
+		fmt.Sprintf("removed from %s allow-list: %s", kind, addressesToString(addresses)))
+	return nil
+}
+
+func (p *Pool) logAllowListEvent(ctx context.Context, ip string, level event.Level, eventID event.EventID, description string) {
+	if p.eventLog == nil {
+		return
+	}
+
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		IPAddress:   ip,
+		Source:      event.Source_Node,
+		Component:   event.Component_Pool,
+		Level:       level,
+		EventID:     eventID,
+		Description: description,
+	}
+
+	if err := p.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error adding event: %v", err)
+	}
+}
+
+func addressesToString(addresses []common.Address) string {
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, addr.String())
+	}
+	return strings.Join(addrs, ",")
+}
@@ -0,0 +1,90 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ArchivedTransaction is a processed tx moved from the pool to the archive
+type ArchivedTransaction struct {
+	Transaction
+	From common.Address
+	// BreakEvenGasPrice is the break even gas price stored for the tx, nil if unknown
+	BreakEvenGasPrice *big.Int
+	ArchivedAt        time.Time
+}
+
+// DeleteTransactionsByHashes deletes the txs by their hashes, moving them to
+// the archive instead when it is enabled
+func (p *Pool) DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error {
+	if !p.cfg.Archive.Enabled {
+		return p.storage.DeleteTransactionsByHashes(ctx, hashes)
+	}
+	return p.storage.ArchiveTransactionsByHashes(ctx, hashes, time.Now())
+}
+
+// DeleteFailedTransactionsOlderThan deletes the failed txs received before the
+// given date, moving them to the archive instead when it is enabled
+func (p *Pool) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
+	if !p.cfg.Archive.Enabled {
+		return p.storage.DeleteFailedTransactionsOlderThan(ctx, date)
+	}
+	return p.storage.ArchiveTransactionsOlderThan(ctx, TxStatusFailed, date, time.Now())
+}
+
+// GetArchivedTransactionByHash returns a tx moved to the archive by its hash
+func (p *Pool) GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*ArchivedTransaction, error) {
+	return p.storage.GetArchivedTransactionByHash(ctx, hash)
+}
+
+// GetArchivedTransactionByL2Hash returns a tx moved to the archive by its l2 hash
+func (p *Pool) GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*ArchivedTransaction, error) {
+	return p.storage.GetArchivedTransactionByL2Hash(ctx, hash)
+}
+
+// StartArchivingPeriodically will make this instance of the pool to move
+// periodically(accordingly to the configuration) the old invalid txs to
+// the archive and to delete the archived txs past their retention. It does
+// nothing if the archive is not enabled. It must be started by a single
+// instance of the pool sharing the same storage
+func (p *Pool) StartArchivingPeriodically() {
+	if !p.cfg.Archive.Enabled {
+		return
+	}
+
+	go func(p *Pool) {
+		for {
+			p.archive(context.Background())
+			time.Sleep(p.cfg.Archive.Interval.Duration)
+		}
+	}(p)
+}
+
+// archive moves the old invalid txs to the archive and deletes the archived
+// txs past the retention of their status
+func (p *Pool) archive(ctx context.Context) {
+	now := time.Now()
+	if p.cfg.Archive.ArchiveInvalidAfter.Duration > 0 {
+		if err := p.storage.ArchiveTransactionsOlderThan(ctx, TxStatusInvalid, now.Add(-p.cfg.Archive.ArchiveInvalidAfter.Duration), now); err != nil {
+			log.Errorf("failed to archive invalid txs: %v", err)
+		}
+	}
+
+	retentions := map[TxStatus]time.Duration{
+		TxStatusSelected: p.cfg.Archive.SelectedRetention.Duration,
+		TxStatusFailed:   p.cfg.Archive.FailedRetention.Duration,
+		TxStatusInvalid:  p.cfg.Archive.InvalidRetention.Duration,
+	}
+	for status, retention := range retentions {
+		if retention == 0 {
+			continue
+		}
+		if err := p.storage.DeleteArchivedTransactionsOlderThan(ctx, status, now.Add(-retention)); err != nil {
+			log.Errorf("failed to delete archived %s txs: %v", status, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Archive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	archiveCfg := cfg
+	archiveCfg.Archive = pool.ArchiveCfg{
+		Enabled:             true,
+		Interval:            cfgTypes.NewDuration(time.Hour),
+		ArchiveInvalidAfter: cfgTypes.NewDuration(time.Minute),
+		FailedRetention:     cfgTypes.NewDuration(time.Hour),
+	}
+
+	s := memorypoolstorage.NewMemoryPoolStorage()
+	p := pool.NewPool(archiveCfg, bc, s, nil, chainID.Uint64(), nil)
+	sender := newStorageTestSender(t)
+
+	selected := sender.poolTx(t, 0, 10, pool.TxStatusSelected, now)
+	failed := sender.poolTx(t, 1, 10, pool.TxStatusFailed, now.Add(-time.Hour))
+	invalid := sender.poolTx(t, 2, 10, pool.TxStatusInvalid, now.Add(-time.Hour))
+	for _, tx := range []pool.Transaction{selected, failed, invalid} {
+		require.NoError(t, s.AddTx(ctx, tx))
+	}
+
+	// the txs deleted by the sequencer are moved to the archive
+	require.NoError(t, p.DeleteTransactionsByHashes(ctx, []common.Hash{selected.Hash()}))
+	require.NoError(t, p.DeleteFailedTransactionsOlderThan(ctx, now.Add(-time.Minute)))
+	for _, tx := range []pool.Transaction{selected, failed} {
+		_, err := p.GetTransactionByHash(ctx, tx.Hash())
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		archived, err := p.GetArchivedTransactionByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, tx.Status, archived.Status)
+	}
+
+	// the archiver moves the old invalid txs to the archive
+	p.StartArchivingPeriodically()
+	require.Eventually(t, func() bool {
+		_, err := p.GetArchivedTransactionByHash(ctx, invalid.Hash())
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err := p.GetTransactionByHash(ctx, invalid.Hash())
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+
+	// the archived txs are deleted after the retention of their status
+	require.NoError(t, s.DeleteArchivedTransactionsOlderThan(ctx, pool.TxStatusFailed, now.Add(time.Minute)))
+	_, err = p.GetArchivedTransactionByHash(ctx, failed.Hash())
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+	_, err = p.GetArchivedTransactionByHash(ctx, selected.Hash())
+	require.NoError(t, err)
+}
+
+func Test_ArchiveDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	s := memorypoolstorage.NewMemoryPoolStorage()
+	p := pool.NewPool(cfg, bc, s, nil, chainID.Uint64(), nil)
+	sender := newStorageTestSender(t)
+
+	tx := sender.poolTx(t, 0, 10, pool.TxStatusSelected, time.Now())
+	require.NoError(t, s.AddTx(ctx, tx))
+	require.NoError(t, p.DeleteTransactionsByHashes(ctx, []common.Hash{tx.Hash()}))
+
+	_, err := p.GetTransactionByHash(ctx, tx.Hash())
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+	_, err = p.GetArchivedTransactionByHash(ctx, tx.Hash())
+	assert.ErrorIs(t, err, pool.ErrNotFound)
+}
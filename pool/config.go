@@ -3,6 +3,17 @@ package pool
 import (
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
 	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StorageType different pool storage backends.
+type StorageType string
+
+const (
+	// StoragePostgres stores the pool txs in the postgres pool database.
+	StoragePostgres StorageType = "postgres"
+	// StorageMemory stores the pool txs in memory.
+	StorageMemory StorageType = "memory"
 )
 
 // Config is the pool configuration
@@ -20,6 +31,11 @@ type Config struct {
 	// MaxTxDataBytesSize is the max size of the data field of a transaction in bytes
 	MaxTxDataBytesSize int `mapstructure:"MaxTxDataBytesSize"`
 
+	// Storage is the backend used to store the pool txs: "postgres" (default) or "memory".
+	// The memory storage is meant for development and tests only, its content is lost on
+	// restart and it can not be shared between processes, so the DB config is ignored
+	Storage StorageType `mapstructure:"Storage"`
+
 	// DB is the database configuration
 	DB db.Config `mapstructure:"DB"`
 
@@ -35,9 +51,14 @@ type Config struct {
 	// AccountQueue represents the maximum number of non-executable transaction slots permitted per account
 	AccountQueue uint64 `mapstructure:"AccountQueue"`
 
-	// GlobalQueue represents the maximum number of non-executable transaction slots for all accounts
+	// GlobalQueue represents the maximum number of non-executable transaction slots for all accounts.
+	// When it is reached, the cheapest non-executable tx is evicted to make room for a better paying tx
 	GlobalQueue uint64 `mapstructure:"GlobalQueue"`
 
+	// PriceBump is the minimum bump percentage of both the gas price (fee cap) and the gas
+	// tip cap required to replace a pending tx of the same account and nonce
+	PriceBump uint64 `mapstructure:"PriceBump"`
+
 	// EffectiveGasPrice is the config for the effective gas price calculation
 	EffectiveGasPrice EffectiveGasPriceCfg `mapstructure:"EffectiveGasPrice"`
 
@@ -47,6 +68,143 @@ type Config struct {
 	// TxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether. 0 means no cap.
 	TxFeeCap float64 `mapstructure:"TxFeeCap"`
+
+	// AllowList is the configuration of the permissioned chain mode, in which only
+	// the allow-listed accounts are able to send transactions to the pool
+	AllowList AllowListCfg `mapstructure:"AllowList"`
+
+	// Reputation is the configuration of the automatic throttling and banning of the
+	// senders and IPs whose txs keep failing
+	Reputation ReputationCfg `mapstructure:"Reputation"`
+
+	// Archive is the configuration of the archive where the processed txs are moved
+	// instead of being deleted from the pool
+	Archive ArchiveCfg `mapstructure:"Archive"`
+
+	// SponsoredClaims is the configuration of the zero gas price bridge claims accepted
+	// by the pool, so the users arriving through the bridge can claim without L2 ETH
+	SponsoredClaims SponsoredClaimsCfg `mapstructure:"SponsoredClaims"`
+
+	// PreExecution is the configuration of the cache and the batching of the executor
+	// calls made to pre-execute the txs added to the pool
+	PreExecution PreExecutionCfg `mapstructure:"PreExecution"`
+}
+
+// PreExecutionCfg contains the configuration properties for the pre-execution of the pool txs
+type PreExecutionCfg struct {
+	// CacheTTL is the time the pre-execution result of a tx is reused when the same tx
+	// is sent again on top of the same state root. 0 disables the cache
+	CacheTTL types.Duration `mapstructure:"CacheTTL"`
+
+	// CacheMaxSize is the max number of pre-execution results kept in the cache
+	CacheMaxSize int `mapstructure:"CacheMaxSize"`
+
+	// BatchingEnabled groups the pre-executions requested within BatchWindow, so all the
+	// requests of the same tx share a single executor call. The executor only returns the
+	// zkCounters of a whole batch, so each distinct tx of a group still gets its own
+	// executor call to get its own zkCounters
+	BatchingEnabled bool `mapstructure:"BatchingEnabled"`
+
+	// BatchWindow is the time to wait for other requests to group with the first one
+	BatchWindow types.Duration `mapstructure:"BatchWindow"`
+
+	// BatchMaxSize is the max number of requests grouped together
+	BatchMaxSize int `mapstructure:"BatchMaxSize"`
+}
+
+// SponsoredClaimsCfg contains the configuration properties for the sponsored bridge claims.
+// A sponsored claim is a tx with zero gas price calling claimAsset or claimMessage on the
+// bridge contract, it skips the gas price, break even gas price and balance checks and
+// it is rejected if it fails in the pre-execution
+type SponsoredClaimsCfg struct {
+	// Enabled is a flag to enable/disable the sponsored claims
+	Enabled bool `mapstructure:"Enabled"`
+
+	// BridgeAddress is the address of the L2 bridge contract
+	BridgeAddress common.Address `mapstructure:"BridgeAddress"`
+
+	// MaxGas is the max gas limit of a sponsored claim
+	MaxGas uint64 `mapstructure:"MaxGas"`
+
+	// RecipientRateLimit is the max number of sponsored claims accepted to the same
+	// recipient, the destinationAddress of the claim, within RateLimitInterval.
+	// 0 means no limit
+	RecipientRateLimit uint64 `mapstructure:"RecipientRateLimit"`
+
+	// RateLimitInterval is the time window of RecipientRateLimit
+	RateLimitInterval types.Duration `mapstructure:"RateLimitInterval"`
+}
+
+// ArchiveCfg contains the configuration properties for the archive of the pool txs.
+// When it is enabled, the selected and failed txs deleted by the sequencer are moved
+// to the archive, keeping their final status, failed reason, gas prices and counters
+type ArchiveCfg struct {
+	// Enabled is a flag to enable/disable the archive
+	Enabled bool `mapstructure:"Enabled"`
+
+	// Interval is the time between the runs of the archiver
+	Interval types.Duration `mapstructure:"Interval"`
+
+	// ArchiveInvalidAfter is the time after which the invalid txs are moved to the archive,
+	// they were kept in the pool forever otherwise. 0 means they are not archived
+	ArchiveInvalidAfter types.Duration `mapstructure:"ArchiveInvalidAfter"`
+
+	// SelectedRetention is the time the selected txs are kept in the archive. 0 means forever
+	SelectedRetention types.Duration `mapstructure:"SelectedRetention"`
+
+	// FailedRetention is the time the failed txs are kept in the archive. 0 means forever
+	FailedRetention types.Duration `mapstructure:"FailedRetention"`
+
+	// InvalidRetention is the time the invalid txs are kept in the archive. 0 means forever
+	InvalidRetention types.Duration `mapstructure:"InvalidRetention"`
+}
+
+// ReputationCfg contains the configuration properties for the reputation of the senders and IPs.
+// A failure is recorded when a tx is set as failed or invalid in the pool, or when its
+// pre-execution runs out of counters or gas
+type ReputationCfg struct {
+	// Enabled is a flag to enable/disable the reputation tracking
+	Enabled bool `mapstructure:"Enabled"`
+
+	// FailuresWindow is the time after which the failures of a sender or IP are forgotten
+	FailuresWindow types.Duration `mapstructure:"FailuresWindow"`
+
+	// ThrottleThreshold is the number of failures from which the txs of a sender or IP are throttled.
+	// 0 means no throttling
+	ThrottleThreshold uint64 `mapstructure:"ThrottleThreshold"`
+
+	// ThrottleInterval is the minimum time between the txs accepted from a throttled sender or IP. It
+	// is multiplied by the number of failures over ThrottleThreshold, so the throttling is graduated
+	ThrottleInterval types.Duration `mapstructure:"ThrottleInterval"`
+
+	// BanThreshold is the number of failures from which a sender or IP is banned. 0 means no bans
+	BanThreshold uint64 `mapstructure:"BanThreshold"`
+
+	// BanDuration is the time a sender or IP is banned
+	BanDuration types.Duration `mapstructure:"BanDuration"`
+
+	// IgnoredFailedReasons are the failed reasons not recorded as failures, a failed reason
+	// is ignored if it contains any of them. Used for the txs that fail without being spam,
+	// like the replaced or expired ones
+	IgnoredFailedReasons []string `mapstructure:"IgnoredFailedReasons"`
+
+	// IntervalToRefresh is the time it takes to sync the reputations from db to memory
+	IntervalToRefresh types.Duration `mapstructure:"IntervalToRefresh"`
+}
+
+// AllowListCfg contains the configuration properties for the allow-lists of the pool
+type AllowListCfg struct {
+	// SendersEnabled rejects the transactions sent by accounts that are not in the
+	// senders allow-list (pool.whitelisted table)
+	SendersEnabled bool `mapstructure:"SendersEnabled"`
+
+	// DeployersEnabled rejects the contract deployments sent by accounts that are not in the
+	// deployers allow-list (pool.whitelisted_deployer table). Deployments are still subject
+	// to the senders allow-list when SendersEnabled is set
+	DeployersEnabled bool `mapstructure:"DeployersEnabled"`
+
+	// IntervalToRefresh is the time it takes to sync the allow-lists from db to memory
+	IntervalToRefresh types.Duration `mapstructure:"IntervalToRefresh"`
 }
 
 // EffectiveGasPriceCfg contains the configuration properties for the effective gas price
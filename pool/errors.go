@@ -30,6 +30,37 @@ var (
 	// ErrBlockedSender is returned if the transaction is sent by a blocked account.
 	ErrBlockedSender = errors.New("blocked sender")
 
+	// ErrSenderNotAllowed is returned if the senders allow-list is enabled and
+	// the transaction is sent by an account that is not in the list.
+	ErrSenderNotAllowed = errors.New("sender not allowed")
+
+	// ErrDeployerNotAllowed is returned if the deployers allow-list is enabled and
+	// the contract deployment is sent by an account that is not in the list.
+	ErrDeployerNotAllowed = errors.New("contract deployer not allowed")
+
+	// ErrInvalidAllowListKind is returned if the allow-list kind is unknown.
+	ErrInvalidAllowListKind = errors.New("invalid allow-list, expected senders or deployers")
+
+	// ErrReputationBanned is returned if the sender or the IP of the transaction
+	// are temporarily banned because too many of their transactions failed.
+	ErrReputationBanned = errors.New("sender or IP temporarily banned because of too many failed transactions")
+
+	// ErrReputationThrottled is returned if the sender or the IP of the transaction
+	// are throttled because some of their transactions failed and they sent another
+	// transaction too soon.
+	ErrReputationThrottled = errors.New("sender or IP throttled because of failed transactions, try again later")
+
+	// ErrSponsoredClaimRateLimited is returned if the recipient of a sponsored bridge
+	// claim has reached the limit of sponsored claims.
+	ErrSponsoredClaimRateLimited = errors.New("reached the limit of sponsored claims, try again later")
+
+	// ErrSponsoredClaimFailed is returned if a sponsored bridge claim reverts or runs
+	// out of gas in the pre-execution.
+	ErrSponsoredClaimFailed = errors.New("sponsored claim failed in the pre-execution")
+
+	// ErrInvalidReputationKind is returned if the reputation kind is unknown.
+	ErrInvalidReputationKind = errors.New("invalid reputation kind, expected sender or ip")
+
 	// ErrGasLimit is returned if a transaction's requested gas limit exceeds the
 	// maximum allowance of the current block.
 	ErrGasLimit = errors.New("exceeds block gas limit")
@@ -39,6 +70,14 @@ var (
 	// AccountQueue and can't accept another remote transaction.
 	ErrTxPoolAccountOverflow = errors.New("account has reached the tx limit in the txpool")
 
+	// ErrTxReplaced is the failed reason of the txs replaced by a new tx with the
+	// same nonce and a bumped gas price
+	ErrTxReplaced = errors.New("replaced by a tx with the same nonce and a higher gas price")
+
+	// ErrTxEvicted is the failed reason of the non-executable txs evicted from the full
+	// pool to make room for a tx with a higher gas price
+	ErrTxEvicted = errors.New("evicted from the full pool by a tx with a higher gas price")
+
 	// ErrTxPoolOverflow is returned if the transaction pool is full and can't accept
 	// another remote transaction.
 	ErrTxPoolOverflow = errors.New("txpool is full")
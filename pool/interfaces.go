@@ -11,7 +11,8 @@ import (
 	"github.com/jackc/pgx/v4"
 )
 
-type storage interface {
+// Storage is the interface implemented by the pool storage backends
+type Storage interface {
 	AddTx(ctx context.Context, tx Transaction) error
 	CountTransactionsByStatus(ctx context.Context, status ...TxStatus) (uint64, error)
 	CountTransactionsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) (uint64, error)
@@ -20,12 +21,19 @@ type storage interface {
 	GetNonce(ctx context.Context, address common.Address) (uint64, error)
 	GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error)
 	GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]Transaction, error)
+	GetEvictionCandidates(ctx context.Context, maxGasPrice uint64, limit uint64) ([]EvictionCandidate, error)
 	GetTxsByStatus(ctx context.Context, state TxStatus, limit uint64) ([]Transaction, error)
 	GetNonWIPPendingTxs(ctx context.Context) ([]Transaction, error)
+	GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]Transaction, error)
 	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
 	SetGasPrices(ctx context.Context, l2GasPrice uint64, l1GasPrice uint64) error
 	DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error
 	DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error
+	ArchiveTransactionsByHashes(ctx context.Context, hashes []common.Hash, archivedAt time.Time) error
+	ArchiveTransactionsOlderThan(ctx context.Context, status TxStatus, date time.Time, archivedAt time.Time) error
+	GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*ArchivedTransaction, error)
+	GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*ArchivedTransaction, error)
+	DeleteArchivedTransactionsOlderThan(ctx context.Context, status TxStatus, date time.Time) error
 	UpdateTxsStatus(ctx context.Context, updateInfo []TxStatusUpdateInfo) error
 	UpdateTxStatus(ctx context.Context, updateInfo TxStatusUpdateInfo) error
 	UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error
@@ -37,13 +45,37 @@ type storage interface {
 	DeleteTransactionByHash(ctx context.Context, hash common.Hash) error
 	MarkWIPTxsAsPending(ctx context.Context) error
 	GetAllAddressesBlocked(ctx context.Context) ([]common.Address, error)
+	GetAllowListAddresses(ctx context.Context, kind AllowListKind) ([]common.Address, error)
+	AddAllowListAddresses(ctx context.Context, kind AllowListKind, addresses []common.Address) error
+	DeleteAllowListAddresses(ctx context.Context, kind AllowListKind, addresses []common.Address) error
 	MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error)
+	AddReputationFailure(ctx context.Context, kind ReputationKind, key string, failedAt time.Time, forgetBefore time.Time) (*Reputation, error)
+	BanReputation(ctx context.Context, kind ReputationKind, key string, until time.Time) error
+	GetReputations(ctx context.Context) ([]Reputation, error)
+	DeleteReputation(ctx context.Context, kind ReputationKind, key string) error
+	DeleteReputationsOlderThan(ctx context.Context, date time.Time) error
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	AddPreconfirmation(ctx context.Context, preconfirmation Preconfirmation) error
+	RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64, rescindedAt time.Time) ([]common.Hash, error)
+	GetPreconfirmationByHash(ctx context.Context, hash common.Hash) (*Preconfirmation, error)
 }
 
+// NewTxsListener is implemented by the storages able to notify the pending txs
+// added to the pool by any process sharing the storage
+type NewTxsListener interface {
+	// ListenNewTxs calls onNewTx with the hash of every new pending tx until
+	// the context is done or the notifications can not be received anymore
+	ListenNewTxs(ctx context.Context, onNewTx func(hash common.Hash)) error
+}
+
+// storage is embedded in the Pool to expose the storage methods without exporting it
+type storage = Storage
+
 type stateInterface interface {
 	GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error)
 	GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error)
+	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetForkIDByBatchNumber(batchNumber uint64) uint64
 	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	PreProcessTransaction(ctx context.Context, tx *types.Transaction, dbTx pgx.Tx) (*state.ProcessBatchResponse, error)
@@ -0,0 +1,848 @@
+package memorypoolstorage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// storedTx is a pool tx kept in memory along with its sender
+type storedTx struct {
+	tx   pool.Transaction
+	from common.Address
+}
+
+// gasPrice is an entry of the gas prices history
+type gasPrice struct {
+	l2GasPrice uint64
+	l1GasPrice uint64
+	timestamp  time.Time
+}
+
+// reputationID identifies the reputation of a sender or IP
+type reputationID struct {
+	kind pool.ReputationKind
+	key  string
+}
+
+// MemoryPoolStorage is an implementation of the Pool interface
+// that keeps the data in memory. It is meant for development and
+// tests, the data is lost when the process stops
+type MemoryPoolStorage struct {
+	mutex       sync.RWMutex
+	txs         map[common.Hash]*storedTx
+	gasPrices   []gasPrice
+	blocked     map[common.Address]struct{}
+	allowList   map[pool.AllowListKind]map[common.Address]struct{}
+	reputations map[reputationID]*pool.Reputation
+	archive     map[common.Hash]*pool.ArchivedTransaction
+	// preconfirmations are deleted along with their txs
+	preconfirmations map[common.Hash]*pool.Preconfirmation
+}
+
+// NewMemoryPoolStorage creates and initializes an instance of MemoryPoolStorage
+func NewMemoryPoolStorage() *MemoryPoolStorage {
+	return &MemoryPoolStorage{
+		txs:     map[common.Hash]*storedTx{},
+		blocked: map[common.Address]struct{}{},
+		allowList: map[pool.AllowListKind]map[common.Address]struct{}{
+			pool.AllowListSenders:   {},
+			pool.AllowListDeployers: {},
+		},
+		reputations:      map[reputationID]*pool.Reputation{},
+		archive:          map[common.Hash]*pool.ArchivedTransaction{},
+		preconfirmations: map[common.Hash]*pool.Preconfirmation{},
+	}
+}
+
+// copyTx returns a copy of the stored tx that can be modified by the caller
+func copyTx(stored *storedTx) *pool.Transaction {
+	tx := stored.tx
+	if stored.tx.FailedReason != nil {
+		failedReason := *stored.tx.FailedReason
+		tx.FailedReason = &failedReason
+	}
+	return &tx
+}
+
+// sortedTxs returns the stored txs that match the filter sorted by the time they were received
+func (p *MemoryPoolStorage) sortedTxs(filter func(stored *storedTx) bool) []*storedTx {
+	txs := make([]*storedTx, 0, len(p.txs))
+	for _, stored := range p.txs {
+		if filter(stored) {
+			txs = append(txs, stored)
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].tx.ReceivedAt.Equal(txs[j].tx.ReceivedAt) {
+			return txs[i].tx.Hash().String() < txs[j].tx.Hash().String()
+		}
+		return txs[i].tx.ReceivedAt.Before(txs[j].tx.ReceivedAt)
+	})
+	return txs
+}
+
+func hasStatus(stored *storedTx, status []pool.TxStatus) bool {
+	for _, s := range status {
+		if stored.tx.Status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTx adds a transaction to the pool with the provided status,
+// replacing the tx with the same hash if it is already stored. A private tx
+// stays private when it is stored again
+func (p *MemoryPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) error {
+	from, err := state.GetSender(tx.Transaction)
+	if err != nil {
+		return err
+	}
+
+	tx.FailedReason = nil
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if stored, found := p.txs[tx.Hash()]; found {
+		tx.IsPrivate = tx.IsPrivate || stored.tx.IsPrivate
+	}
+	p.txs[tx.Hash()] = &storedTx{tx: tx, from: from}
+	return nil
+}
+
+// GetTxsByStatus returns an array of transactions filtered by status
+// limit parameter is used to limit amount txs,
+// if limit = 0, then there is no limit
+func (p *MemoryPoolStorage) GetTxsByStatus(ctx context.Context, status pool.TxStatus, limit uint64) ([]pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored := p.sortedTxs(func(stored *storedTx) bool { return stored.tx.Status == status })
+	sort.SliceStable(stored, func(i, j int) bool {
+		return stored[i].tx.GasPrice().Cmp(stored[j].tx.GasPrice()) > 0
+	})
+	if limit > 0 && uint64(len(stored)) > limit {
+		stored = stored[:limit]
+	}
+
+	txs := make([]pool.Transaction, 0, len(stored))
+	for _, s := range stored {
+		txs = append(txs, *copyTx(s))
+	}
+	return txs, nil
+}
+
+// GetNonWIPPendingTxs returns an array of the pending transactions that are not WIP
+func (p *MemoryPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored := p.sortedTxs(func(stored *storedTx) bool {
+		return !stored.tx.IsWIP && stored.tx.Status == pool.TxStatusPending
+	})
+	txs := make([]pool.Transaction, 0, len(stored))
+	for _, s := range stored {
+		txs = append(txs, *copyTx(s))
+	}
+	return txs, nil
+}
+
+// GetNonWIPPendingTxsByHashes returns the transactions with the provided hashes
+// that are pending and not WIP
+func (p *MemoryPoolStorage) GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	txs := make([]pool.Transaction, 0, len(hashes))
+	added := make(map[common.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		stored, found := p.txs[hash]
+		if _, alreadyAdded := added[hash]; !found || alreadyAdded {
+			continue
+		}
+		if !stored.tx.IsWIP && stored.tx.Status == pool.TxStatusPending {
+			txs = append(txs, *copyTx(stored))
+			added[hash] = struct{}{}
+		}
+	}
+	return txs, nil
+}
+
+// GetPendingTxHashesSince returns the public pending tx since the given time.
+func (p *MemoryPoolStorage) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored := p.sortedTxs(func(stored *storedTx) bool {
+		return stored.tx.Status == pool.TxStatusPending && !stored.tx.ReceivedAt.Before(since) && !stored.tx.IsPrivate
+	})
+	hashes := make([]common.Hash, 0, len(stored))
+	for _, s := range stored {
+		hashes = append(hashes, s.tx.Hash())
+	}
+	return hashes, nil
+}
+
+// GetTxs gets txs with the lowest nonce
+func (p *MemoryPoolStorage) GetTxs(ctx context.Context, filterStatus pool.TxStatus, minGasPrice, limit uint64) ([]*pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored := p.sortedTxs(func(stored *storedTx) bool {
+		return stored.tx.Status == filterStatus && stored.tx.GasPrice().Uint64() >= minGasPrice
+	})
+	sort.SliceStable(stored, func(i, j int) bool {
+		return stored[i].tx.Nonce() < stored[j].tx.Nonce()
+	})
+	if uint64(len(stored)) > limit {
+		stored = stored[:limit]
+	}
+
+	txs := make([]*pool.Transaction, 0, len(stored))
+	for _, s := range stored {
+		txs = append(txs, copyTx(s))
+	}
+	return txs, nil
+}
+
+// CountTransactionsByStatus get number of transactions
+// accordingly to the provided statuses
+func (p *MemoryPoolStorage) CountTransactionsByStatus(ctx context.Context, status ...pool.TxStatus) (uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var counter uint64
+	for _, stored := range p.txs {
+		if hasStatus(stored, status) {
+			counter++
+		}
+	}
+	return counter, nil
+}
+
+// CountTransactionsByFromAndStatus get number of transactions
+// accordingly to the from address and provided statuses
+func (p *MemoryPoolStorage) CountTransactionsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) (uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var counter uint64
+	for _, stored := range p.txs {
+		if stored.from == from && hasStatus(stored, status) {
+			counter++
+		}
+	}
+	return counter, nil
+}
+
+// UpdateTxStatus updates a transaction status accordingly to the
+// provided status and hash
+func (p *MemoryPoolStorage) UpdateTxStatus(ctx context.Context, updateInfo pool.TxStatusUpdateInfo) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.updateTxStatus(updateInfo)
+	return nil
+}
+
+func (p *MemoryPoolStorage) updateTxStatus(updateInfo pool.TxStatusUpdateInfo) {
+	stored, found := p.txs[updateInfo.Hash]
+	if !found {
+		return
+	}
+	stored.tx.Status = updateInfo.NewStatus
+	stored.tx.IsWIP = updateInfo.IsWIP
+	if updateInfo.FailedReason != nil {
+		failedReason := *updateInfo.FailedReason
+		stored.tx.FailedReason = &failedReason
+	}
+}
+
+// UpdateTxsStatus updates transactions status accordingly to the provided status and hashes
+func (p *MemoryPoolStorage) UpdateTxsStatus(ctx context.Context, updateInfos []pool.TxStatusUpdateInfo) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, updateInfo := range updateInfos {
+		p.updateTxStatus(updateInfo)
+	}
+	return nil
+}
+
+// deleteTx deletes a tx along with its pre-confirmation, the caller must hold the lock
+func (p *MemoryPoolStorage) deleteTx(hash common.Hash) {
+	delete(p.txs, hash)
+	delete(p.preconfirmations, hash)
+}
+
+// DeleteTransactionsByHashes deletes txs by their hashes
+func (p *MemoryPoolStorage) DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, hash := range hashes {
+		p.deleteTx(hash)
+	}
+	return nil
+}
+
+// DeleteFailedTransactionsOlderThan deletes all failed transactions older than the given date
+func (p *MemoryPoolStorage) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for hash, stored := range p.txs {
+		if stored.tx.Status == pool.TxStatusFailed && stored.tx.ReceivedAt.Before(date) {
+			p.deleteTx(hash)
+		}
+	}
+	return nil
+}
+
+// archiveTx moves a stored tx to the archive, the caller must hold the lock
+func (p *MemoryPoolStorage) archiveTx(hash common.Hash, stored *storedTx, archivedAt time.Time) {
+	p.archive[hash] = &pool.ArchivedTransaction{
+		Transaction: *copyTx(stored),
+		From:        stored.from,
+		ArchivedAt:  archivedAt,
+	}
+	p.deleteTx(hash)
+}
+
+// ArchiveTransactionsByHashes moves the txs from the pool to the archive by their hashes
+func (p *MemoryPoolStorage) ArchiveTransactionsByHashes(ctx context.Context, hashes []common.Hash, archivedAt time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, hash := range hashes {
+		if stored, found := p.txs[hash]; found {
+			p.archiveTx(hash, stored, archivedAt)
+		}
+	}
+	return nil
+}
+
+// ArchiveTransactionsOlderThan moves the txs with the provided status received
+// before the given date from the pool to the archive
+func (p *MemoryPoolStorage) ArchiveTransactionsOlderThan(ctx context.Context, status pool.TxStatus, date time.Time, archivedAt time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for hash, stored := range p.txs {
+		if stored.tx.Status == status && stored.tx.ReceivedAt.Before(date) {
+			p.archiveTx(hash, stored, archivedAt)
+		}
+	}
+	return nil
+}
+
+// GetArchivedTransactionByHash gets an archived transaction by its hash
+func (p *MemoryPoolStorage) GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	archived, found := p.archive[hash]
+	if !found {
+		return nil, pool.ErrNotFound
+	}
+	archivedTx := *archived
+	archivedTx.Transaction = *copyTx(&storedTx{tx: archived.Transaction})
+	return &archivedTx, nil
+}
+
+// GetArchivedTransactionByL2Hash gets an archived transaction by its l2 hash. As
+// in the pool, the l2 hash is not stored so the tx is never found
+func (p *MemoryPoolStorage) GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	return nil, pool.ErrNotFound
+}
+
+// DeleteArchivedTransactionsOlderThan deletes the archived txs with the provided status
+// archived before the given date
+func (p *MemoryPoolStorage) DeleteArchivedTransactionsOlderThan(ctx context.Context, status pool.TxStatus, date time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for hash, archived := range p.archive {
+		if archived.Status == status && archived.ArchivedAt.Before(date) {
+			delete(p.archive, hash)
+		}
+	}
+	return nil
+}
+
+// SetGasPrices sets the latest l2 and l1 gas prices
+func (p *MemoryPoolStorage) SetGasPrices(ctx context.Context, l2GasPrice, l1GasPrice uint64) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.gasPrices = append(p.gasPrices, gasPrice{l2GasPrice: l2GasPrice, l1GasPrice: l1GasPrice, timestamp: time.Now().UTC()})
+	return nil
+}
+
+// GetGasPrices returns the latest l2 and l1 gas prices
+func (p *MemoryPoolStorage) GetGasPrices(ctx context.Context) (uint64, uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if len(p.gasPrices) == 0 {
+		return 0, 0, nil
+	}
+	last := p.gasPrices[len(p.gasPrices)-1]
+	return last.l2GasPrice, last.l1GasPrice, nil
+}
+
+// DeleteGasPricesHistoryOlderThan deletes all gas prices older than the given date except the last one
+func (p *MemoryPoolStorage) DeleteGasPricesHistoryOlderThan(ctx context.Context, date time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.gasPrices) == 0 {
+		return nil
+	}
+	last := len(p.gasPrices) - 1
+	gasPrices := make([]gasPrice, 0, len(p.gasPrices))
+	for i, gp := range p.gasPrices {
+		if i == last || !gp.timestamp.Before(date) {
+			gasPrices = append(gasPrices, gp)
+		}
+	}
+	p.gasPrices = gasPrices
+	return nil
+}
+
+// MinL2GasPriceSince returns the min L2 gas price after given timestamp
+func (p *MemoryPoolStorage) MinL2GasPriceSince(ctx context.Context, timestamp time.Time) (uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var minGasPrice uint64
+	for _, gp := range p.gasPrices {
+		if gp.timestamp.Before(timestamp) {
+			continue
+		}
+		if minGasPrice == 0 || gp.l2GasPrice < minGasPrice {
+			minGasPrice = gp.l2GasPrice
+		}
+	}
+	if minGasPrice == 0 {
+		return 0, state.ErrNotFound
+	}
+	return minGasPrice, nil
+}
+
+// IsTxPending determines if the tx associated to the given hash is pending or
+// not.
+func (p *MemoryPoolStorage) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored, found := p.txs[hash]
+	return found && stored.tx.Status == pool.TxStatusPending, nil
+}
+
+// GetTxsByFromAndNonce get all the transactions from the pool with the same from and nonce
+func (p *MemoryPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored := p.sortedTxs(func(stored *storedTx) bool {
+		return stored.from == from && stored.tx.Nonce() == nonce
+	})
+	txs := make([]pool.Transaction, 0, len(stored))
+	for _, s := range stored {
+		txs = append(txs, *copyTx(s))
+	}
+	return txs, nil
+}
+
+// GetEvictionCandidates gets the pending txs with a gas price lower than maxGasPrice sorted
+// from the cheapest to the most expensive, flagging the txs whose nonce comes after a gap
+// in the pending nonces of the sender, which can't be executed until the gap is filled
+func (p *MemoryPoolStorage) GetEvictionCandidates(ctx context.Context, maxGasPrice uint64, limit uint64) ([]pool.EvictionCandidate, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	pending := p.sortedTxs(func(stored *storedTx) bool { return stored.tx.Status == pool.TxStatusPending })
+
+	// distinct pending nonces of each sender sorted ascending
+	senderNonces := map[common.Address][]uint64{}
+	for _, stored := range pending {
+		senderNonces[stored.from] = append(senderNonces[stored.from], stored.tx.Nonce())
+	}
+	for from, nonces := range senderNonces {
+		sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+		distinct := nonces[:0]
+		for i, nonce := range nonces {
+			if i == 0 || nonce != nonces[i-1] {
+				distinct = append(distinct, nonce)
+			}
+		}
+		senderNonces[from] = distinct
+	}
+
+	candidates := make([]pool.EvictionCandidate, 0, len(pending))
+	receivedAt := make(map[common.Hash]time.Time, len(pending))
+	for _, stored := range pending {
+		gasPrice := stored.tx.GasPrice().Uint64()
+		if gasPrice >= maxGasPrice || stored.tx.IsWIP {
+			continue
+		}
+		nonces := senderNonces[stored.from]
+		// the nonce of the tx is dense if all the nonces from the lowest one are in the pool
+		rank := uint64(sort.Search(len(nonces), func(i int) bool { return nonces[i] >= stored.tx.Nonce() }))
+		candidates = append(candidates, pool.EvictionCandidate{
+			Hash:              stored.tx.Hash(),
+			From:              stored.from,
+			Nonce:             stored.tx.Nonce(),
+			GasPrice:          gasPrice,
+			AfterNonceGap:     stored.tx.Nonce()-rank != nonces[0],
+			LowestSenderNonce: nonces[0],
+		})
+		receivedAt[stored.tx.Hash()] = stored.tx.ReceivedAt
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].GasPrice != candidates[j].GasPrice {
+			return candidates[i].GasPrice < candidates[j].GasPrice
+		}
+		return receivedAt[candidates[i].Hash].After(receivedAt[candidates[j].Hash])
+	})
+	if uint64(len(candidates)) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates, nil
+}
+
+// GetTxFromAddressFromByHash gets tx from address by hash
+func (p *MemoryPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored, found := p.txs[hash]
+	if !found {
+		return common.Address{}, 0, pool.ErrNotFound
+	}
+	return stored.from, stored.tx.Nonce(), nil
+}
+
+// GetNonce gets the nonce to the provided address accordingly to the txs in the pool
+func (p *MemoryPoolStorage) GetNonce(ctx context.Context, address common.Address) (uint64, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	var (
+		nonce uint64
+		found bool
+	)
+	for _, stored := range p.txs {
+		if stored.from != address || !hasStatus(stored, []pool.TxStatus{pool.TxStatusPending, pool.TxStatusSelected}) {
+			continue
+		}
+		if !found || stored.tx.Nonce() > nonce {
+			nonce = stored.tx.Nonce()
+			found = true
+		}
+	}
+	if !found {
+		return 0, nil
+	}
+	return nonce + 1, nil
+}
+
+// GetTransactionByHash gets a transaction in the pool by its hash
+func (p *MemoryPoolStorage) GetTransactionByHash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored, found := p.txs[hash]
+	if !found {
+		return nil, pool.ErrNotFound
+	}
+	return copyTx(stored), nil
+}
+
+// GetTransactionByL2Hash gets a transaction in the pool by its l2 hash.
+// The l2 hash is not stored when a tx is added to the pool, so as in
+// the postgres storage the tx is never found
+func (p *MemoryPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.Transaction, error) {
+	return nil, pool.ErrNotFound
+}
+
+// DeleteTransactionByHash deletes tx by its hash
+func (p *MemoryPoolStorage) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.deleteTx(hash)
+	return nil
+}
+
+// GetTxZkCountersByHash gets a transaction zkcounters by its hash
+func (p *MemoryPoolStorage) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	stored, found := p.txs[hash]
+	if !found {
+		return nil, nil, pool.ErrNotFound
+	}
+	usedZKCounters := stored.tx.ZKCounters
+	reservedZKCounters := stored.tx.ReservedZKCounters
+	return &usedZKCounters, &reservedZKCounters, nil
+}
+
+// MarkWIPTxsAsPending updates WIP status to non WIP
+func (p *MemoryPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, stored := range p.txs {
+		stored.tx.IsWIP = false
+	}
+	return nil
+}
+
+// UpdateTxWIPStatus updates a transaction wip status accordingly to the
+// provided WIP status and hash
+func (p *MemoryPoolStorage) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if stored, found := p.txs[hash]; found {
+		stored.tx.IsWIP = isWIP
+	}
+	return nil
+}
+
+// GetAllAddressesBlocked get all addresses blocked
+func (p *MemoryPoolStorage) GetAllAddressesBlocked(ctx context.Context) ([]common.Address, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return sortedAddresses(p.blocked), nil
+}
+
+// BlockAddresses adds the addresses to the blocked list. The pool storage
+// interface has no way to block addresses, in the postgres storage they
+// are inserted manually in the pool.blocked table
+func (p *MemoryPoolStorage) BlockAddresses(addresses ...common.Address) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, addr := range addresses {
+		p.blocked[addr] = struct{}{}
+	}
+}
+
+// UnblockAddresses removes the addresses from the blocked list
+func (p *MemoryPoolStorage) UnblockAddresses(addresses ...common.Address) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, addr := range addresses {
+		delete(p.blocked, addr)
+	}
+}
+
+// GetAllowListAddresses get all the addresses of the provided allow-list
+func (p *MemoryPoolStorage) GetAllowListAddresses(ctx context.Context, kind pool.AllowListKind) ([]common.Address, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	list, found := p.allowList[kind]
+	if !found {
+		return nil, pool.ErrInvalidAllowListKind
+	}
+	return sortedAddresses(list), nil
+}
+
+// AddAllowListAddresses adds the addresses to the provided allow-list,
+// addresses already in the list are ignored
+func (p *MemoryPoolStorage) AddAllowListAddresses(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	list, found := p.allowList[kind]
+	if !found {
+		return pool.ErrInvalidAllowListKind
+	}
+	for _, addr := range addresses {
+		list[addr] = struct{}{}
+	}
+	return nil
+}
+
+// DeleteAllowListAddresses removes the addresses from the provided allow-list
+func (p *MemoryPoolStorage) DeleteAllowListAddresses(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	list, found := p.allowList[kind]
+	if !found {
+		return pool.ErrInvalidAllowListKind
+	}
+	for _, addr := range addresses {
+		delete(list, addr)
+	}
+	return nil
+}
+
+// GetEarliestProcessedTx gets the earliest processed tx from the pool. Mainly used for cleanup
+func (p *MemoryPoolStorage) GetEarliestProcessedTx(ctx context.Context) (common.Hash, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	selected := p.sortedTxs(func(stored *storedTx) bool { return stored.tx.Status == pool.TxStatusSelected })
+	if len(selected) == 0 {
+		return common.Hash{}, nil
+	}
+	return selected[0].tx.Hash(), nil
+}
+
+func sortedAddresses(set map[common.Address]struct{}) []common.Address {
+	addrs := make([]common.Address, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Hex() < addrs[j].Hex() })
+	return addrs
+}
+
+// AddReputationFailure adds a failure to the reputation of the provided sender or IP and returns
+// the updated reputation. The failures of a reputation whose window started before forgetBefore
+// are forgotten, starting a new window with this failure
+func (p *MemoryPoolStorage) AddReputationFailure(ctx context.Context, kind pool.ReputationKind, key string, failedAt time.Time, forgetBefore time.Time) (*pool.Reputation, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	id := reputationID{kind: kind, key: key}
+	reputation, found := p.reputations[id]
+	if !found || reputation.WindowStart.Before(forgetBefore) {
+		if !found {
+			reputation = &pool.Reputation{Kind: kind, Key: key}
+			p.reputations[id] = reputation
+		}
+		reputation.Failures = 0
+		reputation.WindowStart = failedAt
+	}
+	reputation.Failures++
+	reputation.LastFailureAt = failedAt
+
+	return copyReputation(reputation), nil
+}
+
+// BanReputation bans the provided sender or IP until the provided time
+func (p *MemoryPoolStorage) BanReputation(ctx context.Context, kind pool.ReputationKind, key string, until time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if reputation, found := p.reputations[reputationID{kind: kind, key: key}]; found {
+		reputation.BannedUntil = &until
+	}
+	return nil
+}
+
+// GetReputations returns all the stored reputations
+func (p *MemoryPoolStorage) GetReputations(ctx context.Context) ([]pool.Reputation, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	reputations := make([]pool.Reputation, 0, len(p.reputations))
+	for _, reputation := range p.reputations {
+		reputations = append(reputations, *copyReputation(reputation))
+	}
+	sort.Slice(reputations, func(i, j int) bool {
+		if reputations[i].Kind != reputations[j].Kind {
+			return reputations[i].Kind < reputations[j].Kind
+		}
+		return reputations[i].Key < reputations[j].Key
+	})
+	return reputations, nil
+}
+
+// DeleteReputation deletes the reputation of the provided sender or IP
+func (p *MemoryPoolStorage) DeleteReputation(ctx context.Context, kind pool.ReputationKind, key string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.reputations, reputationID{kind: kind, key: key})
+	return nil
+}
+
+// DeleteReputationsOlderThan deletes the reputations without failures since the given date
+// that are not banned after it
+func (p *MemoryPoolStorage) DeleteReputationsOlderThan(ctx context.Context, date time.Time) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for id, reputation := range p.reputations {
+		if reputation.LastFailureAt.Before(date) && (reputation.BannedUntil == nil || reputation.BannedUntil.Before(date)) {
+			delete(p.reputations, id)
+		}
+	}
+	return nil
+}
+
+func copyReputation(reputation *pool.Reputation) *pool.Reputation {
+	r := *reputation
+	if reputation.BannedUntil != nil {
+		bannedUntil := *reputation.BannedUntil
+		r.BannedUntil = &bannedUntil
+	}
+	return &r
+}
+
+// AddPreconfirmation stores the pre-confirmation of a tx, replacing the previous one of the tx
+func (p *MemoryPoolStorage) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, found := p.txs[preconfirmation.TxHash]; !found {
+		return pool.ErrNotFound
+	}
+
+	preconfirmation.Signature = append([]byte{}, preconfirmation.Signature...)
+	preconfirmation.Rescinded = false
+	preconfirmation.UpdatedAt = preconfirmation.CreatedAt
+	p.preconfirmations[preconfirmation.TxHash] = &preconfirmation
+	return nil
+}
+
+// RescindPreconfirmations rescinds the pre-confirmations of the txs planned in the L2 blocks from
+// the given number that are not rescinded yet, returning the hashes of the txs rescinded
+func (p *MemoryPoolStorage) RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64, rescindedAt time.Time) ([]common.Hash, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	hashes := []common.Hash{}
+	for hash, preconfirmation := range p.preconfirmations {
+		if preconfirmation.L2BlockNumber >= fromL2BlockNumber && !preconfirmation.Rescinded {
+			preconfirmation.Rescinded = true
+			preconfirmation.UpdatedAt = rescindedAt
+			hashes = append(hashes, hash)
+		}
+	}
+	return hashes, nil
+}
+
+// GetPreconfirmationByHash returns the pre-confirmation of a tx
+func (p *MemoryPoolStorage) GetPreconfirmationByHash(ctx context.Context, hash common.Hash) (*pool.Preconfirmation, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	preconfirmation, found := p.preconfirmations[hash]
+	if !found {
+		return nil, pool.ErrNotFound
+	}
+
+	preconfirmationCopy := *preconfirmation
+	preconfirmationCopy.Signature = append([]byte{}, preconfirmation.Signature...)
+	preconfirmationCopy.IsPrivate = p.txs[hash].tx.IsPrivate
+	return &preconfirmationCopy, nil
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	prefix                     = "pool_"
+	preExecutionPrefix         = prefix + "preexecution_"
+	preExecutionCacheName      = preExecutionPrefix + "cache"
+	preExecutionCallsName      = preExecutionPrefix + "executor_calls"
+	preExecutionBatchSizeName  = preExecutionPrefix + "batch_size"
+	preExecutionBatchTxsName   = preExecutionPrefix + "batch_txs"
+	preExecutionCacheLabelName = "result"
+)
+
+// PreExecutionCacheLabel represents the possible values for the
+// `pool_preexecution_cache` metric `result` label.
+type PreExecutionCacheLabel string
+
+const (
+	// PreExecutionCacheHit represents a pre-execution result found in the cache
+	PreExecutionCacheHit PreExecutionCacheLabel = "hit"
+	// PreExecutionCacheMiss represents a pre-execution result not found in the cache
+	PreExecutionCacheMiss PreExecutionCacheLabel = "miss"
+)
+
+// Register the metrics for the pool package.
+func Register() {
+	counters := []prometheus.CounterOpts{
+		{
+			Name: preExecutionCallsName,
+			Help: "[POOL] number of executor calls to pre-execute txs",
+		},
+	}
+
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: preExecutionCacheName,
+				Help: "[POOL] number of pre-execution cache lookups",
+			},
+			Labels: []string{preExecutionCacheLabelName},
+		},
+	}
+
+	histograms := []prometheus.HistogramOpts{
+		{
+			Name:    preExecutionBatchSizeName,
+			Help:    "[POOL] Histogram for the number of pre-execution requests grouped together",
+			Buckets: prometheus.LinearBuckets(1, 1, 20), //nolint:gomnd
+		},
+		{
+			Name:    preExecutionBatchTxsName,
+			Help:    "[POOL] Histogram for the number of distinct txs of the pre-execution requests grouped together",
+			Buckets: prometheus.LinearBuckets(1, 1, 20), //nolint:gomnd
+		},
+	}
+
+	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
+	metrics.RegisterHistograms(histograms...)
+}
+
+// PreExecutionCache increments the pre-execution cache lookups counter vector
+// by one for the given label.
+func PreExecutionCache(label PreExecutionCacheLabel) {
+	metrics.CounterVecInc(preExecutionCacheName, string(label))
+}
+
+// PreExecutionCall increments the pre-execution executor calls counter by one.
+func PreExecutionCall() {
+	metrics.CounterInc(preExecutionCallsName)
+}
+
+// PreExecutionBatch observes (histogram) the number of pre-execution requests grouped
+// together and the number of distinct txs among them.
+func PreExecutionBatch(size int, txs int) {
+	metrics.HistogramObserve(preExecutionBatchSizeName, float64(size))
+	metrics.HistogramObserve(preExecutionBatchTxsName, float64(txs))
+}
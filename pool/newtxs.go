@@ -0,0 +1,69 @@
+package pool
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// newTxsSubscriptionBufferSize is the number of new tx notifications kept for a
+	// subscriber that is not reading them, the next ones are dropped
+	newTxsSubscriptionBufferSize = 1024
+	// newTxsListenRetryInterval is the time to wait before listening again to the
+	// storage notifications after an error
+	newTxsListenRetryInterval = 5 * time.Second
+)
+
+// SubscribeNewTxs returns a channel that receives the hash of the pending txs
+// added to the pool until the context is done. The txs added by this instance
+// are notified in-process, and the ones added by other processes sharing the
+// storage are notified if the storage supports it. The notifications are best
+// effort: they are dropped when the subscriber does not keep up
+func (p *Pool) SubscribeNewTxs(ctx context.Context) <-chan common.Hash {
+	ch := make(chan common.Hash, newTxsSubscriptionBufferSize)
+
+	p.newTxsSubscribersMux.Lock()
+	p.newTxsSubscribers[ch] = struct{}{}
+	if listener, ok := p.storage.(NewTxsListener); ok && !p.listeningNewTxs {
+		p.listeningNewTxs = true
+		go p.listenNewTxs(listener)
+	}
+	p.newTxsSubscribersMux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.newTxsSubscribersMux.Lock()
+		delete(p.newTxsSubscribers, ch)
+		close(ch)
+		p.newTxsSubscribersMux.Unlock()
+	}()
+
+	return ch
+}
+
+// notifyNewTx sends the hash of a new pending tx to the subscribers
+func (p *Pool) notifyNewTx(hash common.Hash) {
+	p.newTxsSubscribersMux.Lock()
+	defer p.newTxsSubscribersMux.Unlock()
+
+	for ch := range p.newTxsSubscribers {
+		select {
+		case ch <- hash:
+		default:
+			log.Debugf("new tx notification for tx %s dropped, subscriber is full", hash.String())
+		}
+	}
+}
+
+// listenNewTxs forwards the new txs notified by the storage to the subscribers
+func (p *Pool) listenNewTxs(listener NewTxsListener) {
+	ctx := context.Background()
+	for {
+		err := listener.ListenNewTxs(ctx, p.notifyNewTx)
+		log.Errorf("error listening to the new txs stored in the pool, retrying in %s: %v", newTxsListenRetryInterval, err)
+		time.Sleep(newTxsListenRetryInterval)
+	}
+}
@@ -0,0 +1,80 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenerStorage is a memory storage that notifies the hashes sent to its channel
+// as if they were added to the pool by another process
+type listenerStorage struct {
+	*memorypoolstorage.MemoryPoolStorage
+	newTxs chan common.Hash
+}
+
+func (s *listenerStorage) ListenNewTxs(ctx context.Context, onNewTx func(hash common.Hash)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case hash := <-s.newTxs:
+			onNewTx(hash)
+		}
+	}
+}
+
+func Test_SubscribeNewTxs(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	s := &listenerStorage{
+		MemoryPoolStorage: memorypoolstorage.NewMemoryPoolStorage(),
+		newTxs:            make(chan common.Hash),
+	}
+	p := pool.NewPool(cfg, bc, s, nil, chainID.Uint64(), eventLog)
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+	sub1 := p.SubscribeNewTxs(ctx1)
+	sub2 := p.SubscribeNewTxs(ctx2)
+
+	hash := common.HexToHash("0x1")
+	s.newTxs <- hash
+	for _, sub := range []<-chan common.Hash{sub1, sub2} {
+		select {
+		case notified := <-sub:
+			assert.Equal(t, hash, notified)
+		case <-time.After(time.Second):
+			require.FailNow(t, "new tx not notified")
+		}
+	}
+
+	// the subscription is closed when its context is done
+	cancel1()
+	select {
+	case _, ok := <-sub1:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		require.FailNow(t, "subscription not closed")
+	}
+
+	hash = common.HexToHash("0x2")
+	s.newTxs <- hash
+	select {
+	case notified := <-sub2:
+		assert.Equal(t, hash, notified)
+	case <-time.After(time.Second):
+		require.FailNow(t, "new tx not notified")
+	}
+}
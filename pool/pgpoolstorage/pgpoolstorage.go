@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/db"
@@ -16,6 +19,13 @@ import (
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+const (
+	// newTxChannel is the channel notified by the pool.transaction trigger when a pending tx is stored
+	newTxChannel = "pool_new_tx"
+	// preconfirmationChannel is the channel notified by the pool.preconfirmation trigger when a pre-confirmation is stored or rescinded
+	preconfirmationChannel = "pool_preconfirmation"
+)
+
 // PostgresPoolStorage is an implementation of the Pool interface
 // that uses a postgres database to store the data
 type PostgresPoolStorage struct {
@@ -76,10 +86,11 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip,
 			ip,
 			failed_reason,
-			reserved_zkcounters
+			reserved_zkcounters,
+			is_private
 		) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20, $21)
 			ON CONFLICT (hash) DO UPDATE SET 
 			encoded = $2,
 			decoded = $3,
@@ -100,7 +111,8 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip = $18,
 			ip = $19,
 			failed_reason = NULL,
-			reserved_zkcounters = $20
+			reserved_zkcounters = $20,
+			is_private = pool.transaction.is_private OR $21
 	`
 
 	// Get FromAddress from the JSON data
@@ -130,7 +142,8 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 		fromAddress,
 		tx.IsWIP,
 		tx.IP,
-		tx.ReservedZKCounters); err != nil {
+		tx.ReservedZKCounters,
+		tx.IsPrivate); err != nil {
 		return err
 	}
 	return nil
@@ -200,9 +213,59 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context) ([]pool.T
 	return txs, nil
 }
 
-// GetPendingTxHashesSince returns the pending tx since the given time.
+// GetNonWIPPendingTxsByHashes returns the transactions with the provided hashes
+// that are pending and not WIP
+func (p *PostgresPoolStorage) GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]pool.Transaction, error) {
+	hh := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hh = append(hh, h.Hex())
+	}
+
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1 AND hash = ANY ($2)`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, hh)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(hashes))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
+// ListenNewTxs listens to the notifications sent by the pool.transaction trigger
+// every time a pending tx is stored, calling onNewTx with the hash of each tx
+func (p *PostgresPoolStorage) ListenNewTxs(ctx context.Context, onNewTx func(hash common.Hash)) error {
+	conn, err := p.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+newTxChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNewTx(common.HexToHash(notification.Payload))
+	}
+}
+
+// GetPendingTxHashesSince returns the public pending tx since the given time.
 func (p *PostgresPoolStorage) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
-	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2"
+	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2 AND is_private IS NOT TRUE"
 	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, since)
 	if err != nil {
 		return nil, err
@@ -440,6 +503,152 @@ func (p *PostgresPoolStorage) DeleteFailedTransactionsOlderThan(ctx context.Cont
 	return nil
 }
 
+// archiveColumns are the columns copied from pool.transaction to pool.transaction_archive
+const archiveColumns = `hash, encoded, decoded, status, gas_price, break_even_gas_price, nonce,
+	cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, reserved_zkcounters,
+	received_at, from_address, ip, failed_reason, l2_hash, is_private`
+
+// archiveTxsSQL moves the txs deleted by the provided condition to the archive,
+// the archiving time is the first parameter of the query
+const archiveTxsSQL = `
+	WITH archived AS (
+		DELETE FROM pool.transaction WHERE %s RETURNING *
+	)
+	INSERT INTO pool.transaction_archive (` + archiveColumns + `, archived_at)
+	SELECT ` + archiveColumns + `, $1 FROM archived
+	ON CONFLICT (hash) DO UPDATE SET
+		encoded = EXCLUDED.encoded,
+		decoded = EXCLUDED.decoded,
+		status = EXCLUDED.status,
+		gas_price = EXCLUDED.gas_price,
+		break_even_gas_price = EXCLUDED.break_even_gas_price,
+		nonce = EXCLUDED.nonce,
+		cumulative_gas_used = EXCLUDED.cumulative_gas_used,
+		used_keccak_hashes = EXCLUDED.used_keccak_hashes,
+		used_poseidon_hashes = EXCLUDED.used_poseidon_hashes,
+		used_poseidon_paddings = EXCLUDED.used_poseidon_paddings,
+		used_mem_aligns = EXCLUDED.used_mem_aligns,
+		used_arithmetics = EXCLUDED.used_arithmetics,
+		used_binaries = EXCLUDED.used_binaries,
+		used_steps = EXCLUDED.used_steps,
+		used_sha256_hashes = EXCLUDED.used_sha256_hashes,
+		reserved_zkcounters = EXCLUDED.reserved_zkcounters,
+		received_at = EXCLUDED.received_at,
+		from_address = EXCLUDED.from_address,
+		ip = EXCLUDED.ip,
+		failed_reason = EXCLUDED.failed_reason,
+		l2_hash = EXCLUDED.l2_hash,
+		is_private = pool.transaction_archive.is_private OR EXCLUDED.is_private,
+		archived_at = EXCLUDED.archived_at`
+
+// ArchiveTransactionsByHashes moves the txs from the pool to the archive by their hashes
+func (p *PostgresPoolStorage) ArchiveTransactionsByHashes(ctx context.Context, hashes []common.Hash, archivedAt time.Time) error {
+	hh := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		hh = append(hh, h.Hex())
+	}
+
+	sql := fmt.Sprintf(archiveTxsSQL, "hash = ANY ($2)")
+	if _, err := p.db.Exec(ctx, sql, archivedAt, hh); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ArchiveTransactionsOlderThan moves the txs with the provided status received
+// before the given date from the pool to the archive
+func (p *PostgresPoolStorage) ArchiveTransactionsOlderThan(ctx context.Context, status pool.TxStatus, date time.Time, archivedAt time.Time) error {
+	sql := fmt.Sprintf(archiveTxsSQL, "status = $2 AND received_at < $3")
+	if _, err := p.db.Exec(ctx, sql, archivedAt, status, date); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetArchivedTransactionByHash gets an archived transaction by its hash
+func (p *PostgresPoolStorage) GetArchivedTransactionByHash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	return p.getArchivedTransaction(ctx, "hash", hash)
+}
+
+// GetArchivedTransactionByL2Hash gets an archived transaction by its l2 hash
+func (p *PostgresPoolStorage) GetArchivedTransactionByL2Hash(ctx context.Context, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	return p.getArchivedTransaction(ctx, "l2_hash", hash)
+}
+
+// getArchivedTransaction gets an archived transaction by the provided hash column
+func (p *PostgresPoolStorage) getArchivedTransaction(ctx context.Context, hashColumn string, hash common.Hash) (*pool.ArchivedTransaction, error) {
+	var (
+		encoded, status, fromAddress string
+		ip, failedReason             *string
+		breakEvenGasPrice            *uint64
+		receivedAt, archivedAt       time.Time
+		usedZKCounters               state.ZKCounters
+		reservedZKCounters           *state.ZKCounters
+		isPrivate                    bool
+	)
+
+	sql := `SELECT encoded, status, break_even_gas_price, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+			used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes,
+			reserved_zkcounters, received_at, from_address, ip, failed_reason, archived_at, COALESCE(is_private, false)
+	          FROM pool.transaction_archive
+			 WHERE ` + hashColumn + ` = $1`
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &breakEvenGasPrice, &usedZKCounters.GasUsed,
+		&usedZKCounters.KeccakHashes, &usedZKCounters.PoseidonHashes, &usedZKCounters.PoseidonPaddings, &usedZKCounters.MemAligns,
+		&usedZKCounters.Arithmetics, &usedZKCounters.Binaries, &usedZKCounters.Steps, &usedZKCounters.Sha256Hashes_V2,
+		&reservedZKCounters, &receivedAt, &fromAddress, &ip, &failedReason, &archivedAt, &isPrivate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	b, err := hex.DecodeHex(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+
+	archivedTx := &pool.ArchivedTransaction{
+		Transaction: pool.Transaction{
+			Transaction:  *tx,
+			Status:       pool.TxStatus(status),
+			ZKCounters:   usedZKCounters,
+			ReceivedAt:   receivedAt,
+			FailedReason: failedReason,
+			IsPrivate:    isPrivate,
+		},
+		From:       common.HexToAddress(fromAddress),
+		ArchivedAt: archivedAt,
+	}
+	if reservedZKCounters != nil {
+		archivedTx.ReservedZKCounters = *reservedZKCounters
+	}
+	if ip != nil {
+		archivedTx.IP = *ip
+	}
+	if breakEvenGasPrice != nil {
+		archivedTx.BreakEvenGasPrice = new(big.Int).SetUint64(*breakEvenGasPrice)
+	}
+
+	return archivedTx, nil
+}
+
+// DeleteArchivedTransactionsOlderThan deletes the archived txs with the provided status
+// archived before the given date
+func (p *PostgresPoolStorage) DeleteArchivedTransactionsOlderThan(ctx context.Context, status pool.TxStatus, date time.Time) error {
+	sql := `DELETE FROM pool.transaction_archive WHERE status = $1 AND archived_at < $2`
+
+	if _, err := p.db.Exec(ctx, sql, status, date); err != nil {
+		return err
+	}
+	return nil
+}
+
 // SetGasPrices sets the latest l2 and l1 gas prices
 func (p *PostgresPoolStorage) SetGasPrices(ctx context.Context, l2GasPrice, l1GasPrice uint64) error {
 	sql := "INSERT INTO pool.gas_price (price, l1_price, timestamp) VALUES ($1, $2, $3)"
@@ -543,6 +752,47 @@ func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from com
 	return txs, nil
 }
 
+// GetEvictionCandidates gets the non wip pending txs with a gas price lower than maxGasPrice
+// sorted from the cheapest to the most expensive, flagging the txs whose nonce comes after a gap
+// in the pending nonces of the sender, which can't be executed until the gap is filled
+func (p *PostgresPoolStorage) GetEvictionCandidates(ctx context.Context, maxGasPrice uint64, limit uint64) ([]pool.EvictionCandidate, error) {
+	sql := `SELECT hash, from_address, nonce, gas_price, after_nonce_gap, lowest_sender_nonce
+	          FROM (
+			SELECT hash, from_address, nonce, gas_price, received_at, is_wip,
+			       nonce - DENSE_RANK() OVER (PARTITION BY from_address ORDER BY nonce) + 1 <> MIN(nonce) OVER (PARTITION BY from_address) AS after_nonce_gap,
+			       MIN(nonce) OVER (PARTITION BY from_address) AS lowest_sender_nonce
+			  FROM pool.transaction
+			 WHERE status = $1
+		  ) pending
+		 WHERE gas_price < $2
+		   AND is_wip IS FALSE
+		 ORDER BY gas_price ASC, received_at DESC
+		 LIMIT $3`
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, maxGasPrice, limit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []pool.EvictionCandidate
+	for rows.Next() {
+		var (
+			hash, from string
+			candidate  pool.EvictionCandidate
+		)
+		if err := rows.Scan(&hash, &from, &candidate.Nonce, &candidate.GasPrice, &candidate.AfterNonceGap, &candidate.LowestSenderNonce); err != nil {
+			return nil, err
+		}
+		candidate.Hash = common.HexToHash(hash)
+		candidate.From = common.HexToAddress(from)
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
 // GetTxFromAddressFromByHash gets tx from address by hash
 func (p *PostgresPoolStorage) GetTxFromAddressFromByHash(ctx context.Context, hash common.Hash) (common.Address, uint64, error) {
 	query := `SELECT from_address, nonce
@@ -602,13 +852,14 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	var (
 		encoded, status, ip string
 		receivedAt          time.Time
-		isWIP               bool
+		isWIP, isPrivate    bool
+		failedReason        *string
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, failed_reason, COALESCE(is_private, false)
 	          FROM pool.transaction
 			 WHERE hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &failedReason, &isPrivate)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -626,11 +877,13 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		FailedReason: failedReason,
+		IsPrivate:    isPrivate,
 	}
 
 	return poolTx, nil
@@ -641,13 +894,13 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 	var (
 		encoded, status, ip string
 		receivedAt          time.Time
-		isWIP               bool
+		isWIP, isPrivate    bool
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, COALESCE(is_private, false)
 	          FROM pool.transaction
 			 WHERE l2_hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &isPrivate)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -670,6 +923,7 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 		Transaction: *tx,
 		IsWIP:       isWIP,
 		IP:          ip,
+		IsPrivate:   isPrivate,
 	}
 
 	return poolTx, nil
@@ -802,6 +1056,89 @@ func (p *PostgresPoolStorage) GetAllAddressesBlocked(ctx context.Context) ([]com
 	return addrs, nil
 }
 
+// allowListTable returns the table that stores the provided allow-list
+func allowListTable(kind pool.AllowListKind) (string, error) {
+	switch kind {
+	case pool.AllowListSenders:
+		return "pool.whitelisted", nil
+	case pool.AllowListDeployers:
+		return "pool.whitelisted_deployer", nil
+	default:
+		return "", pool.ErrInvalidAllowListKind
+	}
+}
+
+// GetAllowListAddresses get all the addresses of the provided allow-list
+func (p *PostgresPoolStorage) GetAllowListAddresses(ctx context.Context, kind pool.AllowListKind) ([]common.Address, error) {
+	table, err := allowListTable(kind)
+	if err != nil {
+		return nil, err
+	}
+	sql := fmt.Sprintf("SELECT addr FROM %s", table)
+
+	rows, err := p.db.Query(ctx, sql)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		} else {
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	var addrs []common.Address
+	for rows.Next() {
+		var addr string
+		err := rows.Scan(&addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, common.HexToAddress(addr))
+	}
+
+	return addrs, nil
+}
+
+// AddAllowListAddresses adds the addresses to the provided allow-list,
+// addresses already in the list are ignored
+func (p *PostgresPoolStorage) AddAllowListAddresses(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	table, err := allowListTable(kind)
+	if err != nil {
+		return err
+	}
+
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, addr.String())
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s (addr) SELECT unnest($1::VARCHAR[]) ON CONFLICT (addr) DO NOTHING", table)
+	if _, err := p.db.Exec(ctx, sql, addrs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteAllowListAddresses removes the addresses from the provided allow-list
+func (p *PostgresPoolStorage) DeleteAllowListAddresses(ctx context.Context, kind pool.AllowListKind, addresses []common.Address) error {
+	table, err := allowListTable(kind)
+	if err != nil {
+		return err
+	}
+
+	// addresses can be inserted manually with any case
+	addrs := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		addrs = append(addrs, strings.ToLower(addr.String()))
+	}
+
+	sql := fmt.Sprintf("DELETE FROM %s WHERE lower(addr) = ANY ($1)", table)
+	if _, err := p.db.Exec(ctx, sql, addrs); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetEarliestProcessedTx gets the earliest processed tx from the pool. Mainly used for cleanup
 func (p *PostgresPoolStorage) GetEarliestProcessedTx(ctx context.Context) (common.Hash, error) {
 	const getEarliestProcessedTxnFromTxnPool = `SELECT hash
@@ -821,3 +1158,160 @@ func (p *PostgresPoolStorage) GetEarliestProcessedTx(ctx context.Context) (commo
 
 	return common.HexToHash(txnHash), nil
 }
+
+// AddReputationFailure adds a failure to the reputation of the provided sender or IP and returns
+// the updated reputation. The failures of a reputation whose window started before forgetBefore
+// are forgotten, starting a new window with this failure
+func (p *PostgresPoolStorage) AddReputationFailure(ctx context.Context, kind pool.ReputationKind, key string, failedAt time.Time, forgetBefore time.Time) (*pool.Reputation, error) {
+	const addReputationFailureSQL = `
+		INSERT INTO pool.reputation (kind, key, failures, window_start, last_failure_at)
+		VALUES ($1, $2, 1, $3, $3)
+		ON CONFLICT (kind, key) DO UPDATE SET
+			failures = CASE WHEN pool.reputation.window_start < $4 THEN 1 ELSE pool.reputation.failures + 1 END,
+			window_start = CASE WHEN pool.reputation.window_start < $4 THEN $3 ELSE pool.reputation.window_start END,
+			last_failure_at = $3
+		RETURNING failures, window_start, last_failure_at, banned_until`
+
+	reputation := pool.Reputation{Kind: kind, Key: key}
+	err := p.db.QueryRow(ctx, addReputationFailureSQL, kind, key, failedAt, forgetBefore).
+		Scan(&reputation.Failures, &reputation.WindowStart, &reputation.LastFailureAt, &reputation.BannedUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &reputation, nil
+}
+
+// BanReputation bans the provided sender or IP until the provided time
+func (p *PostgresPoolStorage) BanReputation(ctx context.Context, kind pool.ReputationKind, key string, until time.Time) error {
+	const banReputationSQL = "UPDATE pool.reputation SET banned_until = $3 WHERE kind = $1 AND key = $2"
+	if _, err := p.db.Exec(ctx, banReputationSQL, kind, key, until); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetReputations returns all the stored reputations
+func (p *PostgresPoolStorage) GetReputations(ctx context.Context) ([]pool.Reputation, error) {
+	const getReputationsSQL = "SELECT kind, key, failures, window_start, last_failure_at, banned_until FROM pool.reputation ORDER BY kind, key"
+	rows, err := p.db.Query(ctx, getReputationsSQL)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reputations := []pool.Reputation{}
+	for rows.Next() {
+		var (
+			kind       string
+			reputation pool.Reputation
+		)
+		if err := rows.Scan(&kind, &reputation.Key, &reputation.Failures, &reputation.WindowStart, &reputation.LastFailureAt, &reputation.BannedUntil); err != nil {
+			return nil, err
+		}
+		reputation.Kind = pool.ReputationKind(kind)
+		reputations = append(reputations, reputation)
+	}
+
+	return reputations, nil
+}
+
+// DeleteReputation deletes the reputation of the provided sender or IP
+func (p *PostgresPoolStorage) DeleteReputation(ctx context.Context, kind pool.ReputationKind, key string) error {
+	const deleteReputationSQL = "DELETE FROM pool.reputation WHERE kind = $1 AND key = $2"
+	if _, err := p.db.Exec(ctx, deleteReputationSQL, kind, key); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteReputationsOlderThan deletes the reputations without failures since the given date
+// that are not banned after it
+func (p *PostgresPoolStorage) DeleteReputationsOlderThan(ctx context.Context, date time.Time) error {
+	const deleteReputationsSQL = "DELETE FROM pool.reputation WHERE last_failure_at < $1 AND (banned_until IS NULL OR banned_until < $1)"
+	if _, err := p.db.Exec(ctx, deleteReputationsSQL, date); err != nil {
+		return err
+	}
+	return nil
+}
+
+// AddPreconfirmation stores the pre-confirmation of a tx, replacing the previous one of the tx
+func (p *PostgresPoolStorage) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	const addPreconfirmationSQL = `
+		INSERT INTO pool.preconfirmation (hash, l2_block_num, tx_index, status, signature, rescinded, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, false, $6, $6)
+		ON CONFLICT (hash) DO UPDATE SET
+			l2_block_num = EXCLUDED.l2_block_num, tx_index = EXCLUDED.tx_index, status = EXCLUDED.status, signature = EXCLUDED.signature,
+			rescinded = false, created_at = EXCLUDED.created_at, updated_at = EXCLUDED.updated_at`
+
+	_, err := p.db.Exec(ctx, addPreconfirmationSQL, preconfirmation.TxHash.Hex(), preconfirmation.L2BlockNumber, preconfirmation.Index,
+		preconfirmation.Status, preconfirmation.Signature, preconfirmation.CreatedAt)
+	return err
+}
+
+// RescindPreconfirmations rescinds the pre-confirmations of the txs planned in the L2 blocks from
+// the given number that are not rescinded yet, returning the hashes of the txs rescinded
+func (p *PostgresPoolStorage) RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64, rescindedAt time.Time) ([]common.Hash, error) {
+	const rescindPreconfirmationsSQL = `
+		UPDATE pool.preconfirmation SET rescinded = true, updated_at = $2
+		 WHERE l2_block_num >= $1 AND NOT rescinded
+		RETURNING hash`
+
+	rows, err := p.db.Query(ctx, rescindPreconfirmationsSQL, fromL2BlockNumber, rescindedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []common.Hash{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, common.HexToHash(hash))
+	}
+
+	return hashes, rows.Err()
+}
+
+// GetPreconfirmationByHash returns the pre-confirmation of a tx
+func (p *PostgresPoolStorage) GetPreconfirmationByHash(ctx context.Context, hash common.Hash) (*pool.Preconfirmation, error) {
+	const getPreconfirmationSQL = `
+		SELECT c.l2_block_num, c.tx_index, c.status, c.signature, c.rescinded, COALESCE(t.is_private, false), c.created_at, c.updated_at
+		  FROM pool.preconfirmation c
+		  JOIN pool.transaction t ON t.hash = c.hash
+		 WHERE c.hash = $1`
+
+	preconfirmation := pool.Preconfirmation{TxHash: hash}
+	err := p.db.QueryRow(ctx, getPreconfirmationSQL, hash.Hex()).Scan(&preconfirmation.L2BlockNumber, &preconfirmation.Index, &preconfirmation.Status,
+		&preconfirmation.Signature, &preconfirmation.Rescinded, &preconfirmation.IsPrivate, &preconfirmation.CreatedAt, &preconfirmation.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, pool.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &preconfirmation, nil
+}
+
+// ListenPreconfirmations listens to the notifications sent by the pool.preconfirmation trigger every
+// time a pre-confirmation is stored or rescinded, calling onPreconfirmation with the hash of each tx
+func (p *PostgresPoolStorage) ListenPreconfirmations(ctx context.Context, onPreconfirmation func(hash common.Hash)) error {
+	conn, err := p.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+preconfirmationChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onPreconfirmation(common.HexToHash(notification.Payload))
+	}
+}
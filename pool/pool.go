@@ -11,6 +11,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -40,18 +41,37 @@ var (
 // that uses a postgres database to store the data
 type Pool struct {
 	storage
-	state                   stateInterface
-	chainID                 uint64
-	cfg                     Config
-	batchConstraintsCfg     state.BatchConstraintsCfg
-	blockedAddresses        sync.Map
-	minSuggestedGasPrice    *big.Int
-	minSuggestedGasPriceMux *sync.RWMutex
-	eventLog                *event.EventLog
-	startTimestamp          time.Time
-	gasPrices               GasPrices
-	gasPricesMux            *sync.RWMutex
-	effectiveGasPrice       *EffectiveGasPrice
+	state                          stateInterface
+	chainID                        uint64
+	cfg                            Config
+	batchCfg                       state.BatchConfig
+	blockedAddresses               sync.Map
+	allowedSenders                 sync.Map
+	allowedDeployers               sync.Map
+	reputations                    sync.Map
+	reputationLastTx               sync.Map
+	minSuggestedGasPrice           *big.Int
+	minSuggestedGasPriceMux        *sync.RWMutex
+	eventLog                       *event.EventLog
+	startTimestamp                 time.Time
+	gasPrices                      GasPrices
+	gasPricesMux                   *sync.RWMutex
+	effectiveGasPrice              *EffectiveGasPrice
+	newTxsSubscribers              map[chan common.Hash]struct{}
+	newTxsSubscribersMux           *sync.Mutex
+	listeningNewTxs                bool
+	preconfirmationsSubscribers    map[chan Preconfirmation]struct{}
+	preconfirmationsSubscribersMux *sync.Mutex
+	listeningPreconfirmations      bool
+	txValidators                   []forkTxValidator
+	txValidatorsMux                *sync.RWMutex
+	sponsoredClaims                []sponsoredClaim
+	sponsoredClaimsByRecipient     map[common.Address]uint64
+	sponsoredClaimsMux             *sync.Mutex
+	preExecutionCache              map[preExecutionCacheKey]preExecutionCacheEntry
+	preExecutionCacheMux           *sync.Mutex
+	preExecutionRequests           chan preExecutionRequest
+	txStatusFailures               chan txStatusFailure
 }
 
 type preExecutionResponse struct {
@@ -71,22 +91,41 @@ type GasPrices struct {
 }
 
 // NewPool creates and initializes an instance of Pool
-func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storage, st stateInterface, chainID uint64, eventLog *event.EventLog) *Pool {
+func NewPool(cfg Config, batchCfg state.BatchConfig, s Storage, st stateInterface, chainID uint64, eventLog *event.EventLog) *Pool {
 	startTimestamp := time.Now()
 	p := &Pool{
-		cfg:                     cfg,
-		batchConstraintsCfg:     batchConstraintsCfg,
-		startTimestamp:          startTimestamp,
-		storage:                 s,
-		state:                   st,
-		chainID:                 chainID,
-		blockedAddresses:        sync.Map{},
-		minSuggestedGasPriceMux: new(sync.RWMutex),
-		minSuggestedGasPrice:    big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
-		eventLog:                eventLog,
-		gasPrices:               GasPrices{0, 0},
-		gasPricesMux:            new(sync.RWMutex),
-		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		cfg:                            cfg,
+		batchCfg:                       batchCfg,
+		startTimestamp:                 startTimestamp,
+		storage:                        s,
+		state:                          st,
+		chainID:                        chainID,
+		blockedAddresses:               sync.Map{},
+		minSuggestedGasPriceMux:        new(sync.RWMutex),
+		minSuggestedGasPrice:           big.NewInt(int64(cfg.DefaultMinGasPriceAllowed)),
+		eventLog:                       eventLog,
+		gasPrices:                      GasPrices{0, 0},
+		gasPricesMux:                   new(sync.RWMutex),
+		effectiveGasPrice:              NewEffectiveGasPrice(cfg.EffectiveGasPrice),
+		newTxsSubscribers:              map[chan common.Hash]struct{}{},
+		newTxsSubscribersMux:           new(sync.Mutex),
+		preconfirmationsSubscribers:    map[chan Preconfirmation]struct{}{},
+		preconfirmationsSubscribersMux: new(sync.Mutex),
+		txValidatorsMux:                new(sync.RWMutex),
+		sponsoredClaimsByRecipient:     map[common.Address]uint64{},
+		sponsoredClaimsMux:             new(sync.Mutex),
+		preExecutionCache:              map[preExecutionCacheKey]preExecutionCacheEntry{},
+		preExecutionCacheMux:           new(sync.Mutex),
+		preExecutionRequests:           make(chan preExecutionRequest),
+		txStatusFailures:               make(chan txStatusFailure, txStatusFailuresQueueSize),
+	}
+	metrics.Register()
+	p.registerDefaultTxValidators()
+	if cfg.PreExecution.BatchingEnabled {
+		go p.batchPreExecutions()
+	}
+	if cfg.Reputation.Enabled {
+		go p.recordTxStatusFailures()
 	}
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
@@ -174,18 +213,56 @@ func (p *Pool) StartPollingMinSuggestedGasPrice(ctx context.Context) {
 
 // AddTx adds a transaction to the pool with the pending state
 func (p *Pool) AddTx(ctx context.Context, tx types.Transaction, ip string) error {
+	return p.addTx(ctx, tx, ip, false)
+}
+
+// AddPrivateTx adds a transaction to the pool with the pending state, hiding it from
+// the public views of the pool until it is included in a block. It is selected by the
+// sequencer like any other tx
+func (p *Pool) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error {
+	return p.addTx(ctx, tx, ip, true)
+}
+
+func (p *Pool) addTx(ctx context.Context, tx types.Transaction, ip string, isPrivate bool) error {
 	poolTx := NewTransaction(tx, ip, false)
+	poolTx.IsPrivate = isPrivate
 	if err := p.validateTx(ctx, *poolTx); err != nil {
 		return err
 	}
 
-	return p.StoreTx(ctx, tx, ip, false)
+	displaced, err := p.checkTxDisplacement(ctx, *poolTx)
+	if err != nil {
+		return err
+	}
+
+	if err := p.storeTx(ctx, tx, ip, false, isPrivate); err != nil {
+		return err
+	}
+
+	p.removeDisplacedTxs(ctx, *poolTx, displaced)
+	if from, err := state.GetSender(tx); err == nil {
+		p.trackReputationTx(*poolTx, from)
+	}
+	return nil
 }
 
 // StoreTx adds a transaction to the pool with the pending state
 func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool) error {
+	return p.storeTx(ctx, tx, ip, isWIP, false)
+}
+
+// getBatchConstraints returns the batch constraints of the fork the next batch will be executed under
+func (p *Pool) getBatchConstraints(ctx context.Context) (state.BatchConstraintsCfg, error) {
+	constraints, err := p.batchCfg.NextBatchConstraints(ctx, p.state)
+	if err != nil {
+		return state.BatchConstraintsCfg{}, fmt.Errorf("failed to get the batch constraints, error: %v", err)
+	}
+	return constraints, nil
+}
+
+func (p *Pool) storeTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool, isPrivate bool) error {
 	// Execute transaction to calculate its zkCounters
-	preExecutionResponse, err := p.preExecuteTx(ctx, tx)
+	preExecutionResponse, err := p.preExecute(ctx, tx)
 	if errors.Is(err, runtime.ErrIntrinsicInvalidBatchGasLimit) {
 		return ErrGasLimit
 	} else if preExecutionResponse.isExecutorLevelError {
@@ -200,7 +277,11 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 	if preExecutionResponse.OOCError != nil {
 		oocError = preExecutionResponse.OOCError
 	} else {
-		if err = p.batchConstraintsCfg.CheckNodeLevelOOC(preExecutionResponse.reservedZKCounters); err != nil {
+		batchConstraints, err := p.getBatchConstraints(ctx)
+		if err != nil {
+			return err
+		}
+		if err = batchConstraints.CheckNodeLevelOOC(preExecutionResponse.reservedZKCounters); err != nil {
 			oocError = err
 		}
 	}
@@ -220,6 +301,7 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		if err != nil {
 			log.Errorf("error adding event: %v", err)
 		}
+		p.recordPreExecutionFailure(ctx, tx, ip, oocError)
 		// Do not add tx to the pool
 		return fmt.Errorf("failed to add tx to the pool: %w", oocError)
 	} else if preExecutionResponse.OOGError != nil {
@@ -237,6 +319,7 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		if err != nil {
 			log.Errorf("error adding event: %v", err)
 		}
+		p.recordPreExecutionFailure(ctx, tx, ip, preExecutionResponse.OOGError)
 	}
 
 	gasPrices, err := p.GetGasPrices(ctx)
@@ -244,17 +327,33 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 		return err
 	}
 
-	err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
-	if err != nil {
-		return err
+	// sponsored claims pay no fees, so there is no break even gas price to validate,
+	// but they must succeed to not use the sequencer resources for free
+	if p.IsSponsoredClaim(tx) {
+		if preExecutionResponse.isReverted || preExecutionResponse.OOGError != nil {
+			return ErrSponsoredClaimFailed
+		}
+	} else {
+		err = p.ValidateBreakEvenGasPrice(ctx, tx, preExecutionResponse.txResponse.GasUsed, gasPrices)
+		if err != nil {
+			return err
+		}
 	}
 
 	poolTx := NewTransaction(tx, ip, isWIP)
+	poolTx.IsPrivate = isPrivate
 	poolTx.GasUsed = preExecutionResponse.txResponse.GasUsed
 	poolTx.ZKCounters = preExecutionResponse.usedZKCounters
 	poolTx.ReservedZKCounters = preExecutionResponse.reservedZKCounters
 
-	return p.storage.AddTx(ctx, *poolTx)
+	if err := p.storage.AddTx(ctx, *poolTx); err != nil {
+		return err
+	}
+
+	if !isWIP {
+		p.notifyNewTx(poolTx.Hash())
+	}
+	return nil
 }
 
 // ValidateBreakEvenGasPrice validates the effective gas price
@@ -306,6 +405,7 @@ func (p *Pool) ValidateBreakEvenGasPrice(ctx context.Context, tx types.Transacti
 
 // preExecuteTx executes a transaction to calculate its zkCounters
 func (p *Pool) preExecuteTx(ctx context.Context, tx types.Transaction) (preExecutionResponse, error) {
+	metrics.PreExecutionCall()
 	response := preExecutionResponse{usedZKCounters: state.ZKCounters{}, reservedZKCounters: state.ZKCounters{}, OOCError: nil, OOGError: nil, isReverted: false}
 
 	// TODO: Add effectivePercentage = 0xFF to the request (factor of 1) when gRPC message is updated
@@ -369,7 +469,8 @@ func (p *Pool) GetSelectedTxs(ctx context.Context, limit uint64) ([]Transaction,
 	return p.storage.GetTxsByStatus(ctx, TxStatusSelected, limit)
 }
 
-// GetPendingTxHashesSince returns the hashes of pending tx since the given date.
+// GetPendingTxHashesSince returns the hashes of the public pending tx since the given date,
+// the private txs are excluded
 func (p *Pool) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
 	return p.storage.GetPendingTxHashesSince(ctx, since)
 }
@@ -377,12 +478,18 @@ func (p *Pool) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]
 // UpdateTxStatus updates a transaction state accordingly to the
 // provided state and hash
 func (p *Pool) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus TxStatus, isWIP bool, failedReason *string) error {
-	return p.storage.UpdateTxStatus(ctx, TxStatusUpdateInfo{
+	err := p.storage.UpdateTxStatus(ctx, TxStatusUpdateInfo{
 		Hash:         hash,
 		NewStatus:    newStatus,
 		IsWIP:        isWIP,
 		FailedReason: failedReason,
 	})
+	if err != nil {
+		return err
+	}
+
+	p.recordTxStatusFailure(hash, newStatus, failedReason)
+	return nil
 }
 
 // SetGasPrices sets the current L2 Gas Price and L1 Gas Price
@@ -429,9 +536,15 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrInvalidChainID
 	}
 
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if poolTx.Type() != types.LegacyTxType {
-		return ErrTxTypeNotSupported
+	// fork IDs the tx may be executed in, to check the rules of each of them
+	forkIDs, err := p.txForkIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Accept only the tx types supported by the forks
+	if err := p.validateTxForForks(ctx, poolTx, forkIDs, TxValidationStageType); err != nil {
+		return err
 	}
 
 	// check Pre EIP155 txs signature
@@ -445,15 +558,9 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrInvalidSender
 	}
 
-	// Reject transactions over defined size to prevent DOS attacks
-	decodedTx, err := state.EncodeTransaction(poolTx.Transaction, 0xFF, p.cfg.ForkID) //nolint: gomnd
-	if err != nil {
-		return ErrTxTypeNotSupported
-	}
-
-	if uint64(len(decodedTx)) > p.cfg.MaxTxBytesSize {
-		log.Infof("%v: %v", ErrOversizedData.Error(), from.String())
-		return ErrOversizedData
+	// Reject transactions over the size defined by the forks to prevent DOS attacks
+	if err := p.validateTxForForks(ctx, poolTx, forkIDs, TxValidationStageSize); err != nil {
+		return err
 	}
 
 	// Transactions can't be negative. This may never happen using RLP decoded
@@ -473,6 +580,24 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrBlockedSender
 	}
 
+	// check if sender or IP are banned or throttled
+	if err := p.checkReputation(poolTx, from); err != nil {
+		return err
+	}
+
+	// check if sender is allowed when running in permissioned mode
+	if err := p.checkAllowLists(ctx, poolTx, from); err != nil {
+		return err
+	}
+
+	// sponsored bridge claims are accepted without fees within the rate limits
+	recipient, sponsored := p.sponsoredClaimRecipient(poolTx.Transaction)
+	if sponsored {
+		if err := p.acceptSponsoredClaim(recipient); err != nil {
+			return err
+		}
+	}
+
 	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
 	if err != nil {
 		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
@@ -506,39 +631,29 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		}
 	}
 
-	// check if the pool is full
-	if p.cfg.GlobalQueue > 0 {
-		txCount, err := p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
-		if err != nil {
-			log.Errorf("failed to count pool txs by status pending while adding tx to the pool", err)
-			return err
+	if !sponsored {
+		// Reject transactions with a gas price lower than the minimum gas price
+		p.minSuggestedGasPriceMux.RLock()
+		gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
+		if gasPriceCmp == -1 {
+			log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
 		}
-		if txCount >= p.cfg.GlobalQueue {
-			return ErrTxPoolOverflow
+		p.minSuggestedGasPriceMux.RUnlock()
+		if gasPriceCmp == -1 {
+			return ErrGasPrice
 		}
-	}
-
-	// Reject transactions with a gas price lower than the minimum gas price
-	p.minSuggestedGasPriceMux.RLock()
-	gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
-	if gasPriceCmp == -1 {
-		log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
-	}
-	p.minSuggestedGasPriceMux.RUnlock()
-	if gasPriceCmp == -1 {
-		return ErrGasPrice
-	}
 
-	// Transactor should have enough funds to cover the costs
-	// cost == V + GP * GL
-	balance, err := p.state.GetBalance(ctx, from, lastL2Block.Root())
-	if err != nil {
-		log.Errorf("failed to get balance for account %v while adding tx to the pool", from.String(), err)
-		return err
-	}
+		// Transactor should have enough funds to cover the costs
+		// cost == V + GP * GL
+		balance, err := p.state.GetBalance(ctx, from, lastL2Block.Root())
+		if err != nil {
+			log.Errorf("failed to get balance for account %v while adding tx to the pool", from.String(), err)
+			return err
+		}
 
-	if balance.Cmp(poolTx.Cost()) < 0 {
-		return ErrInsufficientFunds
+		if balance.Cmp(poolTx.Cost()) < 0 {
+			return ErrInsufficientFunds
+		}
 	}
 
 	// Ensure the transaction has more gas than the basic poolTx fee.
@@ -550,38 +665,8 @@ func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
 		return ErrIntrinsicGas
 	}
 
-	// try to get a transaction from the pool with the same nonce to check
-	// if the new one has a price bump
-	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
-	if err != nil {
-		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
-		return err
-	}
-
-	// check if the new transaction has more gas than all the other txs in the pool
-	// with the same from and nonce to be able to replace the current txs by the new
-	// when being selected
-	for _, oldTx := range oldTxs {
-		// discard invalid txs
-		if oldTx.Status == TxStatusInvalid || oldTx.Status == TxStatusFailed {
-			continue
-		}
-
-		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
-		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
-
-		if oldTx.Hash() == poolTx.Hash() {
-			return ErrAlreadyKnown
-		}
-
-		// if old Tx Price is higher than the new poolTx price, it returns an error
-		if oldTxPrice.Cmp(txPrice) > 0 {
-			return ErrReplaceUnderpriced
-		}
-	}
-
 	// Executor field size requirements check
-	if err := p.checkTxFieldCompatibilityWithExecutor(ctx, poolTx.Transaction); err != nil {
+	if err := p.validateTxForForks(ctx, poolTx, forkIDs, TxValidationStageExecutor); err != nil {
 		return err
 	}
 
@@ -621,36 +706,6 @@ func (p *Pool) tryUpdateMinSuggestedGasPrice(minSuggestedGasPrice uint64) {
 	p.minSuggestedGasPriceMux.Unlock()
 }
 
-// checkTxFieldCompatibilityWithExecutor checks the field sizes of the transaction to make sure
-// they ar compatible with the Executor needs
-// GasLimit: 256 bits
-// GasPrice: 256 bits
-// Value: 256 bits
-// Data: 30000 bytes
-// Nonce: 64 bits
-// To: 160 bits
-// ChainId: 64 bits
-func (p *Pool) checkTxFieldCompatibilityWithExecutor(ctx context.Context, tx types.Transaction) error {
-	maxUint64BigInt := big.NewInt(0).SetUint64(math.MaxUint64)
-
-	// GasLimit, Nonce and To fields are limited by their types, no need to check
-	// Gas Price and Value are checked against the balance, and the max balance allowed
-	// by the merkletree service is uint256, in this case, if the transaction has a
-	// gas price or value bigger than uint256, the check against the balance will
-	// reject the transaction
-
-	dataSize := len(tx.Data())
-	if dataSize > p.cfg.MaxTxDataBytesSize {
-		return fmt.Errorf("data size bigger than allowed, current size is %v bytes and max allowed is %v bytes", dataSize, p.cfg.MaxTxDataBytesSize)
-	}
-
-	if tx.ChainId().Cmp(maxUint64BigInt) == 1 {
-		return fmt.Errorf("chain id higher than allowed, max allowed is %v", uint64(math.MaxUint64))
-	}
-
-	return nil
-}
-
 // DeleteReorgedTransactions deletes transactions from the pool
 func (p *Pool) DeleteReorgedTransactions(ctx context.Context, transactions []*types.Transaction) error {
 	hashes := []common.Hash{}
@@ -67,6 +67,7 @@ var (
 		IntervalToRefreshGasPrices:        cfgTypes.NewDuration(5 * time.Second),
 		AccountQueue:                      15,
 		GlobalQueue:                       20,
+		PriceBump:                         10,
 		TxFeeCap:                          1,
 		EffectiveGasPrice: pool.EffectiveGasPriceCfg{
 			Enabled:                     true,
@@ -85,18 +86,20 @@ var (
 	l1GasPrice = big.NewInt(1000000000000)
 	gasLimit   = uint64(21000)
 	chainID    = big.NewInt(1337)
-	bc         = state.BatchConstraintsCfg{
-		MaxTxsPerBatch:       300,
-		MaxBatchBytesSize:    120000,
-		MaxCumulativeGasUsed: 30000000,
-		MaxKeccakHashes:      2145,
-		MaxPoseidonHashes:    252357,
-		MaxPoseidonPaddings:  135191,
-		MaxMemAligns:         236585,
-		MaxArithmetics:       236585,
-		MaxBinaries:          473170,
-		MaxSteps:             7570538,
-		MaxSHA256Hashes:      1596,
+	bc         = state.BatchConfig{
+		Constraints: state.BatchConstraintsCfg{
+			MaxTxsPerBatch:       300,
+			MaxBatchBytesSize:    120000,
+			MaxCumulativeGasUsed: 30000000,
+			MaxKeccakHashes:      2145,
+			MaxPoseidonHashes:    252357,
+			MaxPoseidonPaddings:  135191,
+			MaxMemAligns:         236585,
+			MaxArithmetics:       236585,
+			MaxBinaries:          473170,
+			MaxSteps:             7570538,
+			MaxSHA256Hashes:      1596,
+		},
 	}
 	ip = "101.1.50.20"
 )
@@ -1543,6 +1546,132 @@ func Test_BlockedAddress(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_AllowList(t *testing.T) {
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	st := newState(stateSqlDB, eventLog)
+
+	auth := operations.MustGetAuth(operations.DefaultSequencerPrivateKey, chainID.Uint64())
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: auth.From.String(),
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	cfg := pool.Config{
+		MaxTxBytesSize:                    30132,
+		MaxTxDataBytesSize:                30000,
+		MinAllowedGasPriceInterval:        cfgTypes.NewDuration(5 * time.Minute),
+		PollMinAllowedGasPriceInterval:    cfgTypes.NewDuration(15 * time.Second),
+		DefaultMinGasPriceAllowed:         1000000000,
+		IntervalToRefreshBlockedAddresses: cfgTypes.NewDuration(5 * time.Second),
+		IntervalToRefreshGasPrices:        cfgTypes.NewDuration(5 * time.Second),
+		AccountQueue:                      64,
+		GlobalQueue:                       1024,
+		AllowList: pool.AllowListCfg{
+			SendersEnabled:    true,
+			DeployersEnabled:  true,
+			IntervalToRefresh: cfgTypes.NewDuration(5 * time.Second),
+		},
+	}
+
+	p := setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+	p.StartRefreshingAllowListsPeriodically()
+
+	gasPrices, err := p.GetGasPrices(ctx)
+	require.NoError(t, err)
+
+	transfer, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    0,
+		GasPrice: big.NewInt(0).SetInt64(int64(gasPrices.L2GasPrice)),
+		Gas:      24000,
+		To:       &auth.From,
+		Value:    big.NewInt(1000),
+	}))
+	require.NoError(t, err)
+
+	deployment, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    1,
+		GasPrice: big.NewInt(0).SetInt64(int64(gasPrices.L2GasPrice)),
+		Gas:      100000,
+		Data:     common.Hex2Bytes("6080"),
+	}))
+	require.NoError(t, err)
+
+	// sender not allowed
+	err = p.AddTx(ctx, *transfer, ip)
+	require.Equal(t, pool.ErrSenderNotAllowed, err)
+
+	// allow sender, the in memory list is refreshed right away
+	err = p.AddToAllowList(ctx, pool.AllowListSenders, []common.Address{auth.From}, ip)
+	require.NoError(t, err)
+
+	addresses, err := p.GetAllowList(ctx, pool.AllowListSenders)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{auth.From}, addresses)
+
+	err = p.AddTx(ctx, *transfer, ip)
+	require.NoError(t, err)
+
+	// deployer not allowed
+	err = p.AddTx(ctx, *deployment, ip)
+	require.Equal(t, pool.ErrDeployerNotAllowed, err)
+
+	// allow deployer
+	err = p.AddToAllowList(ctx, pool.AllowListDeployers, []common.Address{auth.From}, ip)
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *deployment, ip)
+	require.NoError(t, err)
+
+	// remove sender
+	err = p.RemoveFromAllowList(ctx, pool.AllowListSenders, []common.Address{auth.From}, ip)
+	require.NoError(t, err)
+
+	transfer, err = auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    2,
+		GasPrice: big.NewInt(0).SetInt64(int64(gasPrices.L2GasPrice)),
+		Gas:      24000,
+		To:       &auth.From,
+		Value:    big.NewInt(1000),
+	}))
+	require.NoError(t, err)
+
+	err = p.AddTx(ctx, *transfer, ip)
+	require.Equal(t, pool.ErrSenderNotAllowed, err)
+}
+
 /*
 func Test_AddTx_GasOverBatchLimit(t *testing.T) {
 	testCases := []struct {
@@ -1831,6 +1960,204 @@ func Test_AddTx_GlobalQueueLimit(t *testing.T) {
 	require.Error(t, err, pool.ErrTxPoolOverflow)
 }
 
+func Test_AddTx_GlobalQueueEviction(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+	require.NoError(t, err)
+	defer poolSqlDB.Close() //nolint:gosec,errcheck
+
+	st := newState(stateSqlDB, eventLog)
+
+	otherPrivateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddress := crypto.PubkeyToAddress(otherPrivateKey.PublicKey)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+			{
+				Address: otherAddress.String(),
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	evictionCfg := cfg
+	evictionCfg.GlobalQueue = 2
+	p := setupPool(t, evictionCfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+	otherAuth, err := bind.NewKeyedTransactorWithChainID(otherPrivateKey, chainID)
+	require.NoError(t, err)
+
+	signTx := func(auth *bind.TransactOpts, nonce uint64, gasPrice *big.Int) *ethTypes.Transaction {
+		signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+			Nonce:    nonce,
+			Value:    big.NewInt(0),
+			Gas:      uint64(1000000),
+			GasPrice: gasPrice,
+		}))
+		require.NoError(t, err)
+		return signedTx
+	}
+
+	// fill the pool with an executable tx and a tx after a nonce gap
+	executableTx := signTx(auth, 0, gasPrice)
+	require.NoError(t, p.AddTx(ctx, *executableTx, ip))
+	futureTx := signTx(auth, 2, gasPrice)
+	require.NoError(t, p.AddTx(ctx, *futureTx, ip))
+
+	// a tx paying the same as the non-executable tx is rejected
+	err = p.AddTx(ctx, *signTx(otherAuth, 0, gasPrice), ip)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+
+	// a better paying tx evicts the non-executable tx
+	betterTx := signTx(otherAuth, 0, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	require.NoError(t, p.AddTx(ctx, *betterTx, ip))
+
+	evictedTx, err := p.GetTransactionByHash(ctx, futureTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, pool.TxStatusFailed, evictedTx.Status)
+	require.NotNil(t, evictedTx.FailedReason)
+	require.Equal(t, fmt.Sprintf("%s: %s", pool.ErrTxEvicted.Error(), betterTx.Hash().String()), *evictedTx.FailedReason)
+
+	// executable txs are never evicted
+	err = p.AddTx(ctx, *signTx(otherAuth, 1, new(big.Int).Mul(gasPrice, big.NewInt(3))), ip)
+	require.ErrorIs(t, err, pool.ErrTxPoolOverflow)
+}
+
+func Test_AddTx_ReplaceByFee(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	if err != nil {
+		panic(err)
+	}
+	defer stateSqlDB.Close() //nolint:gosec,errcheck
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	genesis := state.Genesis{
+		Actions: []*state.GenesisAction{
+			{
+				Address: senderAddress,
+				Type:    int(merkletree.LeafTypeBalance),
+				Value:   "1000000000000000000000",
+			},
+		},
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, genesis, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	p := setupPool(t, cfg, bc, s, st, chainID.Uint64(), ctx, eventLog)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signTx := func(gasPrice *big.Int) *ethTypes.Transaction {
+		signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+			Nonce:    0,
+			Value:    big.NewInt(0),
+			Gas:      uint64(1000000),
+			GasPrice: gasPrice,
+		}))
+		require.NoError(t, err)
+		return signedTx
+	}
+
+	tx := signTx(gasPrice)
+	require.NoError(t, p.AddTx(ctx, *tx, ip))
+
+	err = p.AddTx(ctx, *tx, ip)
+	require.ErrorIs(t, err, pool.ErrAlreadyKnown)
+
+	// 5% bump is not enough
+	err = p.AddTx(ctx, *signTx(new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(105)), big.NewInt(100))), ip)
+	require.ErrorIs(t, err, pool.ErrReplaceUnderpriced)
+
+	// 10% bump replaces the tx
+	replacementTx := signTx(new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(110)), big.NewInt(100)))
+	require.NoError(t, p.AddTx(ctx, *replacementTx, ip))
+
+	replacedTx, err := p.GetTransactionByHash(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, pool.TxStatusFailed, replacedTx.Status)
+	require.NotNil(t, replacedTx.FailedReason)
+	require.Equal(t, fmt.Sprintf("%s: %s", pool.ErrTxReplaced.Error(), replacementTx.Hash().String()), *replacedTx.FailedReason)
+
+	pendingTxs, err := p.GetPendingTxs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, pendingTxs, 1)
+	require.Equal(t, replacementTx.Hash(), pendingTxs[0].Hash())
+
+	// a wip tx is left pending to be replaced by the sequencer
+	require.NoError(t, p.UpdateTxWIPStatus(ctx, replacementTx.Hash(), true))
+	wipReplacementTx := signTx(new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(121)), big.NewInt(100)))
+	require.NoError(t, p.AddTx(ctx, *wipReplacementTx, ip))
+
+	wipTx, err := p.GetTransactionByHash(ctx, replacementTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, pool.TxStatusPending, wipTx.Status)
+	require.True(t, wipTx.IsWIP)
+}
+
 func Test_AddTx_NonceTooHigh(t *testing.T) {
 	eventStorage, err := nileventstorage.NewNilEventStorage()
 	if err != nil {
@@ -2077,10 +2404,10 @@ func Test_AddTx_TxFeeCap(t *testing.T) {
 	}
 }
 
-func setupPool(t *testing.T, cfg pool.Config, constraintsCfg state.BatchConstraintsCfg, s *pgpoolstorage.PostgresPoolStorage, st *state.State, chainID uint64, ctx context.Context, eventLog *event.EventLog) *pool.Pool {
+func setupPool(t *testing.T, cfg pool.Config, batchCfg state.BatchConfig, s *pgpoolstorage.PostgresPoolStorage, st *state.State, chainID uint64, ctx context.Context, eventLog *event.EventLog) *pool.Pool {
 	err := s.SetGasPrices(ctx, gasPrice.Uint64(), l1GasPrice.Uint64())
 	require.NoError(t, err)
-	p := pool.NewPool(cfg, constraintsCfg, s, st, chainID, eventLog)
+	p := pool.NewPool(cfg, batchCfg, s, st, chainID, eventLog)
 	p.StartPollingMinSuggestedGasPrice(ctx)
 	return p
 }
@@ -0,0 +1,172 @@
+package pool
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// preconfirmationsSubscriptionBufferSize is the number of pre-confirmations kept for a
+	// subscriber that is not reading them, the next ones are dropped
+	preconfirmationsSubscriptionBufferSize = 1024
+	// preconfirmationsListenRetryInterval is the time to wait before listening again to the
+	// storage notifications after an error
+	preconfirmationsListenRetryInterval = 5 * time.Second
+)
+
+// PreconfirmationSigningDomain prefixes the data signed in the pre-confirmations, so their
+// signatures can not be taken as the signature of any other message
+const PreconfirmationSigningDomain = "zkevm-node pre-confirmation"
+
+// Preconfirmation is the commitment signed by the sequencer to include a tx in a
+// L2 block as soon as the tx is executed in the wip L2 block, before the L2 block
+// is closed and stored. It is rescinded if the tx is dropped by a L2 block reorg
+type Preconfirmation struct {
+	TxHash common.Hash
+	// L2BlockNumber is the number of the L2 block in which the tx is planned to be included
+	L2BlockNumber uint64
+	// Index is the position of the tx in the L2 block
+	Index uint64
+	// Status is the execution status of the tx, with the same values of the receipt status
+	Status uint64
+	// Signature is the signature of the SigningHash by the sequencer
+	Signature []byte
+	// Rescinded is set when the tx has been dropped from the planned L2 block
+	Rescinded bool
+	// IsPrivate is set when the tx was added to the pool as private
+	IsPrivate bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SigningHash returns the hash signed by the sequencer of the L2 chain: the keccak256 of the
+// PreconfirmationSigningDomain, the L2 chain ID as 8 bytes big endian, the tx hash and then
+// the L2 block number, the index and the status as 8 bytes big endian. The chain ID keeps
+// a pre-confirmation from being replayed on another chain
+func (p *Preconfirmation) SigningHash(chainID uint64) common.Hash {
+	data := make([]byte, 0, len(PreconfirmationSigningDomain)+common.HashLength+32) //nolint:gomnd
+	data = append(data, PreconfirmationSigningDomain...)
+	data = binary.BigEndian.AppendUint64(data, chainID)
+	data = append(data, p.TxHash.Bytes()...)
+	data = binary.BigEndian.AppendUint64(data, p.L2BlockNumber)
+	data = binary.BigEndian.AppendUint64(data, p.Index)
+	data = binary.BigEndian.AppendUint64(data, p.Status)
+	return crypto.Keccak256Hash(data)
+}
+
+// Signer returns the address that signed the pre-confirmation for the L2 chain
+func (p *Preconfirmation) Signer(chainID uint64) (common.Address, error) {
+	pubKey, err := crypto.SigToPub(p.SigningHash(chainID).Bytes(), p.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// PreconfirmationsListener is implemented by the storages able to notify the
+// pre-confirmations added or rescinded by any process sharing the storage
+type PreconfirmationsListener interface {
+	// ListenPreconfirmations calls onPreconfirmation with the tx hash of every pre-confirmation
+	// added or rescinded until the context is done or the notifications can not be received anymore
+	ListenPreconfirmations(ctx context.Context, onPreconfirmation func(hash common.Hash)) error
+}
+
+// AddPreconfirmation stores the pre-confirmation of a tx, replacing the previous one of the tx
+func (p *Pool) AddPreconfirmation(ctx context.Context, preconfirmation Preconfirmation) error {
+	err := p.storage.AddPreconfirmation(ctx, preconfirmation)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := p.storage.(PreconfirmationsListener); !ok {
+		p.notifyPreconfirmation(preconfirmation.TxHash)
+	}
+	return nil
+}
+
+// RescindPreconfirmations rescinds the pre-confirmations of the txs planned in the L2 blocks
+// from the given number, returning the hashes of the txs rescinded
+func (p *Pool) RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64) ([]common.Hash, error) {
+	hashes, err := p.storage.RescindPreconfirmations(ctx, fromL2BlockNumber, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := p.storage.(PreconfirmationsListener); !ok {
+		for _, hash := range hashes {
+			p.notifyPreconfirmation(hash)
+		}
+	}
+	return hashes, nil
+}
+
+// SubscribePreconfirmations returns a channel that receives the pre-confirmations added or
+// rescinded until the context is done, including the ones of other processes sharing the
+// storage if the storage supports it. The notifications are best effort: they are dropped
+// when the subscriber does not keep up
+func (p *Pool) SubscribePreconfirmations(ctx context.Context) <-chan Preconfirmation {
+	ch := make(chan Preconfirmation, preconfirmationsSubscriptionBufferSize)
+
+	p.preconfirmationsSubscribersMux.Lock()
+	p.preconfirmationsSubscribers[ch] = struct{}{}
+	if listener, ok := p.storage.(PreconfirmationsListener); ok && !p.listeningPreconfirmations {
+		p.listeningPreconfirmations = true
+		go p.listenPreconfirmations(listener)
+	}
+	p.preconfirmationsSubscribersMux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.preconfirmationsSubscribersMux.Lock()
+		delete(p.preconfirmationsSubscribers, ch)
+		close(ch)
+		p.preconfirmationsSubscribersMux.Unlock()
+	}()
+
+	return ch
+}
+
+// notifyPreconfirmation loads the current pre-confirmation of a tx and sends it to the subscribers.
+// The pre-confirmation is loaded without holding the subscribers lock, so a slow storage doesn't
+// block the subscriptions
+func (p *Pool) notifyPreconfirmation(hash common.Hash) {
+	p.preconfirmationsSubscribersMux.Lock()
+	subscribers := len(p.preconfirmationsSubscribers)
+	p.preconfirmationsSubscribersMux.Unlock()
+
+	if subscribers == 0 {
+		return
+	}
+
+	preconfirmation, err := p.storage.GetPreconfirmationByHash(context.Background(), hash)
+	if err != nil {
+		log.Errorf("failed to load pre-confirmation of tx %s to notify it, error: %v", hash.String(), err)
+		return
+	}
+
+	p.preconfirmationsSubscribersMux.Lock()
+	defer p.preconfirmationsSubscribersMux.Unlock()
+
+	for ch := range p.preconfirmationsSubscribers {
+		select {
+		case ch <- *preconfirmation:
+		default:
+			log.Debugf("pre-confirmation notification for tx %s dropped, subscriber is full", hash.String())
+		}
+	}
+}
+
+// listenPreconfirmations forwards the pre-confirmations notified by the storage to the subscribers
+func (p *Pool) listenPreconfirmations(listener PreconfirmationsListener) {
+	ctx := context.Background()
+	for {
+		err := listener.ListenPreconfirmations(ctx, p.notifyPreconfirmation)
+		log.Errorf("error listening to the pre-confirmations stored in the pool, retrying in %s: %v", preconfirmationsListenRetryInterval, err)
+		time.Sleep(preconfirmationsListenRetryInterval)
+	}
+}
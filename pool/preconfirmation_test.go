@@ -0,0 +1,73 @@
+package pool_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PreconfirmationSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	preconfirmation := pool.Preconfirmation{TxHash: common.HexToHash("0x1"), L2BlockNumber: 10, Index: 2, Status: 1}
+	preconfirmation.Signature, err = crypto.Sign(preconfirmation.SigningHash(1001).Bytes(), key)
+	require.NoError(t, err)
+
+	signer, err := preconfirmation.Signer(1001)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// The signature doesn't match on another chain
+	signer, err = preconfirmation.Signer(1002)
+	require.NoError(t, err)
+	assert.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// The signature doesn't match if any of the signed fields changes
+	preconfirmation.Index = 3
+	signer, err = preconfirmation.Signer(1001)
+	require.NoError(t, err)
+	assert.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+}
+
+func Test_SubscribePreconfirmations(t *testing.T) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	s := memorypoolstorage.NewMemoryPoolStorage()
+	p := pool.NewPool(cfg, bc, s, nil, chainID.Uint64(), eventLog)
+
+	sender := newStorageTestSender(t)
+	tx := sender.poolTx(t, 0, 10, pool.TxStatusPending, time.Now())
+	require.NoError(t, s.AddTx(context.Background(), tx))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sub := p.SubscribePreconfirmations(ctx)
+
+	// The storage doesn't notify the changes, so they are notified in-process
+	require.NoError(t, p.AddPreconfirmation(ctx, pool.Preconfirmation{TxHash: tx.Hash(), L2BlockNumber: 10, Status: 1, Signature: []byte{1}, CreatedAt: time.Now()}))
+	_, err = p.RescindPreconfirmations(ctx, 10)
+	require.NoError(t, err)
+
+	for _, rescinded := range []bool{false, true} {
+		select {
+		case notified := <-sub:
+			assert.Equal(t, tx.Hash(), notified.TxHash)
+			assert.Equal(t, uint64(10), notified.L2BlockNumber)
+			assert.Equal(t, rescinded, notified.Rescinded)
+		case <-time.After(time.Second):
+			require.FailNow(t, "pre-confirmation not notified")
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// preExecutionCacheKey identifies a pre-execution result, the result of a tx is
+// only valid for the state root it was pre-executed on
+type preExecutionCacheKey struct {
+	txHash    common.Hash
+	stateRoot common.Hash
+}
+
+type preExecutionCacheEntry struct {
+	response  preExecutionResponse
+	expiresAt time.Time
+}
+
+// preExecutionRequest is a tx waiting to be pre-executed in a group of requests
+type preExecutionRequest struct {
+	tx     types.Transaction
+	result chan preExecutionResult
+}
+
+type preExecutionResult struct {
+	response preExecutionResponse
+	err      error
+}
+
+// preExecute pre-executes the tx, reusing the result of a previous pre-execution of
+// the same tx on top of the same state root when the cache is enabled
+func (p *Pool) preExecute(ctx context.Context, tx types.Transaction) (preExecutionResponse, error) {
+	if p.cfg.PreExecution.CacheTTL.Duration == 0 {
+		return p.preExecuteTxOrBatch(ctx, tx)
+	}
+
+	l2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		log.Warnf("failed to get the last L2 block to check the pre-execution cache: %v", err)
+		return p.preExecuteTxOrBatch(ctx, tx)
+	}
+
+	key := preExecutionCacheKey{txHash: tx.Hash(), stateRoot: l2Block.Root()}
+	if response, found := p.cachedPreExecution(key); found {
+		metrics.PreExecutionCache(metrics.PreExecutionCacheHit)
+		return response, nil
+	}
+	metrics.PreExecutionCache(metrics.PreExecutionCacheMiss)
+
+	response, err := p.preExecuteTxOrBatch(ctx, tx)
+	if err == nil {
+		p.cachePreExecution(key, response)
+	}
+	return response, err
+}
+
+// cachedPreExecution returns the cached pre-execution result for the key if it has not expired
+func (p *Pool) cachedPreExecution(key preExecutionCacheKey) (preExecutionResponse, bool) {
+	p.preExecutionCacheMux.Lock()
+	defer p.preExecutionCacheMux.Unlock()
+
+	entry, found := p.preExecutionCache[key]
+	if !found {
+		return preExecutionResponse{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(p.preExecutionCache, key)
+		return preExecutionResponse{}, false
+	}
+	return entry.response, true
+}
+
+// cachePreExecution stores the pre-execution result for the key. When the cache is full the
+// expired results are deleted and, if it is still full, an arbitrary result is evicted
+func (p *Pool) cachePreExecution(key preExecutionCacheKey, response preExecutionResponse) {
+	if p.cfg.PreExecution.CacheMaxSize <= 0 {
+		return
+	}
+
+	p.preExecutionCacheMux.Lock()
+	defer p.preExecutionCacheMux.Unlock()
+
+	now := time.Now()
+	if len(p.preExecutionCache) >= p.cfg.PreExecution.CacheMaxSize {
+		for k, entry := range p.preExecutionCache {
+			if now.After(entry.expiresAt) {
+				delete(p.preExecutionCache, k)
+			}
+		}
+	}
+	if len(p.preExecutionCache) >= p.cfg.PreExecution.CacheMaxSize {
+		for k := range p.preExecutionCache {
+			delete(p.preExecutionCache, k)
+			break
+		}
+	}
+
+	p.preExecutionCache[key] = preExecutionCacheEntry{response: response, expiresAt: now.Add(p.cfg.PreExecution.CacheTTL.Duration)}
+}
+
+// preExecuteTxOrBatch pre-executes the tx alone or, when the batching is enabled, in the
+// group of the requests arriving within the batch window
+func (p *Pool) preExecuteTxOrBatch(ctx context.Context, tx types.Transaction) (preExecutionResponse, error) {
+	if !p.cfg.PreExecution.BatchingEnabled {
+		return p.preExecuteTx(ctx, tx)
+	}
+
+	request := preExecutionRequest{tx: tx, result: make(chan preExecutionResult, 1)}
+	select {
+	case p.preExecutionRequests <- request:
+	case <-ctx.Done():
+		return preExecutionResponse{}, ctx.Err()
+	}
+
+	select {
+	case result := <-request.result:
+		return result.response, result.err
+	case <-ctx.Done():
+		return preExecutionResponse{}, ctx.Err()
+	}
+}
+
+// batchPreExecutions groups the pre-execution requests arriving within the batch window,
+// up to the batch max size, and pre-executes each group in the background
+func (p *Pool) batchPreExecutions() {
+	for {
+		requests := []preExecutionRequest{<-p.preExecutionRequests}
+
+		timer := time.NewTimer(p.cfg.PreExecution.BatchWindow.Duration)
+	collect:
+		for len(requests) < p.cfg.PreExecution.BatchMaxSize {
+			select {
+			case request := <-p.preExecutionRequests:
+				requests = append(requests, request)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		go p.preExecuteRequests(requests)
+	}
+}
+
+// preExecuteRequests pre-executes once each distinct tx of the requests, sharing the result
+// between all the requests of the same tx. The distinct txs are not pre-executed together
+// because the executor only returns the zkCounters of the whole batch, not the ones of each tx
+func (p *Pool) preExecuteRequests(requests []preExecutionRequest) {
+	ctx := context.Background()
+
+	txs := []types.Transaction{}
+	requestsByHash := map[common.Hash][]preExecutionRequest{}
+	for _, request := range requests {
+		hash := request.tx.Hash()
+		if _, found := requestsByHash[hash]; !found {
+			txs = append(txs, request.tx)
+		}
+		requestsByHash[hash] = append(requestsByHash[hash], request)
+	}
+	metrics.PreExecutionBatch(len(requests), len(txs))
+
+	var wg sync.WaitGroup
+	for _, tx := range txs {
+		wg.Add(1)
+		go func(tx types.Transaction, requests []preExecutionRequest) {
+			defer wg.Done()
+			response, err := p.preExecuteTx(ctx, tx)
+			for _, request := range requests {
+				request.result <- preExecutionResult{response: response, err: err}
+			}
+		}(tx, requestsByHash[tx.Hash()])
+	}
+	wg.Wait()
+}
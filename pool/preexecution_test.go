@@ -0,0 +1,144 @@
+package pool
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// preExecutionState is a stateInterface counting the pre-executions sent to the executor
+type preExecutionState struct {
+	stateInterface
+	mux       sync.Mutex
+	stateRoot common.Hash
+	singleTxs []common.Hash
+}
+
+func (s *preExecutionState) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Root: s.stateRoot})), nil
+}
+
+func (s *preExecutionState) PreProcessTransaction(ctx context.Context, tx *ethTypes.Transaction, dbTx pgx.Tx) (*state.ProcessBatchResponse, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.singleTxs = append(s.singleTxs, tx.Hash())
+	return &state.ProcessBatchResponse{
+		UsedZkCounters:     state.ZKCounters{GasUsed: tx.Gas()},
+		ReservedZkCounters: state.ZKCounters{GasUsed: tx.Gas()},
+		BlockResponses: []*state.ProcessBlockResponse{{
+			TransactionResponses: []*state.ProcessTransactionResponse{{TxHash: tx.Hash(), GasUsed: tx.Gas()}},
+		}},
+	}, nil
+}
+
+func newPreExecutionTestPool(st *preExecutionState, cfg PreExecutionCfg) *Pool {
+	p := &Pool{
+		state:                st,
+		cfg:                  Config{PreExecution: cfg},
+		preExecutionCache:    map[preExecutionCacheKey]preExecutionCacheEntry{},
+		preExecutionCacheMux: new(sync.Mutex),
+		preExecutionRequests: make(chan preExecutionRequest),
+	}
+	if cfg.BatchingEnabled {
+		go p.batchPreExecutions()
+	}
+	return p
+}
+
+func preExecutionTestTx(nonce uint64, gas uint64) ethTypes.Transaction {
+	return *ethTypes.NewTransaction(nonce, common.HexToAddress("0x1"), big.NewInt(0), gas, big.NewInt(1), nil)
+}
+
+func Test_PreExecutionCache(t *testing.T) {
+	ctx := context.Background()
+	st := &preExecutionState{stateRoot: common.HexToHash("0x1")}
+	p := newPreExecutionTestPool(st, PreExecutionCfg{CacheTTL: types.NewDuration(time.Minute), CacheMaxSize: 2})
+
+	tx := preExecutionTestTx(0, 21000)
+	response, err := p.preExecute(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(21000), response.txResponse.GasUsed)
+
+	// the same tx on the same state root is not pre-executed again
+	cached, err := p.preExecute(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, response, cached)
+	assert.Equal(t, []common.Hash{tx.Hash()}, st.singleTxs)
+
+	// a new state root invalidates the result
+	st.stateRoot = common.HexToHash("0x2")
+	_, err = p.preExecute(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{tx.Hash(), tx.Hash()}, st.singleTxs)
+
+	// the cache does not grow over its max size
+	_, err = p.preExecute(ctx, preExecutionTestTx(1, 21000))
+	require.NoError(t, err)
+	assert.Len(t, p.preExecutionCache, 2)
+
+	// the expired results are not reused
+	for key, entry := range p.preExecutionCache {
+		entry.expiresAt = time.Now().Add(-time.Second)
+		p.preExecutionCache[key] = entry
+	}
+	_, err = p.preExecute(ctx, tx)
+	require.NoError(t, err)
+	assert.Len(t, st.singleTxs, 4)
+}
+
+func Test_PreExecutionBatching(t *testing.T) {
+	st := &preExecutionState{}
+	p := newPreExecutionTestPool(st, PreExecutionCfg{BatchingEnabled: true, BatchWindow: types.NewDuration(time.Second), BatchMaxSize: 5})
+
+	tx1 := preExecutionTestTx(0, 21000)
+	tx2 := preExecutionTestTx(1, 30000)
+
+	// the batch max size is reached with the resubmissions of the txs
+	txs := []ethTypes.Transaction{tx1, tx2, tx1, tx1, tx2}
+	responses := make([]preExecutionResponse, len(txs))
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		wg.Add(1)
+		go func(i int, tx ethTypes.Transaction) {
+			defer wg.Done()
+			response, err := p.preExecute(context.Background(), tx)
+			require.NoError(t, err)
+			responses[i] = response
+		}(i, tx)
+	}
+	wg.Wait()
+
+	// each distinct tx is pre-executed once
+	assert.ElementsMatch(t, []common.Hash{tx1.Hash(), tx2.Hash()}, st.singleTxs)
+
+	// and every request gets the result and the counters of its own tx
+	for i, tx := range txs {
+		assert.Equal(t, tx.Hash(), responses[i].txResponse.TxHash)
+		assert.Equal(t, tx.Gas(), responses[i].usedZKCounters.GasUsed)
+		assert.Equal(t, tx.Gas(), responses[i].reservedZKCounters.GasUsed)
+	}
+}
+
+func Test_PreExecutionBatchingCanceled(t *testing.T) {
+	st := &preExecutionState{}
+	p := newPreExecutionTestPool(st, PreExecutionCfg{BatchingEnabled: true, BatchWindow: types.NewDuration(time.Minute), BatchMaxSize: 5})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// the request waits for the window to be closed until its context is done
+	_, err := p.preExecute(ctx, preExecutionTestTx(0, 21000))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,196 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// evictionCandidatesLimit is the max number of pending txs checked
+// when looking for a non-executable tx to evict from the full pool
+const evictionCandidatesLimit = 100
+
+// EvictionCandidate is a pending tx that can be evicted from the pool
+// to make room for a better paying tx if it is non-executable
+type EvictionCandidate struct {
+	Hash     common.Hash
+	From     common.Address
+	Nonce    uint64
+	GasPrice uint64
+	// AfterNonceGap is true when some of the nonces between the lowest pending
+	// nonce of the sender and the nonce of the tx are missing in the pool
+	AfterNonceGap bool
+	// LowestSenderNonce is the lowest nonce of the pending txs of the sender
+	LowestSenderNonce uint64
+}
+
+// displacedTxs contains the pending txs that leave the pool once the new tx is stored
+type displacedTxs struct {
+	replaced []common.Hash
+	evicted  *common.Hash
+	// replacedWIP are the replaced txs already loaded by the sequencer worker, they
+	// are set as failed by the sequencer when the worker replaces them with the new tx
+	replacedWIP []common.Hash
+}
+
+// IsPriceBumped returns true if newGasPrice is at least priceBump percent higher than oldGasPrice
+func IsPriceBumped(oldGasPrice, newGasPrice *big.Int, priceBump uint64) bool {
+	threshold := new(big.Int).Mul(oldGasPrice, new(big.Int).SetUint64(100+priceBump)) //nolint:gomnd
+	return new(big.Int).Mul(newGasPrice, big.NewInt(100)).Cmp(threshold) >= 0         //nolint:gomnd
+}
+
+// IsTxPriceBumped returns true if newTx bumps by at least priceBump percent both the gas fee cap
+// and the gas tip cap of oldTx, so the price paid by the new tx is higher whatever the base fee is.
+// Both values are the gas price for legacy txs
+func IsTxPriceBumped(oldTx, newTx *types.Transaction, priceBump uint64) bool {
+	return IsPriceBumped(oldTx.GasFeeCap(), newTx.GasFeeCap(), priceBump) && IsPriceBumped(oldTx.GasTipCap(), newTx.GasTipCap(), priceBump)
+}
+
+// checkTxDisplacement checks the tx against the pending txs with the same from and nonce,
+// which are replaced if the tx bumps their gas price enough, and against the GlobalQueue
+// limit, in which case the cheapest non-executable tx is evicted if the tx pays more
+func (p *Pool) checkTxDisplacement(ctx context.Context, poolTx Transaction) (*displacedTxs, error) {
+	from, err := state.GetSender(poolTx.Transaction)
+	if err != nil {
+		return nil, ErrInvalidSender
+	}
+
+	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
+	if err != nil {
+		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
+		return nil, err
+	}
+
+	displaced := &displacedTxs{}
+	for _, oldTx := range oldTxs {
+		// discard invalid txs
+		if oldTx.Status == TxStatusInvalid || oldTx.Status == TxStatusFailed {
+			continue
+		}
+
+		if oldTx.Hash() == poolTx.Hash() {
+			return nil, ErrAlreadyKnown
+		}
+
+		if !IsTxPriceBumped(&oldTx.Transaction, &poolTx.Transaction, p.cfg.PriceBump) {
+			log.Infof("%v: tx %v does not bump the gas fee cap and tip of tx %v by %d%%", ErrReplaceUnderpriced.Error(), poolTx.Hash().String(), oldTx.Hash().String(), p.cfg.PriceBump)
+			return nil, ErrReplaceUnderpriced
+		}
+
+		if oldTx.Status == TxStatusPending {
+			// the worker may be executing the wip tx, so it's the worker who
+			// replaces it when the new tx is loaded from the pool
+			if oldTx.IsWIP {
+				displaced.replacedWIP = append(displaced.replacedWIP, oldTx.Hash())
+			} else {
+				displaced.replaced = append(displaced.replaced, oldTx.Hash())
+			}
+		}
+	}
+
+	// a replacement takes the slot of the replaced tx
+	if p.cfg.GlobalQueue == 0 || len(displaced.replaced) > 0 || len(displaced.replacedWIP) > 0 {
+		return displaced, nil
+	}
+
+	txCount, err := p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	if err != nil {
+		log.Errorf("failed to count pool txs by status pending while adding tx to the pool", err)
+		return nil, err
+	}
+	if txCount < p.cfg.GlobalQueue {
+		return displaced, nil
+	}
+
+	displaced.evicted, err = p.findTxToEvict(ctx, poolTx)
+	if err != nil {
+		return nil, err
+	}
+	if displaced.evicted == nil {
+		return nil, ErrTxPoolOverflow
+	}
+
+	return displaced, nil
+}
+
+// findTxToEvict returns the cheapest non-executable pending tx with a gas price
+// lower than the provided tx, or nil if there is not any. The wip txs are never
+// evicted since they are already loaded by the sequencer worker
+func (p *Pool) findTxToEvict(ctx context.Context, poolTx Transaction) (*common.Hash, error) {
+	if !poolTx.GasPrice().IsUint64() {
+		return nil, nil
+	}
+
+	candidates, err := p.storage.GetEvictionCandidates(ctx, poolTx.GasPrice().Uint64(), evictionCandidatesLimit)
+	if err != nil {
+		log.Errorf("failed to get the eviction candidates while adding tx to the pool", err)
+		return nil, err
+	}
+
+	var root *common.Hash
+	stateNonces := map[common.Address]uint64{}
+	for _, candidate := range candidates {
+		if !candidate.AfterNonceGap {
+			// the tx is executable if the lowest pending nonce of the sender is the next one in the state
+			stateNonce, found := stateNonces[candidate.From]
+			if !found {
+				if root == nil {
+					lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+					if err != nil {
+						log.Errorf("failed to load last l2 block while adding tx to the pool", err)
+						return nil, err
+					}
+					r := lastL2Block.Root()
+					root = &r
+				}
+				stateNonce, err = p.state.GetNonce(ctx, candidate.From, *root)
+				if err != nil {
+					log.Errorf("failed to get nonce while adding tx to the pool", err)
+					return nil, err
+				}
+				stateNonces[candidate.From] = stateNonce
+			}
+			if candidate.LowestSenderNonce <= stateNonce {
+				continue
+			}
+		}
+
+		hash := candidate.Hash
+		return &hash, nil
+	}
+
+	return nil, nil
+}
+
+// removeDisplacedTxs sets as failed the txs replaced or evicted by the provided tx,
+// the tx is already stored so the errors are only logged. The wip txs are left to the
+// sequencer worker, which replaces them once the provided tx is loaded
+func (p *Pool) removeDisplacedTxs(ctx context.Context, poolTx Transaction, displaced *displacedTxs) {
+	for _, hash := range displaced.replacedWIP {
+		log.Infof("tx %s is wip, it will be replaced by tx %s in the sequencer", hash.String(), poolTx.Hash().String())
+	}
+
+	updateInfos := []TxStatusUpdateInfo{}
+	for _, hash := range displaced.replaced {
+		failedReason := fmt.Sprintf("%s: %s", ErrTxReplaced.Error(), poolTx.Hash().String())
+		updateInfos = append(updateInfos, TxStatusUpdateInfo{Hash: hash, NewStatus: TxStatusFailed, FailedReason: &failedReason})
+		log.Infof("tx %s replaced by tx %s", hash.String(), poolTx.Hash().String())
+	}
+	if displaced.evicted != nil {
+		failedReason := fmt.Sprintf("%s: %s", ErrTxEvicted.Error(), poolTx.Hash().String())
+		updateInfos = append(updateInfos, TxStatusUpdateInfo{Hash: *displaced.evicted, NewStatus: TxStatusFailed, FailedReason: &failedReason})
+		log.Infof("tx %s evicted from the full pool by tx %s", displaced.evicted.String(), poolTx.Hash().String())
+	}
+
+	if len(updateInfos) == 0 {
+		return
+	}
+	if err := p.storage.UpdateTxsStatus(ctx, updateInfos); err != nil {
+		log.Errorf("failed to set as failed the txs displaced by tx %s: %v", poolTx.Hash().String(), err)
+	}
+}
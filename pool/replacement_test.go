@@ -0,0 +1,63 @@
+package pool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IsPriceBumped(t *testing.T) {
+	var tests = []struct {
+		name        string
+		oldGasPrice int64
+		newGasPrice int64
+		priceBump   uint64
+		expected    bool
+	}{
+		{"Equal price without bump", 100, 100, 0, true},
+		{"Lower price without bump", 100, 99, 0, false},
+		{"Exact bump", 100, 110, 10, true},
+		{"Not enough bump", 100, 109, 10, false},
+		{"Rounding is not applied", 15, 16, 10, false},
+		{"Higher bump", 15, 17, 10, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := IsPriceBumped(big.NewInt(test.oldGasPrice), big.NewInt(test.newGasPrice), test.priceBump)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func Test_IsTxPriceBumped(t *testing.T) {
+	legacyTx := func(gasPrice int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(gasPrice)})
+	}
+	dynamicFeeTx := func(gasFeeCap, gasTipCap int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{GasFeeCap: big.NewInt(gasFeeCap), GasTipCap: big.NewInt(gasTipCap)})
+	}
+
+	var tests = []struct {
+		name     string
+		oldTx    *types.Transaction
+		newTx    *types.Transaction
+		expected bool
+	}{
+		{"Legacy bump", legacyTx(100), legacyTx(110), true},
+		{"Legacy not enough bump", legacyTx(100), legacyTx(109), false},
+		{"Fee cap and tip bump", dynamicFeeTx(100, 10), dynamicFeeTx(110, 11), true},
+		{"Only fee cap bump", dynamicFeeTx(100, 10), dynamicFeeTx(200, 10), false},
+		{"Only tip bump", dynamicFeeTx(100, 10), dynamicFeeTx(100, 20), false},
+		{"Legacy replaced by dynamic fee", legacyTx(100), dynamicFeeTx(110, 110), true},
+		{"Legacy replaced by dynamic fee with low tip", legacyTx(100), dynamicFeeTx(110, 1), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsTxPriceBumped(test.oldTx, test.newTx, 10))
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txStatusFailuresQueueSize is the max number of failed txs waiting to be
+// recorded in the reputations, the failures beyond it are discarded
+const txStatusFailuresQueueSize = 1000
+
+// txStatusFailure is a tx set as failed to be recorded in the reputations
+type txStatusFailure struct {
+	hash   common.Hash
+	reason string
+}
+
+// ReputationKind identifies the entity whose reputation is tracked
+type ReputationKind string
+
+const (
+	// ReputationSender is the reputation of the account that signs the txs
+	ReputationSender ReputationKind = "sender"
+	// ReputationIP is the reputation of the IP the txs are sent from
+	ReputationIP ReputationKind = "ip"
+)
+
+// ParseReputationKind converts a string like "sender" or "ip" to a ReputationKind
+func ParseReputationKind(s string) (ReputationKind, error) {
+	kind := ReputationKind(strings.ToLower(strings.TrimSpace(s)))
+	switch kind {
+	case ReputationSender, ReputationIP:
+		return kind, nil
+	default:
+		return "", ErrInvalidReputationKind
+	}
+}
+
+// Reputation contains the failed txs of a sender or IP
+type Reputation struct {
+	Kind ReputationKind `json:"kind"`
+	// Key is the sender address or the IP
+	Key string `json:"key"`
+	// Failures is the number of failures since WindowStart
+	Failures      uint64     `json:"failures"`
+	WindowStart   time.Time  `json:"windowStart"`
+	LastFailureAt time.Time  `json:"lastFailureAt"`
+	BannedUntil   *time.Time `json:"bannedUntil"`
+}
+
+// reputationKey returns the key of the reputation in the in memory reputations
+func reputationKey(kind ReputationKind, key string) string {
+	return string(kind) + ":" + key
+}
+
+// StartRefreshingReputationsPeriodically will make this instance of the pool
+// to check periodically(accordingly to the configuration) for updates regarding
+// the reputations and update the in memory reputations. It does nothing if
+// the reputation tracking is not enabled
+func (p *Pool) StartRefreshingReputationsPeriodically() {
+	if !p.cfg.Reputation.Enabled {
+		return
+	}
+
+	p.refreshReputations()
+	go func(p *Pool) {
+		for {
+			time.Sleep(p.cfg.Reputation.IntervalToRefresh.Duration)
+			p.refreshReputations()
+		}
+	}(p)
+}
+
+// refreshReputations deletes the expired reputations and refreshes the in memory ones
+func (p *Pool) refreshReputations() {
+	ctx := context.Background()
+	if err := p.storage.DeleteReputationsOlderThan(ctx, time.Now().Add(-p.cfg.Reputation.FailuresWindow.Duration)); err != nil {
+		log.Errorf("failed to delete expired reputations: %v", err)
+	}
+
+	reputations, err := p.storage.GetReputations(ctx)
+	if err != nil {
+		log.Errorf("failed to load reputations: %v", err)
+		return
+	}
+
+	reputationsMap := map[string]struct{}{}
+	for _, reputation := range reputations {
+		key := reputationKey(reputation.Kind, reputation.Key)
+		reputationsMap[key] = struct{}{}
+		p.reputations.Store(key, reputation)
+	}
+
+	p.reputations.Range(func(key, value any) bool {
+		if _, found := reputationsMap[key.(string)]; !found {
+			p.reputations.Delete(key)
+			p.reputationLastTx.Delete(key)
+		}
+		return true
+	})
+}
+
+// currentFailures returns the failures of the reputation that are not forgotten yet
+func (p *Pool) currentFailures(reputation Reputation, now time.Time) uint64 {
+	if reputation.WindowStart.Before(now.Add(-p.cfg.Reputation.FailuresWindow.Duration)) {
+		return 0
+	}
+	return reputation.Failures
+}
+
+// checkReputation rejects the tx if its sender or IP are banned, or if they are
+// throttled and the previous tx was accepted too recently
+func (p *Pool) checkReputation(poolTx Transaction, from common.Address) error {
+	if !p.cfg.Reputation.Enabled {
+		return nil
+	}
+
+	now := time.Now()
+	for _, key := range p.txReputationKeys(poolTx, from) {
+		value, found := p.reputations.Load(key)
+		if !found {
+			continue
+		}
+		reputation := value.(Reputation)
+
+		if reputation.BannedUntil != nil && reputation.BannedUntil.After(now) {
+			log.Infof("%v: %s until %s", ErrReputationBanned.Error(), key, reputation.BannedUntil.String())
+			return ErrReputationBanned
+		}
+
+		failures := p.currentFailures(reputation, now)
+		if p.cfg.Reputation.ThrottleThreshold == 0 || failures < p.cfg.Reputation.ThrottleThreshold {
+			continue
+		}
+		interval := p.cfg.Reputation.ThrottleInterval.Duration * time.Duration(failures-p.cfg.Reputation.ThrottleThreshold+1)
+		if lastTx, found := p.reputationLastTx.Load(key); found && now.Sub(lastTx.(time.Time)) < interval {
+			log.Infof("%v: %s, %d failures", ErrReputationThrottled.Error(), key, failures)
+			return ErrReputationThrottled
+		}
+	}
+
+	return nil
+}
+
+// trackReputationTx records the time the tx was accepted for the senders and IPs
+// with a reputation, to throttle their next txs
+func (p *Pool) trackReputationTx(poolTx Transaction, from common.Address) {
+	if !p.cfg.Reputation.Enabled {
+		return
+	}
+
+	now := time.Now()
+	for _, key := range p.txReputationKeys(poolTx, from) {
+		if _, found := p.reputations.Load(key); found {
+			p.reputationLastTx.Store(key, now)
+		}
+	}
+}
+
+func (p *Pool) txReputationKeys(poolTx Transaction, from common.Address) []string {
+	keys := []string{reputationKey(ReputationSender, from.String())}
+	if poolTx.IP != "" {
+		keys = append(keys, reputationKey(ReputationIP, poolTx.IP))
+	}
+	return keys
+}
+
+// recordTxStatusFailure queues a failure for the sender and IP of the tx if it has
+// been set as failed or invalid for a reason that is not ignored. The failures are
+// recorded in the background to keep the reputation writes out of the status updates
+func (p *Pool) recordTxStatusFailure(hash common.Hash, newStatus TxStatus, failedReason *string) {
+	if !p.cfg.Reputation.Enabled || (newStatus != TxStatusFailed && newStatus != TxStatusInvalid) {
+		return
+	}
+
+	reason := ""
+	if failedReason != nil {
+		reason = *failedReason
+	}
+	for _, ignored := range p.cfg.Reputation.IgnoredFailedReasons {
+		if ignored != "" && strings.Contains(reason, ignored) {
+			return
+		}
+	}
+
+	select {
+	case p.txStatusFailures <- txStatusFailure{hash: hash, reason: reason}:
+	default:
+		log.Warnf("reputation failures queue is full, failure of tx %s not recorded", hash.String())
+	}
+}
+
+// recordTxStatusFailures records the queued failures of the txs set as failed
+func (p *Pool) recordTxStatusFailures() {
+	ctx := context.Background()
+	for failure := range p.txStatusFailures {
+		poolTx, err := p.storage.GetTransactionByHash(ctx, failure.hash)
+		if err != nil {
+			log.Errorf("failed to load tx %s to record its failure in the reputation: %v", failure.hash.String(), err)
+			continue
+		}
+		from, err := state.GetSender(poolTx.Transaction)
+		if err != nil {
+			log.Errorf("failed to get the sender of tx %s to record its failure in the reputation: %v", failure.hash.String(), err)
+			continue
+		}
+
+		p.recordTxFailure(ctx, *poolTx, from, failure.reason)
+	}
+}
+
+// recordPreExecutionFailure records a failure for the sender and IP of a tx
+// whose pre-execution ran out of counters or gas
+func (p *Pool) recordPreExecutionFailure(ctx context.Context, tx types.Transaction, ip string, err error) {
+	if !p.cfg.Reputation.Enabled {
+		return
+	}
+
+	from, senderErr := state.GetSender(tx)
+	if senderErr != nil {
+		log.Errorf("failed to get the sender of tx %s to record its failure in the reputation: %v", tx.Hash().String(), senderErr)
+		return
+	}
+	p.recordTxFailure(ctx, *NewTransaction(tx, ip, false), from, err.Error())
+}
+
+// recordTxFailure records a failure for the sender and IP of the tx, banning them
+// if they reach the ban threshold
+func (p *Pool) recordTxFailure(ctx context.Context, poolTx Transaction, from common.Address, reason string) {
+	if !p.cfg.Reputation.Enabled {
+		return
+	}
+
+	now := time.Now()
+	entries := map[ReputationKind]string{ReputationSender: from.String()}
+	if poolTx.IP != "" {
+		entries[ReputationIP] = poolTx.IP
+	}
+
+	for kind, key := range entries {
+		reputation, err := p.storage.AddReputationFailure(ctx, kind, key, now, now.Add(-p.cfg.Reputation.FailuresWindow.Duration))
+		if err != nil {
+			log.Errorf("failed to record failure of tx %s in the %s %s reputation: %v", poolTx.Hash().String(), kind, key, err)
+			continue
+		}
+		log.Debugf("tx %s failure recorded in the %s %s reputation, failures: %d, reason: %s", poolTx.Hash().String(), kind, key, reputation.Failures, reason)
+
+		alreadyBanned := reputation.BannedUntil != nil && reputation.BannedUntil.After(now)
+		if p.cfg.Reputation.BanThreshold > 0 && reputation.Failures >= p.cfg.Reputation.BanThreshold && !alreadyBanned {
+			// the ban applies to this instance even if it can't be stored for the other ones
+			bannedUntil := now.Add(p.cfg.Reputation.BanDuration.Duration)
+			reputation.BannedUntil = &bannedUntil
+			p.reputations.Store(reputationKey(kind, key), *reputation)
+			log.Infof("%s %s banned until %s after %d failed txs", kind, key, bannedUntil.String(), reputation.Failures)
+			if err := p.storage.BanReputation(ctx, kind, key, bannedUntil); err != nil {
+				log.Errorf("failed to store the ban of %s %s: %v", kind, key, err)
+			}
+			p.logReputationEvent(ctx, poolTx.IP, fmt.Sprintf("%s %s banned until %s after %d failed txs", kind, key, bannedUntil.String(), reputation.Failures))
+			continue
+		}
+
+		p.reputations.Store(reputationKey(kind, key), *reputation)
+	}
+}
+
+// GetReputations returns the stored reputations of the senders and IPs
+func (p *Pool) GetReputations(ctx context.Context) ([]Reputation, error) {
+	return p.storage.GetReputations(ctx)
+}
+
+// ResetReputation deletes the failures and the ban of a sender or IP, other
+// instances of the pool sharing the same storage are updated in the next refresh
+func (p *Pool) ResetReputation(ctx context.Context, kind ReputationKind, key string, ip string) error {
+	// the senders are stored with the checksum format
+	if kind == ReputationSender && common.IsHexAddress(key) {
+		key = common.HexToAddress(key).String()
+	}
+
+	if err := p.storage.DeleteReputation(ctx, kind, key); err != nil {
+		return err
+	}
+	p.reputations.Delete(reputationKey(kind, key))
+	p.reputationLastTx.Delete(reputationKey(kind, key))
+	p.logReputationEvent(ctx, ip, fmt.Sprintf("%s %s reputation reset", kind, key))
+	return nil
+}
+
+func (p *Pool) logReputationEvent(ctx context.Context, ip string, description string) {
+	if p.eventLog == nil {
+		return
+	}
+
+	ev := &event.Event{
+		ReceivedAt:  time.Now(),
+		IPAddress:   ip,
+		Source:      event.Source_Node,
+		Component:   event.Component_Pool,
+		Level:       event.Level_Warning,
+		EventID:     event.EventID_ReputationUpdated,
+		Description: description,
+	}
+
+	if err := p.eventLog.LogEvent(ctx, ev); err != nil {
+		log.Errorf("error adding event: %v", err)
+	}
+}
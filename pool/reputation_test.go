@@ -0,0 +1,125 @@
+package pool_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Reputation(t *testing.T) {
+	ctx := context.Background()
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	reputationCfg := cfg
+	reputationCfg.Reputation = pool.ReputationCfg{
+		Enabled:              true,
+		FailuresWindow:       cfgTypes.NewDuration(time.Hour),
+		ThrottleThreshold:    2,
+		ThrottleInterval:     cfgTypes.NewDuration(time.Minute),
+		BanThreshold:         3,
+		BanDuration:          cfgTypes.NewDuration(time.Hour),
+		IgnoredFailedReasons: []string{"replaced"},
+		IntervalToRefresh:    cfgTypes.NewDuration(time.Minute),
+	}
+
+	s := memorypoolstorage.NewMemoryPoolStorage()
+	p := pool.NewPool(reputationCfg, bc, s, nil, chainID.Uint64(), eventLog)
+	sender := newStorageTestSender(t)
+
+	failTx := func(nonce uint64, reason string) {
+		tx := sender.poolTx(t, nonce, 10, pool.TxStatusPending, time.Now())
+		require.NoError(t, s.AddTx(ctx, tx))
+		require.NoError(t, p.UpdateTxStatus(ctx, tx.Hash(), pool.TxStatusFailed, false, &reason))
+	}
+
+	// the failures are recorded in the background
+	waitFailures := func(failures uint64, banned bool) []pool.Reputation {
+		var reputations []pool.Reputation
+		require.Eventually(t, func() bool {
+			var err error
+			reputations, err = p.GetReputations(ctx)
+			require.NoError(t, err)
+			if len(reputations) != 2 {
+				return false
+			}
+			for _, reputation := range reputations {
+				if reputation.Failures != failures || (reputation.BannedUntil != nil) != banned {
+					return false
+				}
+			}
+			return true
+		}, 5*time.Second, 10*time.Millisecond)
+		return reputations
+	}
+
+	failTx(0, "insufficient funds")
+	reputations := waitFailures(1, false)
+	for _, reputation := range reputations {
+		assert.Equal(t, uint64(1), reputation.Failures)
+		assert.Nil(t, reputation.BannedUntil)
+	}
+	assert.Equal(t, ip, reputations[0].Key)
+	assert.Equal(t, sender.auth.From.String(), reputations[1].Key)
+
+	// ignored failed reasons and non failed statuses are not recorded
+	failTx(1, "replaced transaction")
+	tx := sender.poolTx(t, 2, 10, pool.TxStatusPending, time.Now())
+	require.NoError(t, s.AddTx(ctx, tx))
+	require.NoError(t, p.UpdateTxStatus(ctx, tx.Hash(), pool.TxStatusSelected, false, nil))
+	reputations, err = p.GetReputations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), reputations[0].Failures)
+
+	// the sender and IP are banned when they reach the ban threshold
+	failTx(3, "out of counters")
+	failTx(4, "out of counters")
+	reputations = waitFailures(3, true)
+	for _, reputation := range reputations {
+		assert.Equal(t, uint64(3), reputation.Failures)
+		require.NotNil(t, reputation.BannedUntil)
+		assert.True(t, reputation.BannedUntil.After(time.Now()))
+	}
+
+	newTx, err := sender.auth.Signer(sender.auth.From, ethTypes.NewTransaction(5, common.HexToAddress(senderAddress), big.NewInt(0), gasLimit, gasPrice, nil))
+	require.NoError(t, err)
+	err = p.AddTx(ctx, *newTx, ip)
+	assert.ErrorIs(t, err, pool.ErrReputationBanned)
+
+	// the sender is still banned from another IP
+	err = p.AddTx(ctx, *newTx, "101.1.50.21")
+	assert.ErrorIs(t, err, pool.ErrReputationBanned)
+
+	require.NoError(t, p.ResetReputation(ctx, pool.ReputationSender, strings.ToLower(sender.auth.From.Hex()), ""))
+	require.NoError(t, p.ResetReputation(ctx, pool.ReputationIP, ip, ""))
+	reputations, err = p.GetReputations(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, reputations)
+}
+
+func Test_ParseReputationKind(t *testing.T) {
+	kind, err := pool.ParseReputationKind(" IP ")
+	require.NoError(t, err)
+	assert.Equal(t, pool.ReputationIP, kind)
+
+	kind, err = pool.ParseReputationKind("sender")
+	require.NoError(t, err)
+	assert.Equal(t, pool.ReputationSender, kind)
+
+	_, err = pool.ParseReputationKind("receiver")
+	assert.ErrorIs(t, err, pool.ErrInvalidReputationKind)
+}
@@ -0,0 +1,110 @@
+package pool
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/etrogpolygonzkevmbridge"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// claimAssetMethod is the bridge method to claim the bridged assets
+	claimAssetMethod = "claimAsset"
+	// claimMessageMethod is the bridge method to claim the bridged messages
+	claimMessageMethod = "claimMessage"
+	// claimRecipientArg is the argument of the claim methods with the recipient of the claim
+	claimRecipientArg = "destinationAddress"
+	// methodIDLength is the length of the method selector at the beginning of the tx data
+	methodIDLength = 4
+)
+
+// sponsoredClaim is a sponsored claim accepted by the pool
+type sponsoredClaim struct {
+	recipient  common.Address
+	acceptedAt time.Time
+}
+
+// IsSponsoredClaim returns true if the tx is a sponsored claim: a zero gas price and
+// zero value tx calling claimAsset or claimMessage on the bridge contract within the
+// configured gas limit
+func (p *Pool) IsSponsoredClaim(tx types.Transaction) bool {
+	_, sponsored := p.sponsoredClaimRecipient(tx)
+	return sponsored
+}
+
+// sponsoredClaimRecipient returns the recipient of the claim, the destinationAddress
+// argument, and true if the tx is a sponsored claim
+func (p *Pool) sponsoredClaimRecipient(tx types.Transaction) (common.Address, bool) {
+	cfg := p.cfg.SponsoredClaims
+	if !cfg.Enabled || tx.GasPrice().Sign() != 0 || tx.Value().Sign() != 0 || tx.To() == nil || *tx.To() != cfg.BridgeAddress ||
+		tx.Gas() > cfg.MaxGas || len(tx.Data()) < methodIDLength {
+		return common.Address{}, false
+	}
+
+	bridgeABI, err := etrogpolygonzkevmbridge.EtrogpolygonzkevmbridgeMetaData.GetAbi()
+	if err != nil {
+		log.Errorf("failed to load the bridge ABI to check the sponsored claims: %v", err)
+		return common.Address{}, false
+	}
+
+	method, err := bridgeABI.MethodById(tx.Data()[:methodIDLength])
+	if err != nil || (method.Name != claimAssetMethod && method.Name != claimMessageMethod) {
+		return common.Address{}, false
+	}
+
+	args := map[string]interface{}{}
+	if err := method.Inputs.UnpackIntoMap(args, tx.Data()[methodIDLength:]); err != nil {
+		log.Debugf("failed to decode the %s call of tx %s: %v", method.Name, tx.Hash().String(), err)
+		return common.Address{}, false
+	}
+
+	recipient, ok := args[claimRecipientArg].(common.Address)
+	return recipient, ok
+}
+
+// acceptSponsoredClaim records the sponsored claim to the recipient, or rejects it if
+// the recipient has reached the limit of sponsored claims within the configured interval.
+// The limit is by recipient because the senders of zero gas txs cost nothing to create.
+// The check and the record are done under the same lock, so the concurrent claims can't
+// exceed the limit. The claims rejected later by the pre-execution count too, as they
+// used the executor anyway
+func (p *Pool) acceptSponsoredClaim(recipient common.Address) error {
+	cfg := p.cfg.SponsoredClaims
+	if cfg.RecipientRateLimit == 0 {
+		return nil
+	}
+
+	p.sponsoredClaimsMux.Lock()
+	defer p.sponsoredClaimsMux.Unlock()
+
+	now := time.Now()
+	p.forgetOldSponsoredClaims(now)
+
+	if p.sponsoredClaimsByRecipient[recipient] >= cfg.RecipientRateLimit {
+		log.Infof("%v: %v", ErrSponsoredClaimRateLimited.Error(), recipient.String())
+		return ErrSponsoredClaimRateLimited
+	}
+
+	p.sponsoredClaims = append(p.sponsoredClaims, sponsoredClaim{recipient: recipient, acceptedAt: now})
+	p.sponsoredClaimsByRecipient[recipient]++
+	return nil
+}
+
+// forgetOldSponsoredClaims deletes the claims out of the rate limit interval. The
+// claims are sorted by the time they were accepted, so only the expired ones are
+// visited. The caller must hold the lock
+func (p *Pool) forgetOldSponsoredClaims(now time.Time) {
+	from := now.Add(-p.cfg.SponsoredClaims.RateLimitInterval.Duration)
+	expired := 0
+	for expired < len(p.sponsoredClaims) && !p.sponsoredClaims[expired].acceptedAt.After(from) {
+		recipient := p.sponsoredClaims[expired].recipient
+		p.sponsoredClaimsByRecipient[recipient]--
+		if p.sponsoredClaimsByRecipient[recipient] == 0 {
+			delete(p.sponsoredClaimsByRecipient, recipient)
+		}
+		expired++
+	}
+	p.sponsoredClaims = p.sponsoredClaims[expired:]
+}
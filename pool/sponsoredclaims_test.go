@@ -0,0 +1,125 @@
+package pool
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/etrogpolygonzkevmbridge"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func claimData(t *testing.T, method string, recipient common.Address) []byte {
+	bridgeABI, err := etrogpolygonzkevmbridge.EtrogpolygonzkevmbridgeMetaData.GetAbi()
+	require.NoError(t, err)
+
+	data, err := bridgeABI.Pack(method, [32][32]byte{}, [32][32]byte{}, big.NewInt(1), [32]byte{}, [32]byte{},
+		uint32(0), common.HexToAddress("0x3"), uint32(1), recipient, big.NewInt(100), []byte{})
+	require.NoError(t, err)
+	return data
+}
+
+func Test_IsSponsoredClaim(t *testing.T) {
+	bridge := common.HexToAddress("0x1")
+	recipient := common.HexToAddress("0x2")
+	p := &Pool{cfg: Config{SponsoredClaims: SponsoredClaimsCfg{
+		Enabled:       true,
+		BridgeAddress: bridge,
+		MaxGas:        500000,
+	}}}
+
+	bridgeABI, err := etrogpolygonzkevmbridge.EtrogpolygonzkevmbridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	bridgeAssetData, err := bridgeABI.Pack("bridgeAsset", uint32(0), recipient, big.NewInt(1), common.Address{}, false, []byte{})
+	require.NoError(t, err)
+
+	var tests = []struct {
+		name      string
+		tx        *types.Transaction
+		sponsored bool
+	}{
+		{"claim asset", types.NewTransaction(0, bridge, big.NewInt(0), 300000, big.NewInt(0), claimData(t, claimAssetMethod, recipient)), true},
+		{"claim message", types.NewTransaction(0, bridge, big.NewInt(0), 300000, big.NewInt(0), claimData(t, claimMessageMethod, recipient)), true},
+		{"gas price", types.NewTransaction(0, bridge, big.NewInt(0), 300000, big.NewInt(1), claimData(t, claimAssetMethod, recipient)), false},
+		{"value", types.NewTransaction(0, bridge, big.NewInt(1), 300000, big.NewInt(0), claimData(t, claimAssetMethod, recipient)), false},
+		{"gas limit", types.NewTransaction(0, bridge, big.NewInt(0), 500001, big.NewInt(0), claimData(t, claimAssetMethod, recipient)), false},
+		{"other contract", types.NewTransaction(0, recipient, big.NewInt(0), 300000, big.NewInt(0), claimData(t, claimAssetMethod, recipient)), false},
+		{"other method", types.NewTransaction(0, bridge, big.NewInt(0), 300000, big.NewInt(0), bridgeAssetData), false},
+		{"invalid data", types.NewTransaction(0, bridge, big.NewInt(0), 300000, big.NewInt(0), claimData(t, claimAssetMethod, recipient)[:100]), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.sponsored, p.IsSponsoredClaim(*tt.tx))
+			if tt.sponsored {
+				claimRecipient, _ := p.sponsoredClaimRecipient(*tt.tx)
+				assert.Equal(t, recipient, claimRecipient)
+			}
+		})
+	}
+
+	p.cfg.SponsoredClaims.Enabled = false
+	assert.False(t, p.IsSponsoredClaim(*tests[0].tx))
+}
+
+func Test_SponsoredClaimRateLimit(t *testing.T) {
+	recipient := common.HexToAddress("0x2")
+	otherRecipient := common.HexToAddress("0x3")
+	p := &Pool{
+		cfg: Config{SponsoredClaims: SponsoredClaimsCfg{
+			RecipientRateLimit: 2,
+			RateLimitInterval:  cfgTypes.NewDuration(time.Hour),
+		}},
+		sponsoredClaimsByRecipient: map[common.Address]uint64{},
+		sponsoredClaimsMux:         new(sync.Mutex),
+	}
+
+	require.NoError(t, p.acceptSponsoredClaim(recipient))
+	require.NoError(t, p.acceptSponsoredClaim(recipient))
+	assert.ErrorIs(t, p.acceptSponsoredClaim(recipient), ErrSponsoredClaimRateLimited)
+
+	// the claims to other recipients are not limited by the claims to the first one
+	require.NoError(t, p.acceptSponsoredClaim(otherRecipient))
+	assert.Len(t, p.sponsoredClaims, 3)
+
+	// the claims older than the interval are forgotten
+	p.sponsoredClaims[0].acceptedAt = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, p.acceptSponsoredClaim(recipient))
+	assert.Equal(t, uint64(2), p.sponsoredClaimsByRecipient[recipient])
+	p.sponsoredClaims[0].acceptedAt = time.Now().Add(-2 * time.Hour)
+	p.sponsoredClaims[1].acceptedAt = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, p.acceptSponsoredClaim(common.HexToAddress("0x4")))
+	assert.NotContains(t, p.sponsoredClaimsByRecipient, otherRecipient)
+	assert.Equal(t, uint64(1), p.sponsoredClaimsByRecipient[recipient])
+}
+
+func Test_SponsoredClaimRateLimitConcurrency(t *testing.T) {
+	p := &Pool{
+		cfg: Config{SponsoredClaims: SponsoredClaimsCfg{
+			RecipientRateLimit: 5,
+			RateLimitInterval:  cfgTypes.NewDuration(time.Hour),
+		}},
+		sponsoredClaimsByRecipient: map[common.Address]uint64{},
+		sponsoredClaimsMux:         new(sync.Mutex),
+	}
+
+	var accepted atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if p.acceptSponsoredClaim(common.HexToAddress("0x2")) == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(5), accepted.Load())
+}
@@ -0,0 +1,663 @@
+package pool_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/memorypoolstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// storageBackend is a pool storage implementation checked by the conformance tests
+type storageBackend struct {
+	name string
+	// newStorage returns an empty storage
+	newStorage func(t *testing.T) pool.Storage
+	// blockAddress adds the address to the blocked list, which is not part of the storage interface
+	blockAddress func(t *testing.T, s pool.Storage, addr common.Address)
+}
+
+func TestMemoryStorageConformance(t *testing.T) {
+	runStorageConformanceTests(t, storageBackend{
+		name: "memory",
+		newStorage: func(t *testing.T) pool.Storage {
+			return memorypoolstorage.NewMemoryPoolStorage()
+		},
+		blockAddress: func(t *testing.T, s pool.Storage, addr common.Address) {
+			s.(*memorypoolstorage.MemoryPoolStorage).BlockAddresses(addr)
+		},
+	})
+}
+
+func TestPostgresStorageConformance(t *testing.T) {
+	runStorageConformanceTests(t, storageBackend{
+		name: "postgres",
+		newStorage: func(t *testing.T) pool.Storage {
+			initOrResetDB(t)
+			s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+			require.NoError(t, err)
+			return s
+		},
+		blockAddress: func(t *testing.T, s pool.Storage, addr common.Address) {
+			poolSqlDB, err := db.NewSQLDB(poolDBCfg)
+			require.NoError(t, err)
+			defer poolSqlDB.Close()
+			_, err = poolSqlDB.Exec(context.Background(), "INSERT INTO pool.blocked(addr) VALUES($1)", addr.String())
+			require.NoError(t, err)
+		},
+	})
+}
+
+// storageTestSender signs the txs added to the storage by the conformance tests
+type storageTestSender struct {
+	auth *bind.TransactOpts
+}
+
+func newStorageTestSender(t *testing.T) *storageTestSender {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+	return &storageTestSender{auth: auth}
+}
+
+// poolTx returns a signed pool tx, the received time is truncated to
+// the precision stored by postgres
+func (s *storageTestSender) poolTx(t *testing.T, nonce uint64, gasPrice uint64, status pool.TxStatus, receivedAt time.Time) pool.Transaction {
+	tx := ethTypes.NewTransaction(nonce, common.HexToAddress(senderAddress), big.NewInt(0), gasLimit, new(big.Int).SetUint64(gasPrice), nil)
+	signedTx, err := s.auth.Signer(s.auth.From, tx)
+	require.NoError(t, err)
+
+	poolTx := pool.NewTransaction(*signedTx, ip, false)
+	poolTx.Status = status
+	poolTx.ReceivedAt = receivedAt.UTC().Truncate(time.Microsecond)
+	return *poolTx
+}
+
+func txHashes(txs []pool.Transaction) []common.Hash {
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash())
+	}
+	return hashes
+}
+
+func txPointerHashes(txs []*pool.Transaction) []common.Hash {
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash())
+	}
+	return hashes
+}
+
+func runStorageConformanceTests(t *testing.T, backend storageBackend) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run(backend.name+"/AddAndGet", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		tx := sender.poolTx(t, 3, 10, pool.TxStatusPending, now)
+		tx.ZKCounters = state.ZKCounters{GasUsed: 21000, KeccakHashes: 1, Steps: 100}
+		tx.ReservedZKCounters = state.ZKCounters{GasUsed: 30000, Steps: 200}
+		require.NoError(t, s.AddTx(ctx, tx))
+
+		stored, err := s.GetTransactionByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, tx.Hash(), stored.Hash())
+		assert.Equal(t, pool.TxStatusPending, stored.Status)
+		assert.Equal(t, ip, stored.IP)
+		assert.False(t, stored.IsWIP)
+		assert.Nil(t, stored.FailedReason)
+		assert.True(t, tx.ReceivedAt.Equal(stored.ReceivedAt))
+
+		from, nonce, err := s.GetTxFromAddressFromByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, sender.auth.From, from)
+		assert.Equal(t, uint64(3), nonce)
+
+		used, reserved, err := s.GetTxZkCountersByHash(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, tx.ZKCounters, *used)
+		assert.Equal(t, tx.ReservedZKCounters, *reserved)
+
+		pending, err := s.IsTxPending(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.True(t, pending)
+
+		missing := common.HexToHash("0x1")
+		_, err = s.GetTransactionByHash(ctx, missing)
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		_, _, err = s.GetTxZkCountersByHash(ctx, missing)
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		_, err = s.GetTransactionByL2Hash(ctx, missing)
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		_, _, err = s.GetTxFromAddressFromByHash(ctx, missing)
+		assert.Error(t, err)
+		pending, err = s.IsTxPending(ctx, missing)
+		require.NoError(t, err)
+		assert.False(t, pending)
+	})
+
+	t.Run(backend.name+"/StatusAndCounts", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender1 := newStorageTestSender(t)
+		sender2 := newStorageTestSender(t)
+
+		tx1 := sender1.poolTx(t, 0, 10, pool.TxStatusPending, now)
+		tx2 := sender1.poolTx(t, 1, 20, pool.TxStatusPending, now.Add(time.Second))
+		tx3 := sender2.poolTx(t, 0, 30, pool.TxStatusSelected, now.Add(2*time.Second))
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		count, err := s.CountTransactionsByStatus(ctx, pool.TxStatusPending)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), count)
+		count, err = s.CountTransactionsByStatus(ctx, pool.TxStatusPending, pool.TxStatusSelected)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), count)
+		count, err = s.CountTransactionsByFromAndStatus(ctx, sender1.auth.From, pool.TxStatusPending)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), count)
+
+		failedReason := "failed"
+		require.NoError(t, s.UpdateTxsStatus(ctx, []pool.TxStatusUpdateInfo{
+			{Hash: tx1.Hash(), NewStatus: pool.TxStatusFailed, FailedReason: &failedReason},
+			{Hash: tx2.Hash(), NewStatus: pool.TxStatusSelected, IsWIP: true},
+			{Hash: common.HexToHash("0x1"), NewStatus: pool.TxStatusInvalid},
+		}))
+
+		stored, err := s.GetTransactionByHash(ctx, tx1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusFailed, stored.Status)
+		require.NotNil(t, stored.FailedReason)
+		assert.Equal(t, failedReason, *stored.FailedReason)
+
+		stored, err = s.GetTransactionByHash(ctx, tx2.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusSelected, stored.Status)
+		assert.True(t, stored.IsWIP)
+		assert.Nil(t, stored.FailedReason)
+
+		failed, err := s.GetTxsByStatus(ctx, pool.TxStatusFailed, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx1.Hash()}, txHashes(failed))
+
+		selected, err := s.GetTxsByStatus(ctx, pool.TxStatusSelected, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx3.Hash(), tx2.Hash()}, txHashes(selected))
+		selected, err = s.GetTxsByStatus(ctx, pool.TxStatusSelected, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx3.Hash()}, txHashes(selected))
+
+		// adding the tx again resets the failed reason
+		require.NoError(t, s.AddTx(ctx, tx1))
+		stored, err = s.GetTransactionByHash(ctx, tx1.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusPending, stored.Status)
+		assert.Nil(t, stored.FailedReason)
+
+		txs, err := s.GetTxsByFromAndNonce(ctx, sender1.auth.From, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash()}, txHashes(txs))
+	})
+
+	t.Run(backend.name+"/WIP", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		tx1 := sender.poolTx(t, 0, 10, pool.TxStatusPending, now)
+		tx2 := sender.poolTx(t, 1, 10, pool.TxStatusPending, now.Add(time.Second))
+		require.NoError(t, s.AddTx(ctx, tx1))
+		require.NoError(t, s.AddTx(ctx, tx2))
+
+		require.NoError(t, s.UpdateTxWIPStatus(ctx, tx1.Hash(), true))
+		txs, err := s.GetNonWIPPendingTxs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash()}, txHashes(txs))
+
+		txs, err = s.GetNonWIPPendingTxsByHashes(ctx, []common.Hash{tx1.Hash(), tx2.Hash(), common.HexToHash("0x1")})
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash()}, txHashes(txs))
+
+		require.NoError(t, s.MarkWIPTxsAsPending(ctx))
+		txs, err = s.GetNonWIPPendingTxs(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []common.Hash{tx1.Hash(), tx2.Hash()}, txHashes(txs))
+		txs, err = s.GetNonWIPPendingTxsByHashes(ctx, []common.Hash{tx1.Hash()})
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx1.Hash()}, txHashes(txs))
+
+		hashes, err := s.GetPendingTxHashesSince(ctx, tx2.ReceivedAt)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash()}, hashes)
+	})
+
+	t.Run(backend.name+"/Private", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		publicTx := sender.poolTx(t, 0, 10, pool.TxStatusPending, now)
+		privateTx := sender.poolTx(t, 1, 10, pool.TxStatusPending, now)
+		privateTx.IsPrivate = true
+		require.NoError(t, s.AddTx(ctx, publicTx))
+		require.NoError(t, s.AddTx(ctx, privateTx))
+
+		hashes, err := s.GetPendingTxHashesSince(ctx, now.Add(-time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{publicTx.Hash()}, hashes)
+
+		stored, err := s.GetTransactionByHash(ctx, privateTx.Hash())
+		require.NoError(t, err)
+		assert.True(t, stored.IsPrivate)
+		stored, err = s.GetTransactionByHash(ctx, publicTx.Hash())
+		require.NoError(t, err)
+		assert.False(t, stored.IsPrivate)
+
+		// private txs are still selectable by the sequencer
+		txs, err := s.GetNonWIPPendingTxs(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []common.Hash{publicTx.Hash(), privateTx.Hash()}, txHashes(txs))
+
+		// a private tx sent again publicly stays private
+		privateTx.IsPrivate = false
+		require.NoError(t, s.AddTx(ctx, privateTx))
+		stored, err = s.GetTransactionByHash(ctx, privateTx.Hash())
+		require.NoError(t, err)
+		assert.True(t, stored.IsPrivate)
+	})
+
+	t.Run(backend.name+"/GasPrices", func(t *testing.T) {
+		s := backend.newStorage(t)
+
+		l2GasPrice, l1GasPrice, err := s.GetGasPrices(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, l2GasPrice)
+		assert.Zero(t, l1GasPrice)
+		_, err = s.MinL2GasPriceSince(ctx, now.Add(-time.Minute))
+		assert.ErrorIs(t, err, state.ErrNotFound)
+
+		require.NoError(t, s.SetGasPrices(ctx, 3, 30))
+		require.NoError(t, s.SetGasPrices(ctx, 1, 10))
+		require.NoError(t, s.SetGasPrices(ctx, 2, 20))
+
+		l2GasPrice, l1GasPrice, err = s.GetGasPrices(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), l2GasPrice)
+		assert.Equal(t, uint64(20), l1GasPrice)
+
+		minGasPrice, err := s.MinL2GasPriceSince(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), minGasPrice)
+
+		// the last gas price is always kept
+		require.NoError(t, s.DeleteGasPricesHistoryOlderThan(ctx, time.Now().Add(time.Minute)))
+		minGasPrice, err = s.MinL2GasPriceSince(ctx, now.Add(-time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), minGasPrice)
+		l2GasPrice, _, err = s.GetGasPrices(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), l2GasPrice)
+	})
+
+	t.Run(backend.name+"/Delete", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		tx1 := sender.poolTx(t, 0, 10, pool.TxStatusPending, now)
+		tx2 := sender.poolTx(t, 1, 10, pool.TxStatusPending, now)
+		tx3 := sender.poolTx(t, 2, 10, pool.TxStatusFailed, now.Add(-time.Hour))
+		tx4 := sender.poolTx(t, 3, 10, pool.TxStatusFailed, now)
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3, tx4} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		require.NoError(t, s.DeleteTransactionsByHashes(ctx, []common.Hash{tx1.Hash()}))
+		require.NoError(t, s.DeleteTransactionByHash(ctx, tx2.Hash()))
+		require.NoError(t, s.DeleteFailedTransactionsOlderThan(ctx, now.Add(-time.Minute)))
+
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3} {
+			_, err := s.GetTransactionByHash(ctx, tx.Hash())
+			assert.ErrorIs(t, err, pool.ErrNotFound)
+		}
+		_, err := s.GetTransactionByHash(ctx, tx4.Hash())
+		require.NoError(t, err)
+	})
+
+	t.Run(backend.name+"/Archive", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		selected := sender.poolTx(t, 0, 10, pool.TxStatusSelected, now)
+		selected.ZKCounters = state.ZKCounters{GasUsed: 21000, Steps: 100}
+		selected.ReservedZKCounters = state.ZKCounters{GasUsed: 30000, Steps: 200}
+		failed := sender.poolTx(t, 1, 10, pool.TxStatusPending, now.Add(-time.Hour))
+		invalid := sender.poolTx(t, 2, 10, pool.TxStatusInvalid, now)
+		failed.IsPrivate = true
+		for _, tx := range []pool.Transaction{selected, failed, invalid} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+		failedReason := "out of counters"
+		require.NoError(t, s.UpdateTxStatus(ctx, pool.TxStatusUpdateInfo{Hash: failed.Hash(), NewStatus: pool.TxStatusFailed, FailedReason: &failedReason}))
+
+		archivedAt := now.UTC().Truncate(time.Microsecond)
+		require.NoError(t, s.ArchiveTransactionsByHashes(ctx, []common.Hash{selected.Hash()}, archivedAt))
+		require.NoError(t, s.ArchiveTransactionsOlderThan(ctx, pool.TxStatusFailed, now.Add(-time.Minute), archivedAt))
+		require.NoError(t, s.ArchiveTransactionsOlderThan(ctx, pool.TxStatusInvalid, now.Add(-time.Minute), archivedAt))
+
+		for _, tx := range []pool.Transaction{selected, failed} {
+			_, err := s.GetTransactionByHash(ctx, tx.Hash())
+			assert.ErrorIs(t, err, pool.ErrNotFound)
+		}
+		_, err := s.GetTransactionByHash(ctx, invalid.Hash())
+		require.NoError(t, err)
+		_, err = s.GetArchivedTransactionByHash(ctx, invalid.Hash())
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+
+		archived, err := s.GetArchivedTransactionByHash(ctx, selected.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, selected.Hash(), archived.Hash())
+		assert.Equal(t, pool.TxStatusSelected, archived.Status)
+		assert.Equal(t, sender.auth.From, archived.From)
+		assert.Equal(t, ip, archived.IP)
+		assert.Equal(t, selected.ZKCounters, archived.ZKCounters)
+		assert.Equal(t, selected.ReservedZKCounters, archived.ReservedZKCounters)
+		assert.True(t, selected.ReceivedAt.Equal(archived.ReceivedAt))
+		assert.True(t, archivedAt.Equal(archived.ArchivedAt))
+
+		archived, err = s.GetArchivedTransactionByHash(ctx, failed.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, pool.TxStatusFailed, archived.Status)
+		require.NotNil(t, archived.FailedReason)
+		assert.Equal(t, failedReason, *archived.FailedReason)
+		assert.True(t, archived.IsPrivate)
+		assert.False(t, selected.IsPrivate)
+
+		require.NoError(t, s.DeleteArchivedTransactionsOlderThan(ctx, pool.TxStatusFailed, archivedAt.Add(time.Second)))
+		_, err = s.GetArchivedTransactionByHash(ctx, failed.Hash())
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		_, err = s.GetArchivedTransactionByHash(ctx, selected.Hash())
+		require.NoError(t, err)
+	})
+
+	t.Run(backend.name+"/GetTxs", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		tx1 := sender.poolTx(t, 2, 10, pool.TxStatusPending, now)
+		tx2 := sender.poolTx(t, 0, 20, pool.TxStatusPending, now)
+		tx3 := sender.poolTx(t, 1, 5, pool.TxStatusPending, now)
+		tx4 := sender.poolTx(t, 3, 20, pool.TxStatusSelected, now)
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3, tx4} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		txs, err := s.GetTxs(ctx, pool.TxStatusPending, 10, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash(), tx1.Hash()}, txPointerHashes(txs))
+
+		txs, err = s.GetTxs(ctx, pool.TxStatusPending, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash(), tx3.Hash()}, txPointerHashes(txs))
+	})
+
+	t.Run(backend.name+"/EvictionCandidates", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender1 := newStorageTestSender(t)
+		sender2 := newStorageTestSender(t)
+
+		// sender1 has a gap between nonces 1 and 3
+		tx1 := sender1.poolTx(t, 0, 5, pool.TxStatusPending, now)
+		tx2 := sender1.poolTx(t, 1, 4, pool.TxStatusPending, now.Add(time.Second))
+		tx3 := sender1.poolTx(t, 3, 3, pool.TxStatusPending, now.Add(2*time.Second))
+		// sender2 lowest pending nonce is 2
+		tx4 := sender2.poolTx(t, 2, 3, pool.TxStatusPending, now.Add(3*time.Second))
+		tx5 := sender2.poolTx(t, 3, 50, pool.TxStatusPending, now.Add(4*time.Second))
+		tx6 := sender2.poolTx(t, 4, 1, pool.TxStatusSelected, now.Add(5*time.Second))
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3, tx4, tx5, tx6} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		candidates, err := s.GetEvictionCandidates(ctx, 10, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []pool.EvictionCandidate{
+			{Hash: tx4.Hash(), From: sender2.auth.From, Nonce: 2, GasPrice: 3, AfterNonceGap: false, LowestSenderNonce: 2},
+			{Hash: tx3.Hash(), From: sender1.auth.From, Nonce: 3, GasPrice: 3, AfterNonceGap: true, LowestSenderNonce: 0},
+			{Hash: tx2.Hash(), From: sender1.auth.From, Nonce: 1, GasPrice: 4, AfterNonceGap: false, LowestSenderNonce: 0},
+			{Hash: tx1.Hash(), From: sender1.auth.From, Nonce: 0, GasPrice: 5, AfterNonceGap: false, LowestSenderNonce: 0},
+		}, candidates)
+
+		candidates, err = s.GetEvictionCandidates(ctx, 4, 1)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, tx4.Hash(), candidates[0].Hash)
+
+		// the wip txs are not evicted but they still count for the nonce gaps
+		require.NoError(t, s.UpdateTxWIPStatus(ctx, tx4.Hash(), true))
+		candidates, err = s.GetEvictionCandidates(ctx, 100, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []pool.EvictionCandidate{
+			{Hash: tx3.Hash(), From: sender1.auth.From, Nonce: 3, GasPrice: 3, AfterNonceGap: true, LowestSenderNonce: 0},
+			{Hash: tx2.Hash(), From: sender1.auth.From, Nonce: 1, GasPrice: 4, AfterNonceGap: false, LowestSenderNonce: 0},
+			{Hash: tx1.Hash(), From: sender1.auth.From, Nonce: 0, GasPrice: 5, AfterNonceGap: false, LowestSenderNonce: 0},
+			{Hash: tx5.Hash(), From: sender2.auth.From, Nonce: 3, GasPrice: 50, AfterNonceGap: false, LowestSenderNonce: 2},
+		}, candidates)
+	})
+
+	t.Run(backend.name+"/Nonce", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		nonce, err := s.GetNonce(ctx, sender.auth.From)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), nonce)
+
+		require.NoError(t, s.AddTx(ctx, sender.poolTx(t, 4, 10, pool.TxStatusPending, now)))
+		require.NoError(t, s.AddTx(ctx, sender.poolTx(t, 5, 10, pool.TxStatusSelected, now)))
+		require.NoError(t, s.AddTx(ctx, sender.poolTx(t, 9, 10, pool.TxStatusFailed, now)))
+
+		nonce, err = s.GetNonce(ctx, sender.auth.From)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), nonce)
+	})
+
+	t.Run(backend.name+"/EarliestProcessedTx", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+
+		hash, err := s.GetEarliestProcessedTx(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, common.Hash{}, hash)
+
+		tx1 := sender.poolTx(t, 0, 10, pool.TxStatusPending, now.Add(-time.Hour))
+		tx2 := sender.poolTx(t, 1, 10, pool.TxStatusSelected, now.Add(time.Second))
+		tx3 := sender.poolTx(t, 2, 10, pool.TxStatusSelected, now)
+		for _, tx := range []pool.Transaction{tx1, tx2, tx3} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		hash, err = s.GetEarliestProcessedTx(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, tx3.Hash(), hash)
+	})
+
+	t.Run(backend.name+"/AddressLists", func(t *testing.T) {
+		s := backend.newStorage(t)
+		addr1 := common.HexToAddress("0x1")
+		addr2 := common.HexToAddress("0x2")
+
+		blocked, err := s.GetAllAddressesBlocked(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, blocked)
+		backend.blockAddress(t, s, addr1)
+		blocked, err = s.GetAllAddressesBlocked(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{addr1}, blocked)
+
+		require.NoError(t, s.AddAllowListAddresses(ctx, pool.AllowListSenders, []common.Address{addr1, addr2}))
+		require.NoError(t, s.AddAllowListAddresses(ctx, pool.AllowListSenders, []common.Address{addr1}))
+		require.NoError(t, s.AddAllowListAddresses(ctx, pool.AllowListDeployers, []common.Address{addr2}))
+
+		senders, err := s.GetAllowListAddresses(ctx, pool.AllowListSenders)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []common.Address{addr1, addr2}, senders)
+
+		require.NoError(t, s.DeleteAllowListAddresses(ctx, pool.AllowListSenders, []common.Address{addr1}))
+		senders, err = s.GetAllowListAddresses(ctx, pool.AllowListSenders)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{addr2}, senders)
+
+		deployers, err := s.GetAllowListAddresses(ctx, pool.AllowListDeployers)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{addr2}, deployers)
+
+		_, err = s.GetAllowListAddresses(ctx, pool.AllowListKind("unknown"))
+		assert.ErrorIs(t, err, pool.ErrInvalidAllowListKind)
+	})
+
+	t.Run(backend.name+"/Reputation", func(t *testing.T) {
+		s := backend.newStorage(t)
+		failedAt := now.UTC().Truncate(time.Microsecond)
+
+		reputation, err := s.AddReputationFailure(ctx, pool.ReputationIP, ip, failedAt.Add(-2*time.Hour), failedAt.Add(-3*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), reputation.Failures)
+		reputation, err = s.AddReputationFailure(ctx, pool.ReputationIP, ip, failedAt.Add(-time.Hour), failedAt.Add(-3*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), reputation.Failures)
+		assert.True(t, failedAt.Add(-2*time.Hour).Equal(reputation.WindowStart))
+
+		// the failures before the window are forgotten
+		reputation, err = s.AddReputationFailure(ctx, pool.ReputationIP, ip, failedAt, failedAt.Add(-90*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), reputation.Failures)
+		assert.True(t, failedAt.Equal(reputation.WindowStart))
+		assert.Nil(t, reputation.BannedUntil)
+
+		bannedUntil := failedAt.Add(time.Hour)
+		require.NoError(t, s.BanReputation(ctx, pool.ReputationIP, ip, bannedUntil))
+		_, err = s.AddReputationFailure(ctx, pool.ReputationSender, senderAddress, failedAt.Add(-2*time.Hour), failedAt.Add(-3*time.Hour))
+		require.NoError(t, err)
+
+		reputations, err := s.GetReputations(ctx)
+		require.NoError(t, err)
+		require.Len(t, reputations, 2)
+		assert.Equal(t, pool.ReputationIP, reputations[0].Kind)
+		assert.Equal(t, ip, reputations[0].Key)
+		require.NotNil(t, reputations[0].BannedUntil)
+		assert.True(t, bannedUntil.Equal(*reputations[0].BannedUntil))
+		assert.Equal(t, pool.ReputationSender, reputations[1].Kind)
+		assert.Equal(t, senderAddress, reputations[1].Key)
+
+		// banned reputations are kept until the ban ends
+		require.NoError(t, s.DeleteReputationsOlderThan(ctx, failedAt.Add(time.Minute)))
+		reputations, err = s.GetReputations(ctx)
+		require.NoError(t, err)
+		require.Len(t, reputations, 1)
+		assert.Equal(t, pool.ReputationIP, reputations[0].Kind)
+
+		require.NoError(t, s.DeleteReputation(ctx, pool.ReputationIP, ip))
+		reputations, err = s.GetReputations(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reputations)
+	})
+
+	t.Run(backend.name+"/Preconfirmations", func(t *testing.T) {
+		s := backend.newStorage(t)
+		sender := newStorageTestSender(t)
+		createdAt := now.Truncate(time.Microsecond)
+
+		tx1 := sender.poolTx(t, 0, 10, pool.TxStatusPending, now)
+		tx2 := sender.poolTx(t, 1, 10, pool.TxStatusPending, now)
+		tx2.IsPrivate = true
+		for _, tx := range []pool.Transaction{tx1, tx2} {
+			require.NoError(t, s.AddTx(ctx, tx))
+		}
+
+		require.NoError(t, s.AddPreconfirmation(ctx, pool.Preconfirmation{TxHash: tx1.Hash(), L2BlockNumber: 10, Index: 0, Status: 1, Signature: []byte{1}, CreatedAt: createdAt}))
+		require.NoError(t, s.AddPreconfirmation(ctx, pool.Preconfirmation{TxHash: tx2.Hash(), L2BlockNumber: 11, Index: 0, Status: 0, Signature: []byte{2}, CreatedAt: createdAt}))
+
+		preconfirmation, err := s.GetPreconfirmationByHash(ctx, tx2.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), preconfirmation.L2BlockNumber)
+		assert.Equal(t, uint64(0), preconfirmation.Status)
+		assert.Equal(t, []byte{2}, preconfirmation.Signature)
+		assert.True(t, preconfirmation.IsPrivate)
+		assert.False(t, preconfirmation.Rescinded)
+		assert.True(t, createdAt.Equal(preconfirmation.CreatedAt))
+
+		rescindedAt := createdAt.Add(time.Second)
+		hashes, err := s.RescindPreconfirmations(ctx, 11, rescindedAt)
+		require.NoError(t, err)
+		assert.Equal(t, []common.Hash{tx2.Hash()}, hashes)
+		hashes, err = s.RescindPreconfirmations(ctx, 11, rescindedAt)
+		require.NoError(t, err)
+		assert.Empty(t, hashes)
+
+		preconfirmation, err = s.GetPreconfirmationByHash(ctx, tx2.Hash())
+		require.NoError(t, err)
+		assert.True(t, preconfirmation.Rescinded)
+		assert.True(t, rescindedAt.Equal(preconfirmation.UpdatedAt))
+		preconfirmation, err = s.GetPreconfirmationByHash(ctx, tx1.Hash())
+		require.NoError(t, err)
+		assert.False(t, preconfirmation.Rescinded)
+
+		// A new pre-confirmation of the tx replaces the rescinded one
+		require.NoError(t, s.AddPreconfirmation(ctx, pool.Preconfirmation{TxHash: tx2.Hash(), L2BlockNumber: 12, Index: 1, Status: 1, Signature: []byte{3}, CreatedAt: rescindedAt}))
+		preconfirmation, err = s.GetPreconfirmationByHash(ctx, tx2.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12), preconfirmation.L2BlockNumber)
+		assert.Equal(t, uint64(1), preconfirmation.Index)
+		assert.False(t, preconfirmation.Rescinded)
+
+		// The pre-confirmations are deleted along with their txs
+		require.NoError(t, s.DeleteTransactionByHash(ctx, tx1.Hash()))
+		_, err = s.GetPreconfirmationByHash(ctx, tx1.Hash())
+		assert.ErrorIs(t, err, pool.ErrNotFound)
+		assert.Error(t, s.AddPreconfirmation(ctx, pool.Preconfirmation{TxHash: tx1.Hash(), Signature: []byte{1}, CreatedAt: createdAt}))
+	})
+
+	t.Run(backend.name+"/Concurrency", func(t *testing.T) {
+		s := backend.newStorage(t)
+		const senders, txsPerSender = 4, 10
+
+		var wg sync.WaitGroup
+		for i := 0; i < senders; i++ {
+			sender := newStorageTestSender(t)
+			txs := make([]pool.Transaction, 0, txsPerSender)
+			for nonce := uint64(0); nonce < txsPerSender; nonce++ {
+				txs = append(txs, sender.poolTx(t, nonce, 10, pool.TxStatusPending, now))
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, tx := range txs {
+					assert.NoError(t, s.AddTx(ctx, tx))
+					_, err := s.GetTransactionByHash(ctx, tx.Hash())
+					assert.NoError(t, err)
+					_, err = s.CountTransactionsByStatus(ctx, pool.TxStatusPending)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		count, err := s.CountTransactionsByStatus(ctx, pool.TxStatusPending)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(senders*txsPerSender), count)
+	})
+}
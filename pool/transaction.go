@@ -46,6 +46,8 @@ type Transaction struct {
 	IsWIP                 bool
 	IP                    string
 	FailedReason          *string
+	// IsPrivate hides the tx from the public views of the pool until it is included in a block
+	IsPrivate bool
 }
 
 // NewTransaction creates a new transaction
@@ -1,8 +1,185 @@
 package pool
 
-import "net"
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/exp/slices"
+)
+
+// TxValidator checks a tx against a rule of the fork ID it is going to be executed in
+type TxValidator func(ctx context.Context, poolTx Transaction, forkID uint64) error
+
+// TxValidationStage is the point of the pool validation where a rule is checked,
+// the stages keep the order and the precedence of the errors returned to the users
+type TxValidationStage int
+
+const (
+	// TxValidationStageType checks the tx type right after the chain ID
+	TxValidationStageType TxValidationStage = iota
+	// TxValidationStageSize checks the tx size once the sender is recovered
+	TxValidationStageSize
+	// TxValidationStageExecutor checks the executor constraints after the intrinsic gas
+	TxValidationStageExecutor
+)
+
+// forkTxValidator is a rule of the validator chain, applied to the txs executed in
+// the fork IDs from fromForkID to toForkID, both included
+type forkTxValidator struct {
+	name       string
+	stage      TxValidationStage
+	fromForkID uint64
+	// toForkID is the last fork ID the rule applies to, 0 means any later fork
+	toForkID uint64
+	validate TxValidator
+}
+
+func (v forkTxValidator) appliesTo(forkID uint64) bool {
+	return forkID >= v.fromForkID && (v.toForkID == 0 || forkID <= v.toForkID)
+}
+
+// forkTxTypes are the tx types supported by a range of fork IDs, both included
+type forkTxTypes struct {
+	fromForkID uint64
+	// toForkID is the last fork ID of the range, 0 means any later fork
+	toForkID uint64
+	txTypes  []uint8
+}
+
+// supportedTxTypes lists the tx types accepted by each fork. The batch L2 data
+// encodes the legacy tx fields only, with or without chain ID
+var supportedTxTypes = []forkTxTypes{
+	{fromForkID: 0, txTypes: []uint8{types.LegacyTxType}},
+}
 
 // IsValidIP returns true if the given string is a valid IP address
 func IsValidIP(ip string) bool {
 	return ip != "" && net.ParseIP(ip) != nil
 }
+
+// RegisterTxValidator adds a rule to the validator chain of the pool. The rule is
+// checked in the given stage for the txs going to be executed in the fork IDs from
+// fromForkID to toForkID, both included, toForkID 0 means any later fork. It allows
+// new forks to add rules, like new tx types, without changing the core validation.
+// The rules are only added, a tx must pass all the rules that apply to its fork, so
+// a rule can't relax the ones registered for all the forks, like the size limits
+func (p *Pool) RegisterTxValidator(name string, stage TxValidationStage, fromForkID, toForkID uint64, validator TxValidator) {
+	p.txValidatorsMux.Lock()
+	defer p.txValidatorsMux.Unlock()
+	p.txValidators = append(p.txValidators, forkTxValidator{
+		name:       name,
+		stage:      stage,
+		fromForkID: fromForkID,
+		toForkID:   toForkID,
+		validate:   validator,
+	})
+}
+
+// registerDefaultTxValidators registers the tx types of each fork and the rules
+// enforced by all the forks
+func (p *Pool) registerDefaultTxValidators() {
+	for _, forkTypes := range supportedTxTypes {
+		p.RegisterTxValidator("tx type", TxValidationStageType, forkTypes.fromForkID, forkTypes.toForkID, newTxTypeValidator(forkTypes))
+	}
+	p.RegisterTxValidator("tx size", TxValidationStageSize, 0, 0, p.validateTxSize)
+	p.RegisterTxValidator("executor fields", TxValidationStageExecutor, 0, 0, p.validateTxExecutorFields)
+}
+
+// txForkIDs returns the fork IDs a new tx may be executed in: the fork ID of the
+// current batch and, when a fork upgrade is scheduled after it, the upcoming one
+func (p *Pool) txForkIDs(ctx context.Context) ([]uint64, error) {
+	if p.state == nil {
+		return []uint64{p.cfg.ForkID}, nil
+	}
+
+	currentForkID, upcomingForkID, err := state.GetLastAndNextBatchForkIDs(ctx, p.state)
+	if err != nil {
+		log.Errorf("failed to get the fork IDs while adding tx to the pool: %v", err)
+		return nil, err
+	}
+
+	if upcomingForkID != currentForkID {
+		return []uint64{currentForkID, upcomingForkID}, nil
+	}
+	return []uint64{currentForkID}, nil
+}
+
+// validateTxForForks checks the tx against the rules of the given stage of each
+// fork ID it may be executed in, so the txs sent close to a fork upgrade are valid in both
+func (p *Pool) validateTxForForks(ctx context.Context, poolTx Transaction, forkIDs []uint64, stage TxValidationStage) error {
+	p.txValidatorsMux.RLock()
+	defer p.txValidatorsMux.RUnlock()
+	for _, forkID := range forkIDs {
+		for _, validator := range p.txValidators {
+			if validator.stage != stage || !validator.appliesTo(forkID) {
+				continue
+			}
+			if err := validator.validate(ctx, poolTx, forkID); err != nil {
+				log.Debugf("tx %s rejected by the %s rule of fork ID %d: %v", poolTx.Hash().String(), validator.name, forkID, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newTxTypeValidator accepts only the tx types supported by the forks of the range
+func newTxTypeValidator(forkTypes forkTxTypes) TxValidator {
+	return func(ctx context.Context, poolTx Transaction, forkID uint64) error {
+		if !slices.Contains(forkTypes.txTypes, poolTx.Type()) {
+			return ErrTxTypeNotSupported
+		}
+		return nil
+	}
+}
+
+// validateTxSize rejects transactions over defined size to prevent DOS attacks
+func (p *Pool) validateTxSize(ctx context.Context, poolTx Transaction, forkID uint64) error {
+	decodedTx, err := state.EncodeTransaction(poolTx.Transaction, 0xFF, forkID) //nolint: gomnd
+	if err != nil {
+		return ErrTxTypeNotSupported
+	}
+
+	if uint64(len(decodedTx)) > p.cfg.MaxTxBytesSize {
+		from, _ := state.GetSender(poolTx.Transaction)
+		log.Infof("%v: %v", ErrOversizedData.Error(), from.String())
+		return ErrOversizedData
+	}
+	return nil
+}
+
+// validateTxExecutorFields checks the field sizes of the transaction to make sure
+// they ar compatible with the Executor needs
+// GasLimit: 256 bits
+// GasPrice: 256 bits
+// Value: 256 bits
+// Data: 30000 bytes
+// Nonce: 64 bits
+// To: 160 bits
+// ChainId: 64 bits
+func (p *Pool) validateTxExecutorFields(ctx context.Context, poolTx Transaction, forkID uint64) error {
+	maxUint64BigInt := big.NewInt(0).SetUint64(math.MaxUint64)
+
+	// GasLimit, Nonce and To fields are limited by their types, no need to check
+	// Gas Price and Value are checked against the balance, and the max balance allowed
+	// by the merkletree service is uint256, in this case, if the transaction has a
+	// gas price or value bigger than uint256, the check against the balance will
+	// reject the transaction
+
+	dataSize := len(poolTx.Data())
+	if dataSize > p.cfg.MaxTxDataBytesSize {
+		return fmt.Errorf("data size bigger than allowed, current size is %v bytes and max allowed is %v bytes", dataSize, p.cfg.MaxTxDataBytesSize)
+	}
+
+	if poolTx.ChainId().Cmp(maxUint64BigInt) == 1 {
+		return fmt.Errorf("chain id higher than allowed, max allowed is %v", uint64(math.MaxUint64))
+	}
+
+	return nil
+}
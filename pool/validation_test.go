@@ -1,9 +1,18 @@
 package pool
 
 import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_IsValidIP(t *testing.T) {
@@ -25,3 +34,98 @@ func Test_IsValidIP(t *testing.T) {
 		})
 	}
 }
+
+// forkState is a stateInterface returning the fork ID of each batch
+type forkState struct {
+	stateInterface
+	lastBatchNumber uint64
+	forkIDs         map[uint64]uint64
+}
+
+func (s *forkState) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return s.lastBatchNumber, nil
+}
+
+func (s *forkState) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	return s.forkIDs[batchNumber]
+}
+
+func Test_ValidateTxForForks(t *testing.T) {
+	ctx := context.Background()
+	errNewRule := errors.New("rejected by the new fork")
+
+	st := &forkState{lastBatchNumber: 10, forkIDs: map[uint64]uint64{10: 8, 11: 8}}
+	p := &Pool{
+		state:           st,
+		cfg:             Config{MaxTxBytesSize: 100132, MaxTxDataBytesSize: 100000},
+		txValidatorsMux: new(sync.RWMutex),
+	}
+	p.registerDefaultTxValidators()
+
+	var checkedForks []uint64
+	p.RegisterTxValidator("new fork", TxValidationStageSize, 9, 0, func(ctx context.Context, poolTx Transaction, forkID uint64) error {
+		checkedForks = append(checkedForks, forkID)
+		return errNewRule
+	})
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signTx := func(signer types.Signer, tx *types.Transaction) Transaction {
+		signedTx, err := types.SignTx(tx, signer, privateKey)
+		require.NoError(t, err)
+		return *NewTransaction(*signedTx, "", false)
+	}
+	chainID := big.NewInt(1000)
+	legacyTx := signTx(types.NewEIP155Signer(chainID), types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil))
+	preEIP155Tx := signTx(types.HomesteadSigner{}, types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil))
+	dynamicFeeTx := signTx(types.NewLondonSigner(chainID), types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Gas: 21000}))
+
+	validate := func(poolTx Transaction) error {
+		forkIDs, err := p.txForkIDs(ctx)
+		require.NoError(t, err)
+		for _, stage := range []TxValidationStage{TxValidationStageType, TxValidationStageSize, TxValidationStageExecutor} {
+			if err := p.validateTxForForks(ctx, poolTx, forkIDs, stage); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// the new rule does not apply before the fork is scheduled
+	require.NoError(t, validate(legacyTx))
+	require.NoError(t, validate(preEIP155Tx))
+	assert.ErrorIs(t, validate(dynamicFeeTx), ErrTxTypeNotSupported)
+	assert.Empty(t, checkedForks)
+
+	// the txs are checked against the upcoming fork when it is scheduled for the next batch
+	st.forkIDs[11] = 9
+	assert.ErrorIs(t, validate(legacyTx), errNewRule)
+	assert.Equal(t, []uint64{9}, checkedForks)
+
+	// the tx type rules are checked before the rules of the later stages
+	assert.ErrorIs(t, validate(dynamicFeeTx), ErrTxTypeNotSupported)
+	assert.Equal(t, []uint64{9}, checkedForks)
+
+	// the txs without chain ID are supported by every fork
+	st.forkIDs[10], st.forkIDs[11] = 6, 6
+	require.NoError(t, validate(preEIP155Tx))
+	require.NoError(t, validate(legacyTx))
+
+	// the data size limit applies to every fork
+	data := make([]byte, 100001)
+	bigTx := signTx(types.NewEIP155Signer(chainID), types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), data))
+	st.forkIDs[10], st.forkIDs[11] = 8, 8
+	assert.Error(t, validate(bigTx))
+}
+
+func Test_ForkTxValidatorAppliesTo(t *testing.T) {
+	bounded := forkTxValidator{fromForkID: 7, toForkID: 9}
+	unbounded := forkTxValidator{fromForkID: 9}
+
+	assert.False(t, bounded.appliesTo(6))
+	assert.True(t, bounded.appliesTo(7))
+	assert.True(t, bounded.appliesTo(9))
+	assert.False(t, bounded.appliesTo(10))
+	assert.False(t, unbounded.appliesTo(8))
+	assert.True(t, unbounded.appliesTo(100))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,10 +21,11 @@ type addrQueue struct {
 	notReadyTxs       map[uint64]*TxTracker
 	forcedTxs         map[common.Hash]struct{}
 	pendingTxsToStore map[common.Hash]struct{}
+	priceBump         uint64
 }
 
 // newAddrQueue creates and init a addrQueue
-func newAddrQueue(addr common.Address, nonce uint64, balance *big.Int) *addrQueue {
+func newAddrQueue(addr common.Address, nonce uint64, balance *big.Int, priceBump uint64) *addrQueue {
 	return &addrQueue{
 		from:              addr,
 		fromStr:           addr.String(),
@@ -33,19 +35,20 @@ func newAddrQueue(addr common.Address, nonce uint64, balance *big.Int) *addrQueu
 		notReadyTxs:       make(map[uint64]*TxTracker),
 		forcedTxs:         make(map[common.Hash]struct{}),
 		pendingTxsToStore: make(map[common.Hash]struct{}),
+		priceBump:         priceBump,
 	}
 }
 
 // addTx adds a tx to the addrQueue and updates the ready a notReady Txs. Also if the new tx matches
-// an existing tx with the same nonce but the new tx has better or equal gasPrice, we will return in the replacedTx
-// the existing tx with lower gasPrice (the replacedTx will be later set as failed in the pool).
-// If the existing tx has better gasPrice then we will drop the new tx (dropReason = ErrDuplicatedNonce)
+// an existing tx with the same nonce but the new tx bumps its gasPrice at least priceBump percent, we will
+// return in the replacedTx the existing tx with lower gasPrice (the replacedTx will be later set as failed in the pool).
+// Otherwise we will drop the new tx (dropReason = ErrDuplicatedNonce)
 func (a *addrQueue) addTx(tx *TxTracker) (newReadyTx, prevReadyTx, replacedTx *TxTracker, dropReason error) {
 	var repTx *TxTracker
 
 	if a.currentNonce == tx.Nonce { // Is a possible readyTx
 		// We set the tx as readyTx if we do not have one assigned or if the gasPrice is better or equal than the current readyTx
-		if a.readyTx == nil || ((a.readyTx != nil) && (tx.GasPrice.Cmp(a.readyTx.GasPrice) >= 0)) {
+		if a.readyTx == nil || a.canBeReplacedBy(a.readyTx, tx) {
 			oldReadyTx := a.readyTx
 			if (oldReadyTx != nil) && (oldReadyTx.HashStr != tx.HashStr) {
 				// if it is a different tx then we need to return the replaced tx to set as failed in the pool
@@ -67,7 +70,7 @@ func (a *addrQueue) addTx(tx *TxTracker) (newReadyTx, prevReadyTx, replacedTx *T
 	}
 
 	nrTx, found := a.notReadyTxs[tx.Nonce]
-	if !found || ((found) && (tx.GasPrice.Cmp(nrTx.GasPrice) >= 0)) {
+	if !found || a.canBeReplacedBy(nrTx, tx) {
 		a.notReadyTxs[tx.Nonce] = tx
 		if (found) && (nrTx.HashStr != tx.HashStr) {
 			// if it is a different tx then we need to return the replaced tx to set as failed in the pool
@@ -80,6 +83,15 @@ func (a *addrQueue) addTx(tx *TxTracker) (newReadyTx, prevReadyTx, replacedTx *T
 	}
 }
 
+// canBeReplacedBy returns true if the tx can replace the existing tx with the same nonce, which requires
+// a bump of at least priceBump percent of both the gasPrice and the gas tip cap unless it is the same tx
+func (a *addrQueue) canBeReplacedBy(existingTx *TxTracker, tx *TxTracker) bool {
+	if existingTx.HashStr == tx.HashStr {
+		return tx.GasPrice.Cmp(existingTx.GasPrice) >= 0
+	}
+	return pool.IsPriceBumped(existingTx.GasPrice, tx.GasPrice, a.priceBump) && pool.IsPriceBumped(existingTx.GasTipCap, tx.GasTipCap, a.priceBump)
+}
+
 // addForcedTx adds a forced tx to the list of forced txs
 func (a *addrQueue) addForcedTx(txHash common.Hash) {
 	a.forcedTxs[txHash] = struct{}{}
@@ -27,7 +27,7 @@ type addrQueueAddTxTestCase struct {
 var addr addrQueue
 
 func newTestTxTracker(hash common.Hash, nonce uint64, gasPrice *big.Int, cost *big.Int) *TxTracker {
-	tx := TxTracker{Hash: hash, Nonce: nonce, GasPrice: gasPrice, Cost: cost}
+	tx := TxTracker{Hash: hash, Nonce: nonce, GasPrice: gasPrice, GasTipCap: gasPrice, Cost: cost}
 	tx.HashStr = tx.Hash.String()
 	return &tx
 }
@@ -212,3 +212,52 @@ func TestAddrQueue(t *testing.T) {
 		}
 	})
 }
+
+func TestAddrQueuePriceBump(t *testing.T) {
+	addr = *newAddrQueue(common.Address{0x99}, 1, new(big.Int).SetInt64(100), 10)
+
+	processAddTxTestCases(t, []addrQueueAddTxTestCase{
+		{
+			name: "Add ready tx 0x01 nonce 1", hash: common.Hash{0x1}, nonce: 1, gasPrice: new(big.Int).SetInt64(100), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x1},
+		},
+		{
+			name: "Add tx 0x11 nonce 1 with equal gasPrice", hash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(100), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x1},
+			err:             ErrDuplicatedNonce,
+		},
+		{
+			name: "Add tx 0x11 nonce 1 without enough gasPrice bump", hash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(109), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x1},
+			err:             ErrDuplicatedNonce,
+		},
+		{
+			name: "Replace readyTx 0x01 by tx 0x11 with enough gasPrice bump", hash: common.Hash{0x11}, nonce: 1, gasPrice: new(big.Int).SetInt64(110), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx:    common.Hash{0x11},
+			expectedReplacedTx: common.Hash{0x1},
+		},
+		{
+			name: "Add not ready tx 0x03 nonce 3", hash: common.Hash{0x3}, nonce: 3, gasPrice: new(big.Int).SetInt64(100), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x11},
+			expectedNotReadyTx: []notReadyTx{
+				{nonce: 3, hash: common.Hash{0x3}},
+			},
+		},
+		{
+			name: "Add tx 0x33 nonce 3 without enough gasPrice bump", hash: common.Hash{0x33}, nonce: 3, gasPrice: new(big.Int).SetInt64(105), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x11},
+			expectedNotReadyTx: []notReadyTx{
+				{nonce: 3, hash: common.Hash{0x3}},
+			},
+			err: ErrDuplicatedNonce,
+		},
+		{
+			name: "Replace not ready tx 0x03 by tx 0x33 with enough gasPrice bump", hash: common.Hash{0x33}, nonce: 3, gasPrice: new(big.Int).SetInt64(120), cost: new(big.Int).SetInt64(5),
+			expectedReadyTx: common.Hash{0x11},
+			expectedNotReadyTx: []notReadyTx{
+				{nonce: 3, hash: common.Hash{0x33}},
+			},
+			expectedReplacedTx: common.Hash{0x3},
+		},
+	})
+}
@@ -29,7 +29,20 @@ type Batch struct {
 	finalRemainingResources     state.BatchResources // remaining batch resources when a L2 block is processed
 	finalHighReservedZKCounters state.ZKCounters
 	closingReason               state.ClosingReason
+	exhaustedResource           string                   // batch resource that caused the batch closing
+	skippedTxs                  map[common.Hash]struct{} // txs that didn't fit in the remaining batch resources when they were selected or processed
+	countOfNodeOOCTxs           int                      // txs set as invalid because they don't fit in an empty batch
+	closedAt                    time.Time
 	finalLocalExitRoot          common.Hash
+	constraints                 state.BatchConstraintsCfg // batch constraints of the fork of the batch
+}
+
+// addSkippedTx records a tx that didn't fit in the remaining batch resources, a tx skipped several times is counted once
+func (b *Batch) addSkippedTx(txHash common.Hash) {
+	if b.skippedTxs == nil {
+		b.skippedTxs = make(map[common.Hash]struct{})
+	}
+	b.skippedTxs[txHash] = struct{}{}
 }
 
 func (b *Batch) isEmpty() bool {
@@ -81,6 +94,7 @@ func (f *finalizer) setWIPBatch(ctx context.Context, wipStateBatch *state.Batch)
 		wipStateBatchCountOfTxs = wipStateBatchCountOfTxs + len(rawBlock.Transactions)
 	}
 
+	f.setBatchConstraints(wipStateBatch.BatchNumber)
 	remainingResources := getMaxBatchResources(f.batchConstraints)
 	overflow, overflowResource := remainingResources.Sub(wipStateBatch.Resources)
 	if overflow {
@@ -101,6 +115,7 @@ func (f *finalizer) setWIPBatch(ctx context.Context, wipStateBatch *state.Batch)
 		imHighReservedZKCounters:    wipStateBatch.HighReservedZKCounters,
 		finalHighReservedZKCounters: wipStateBatch.HighReservedZKCounters,
 		finalLocalExitRoot:          wipStateBatch.LocalExitRoot,
+		constraints:                 f.batchConstraints,
 	}
 
 	return wipBatch, nil
@@ -129,8 +144,8 @@ func (f *finalizer) initWIPBatch(ctx context.Context) {
 	log.Infof("batch %d isClosed: %v", lastBatchNum, isClosed)
 
 	if isClosed { //if the last batch is close then open a new wip batch
-		if lastStateBatch.BatchNumber+1 == f.cfg.HaltOnBatchNumber {
-			f.Halt(ctx, fmt.Errorf("finalizer reached stop sequencer on batch number: %d", f.cfg.HaltOnBatchNumber), false)
+		if haltOnBatchNumber := f.haltOnBatchNumber.Load(); lastStateBatch.BatchNumber+1 == haltOnBatchNumber {
+			f.Halt(ctx, fmt.Errorf("finalizer reached stop sequencer on batch number: %d", haltOnBatchNumber), false)
 		}
 		f.wipBatch = f.openNewWIPBatch(lastStateBatch.BatchNumber+1, lastStateBatch.StateRoot)
 		f.pipBatch = nil
@@ -185,7 +200,7 @@ func (f *finalizer) finalizeWIPBatch(ctx context.Context, closeReason state.Clos
 
 	// Close the wip L2 block if it has transactions, otherwise we keep the wip L2 block to store it in the new wip batch
 	if !f.wipL2Block.isEmpty() {
-		f.closeWIPL2Block(ctx)
+		f.closeWIPL2Block(ctx, l2BlockBatchClosedClosingReason)
 	}
 
 	err := f.closeAndOpenNewWIPBatch(ctx, closeReason)
@@ -206,10 +221,18 @@ func (f *finalizer) finalizeSIPBatch(ctx context.Context) error {
 		return fmt.Errorf("error creating db transaction to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
 	}
 
-	// Close sip batch (close in statedb)
-	err = f.closeSIPBatch(ctx, dbTx)
+	// Check the sequencer still holds the leader lease (high availability mode)
+	err = f.checkLeaderLease(ctx, dbTx)
 	if err != nil {
-		return fmt.Errorf("failed to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
+		err = fmt.Errorf("failed to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
+	}
+
+	// Close sip batch (close in statedb)
+	if err == nil {
+		err = f.closeSIPBatch(ctx, dbTx)
+		if err != nil {
+			return fmt.Errorf("failed to close sip batch %d, error: %v", f.sipBatch.batchNumber, err)
+		}
 	}
 
 	if err != nil {
@@ -235,13 +258,14 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context, closeReason sta
 	f.nextForcedBatchesMux.Unlock()
 
 	f.wipBatch.closingReason = closeReason
+	f.wipBatch.closedAt = f.now()
 
 	var lastStateRoot common.Hash
 
 	//TODO: review forced batches implementation since is not good "idea" to check here for forced batches, maybe is better to do it on finalizeBatches loop
 	if processForcedBatches {
 		// If we have reach the time to sync stateroot or we will process forced batches we must close the current wip L2 block and wip batch
-		f.closeWIPL2Block(ctx)
+		f.closeWIPL2Block(ctx, l2BlockBatchClosedClosingReason)
 		// We need to wait that all pending L2 blocks are processed and stored
 		f.waitPendingL2Blocks()
 
@@ -260,16 +284,16 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context, closeReason sta
 
 	f.closeWIPBatch(ctx)
 
-	if lastBatchNumber+1 == f.cfg.HaltOnBatchNumber {
+	if haltOnBatchNumber := f.haltOnBatchNumber.Load(); lastBatchNumber+1 == haltOnBatchNumber {
 		f.waitPendingL2Blocks()
 
 		// We finalize the current sip batch
 		err := f.finalizeSIPBatch(ctx)
 		if err != nil {
-			return fmt.Errorf("error finalizing sip batch %d when halting on batch %d", f.sipBatch.batchNumber, f.cfg.HaltOnBatchNumber)
+			return fmt.Errorf("error finalizing sip batch %d when halting on batch %d", f.sipBatch.batchNumber, haltOnBatchNumber)
 		}
 
-		f.Halt(ctx, fmt.Errorf("finalizer reached stop sequencer on batch number: %d", f.cfg.HaltOnBatchNumber), false)
+		f.Halt(ctx, fmt.Errorf("finalizer reached stop sequencer on batch number: %d", haltOnBatchNumber), false)
 	}
 
 	// Process forced batches
@@ -302,6 +326,7 @@ func (f *finalizer) closeAndOpenNewWIPBatch(ctx context.Context, closeReason sta
 
 // openNewWIPBatch opens a new batch in the state and returns it as WipBatch
 func (f *finalizer) openNewWIPBatch(batchNumber uint64, stateRoot common.Hash) *Batch {
+	f.setBatchConstraints(batchNumber)
 	maxRemainingResources := getMaxBatchResources(f.batchConstraints)
 
 	return &Batch{
@@ -310,21 +335,40 @@ func (f *finalizer) openNewWIPBatch(batchNumber uint64, stateRoot common.Hash) *
 		initialStateRoot:        stateRoot,
 		imStateRoot:             stateRoot,
 		finalStateRoot:          stateRoot,
-		timestamp:               now(),
+		timestamp:               f.now(),
 		imRemainingResources:    maxRemainingResources,
 		finalRemainingResources: maxRemainingResources,
 		closingReason:           state.EmptyClosingReason,
 		finalLocalExitRoot:      state.ZeroHash,
+		constraints:             f.batchConstraints,
 	}
 }
 
+// setBatchConstraints sets the batch constraints of the fork the batch will be executed under, updating
+// them also in the worker when they change at a fork boundary
+func (f *finalizer) setBatchConstraints(batchNumber uint64) {
+	if len(f.batchCfg.ForkConstraints) == 0 {
+		return
+	}
+
+	forkID := f.stateIntf.GetForkIDByBatchNumber(batchNumber)
+	constraints := f.batchCfg.ConstraintsForForkID(forkID)
+	if constraints == f.batchConstraints {
+		return
+	}
+
+	log.Infof("batch constraints changed for batch %d (forkID %d), new constraints: %+v", batchNumber, forkID, constraints)
+	f.batchConstraints = constraints
+	f.workerIntf.SetBatchConstraints(constraints)
+}
+
 // insertSIPBatch inserts a new state-in-progress batch in the state db
 func (f *finalizer) insertSIPBatch(ctx context.Context, batchNumber uint64, stateRoot common.Hash, dbTx pgx.Tx) error {
 	// open next batch
 	newStateBatch := state.Batch{
 		BatchNumber:    batchNumber,
 		Coinbase:       f.l2Coinbase,
-		Timestamp:      now(),
+		Timestamp:      f.now(),
 		StateRoot:      stateRoot,
 		GlobalExitRoot: state.ZeroHash,
 		LocalExitRoot:  state.ZeroHash,
@@ -364,6 +408,31 @@ func (f *finalizer) closeWIPBatch(ctx context.Context) {
 	f.wipBatch = nil
 }
 
+// addBatchClosingInfo stores in the state the report about the closing of a batch, used for capacity planning
+func (f *finalizer) addBatchClosingInfo(ctx context.Context, batch *Batch, dbTx pgx.Tx) error {
+	closedAt := batch.closedAt
+	if closedAt.IsZero() {
+		closedAt = f.now()
+	}
+
+	closingInfo := &state.BatchClosingInfo{
+		BatchNumber:       batch.batchNumber,
+		ExhaustedResource: batch.exhaustedResource,
+		L2Blocks:          uint64(batch.countOfL2Blocks),
+		Txs:               uint64(batch.countOfTxs),
+		SkippedTxs:        uint64(len(batch.skippedTxs)),
+		NodeOOCTxs:        uint64(batch.countOfNodeOOCTxs),
+		ClosedAt:          closedAt,
+	}
+
+	err := f.stateIntf.AddBatchClosingInfo(ctx, closingInfo, dbTx)
+	if err != nil {
+		return fmt.Errorf("error storing closing info of batch %d, error: %v", batch.batchNumber, err)
+	}
+
+	return nil
+}
+
 // closeSIPBatch closes the current sip batch in the state
 func (f *finalizer) closeSIPBatch(ctx context.Context, dbTx pgx.Tx) error {
 	// Sanity check: this can't happen
@@ -376,7 +445,7 @@ func (f *finalizer) closeSIPBatch(ctx context.Context, dbTx pgx.Tx) error {
 		f.Halt(ctx, fmt.Errorf("closing sip batch %d without L2 blocks and should have at least 1", f.sipBatch.batchNumber), false)
 	}
 
-	usedResources := getUsedBatchResources(f.batchConstraints, f.sipBatch.imRemainingResources)
+	usedResources := getUsedBatchResources(f.sipBatch.constraints, f.sipBatch.imRemainingResources)
 	receipt := state.ProcessingReceipt{
 		BatchNumber:    f.sipBatch.batchNumber,
 		BatchResources: usedResources,
@@ -389,6 +458,11 @@ func (f *finalizer) closeSIPBatch(ctx context.Context, dbTx pgx.Tx) error {
 		return err
 	}
 
+	err = f.addBatchClosingInfo(ctx, f.sipBatch, dbTx)
+	if err != nil {
+		return err
+	}
+
 	// We store values needed for the batch sanity check in local variables, as we can execute the sanity check in a go func (parallel) and in this case f.sipBatch will be nil during some time
 	batchNumber := f.sipBatch.batchNumber
 	initialStateRoot := f.sipBatch.initialStateRoot
@@ -664,17 +738,18 @@ func (f *finalizer) checkIfFinalizeBatch() (bool, state.ClosingReason) {
 	exhausted, resourceDesc := f.isBatchResourcesMarginExhausted(f.wipBatch.imRemainingResources)
 	if exhausted {
 		log.Infof("closing batch %d because it exhausted margin for %s batch resource", f.wipBatch.batchNumber, resourceDesc)
+		f.wipBatch.exhaustedResource = resourceDesc
 		return true, state.ResourceMarginExhaustedClosingReason
 	}
 
 	// Forced batch deadline
-	if f.nextForcedBatchDeadline != 0 && now().Unix() >= f.nextForcedBatchDeadline {
+	if f.nextForcedBatchDeadline != 0 && f.now().Unix() >= f.nextForcedBatchDeadline {
 		log.Infof("closing batch %d, forced batch deadline encountered", f.wipBatch.batchNumber)
 		return true, state.ForcedBatchDeadlineClosingReason
 	}
 
 	// Batch timestamp resolution
-	if !f.wipBatch.isEmpty() && f.wipBatch.timestamp.Add(f.cfg.BatchMaxDeltaTimestamp.Duration).Before(time.Now()) {
+	if !f.wipBatch.isEmpty() && f.wipBatch.timestamp.Add(f.cfg.BatchMaxDeltaTimestamp.Duration).Before(f.now()) {
 		log.Infof("closing batch %d, because of batch max delta timestamp reached", f.wipBatch.batchNumber)
 		return true, state.MaxDeltaTimestampClosingReason
 	}
@@ -23,12 +23,34 @@ type Config struct {
 	// LoadPoolTxsCheckInterval is the time the sequencer waits to check in there are new txs in the pool
 	LoadPoolTxsCheckInterval types.Duration `mapstructure:"LoadPoolTxsCheckInterval"`
 
+	// LoadPoolTxsNotificationsEnabled loads the new txs as soon as the pool notifies they have been added,
+	// instead of waiting for the next check. The pool is still checked every LoadPoolTxsFallbackInterval
+	// to load the txs whose notification was missed
+	LoadPoolTxsNotificationsEnabled bool `mapstructure:"LoadPoolTxsNotificationsEnabled"`
+
+	// LoadPoolTxsFallbackInterval is the time the sequencer waits to check if there are new txs in the pool
+	// when LoadPoolTxsNotificationsEnabled is set
+	LoadPoolTxsFallbackInterval types.Duration `mapstructure:"LoadPoolTxsFallbackInterval"`
+
 	// StateConsistencyCheckInterval is the time the sequencer waits to check if a state inconsistency has happened
 	StateConsistencyCheckInterval types.Duration `mapstructure:"StateConsistencyCheckInterval"`
 
+	// ControlCheckInterval is the time the sequencer waits to check the runtime control requested by the operator
+	// (pause, resume, halt on batch number, close the wip L2 block or batch, drain and stop)
+	ControlCheckInterval types.Duration `mapstructure:"ControlCheckInterval"`
+
 	// L2Coinbase defines which address is going to receive the fees. It gets the config value from SequenceSender.L2Coinbase
 	L2Coinbase common.Address `mapstructure:"L2Coinbase"`
 
+	// TxOrdering is the config of the order in which the ready txs are selected to be processed
+	TxOrdering TxOrderingCfg `mapstructure:"TxOrdering"`
+
+	// HA is the config of the high availability mode
+	HA HACfg `mapstructure:"HA"`
+
+	// Preconfirmations is the config of the soft pre-confirmations of the txs executed in the wip L2 block
+	Preconfirmations PreconfirmationsCfg `mapstructure:"Preconfirmations"`
+
 	// Finalizer's specific config properties
 	Finalizer FinalizerCfg `mapstructure:"Finalizer"`
 
@@ -36,6 +58,57 @@ type Config struct {
 	StreamServer StreamServerCfg `mapstructure:"StreamServer"`
 }
 
+// TxOrderingCfg contains the configuration properties of the order of the ready txs
+type TxOrderingCfg struct {
+	// Policy is the order in which the ready txs are selected to be processed:
+	//   - "gasprice": highest gas price first (default)
+	//   - "fifo": first received by the sequencer first
+	//   - "roundrobin": one tx per sender and round, in arrival order within the round
+	//   - "priority": txs sent by PriorityAddresses first, then highest gas price first
+	//   - "zkefficiency": highest fee per unit of the batch resource the tx uses the most first
+	Policy TxOrderingPolicyType `mapstructure:"Policy"`
+
+	// PriorityAddresses are the senders whose txs are selected first by the "priority" policy,
+	// like the oracles or keepers of the chain
+	PriorityAddresses []common.Address `mapstructure:"PriorityAddresses"`
+}
+
+// HACfg contains the configuration properties of the high availability mode
+type HACfg struct {
+	// Enabled runs the sequencer in active/standby mode. Only the sequencer holding the leader lease in the
+	// state db sequences, the others keep their worker loaded with the pool txs and take over when the lease expires.
+	// The active sequencer exits when it loses the lease, so it must be restarted by its supervisor to run as standby
+	Enabled bool `mapstructure:"Enabled"`
+
+	// NodeID identifies the sequencer holding the leader lease, it must be unique among the sequencers sharing the state db
+	NodeID string `mapstructure:"NodeID"`
+
+	// LeaseDuration is the time the leader lease is valid if it is not renewed, and the max time
+	// the sequencing stops when the active sequencer dies
+	LeaseDuration types.Duration `mapstructure:"LeaseDuration"`
+
+	// RenewInterval is the time the active sequencer waits to renew the leader lease, it must be lower than LeaseDuration
+	RenewInterval types.Duration `mapstructure:"RenewInterval"`
+
+	// StandbyCheckInterval is the time a standby sequencer waits to try to acquire the leader lease
+	// and to update its worker with the pool txs
+	StandbyCheckInterval types.Duration `mapstructure:"StandbyCheckInterval"`
+}
+
+// PreconfirmationsCfg contains the configuration properties of the soft pre-confirmations
+type PreconfirmationsCfg struct {
+	// Enabled publishes in the pool a pre-confirmation signed by the sequencer for every tx as soon as it is
+	// executed in the wip L2 block, before the L2 block is closed. The pre-confirmations of the txs dropped
+	// by a L2 block reorg are rescinded
+	Enabled bool `mapstructure:"Enabled"`
+
+	// PrivateKey is the key store file of the key used to sign the pre-confirmations
+	PrivateKey types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+
+	// ChainID is the L2 ChainID provided by the Network Config, it is signed in the pre-confirmations
+	ChainID uint64
+}
+
 // StreamServerCfg contains the data streamer's configuration properties
 type StreamServerCfg struct {
 	// Port to listen on
@@ -97,7 +170,8 @@ type FinalizerCfg struct {
 	FlushIdCheckInterval types.Duration `mapstructure:"FlushIdCheckInterval"`
 
 	// HaltOnBatchNumber specifies the batch number where the Sequencer will stop to process more transactions and generate new batches.
-	// The Sequencer will halt after it closes the batch equal to this number
+	// The Sequencer will halt after it closes the batch equal to this number. It is overridden by the halt on batch number
+	// set at runtime with the sequencer CLI command, which is stored in the state db
 	HaltOnBatchNumber uint64 `mapstructure:"HaltOnBatchNumber"`
 
 	// SequentialBatchSanityCheck indicates if the reprocess of a closed batch (sanity check) must be done in a
@@ -108,10 +182,28 @@ type FinalizerCfg struct {
 	// in the processPendingL2Blocks go func
 	SequentialProcessL2Block bool `mapstructure:"SequentialProcessL2Block"`
 
+	// L2BlockLimits are the optional limits of a L2 block. When one of them is reached the wip L2 block is closed
+	// and a new one is opened in the same batch
+	L2BlockLimits L2BlockLimitsCfg `mapstructure:"L2BlockLimits"`
+
 	// Metrics is the config for the sequencer metrics
 	Metrics MetricsCfg `mapstructure:"Metrics"`
 }
 
+// L2BlockLimitsCfg contains the limits of a L2 block, a zero value means no limit
+type L2BlockLimitsCfg struct {
+	// MaxTxs is the max number of txs of a L2 block
+	MaxTxs uint64 `mapstructure:"MaxTxs"`
+
+	// MaxGas is the max gas used by the txs of a L2 block. A tx that doesn't fit in the gas left in the L2 block is not added
+	// to it, unless it is the first tx of the L2 block
+	MaxGas uint64 `mapstructure:"MaxGas"`
+
+	// MaxBatchResourcesPct is the max percentage of each batch resource (zkCounters and bytes) used by the txs of a L2 block.
+	// A tx that doesn't fit in the share left in the L2 block is not added to it, unless it is the first tx of the L2 block
+	MaxBatchResourcesPct uint32 `mapstructure:"MaxBatchResourcesPct"`
+}
+
 // MetricsCfg contains the sequencer metrics configuration properties
 type MetricsCfg struct {
 	// Interval is the interval of time to calculate sequencer metrics
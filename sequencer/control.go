@@ -0,0 +1,127 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// Pause stops the selection of new txs, the wip L2 block and batch are still closed on time
+func (f *finalizer) Pause() {
+	log.Info("finalizer paused")
+	f.paused.Store(true)
+}
+
+// Resume resumes the selection of new txs after a pause
+func (f *finalizer) Resume() {
+	log.Info("finalizer resumed")
+	f.paused.Store(false)
+}
+
+// SetHaltOnBatchNumber sets the batch number where the finalizer halts, 0 to unset it
+func (f *finalizer) SetHaltOnBatchNumber(batchNumber uint64) {
+	log.Infof("finalizer halt on batch number set to %d", batchNumber)
+	f.haltOnBatchNumber.Store(batchNumber)
+}
+
+// CloseWIPL2Block requests to close the wip L2 block and open a new one
+func (f *finalizer) CloseWIPL2Block() {
+	f.closeL2BlockRequested.Store(true)
+}
+
+// CloseWIPBatch requests to close the wip batch and open a new one
+func (f *finalizer) CloseWIPBatch() {
+	f.closeBatchRequested.Store(true)
+}
+
+// DrainAndStop requests to close the wip batch, store the pending L2 blocks and halt the finalizer
+func (f *finalizer) DrainAndStop() {
+	f.stopRequested.Store(true)
+}
+
+// applyControlRequests applies the close and stop requests of the operator, it returns true if the wip batch has been closed
+func (f *finalizer) applyControlRequests(ctx context.Context) bool {
+	if f.stopRequested.Load() {
+		// The halt point is stored so the sequencer remains stopped after a restart
+		haltOnBatchNumber := f.wipBatch.batchNumber + 1
+		f.SetHaltOnBatchNumber(haltOnBatchNumber)
+		err := f.stateIntf.SetSequencerHaltOnBatchNumber(ctx, haltOnBatchNumber, nil)
+		if err != nil {
+			log.Errorf("failed to store halt on batch number %d, error: %v", haltOnBatchNumber, err)
+		}
+
+		log.Infof("draining and stopping finalizer, closing wip batch %d requested by the operator", f.wipBatch.batchNumber)
+		f.finalizeWIPBatch(ctx, state.OperatorRequestClosingReason)
+		return true
+	}
+
+	if f.closeBatchRequested.CompareAndSwap(true, false) {
+		log.Infof("closing wip batch %d requested by the operator", f.wipBatch.batchNumber)
+		f.finalizeWIPBatch(ctx, state.OperatorRequestClosingReason)
+		return true
+	}
+
+	if f.closeL2BlockRequested.CompareAndSwap(true, false) {
+		log.Infof("closing wip L2 block [%d] requested by the operator", f.wipL2Block.trackingNum)
+		f.finalizeWIPL2Block(ctx, l2BlockOperatorRequestClosingReason)
+	}
+
+	return false
+}
+
+// loadControlRequests applies to the finalizer the runtime control requested by the operator through the state db
+// and keeps checking for new requests. The requests made before the sequencer starts only apply if they are persistent
+// (pause and halt on batch number)
+func (s *Sequencer) loadControlRequests(ctx context.Context, f finalizerControl, prev *state.SequencerControl) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.cfg.ControlCheckInterval.Duration):
+		}
+
+		control, err := s.stateIntf.GetSequencerControl(ctx, nil)
+		if err != nil {
+			log.Errorf("failed to get sequencer control requests, error: %v", err)
+			continue
+		}
+		applySequencerControl(f, prev, control)
+		prev = control
+	}
+}
+
+// applySequencerControl applies to the finalizer the changes of the runtime control since prev, nil if it is the first time
+func applySequencerControl(f finalizerControl, prev *state.SequencerControl, control *state.SequencerControl) {
+	if prev == nil {
+		if control.Paused {
+			f.Pause()
+		}
+		// The halt on batch number of the config applies if it is not set
+		if control.HaltOnBatchNumber != 0 {
+			f.SetHaltOnBatchNumber(control.HaltOnBatchNumber)
+		}
+		return
+	}
+
+	if control.Paused != prev.Paused {
+		if control.Paused {
+			f.Pause()
+		} else {
+			f.Resume()
+		}
+	}
+	if control.HaltOnBatchNumber != prev.HaltOnBatchNumber {
+		f.SetHaltOnBatchNumber(control.HaltOnBatchNumber)
+	}
+	if control.CloseL2BlockRequests > prev.CloseL2BlockRequests {
+		f.CloseWIPL2Block()
+	}
+	if control.CloseBatchRequests > prev.CloseBatchRequests {
+		f.CloseWIPBatch()
+	}
+	if control.StopRequests > prev.StopRequests {
+		f.DrainAndStop()
+	}
+}
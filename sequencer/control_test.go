@@ -0,0 +1,66 @@
+package sequencer
+
+import (
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/stretchr/testify/assert"
+)
+
+// finalizerControlRecorder is a finalizerControl recording the calls received
+type finalizerControlRecorder struct {
+	calls []string
+	halt  uint64
+}
+
+func (r *finalizerControlRecorder) Pause()  { r.calls = append(r.calls, "pause") }
+func (r *finalizerControlRecorder) Resume() { r.calls = append(r.calls, "resume") }
+func (r *finalizerControlRecorder) SetHaltOnBatchNumber(batchNumber uint64) {
+	r.calls = append(r.calls, "halt")
+	r.halt = batchNumber
+}
+func (r *finalizerControlRecorder) CloseWIPL2Block() { r.calls = append(r.calls, "closel2block") }
+func (r *finalizerControlRecorder) CloseWIPBatch()   { r.calls = append(r.calls, "closebatch") }
+func (r *finalizerControlRecorder) DrainAndStop()    { r.calls = append(r.calls, "stop") }
+
+func TestApplySequencerControl(t *testing.T) {
+	// the one-time requests made before the start are not applied
+	f := &finalizerControlRecorder{}
+	initial := &state.SequencerControl{Paused: true, HaltOnBatchNumber: 10, CloseBatchRequests: 3, StopRequests: 1}
+	applySequencerControl(f, nil, initial)
+	assert.Equal(t, []string{"pause", "halt"}, f.calls)
+	assert.Equal(t, uint64(10), f.halt)
+
+	// a halt on batch number not set does not override the config
+	f = &finalizerControlRecorder{}
+	applySequencerControl(f, nil, &state.SequencerControl{})
+	assert.Empty(t, f.calls)
+
+	// the changes since the previous check are applied
+	f = &finalizerControlRecorder{}
+	applySequencerControl(f, initial, &state.SequencerControl{Paused: false, HaltOnBatchNumber: 0, CloseL2BlockRequests: 1, CloseBatchRequests: 3, StopRequests: 2})
+	assert.Equal(t, []string{"resume", "halt", "closel2block", "stop"}, f.calls)
+	assert.Equal(t, uint64(0), f.halt)
+
+	f = &finalizerControlRecorder{}
+	applySequencerControl(f, initial, initial)
+	assert.Empty(t, f.calls)
+}
+
+func TestFinalizerControl(t *testing.T) {
+	f := &finalizer{}
+	f.Pause()
+	assert.True(t, f.paused.Load())
+	f.Resume()
+	assert.False(t, f.paused.Load())
+
+	f.SetHaltOnBatchNumber(5)
+	assert.Equal(t, uint64(5), f.haltOnBatchNumber.Load())
+
+	f.CloseWIPL2Block()
+	f.CloseWIPBatch()
+	f.DrainAndStop()
+	assert.True(t, f.closeL2BlockRequested.Load())
+	assert.True(t, f.closeBatchRequested.Load())
+	assert.True(t, f.stopRequested.Load())
+}
@@ -8,7 +8,7 @@ var (
 	// ErrEffectiveGasPriceReprocess happens when the effective gas price requires reexecution
 	ErrEffectiveGasPriceReprocess = errors.New("effective gas price requires reprocessing the transaction")
 	// ErrDuplicatedNonce is returned when adding a new tx to the worker and there is an existing tx
-	// with the same nonce and higher gasPrice (in this case we keep the existing tx)
+	// with the same nonce and the new tx doesn't bump its gasPrice enough (in this case we keep the existing tx)
 	ErrDuplicatedNonce = errors.New("duplicated nonce")
 	// ErrReplacedTransaction is returned when an existing tx is replaced by a new tx with the same nonce and higher gasPrice
 	ErrReplacedTransaction = errors.New("replaced transaction")
@@ -33,4 +33,6 @@ var (
 	ErrBatchResourceOverFlow = errors.New("batch resource overflow")
 	// ErrTransactionsListEmpty happens when txSortedList is empty
 	ErrTransactionsListEmpty = errors.New("transactions list empty")
+	// ErrNotSupportedBySimulation happens when the finalizer simulation calls a state or pool method that it doesn't simulate
+	ErrNotSupportedBySimulation = errors.New("not supported by the simulation")
 )
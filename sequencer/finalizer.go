@@ -20,6 +20,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
@@ -27,10 +28,6 @@ const (
 	changeL2BlockSize         = 9 //1 byte (tx type = 0B) + 4 bytes for deltaTimestamp + 4 for l1InfoTreeIndex
 )
 
-var (
-	now = time.Now
-)
-
 // finalizer represents the finalizer component of the sequencer.
 type finalizer struct {
 	cfg              FinalizerCfg
@@ -44,8 +41,11 @@ type finalizer struct {
 	pipBatch         *Batch // processing-in-progress batch is the batch that is being processing (L2 block process)
 	sipBatch         *Batch // storing-in-progress batch is the batch that is being stored/updated in the state db
 	wipL2Block       *L2Block
-	batchConstraints state.BatchConstraintsCfg
+	batchCfg         state.BatchConfig
+	batchConstraints state.BatchConstraintsCfg // batch constraints of the fork of the wip batch
 	haltFinalizer    atomic.Bool
+	leaderLease      *leaderLease  // leader lease held by the sequencer in high availability mode, nil if it is disabled
+	preconfirmer     *preconfirmer // publishes the pre-confirmations of the txs executed in the wip L2 block, nil if they are disabled
 	// stateroot sync
 	nextStateRootSync time.Time
 	// forced batches
@@ -72,6 +72,10 @@ type finalizer struct {
 	pendingL2BlocksToStoreWG *WaitGroupCount
 	// L2 block counter for tracking purposes
 	l2BlockCounter uint64
+	// number of the last L2 block opened, it is the number the L2 block will have when it is stored
+	lastPlannedL2BlockNumber uint64
+	// number a L2 block has been stored with when it differs from the planned one, used to resync the planned numbers
+	l2BlockNumberResync atomic.Pointer[l2BlockNumberResync]
 	// executor flushid control
 	proverID           string
 	storedFlushID      uint64
@@ -80,12 +84,20 @@ type finalizer struct {
 	pendingFlushIDCond *sync.Cond
 	// worker ready txs condition
 	workerReadyTxsCond *timeoutCond
+	waitNewTxs         func()           // waits for new ready txs when there are no txs to process
+	now                func() time.Time // clock used for the timestamps and the deadlines of the batches and L2 blocks
 	// interval metrics
 	metrics *intervalMetrics
 	// stream server
 	streamServer      *datastreamer.StreamServer
 	dataToStream      chan interface{}
 	dataToStreamCount atomic.Int32
+	// runtime control requested by the operator
+	paused                atomic.Bool
+	haltOnBatchNumber     atomic.Uint64
+	closeL2BlockRequested atomic.Bool
+	closeBatchRequested   atomic.Bool
+	stopRequested         atomic.Bool
 }
 
 // newFinalizer returns a new instance of Finalizer.
@@ -98,7 +110,7 @@ func newFinalizer(
 	etherman ethermanInterface,
 	l2Coinbase common.Address,
 	isSynced func(ctx context.Context) bool,
-	batchConstraints state.BatchConstraintsCfg,
+	batchCfg state.BatchConfig,
 	eventLog *event.EventLog,
 	streamServer *datastreamer.StreamServer,
 	workerReadyTxsCond *timeoutCond,
@@ -112,7 +124,8 @@ func newFinalizer(
 		poolIntf:         poolIntf,
 		stateIntf:        stateIntf,
 		etherman:         etherman,
-		batchConstraints: batchConstraints,
+		batchCfg:         batchCfg,
+		batchConstraints: batchCfg.Constraints,
 		// stateroot sync
 		nextStateRootSync: time.Now().Add(cfg.StateRootSyncInterval.Duration),
 		// forced batches
@@ -146,10 +159,14 @@ func newFinalizer(
 		// stream server
 		streamServer: streamServer,
 		dataToStream: dataToStream,
+		// clock
+		now: time.Now,
 	}
 
+	f.waitNewTxs = f.waitWorkerReadyTxs
 	f.l2BlockReorg.Store(false)
 	f.haltFinalizer.Store(false)
+	f.haltOnBatchNumber.Store(cfg.HaltOnBatchNumber)
 
 	return &f
 }
@@ -392,99 +409,142 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 	log.Debug("finalizer init loop")
 	showNotFoundTxLog := true // used to log debug only the first message when there is no txs to process
 	for {
-		if f.l2BlockReorg.Load() {
-			err := f.processL2BlockReorg(ctx)
-			if err != nil {
-				log.Errorf("error processing L2 block reorg, error: %v", err)
-			}
+		f.finalizeBatchesStep(ctx, &showNotFoundTxLog)
+
+		if err := ctx.Err(); err != nil {
+			log.Errorf("stopping finalizer because of context, error: %v", err)
+			return
 		}
+	}
+}
 
-		// We have reached the L2 block time, we need to close the current L2 block and open a new one
-		if f.wipL2Block.createdAt.Add(f.cfg.L2BlockMaxDeltaTimestamp.Duration).Before(time.Now()) {
-			f.finalizeWIPL2Block(ctx)
+// finalizeBatchesStep runs one iteration of the finalizer loop: it processes the next best fitting tx (or waits for new ready txs)
+// and closes the wip L2 block and the wip batch when needed
+func (f *finalizer) finalizeBatchesStep(ctx context.Context, showNotFoundTxLog *bool) {
+	if f.l2BlockReorg.Load() {
+		err := f.processL2BlockReorg(ctx)
+		if err != nil {
+			log.Errorf("error processing L2 block reorg, error: %v", err)
 		}
+	}
 
-		tx, oocTxs, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources, f.wipBatch.imHighReservedZKCounters, (f.wipBatch.countOfL2Blocks == 0 && f.wipL2Block.isEmpty()))
+	// Apply the close and stop requests of the operator
+	if f.applyControlRequests(ctx) {
+		return
+	}
 
-		// Set as invalid txs in the worker pool that will never fit into an empty batch
-		for _, oocTx := range oocTxs {
-			log.Infof("tx %s doesn't fits in empty batch %d (node OOC), setting tx as invalid in the pool", oocTx.HashStr, f.wipL2Block.trackingNum, f.wipBatch.batchNumber)
+	// We have reached the L2 block time, we need to close the current L2 block and open a new one
+	if f.wipL2Block.createdAt.Add(f.cfg.L2BlockMaxDeltaTimestamp.Duration).Before(f.now()) {
+		f.finalizeWIPL2Block(ctx, l2BlockMaxDeltaTimestampClosingReason)
+	}
 
-			f.LogEvent(ctx, event.Level_Info, event.EventID_NodeOOC,
-				fmt.Sprintf("tx %s doesn't fits in empty batch %d (node OOC), from: %s, IP: %s", oocTx.HashStr, f.wipBatch.batchNumber, oocTx.FromStr, oocTx.IP), nil)
+	var (
+		tx         *TxTracker
+		oocTxs     []*TxTracker
+		skippedTxs []*TxTracker
+		err        error
+	)
+	// The txs are selected with the remaining resources of the wip batch limited by the budget left in the wip L2 block
+	remainingResources, l2BlockLimitCloseReason := f.wipL2BlockRemainingResources()
+
+	// No new txs are selected while the finalizer is paused by the operator
+	if !f.paused.Load() {
+		tx, oocTxs, skippedTxs, err = f.workerIntf.GetBestFittingTx(remainingResources, f.wipBatch.imHighReservedZKCounters, (f.wipBatch.countOfL2Blocks == 0 && f.wipL2Block.isEmpty()))
+	}
 
-			// Delete the transaction from the worker
-			f.workerIntf.DeleteTx(oocTx.Hash, oocTx.From)
+	for _, skippedTx := range skippedTxs {
+		f.wipBatch.addSkippedTx(skippedTx.Hash)
+	}
 
-			errMsg := "node OOC"
-			err = f.poolIntf.UpdateTxStatus(ctx, oocTx.Hash, pool.TxStatusInvalid, false, &errMsg)
-			if err != nil {
-				log.Errorf("failed to update status to invalid in the pool for tx %s, error: %v", oocTx.Hash.String(), err)
-			}
-		}
+	// Set as invalid txs in the worker pool that will never fit into an empty batch
+	for _, oocTx := range oocTxs {
+		log.Infof("tx %s doesn't fits in empty batch %d (node OOC), setting tx as invalid in the pool", oocTx.HashStr, f.wipL2Block.trackingNum, f.wipBatch.batchNumber)
 
-		// We have txs pending to process but none of them fits into the wip batch we close the wip batch and open a new one
-		if err == ErrNoFittingTransaction {
-			f.finalizeWIPBatch(ctx, state.NoTxFitsClosingReason)
-			continue
-		}
+		f.LogEvent(ctx, event.Level_Info, event.EventID_NodeOOC,
+			fmt.Sprintf("tx %s doesn't fits in empty batch %d (node OOC), from: %s, IP: %s", oocTx.HashStr, f.wipBatch.batchNumber, oocTx.FromStr, oocTx.IP), nil)
 
-		if tx != nil {
-			showNotFoundTxLog = true
+		// Delete the transaction from the worker
+		f.workerIntf.DeleteTx(oocTx.Hash, oocTx.From)
+		f.wipBatch.countOfNodeOOCTxs++
 
-			firstTxProcess := true
+		errMsg := "node OOC"
+		err = f.poolIntf.UpdateTxStatus(ctx, oocTx.Hash, pool.TxStatusInvalid, false, &errMsg)
+		if err != nil {
+			log.Errorf("failed to update status to invalid in the pool for tx %s, error: %v", oocTx.Hash.String(), err)
+		}
+	}
 
-			for {
-				_, err := f.processTransaction(ctx, tx, firstTxProcess)
-				if err != nil {
-					if err == ErrEffectiveGasPriceReprocess {
-						firstTxProcess = false
-						log.Infof("reprocessing tx %s because of effective gas price calculation", tx.HashStr)
-						continue
-					} else if err == ErrBatchResourceOverFlow {
-						log.Infof("skipping tx %s due to a batch resource overflow", tx.HashStr)
-						break
-					} else {
-						log.Errorf("failed to process tx %s, error: %v", err)
-						break
-					}
-				}
-				break
-			}
-		} else {
-			idleTime := time.Now()
+	// We have txs pending to process but none of them fits into the budget left in the wip L2 block, we close the wip L2 block
+	// and the txs are selected again in a new L2 block with the remaining resources of the wip batch
+	if err == ErrNoFittingTransaction && l2BlockLimitCloseReason != "" {
+		f.finalizeWIPL2Block(ctx, l2BlockLimitCloseReason)
+		return
+	}
 
-			if showNotFoundTxLog {
-				log.Debug("no transactions to be processed. Waiting...")
-				showNotFoundTxLog = false
-			}
+	// We have txs pending to process but none of them fits into the wip batch we close the wip batch and open a new one
+	if err == ErrNoFittingTransaction {
+		f.finalizeWIPBatch(ctx, state.NoTxFitsClosingReason)
+		return
+	}
 
-			// wait for new ready txs in worker
-			f.workerReadyTxsCond.L.Lock()
-			f.workerReadyTxsCond.WaitOrTimeout(f.cfg.NewTxsWaitInterval.Duration)
-			f.workerReadyTxsCond.L.Unlock()
+	if tx != nil {
+		*showNotFoundTxLog = true
 
-			// Increase idle time of the WIP L2Block
-			f.wipL2Block.metrics.idleTime += time.Since(idleTime)
-		}
+		firstTxProcess := true
 
-		if f.haltFinalizer.Load() {
-			// There is a fatal error and we need to halt the finalizer and stop processing new txs
-			for {
-				time.Sleep(5 * time.Second) //nolint:gomnd
+		for {
+			_, err := f.processTransaction(ctx, tx, firstTxProcess)
+			if err != nil {
+				if err == ErrEffectiveGasPriceReprocess {
+					firstTxProcess = false
+					log.Infof("reprocessing tx %s because of effective gas price calculation", tx.HashStr)
+					continue
+				} else if err == ErrBatchResourceOverFlow {
+					log.Infof("skipping tx %s due to a batch resource overflow", tx.HashStr)
+					break
+				} else {
+					log.Errorf("failed to process tx %s, error: %v", err)
+					break
+				}
 			}
+			break
 		}
+	} else {
+		idleTime := time.Now()
 
-		// Check if we must finalize the batch due to a closing reason (resources exhausted, max txs, timestamp resolution, forced batches deadline)
-		if finalize, closeReason := f.checkIfFinalizeBatch(); finalize {
-			f.finalizeWIPBatch(ctx, closeReason)
+		if *showNotFoundTxLog {
+			log.Debug("no transactions to be processed. Waiting...")
+			*showNotFoundTxLog = false
 		}
 
-		if err := ctx.Err(); err != nil {
-			log.Errorf("stopping finalizer because of context, error: %v", err)
-			return
+		// wait for new ready txs in worker
+		f.waitNewTxs()
+
+		// Increase idle time of the WIP L2Block
+		f.wipL2Block.metrics.idleTime += time.Since(idleTime)
+	}
+
+	if f.haltFinalizer.Load() {
+		// There is a fatal error and we need to halt the finalizer and stop processing new txs
+		for {
+			time.Sleep(5 * time.Second) //nolint:gomnd
 		}
 	}
+
+	// Check if we must finalize the batch due to a closing reason (resources exhausted, max txs, timestamp resolution, forced batches deadline)
+	if finalize, closeReason := f.checkIfFinalizeBatch(); finalize {
+		f.finalizeWIPBatch(ctx, closeReason)
+	} else if finalize, closeReason := f.checkIfFinalizeL2Block(); finalize {
+		// Check if we must finalize the L2 block (but not the batch) due to a L2 block limit (max txs, max gas, max batch resources)
+		f.finalizeWIPL2Block(ctx, closeReason)
+	}
+}
+
+// waitWorkerReadyTxs waits until there are new ready txs in the worker or the NewTxsWaitInterval is reached
+func (f *finalizer) waitWorkerReadyTxs() {
+	f.workerReadyTxsCond.L.Lock()
+	f.workerReadyTxsCond.WaitOrTimeout(f.cfg.NewTxsWaitInterval.Duration)
+	f.workerReadyTxsCond.L.Unlock()
 }
 
 // processTransaction processes a single transaction.
@@ -511,8 +571,11 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 
 	txGasPrice := tx.GasPrice
 
+	// The sponsored bridge claims pay no fees so there is no effective gas price to calculate
+	sponsored := tx.IsSponsoredClaim
+
 	// If it is the first time we process this tx then we calculate the EffectiveGasPrice
-	if firstTxProcess {
+	if firstTxProcess && !sponsored {
 		// Get L1 gas price and store in txTracker to make it consistent during the lifespan of the transaction
 		tx.L1GasPrice, tx.L2GasPrice = f.poolIntf.GetL1AndL2GasPrice()
 		// Get the tx and l2 gas price we will use in the egp calculation. If egp is disabled we will use a "simulated" tx gas price
@@ -553,22 +616,29 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 		}
 	}
 
-	egpPercentage, err := f.effectiveGasPrice.CalculateEffectiveGasPricePercentage(txGasPrice, tx.EffectiveGasPrice)
-	if err != nil {
-		if f.effectiveGasPrice.IsEnabled() {
-			return nil, err
+	var egpPercentage uint8
+	if sponsored {
+		tx.EffectiveGasPrice.SetUint64(0)
+		tx.IsLastExecution = true
+		egpPercentage = state.MaxEffectivePercentage
+	} else {
+		egpPercentage, err = f.effectiveGasPrice.CalculateEffectiveGasPricePercentage(txGasPrice, tx.EffectiveGasPrice)
+		if err != nil {
+			if f.effectiveGasPrice.IsEnabled() {
+				return nil, err
+			} else {
+				log.Warnf("effectiveGasPrice is disabled, but failed to to calculate efftive gas price percentage (#1), error: %v", err)
+				tx.EGPLog.Error = fmt.Sprintf("%s; CalculateEffectiveGasPricePercentage#1: %s", tx.EGPLog.Error, err)
+			}
 		} else {
-			log.Warnf("effectiveGasPrice is disabled, but failed to to calculate efftive gas price percentage (#1), error: %v", err)
-			tx.EGPLog.Error = fmt.Sprintf("%s; CalculateEffectiveGasPricePercentage#1: %s", tx.EGPLog.Error, err)
+			// Save percentage for later logging
+			tx.EGPLog.Percentage = egpPercentage
 		}
-	} else {
-		// Save percentage for later logging
-		tx.EGPLog.Percentage = egpPercentage
-	}
 
-	// If EGP is disabled we use tx GasPrice (MaxEffectivePercentage=255)
-	if !f.effectiveGasPrice.IsEnabled() {
-		egpPercentage = state.MaxEffectivePercentage
+		// If EGP is disabled we use tx GasPrice (MaxEffectivePercentage=255)
+		if !f.effectiveGasPrice.IsEnabled() {
+			egpPercentage = state.MaxEffectivePercentage
+		}
 	}
 
 	// Assign applied EGP percentage to tx (TxTracker)
@@ -715,6 +785,7 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 
 			// Delete the transaction from the txSorted list
 			f.workerIntf.DeleteTx(tx.Hash, tx.From)
+			f.wipBatch.countOfNodeOOCTxs++
 
 			errMsg := "node OOC"
 			err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusInvalid, false, &errMsg)
@@ -730,6 +801,7 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 	// we update the ZKCounters of the tx and returns ErrBatchResourceOverFlow error
 	if !fits || subOverflow {
 		f.workerIntf.UpdateTxZKCounters(txResponse.TxHash, tx.From, result.UsedZkCounters, result.ReservedZkCounters)
+		f.wipBatch.addSkippedTx(tx.Hash)
 		return nil, ErrBatchResourceOverFlow, state.ZKCounters{}
 	}
 
@@ -744,7 +816,11 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 		tx.EGPLog.ValueFinal, tx.EGPLog.ValueFirst, tx.EGPLog.ValueSecond, tx.EGPLog.Percentage, tx.EGPLog.FinalDeviation, tx.EGPLog.MaxDeviation, tx.EGPLog.GasUsedFirst, tx.EGPLog.GasUsedSecond,
 		tx.EGPLog.GasPrice, tx.EGPLog.L1GasPrice, tx.EGPLog.L2GasPrice, tx.EGPLog.Reprocess, tx.EGPLog.GasPriceOC, tx.EGPLog.BalanceOC, egpEnabled, len(tx.RawTx), tx.HashStr, tx.EGPLog.Error)
 
-	f.wipL2Block.addTx(tx)
+	f.wipL2Block.addTx(tx, state.BatchResources{ZKCounters: result.UsedZkCounters, Bytes: uint64(len(tx.RawTx))})
+
+	if f.preconfirmer != nil {
+		f.preconfirmer.preconfirm(tx.Hash, f.wipL2Block.number, uint64(len(f.wipL2Block.transactions)-1), txResponse.RomError != nil)
+	}
 
 	f.wipBatch.countOfTxs++
 
@@ -924,6 +1000,20 @@ func (f *finalizer) Halt(ctx context.Context, err error, isFatal bool) {
 	}
 }
 
+// checkLeaderLease checks within the dbTx that the sequencer still holds the leader lease, so the data of
+// a sequencer that has lost the lease is never stored. It does nothing if the high availability mode is disabled
+func (f *finalizer) checkLeaderLease(ctx context.Context, dbTx pgx.Tx) error {
+	if f.leaderLease == nil {
+		return nil
+	}
+
+	err := f.stateIntf.CheckSequencerLease(ctx, f.leaderLease.holder, f.leaderLease.fencingToken, dbTx)
+	if err != nil {
+		return fmt.Errorf("failed to check leader lease of %s (fencing token: %d), error: %w", f.leaderLease.holder, f.leaderLease.fencingToken, err)
+	}
+	return nil
+}
+
 // LogEvent adds an event for runtime debugging
 func (f *finalizer) LogEvent(ctx context.Context, level event.Level, eventId event.EventID, description string, json interface{}) {
 	event := &event.Event{
@@ -117,7 +117,7 @@ func TestNewFinalizer(t *testing.T) {
 	poolMock.On("GetLastSentFlushID", context.Background()).Return(uint64(0), nil)
 
 	// arrange and act
-	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, l2Coinbase, isSynced, bc, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
+	f = newFinalizer(cfg, poolCfg, workerMock, poolMock, stateMock, ethermanMock, l2Coinbase, isSynced, state.BatchConfig{Constraints: bc}, eventLog, nil, newTimeoutCond(&sync.Mutex{}), nil)
 
 	// assert
 	assert.NotNil(t, f)
@@ -382,16 +382,12 @@ func TestNewFinalizer(t *testing.T) {
 
 /*func TestFinalizer_newWIPBatch(t *testing.T) {
 	// arrange
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
-
 	f = setupFinalizer(true)
+	f.now = testNow
 
 	processRequest := state.ProcessRequest{
 		Caller:       stateMetrics.SequencerCallerLabel,
-		Timestamp_V1: now(),
+		Timestamp_V1: f.now(),
 		Transactions: decodedBatchL2Data,
 	}
 	stateRootErr := errors.New("state root must have value to close batch")
@@ -403,7 +399,7 @@ func TestNewFinalizer(t *testing.T) {
 		coinbase:           f.sequencerAddress,
 		initialStateRoot:   newHash,
 		stateRoot:          newHash,
-		timestamp:          now(),
+		timestamp:          f.now(),
 		remainingResources: getMaxRemainingResources(f.batchConstraints),
 	}
 	closeBatchParams := ClosingBatchParameters{
@@ -416,7 +412,7 @@ func TestNewFinalizer(t *testing.T) {
 			StateRoot:      newHash,
 			GlobalExitRoot: oldHash,
 			Transactions:   txs,
-			Timestamp:      now(),
+			Timestamp:      f.now(),
 			BatchL2Data:    decodedBatchL2Data,
 		},
 	}
@@ -512,7 +508,7 @@ func TestNewFinalizer(t *testing.T) {
 				Sequencer:         seqAddr,
 				GlobalExitRoot:    oldHash,
 				RawTxsData:        nil,
-				ForcedAt:          now(),
+				ForcedAt:          f.now(),
 			}},
 			expectedWip:      &expectedForcedNewWipBatch,
 			closeBatchParams: closeBatchParams,
@@ -602,10 +598,7 @@ func TestNewFinalizer(t *testing.T) {
 /*func TestFinalizer_processForcedBatches(t *testing.T) {
 	var err error
 	f = setupFinalizer(false)
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
+	f.now = testNow
 	ctx = context.Background()
 	RawTxsData1 := make([]byte, 0, 2)
 	RawTxsData1 = append(RawTxsData1, []byte(testBatchL2DataAsString)...)
@@ -688,7 +681,7 @@ func TestNewFinalizer(t *testing.T) {
 					batchResponse: batchResponse1,
 					batchNumber:   f.wipBatch.batchNumber + 1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  stateRootHashes[0],
 					isForcedBatch: true,
 					response:      txResp1,
@@ -699,7 +692,7 @@ func TestNewFinalizer(t *testing.T) {
 					batchResponse: batchResponse2,
 					batchNumber:   f.wipBatch.batchNumber + 2,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  stateRootHashes[1],
 					isForcedBatch: true,
 					response:      txResp2,
@@ -729,7 +722,7 @@ func TestNewFinalizer(t *testing.T) {
 					batchResponse: batchResponse1,
 					batchNumber:   f.wipBatch.batchNumber + 1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  stateRootHashes[0],
 					isForcedBatch: true,
 					response:      txResp1,
@@ -740,7 +733,7 @@ func TestNewFinalizer(t *testing.T) {
 					batchResponse: batchResponse2,
 					batchNumber:   f.wipBatch.batchNumber + 2,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  stateRootHashes[1],
 					isForcedBatch: true,
 					response:      txResp2,
@@ -797,7 +790,7 @@ func TestNewFinalizer(t *testing.T) {
 						GlobalExitRoot_V1: forcedBatch.GlobalExitRoot,
 						Transactions:      forcedBatch.RawTxsData,
 						Coinbase:          f.sequencerAddress,
-						Timestamp_V1:      now(),
+						Timestamp_V1:      f.now(),
 						Caller:            stateMetrics.SequencerCallerLabel,
 					}
 					var currResp *state.ProcessBatchResponse
@@ -855,17 +848,14 @@ func TestNewFinalizer(t *testing.T) {
 /*func TestFinalizer_openWIPBatch(t *testing.T) {
 	// arrange
 	f = setupFinalizer(true)
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
+	f.now = testNow
 	batchNum := f.wipBatch.batchNumber + 1
 	expectedWipBatch := &Batch{
 		batchNumber:        batchNum,
 		coinbase:           f.sequencerAddress,
 		initialStateRoot:   oldHash,
 		imStateRoot:        oldHash,
-		timestamp:          now(),
+		timestamp:          f.now(),
 		remainingResources: getMaxRemainingResources(f.batchConstraints),
 	}
 	testCases := []struct {
@@ -984,6 +974,9 @@ func TestFinalizer_finalizeSIPBatch(t *testing.T) {
 			stateMock.On("CloseWIPBatch", ctx, receipt, mock.Anything).Return(tc.managerErr).Once()
 			stateMock.On("GetForkIDByBatchNumber", mock.Anything).Return(uint64(state.FORKID_BLUEBERRY))
 			if tc.managerErr == nil {
+				stateMock.On("AddBatchClosingInfo", ctx, mock.MatchedBy(func(closingInfo *state.BatchClosingInfo) bool {
+					return closingInfo.BatchNumber == f.sipBatch.batchNumber && closingInfo.L2Blocks == 1
+				}), mock.Anything).Return(nilErr).Once()
 				stateMock.On("GetBatchByNumber", ctx, f.sipBatch.batchNumber, nil).Return(&state.Batch{BatchNumber: f.sipBatch.batchNumber}, nilErr).Once()
 				stateMock.On("GetForkIDByBatchNumber", f.wipBatch.batchNumber).Return(uint64(9)).Once()
 				stateMock.On("GetL1InfoTreeDataFromBatchL2Data", ctx, mock.Anything, nil).Return(map[uint32]state.L1DataV2{}, state.ZeroHash, state.ZeroHash, nil)
@@ -1010,10 +1003,7 @@ func TestFinalizer_finalizeSIPBatch(t *testing.T) {
 func TestFinalizer_isDeadlineEncountered(t *testing.T) {
 	// arrange
 	f = setupFinalizer(true)
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
+	f.now = testNow
 	testCases := []struct {
 		name                        string
 		nextForcedBatch             int64
@@ -1028,12 +1018,12 @@ func TestFinalizer_isDeadlineEncountered(t *testing.T) {
 		},
 		{
 			name:            "Forced batch deadline",
-			nextForcedBatch: now().Add(time.Second).Unix(),
+			nextForcedBatch: f.now().Add(time.Second).Unix(),
 			expected:        true,
 		},
 		{
 			name:             "Delayed batch deadline",
-			nextDelayedBatch: now().Add(time.Second).Unix(),
+			nextDelayedBatch: f.now().Add(time.Second).Unix(),
 			expected:         false,
 		},
 		{
@@ -1048,7 +1038,7 @@ func TestFinalizer_isDeadlineEncountered(t *testing.T) {
 			// arrange
 			f.nextForcedBatchDeadline = tc.nextForcedBatch
 			if tc.expected == true {
-				now = func() time.Time {
+				f.now = func() time.Time {
 					return testNow().Add(time.Second * 2)
 				}
 			}
@@ -1056,7 +1046,7 @@ func TestFinalizer_isDeadlineEncountered(t *testing.T) {
 			// specifically for "Timestamp resolution deadline" test case
 			if tc.timestampResolutionDeadline == true {
 				// ensure that the batch is not empty and the timestamp is in the past
-				f.wipBatch.timestamp = now().Add(-f.cfg.BatchMaxDeltaTimestamp.Duration * 2)
+				f.wipBatch.timestamp = f.now().Add(-f.cfg.BatchMaxDeltaTimestamp.Duration*2 - time.Second)
 				f.wipBatch.countOfL2Blocks = 1
 			}
 
@@ -1401,10 +1391,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 	RawTxsData2 := make([]byte, 0, 2)
 
 	f = setupFinalizer(false)
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
+	f.now = testNow
 
 	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(pvtKey, "0x"))
 	require.NoError(t, err)
@@ -1502,7 +1489,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 				Transactions: tx1Plustx2,
 				BatchNumber:  1,
 				Coinbase:     seqAddr,
-				Timestamp_V1: now(),
+				Timestamp_V1: f.now(),
 				OldStateRoot: oldHash,
 			},
 			result:       successfulBatchResp,
@@ -1513,7 +1500,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 					from:          auth.From,
 					batchNumber:   1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  oldHash,
 					response:      txResponseOne,
 					isForcedBatch: true,
@@ -1524,7 +1511,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 					from:          auth.From,
 					batchNumber:   1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  newHash,
 					response:      txResponseTwo,
 					isForcedBatch: true,
@@ -1537,7 +1524,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 			request: state.ProcessRequest{
 				BatchNumber:  1,
 				Coinbase:     seqAddr,
-				Timestamp_V1: now(),
+				Timestamp_V1: f.now(),
 				OldStateRoot: oldHash,
 			},
 			result:       revertedBatchResp,
@@ -1548,7 +1535,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 					from:          auth.From,
 					batchNumber:   1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  oldHash,
 					response:      txResponseReverted,
 					isForcedBatch: true,
@@ -1560,7 +1547,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 			request: state.ProcessRequest{
 				BatchNumber:  1,
 				Coinbase:     seqAddr,
-				Timestamp_V1: now(),
+				Timestamp_V1: f.now(),
 				OldStateRoot: oldHash,
 			},
 
@@ -1572,7 +1559,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 					from:          auth.From,
 					batchNumber:   1,
 					coinbase:      seqAddr,
-					timestamp:     now(),
+					timestamp:     f.now(),
 					oldStateRoot:  oldHash,
 					response:      txResponseOne,
 					isForcedBatch: true,
@@ -1638,7 +1625,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 			expectedTxToStore: transactionToStore{
 				batchNumber:  1,
 				coinbase:     seqAddr,
-				timestamp:    now(),
+				timestamp:    f.now(),
 				oldStateRoot: oldHash,
 				response: &state.ProcessTransactionResponse{
 					TxHash: txHash,
@@ -1659,7 +1646,7 @@ func TestFinalizer_checkRemainingResources(t *testing.T) {
 			expectedTxToStore: transactionToStore{
 				batchNumber:  1,
 				coinbase:     seqAddr,
-				timestamp:    now(),
+				timestamp:    f.now(),
 				oldStateRoot: oldHash,
 				response: &state.ProcessTransactionResponse{
 					TxHash: txHash2,
@@ -2059,14 +2046,196 @@ func TestFinalizer_isBatchAlmostFull(t *testing.T) {
 	}
 }
 
+func TestFinalizer_checkIfFinalizeL2Block(t *testing.T) {
+	// arrange
+	tx := &TxTracker{}
+	testCases := []struct {
+		name                string
+		limits              L2BlockLimitsCfg
+		txs                 []*TxTracker
+		usedResources       state.BatchResources
+		expectedResult      bool
+		expectedCloseReason l2BlockClosingReason
+	}{
+		{
+			name:           "No limits",
+			txs:            []*TxTracker{tx, tx, tx},
+			usedResources:  state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1000000, Steps: bc.MaxSteps}},
+			expectedResult: false,
+		},
+		{
+			name:           "Empty L2 block",
+			limits:         L2BlockLimitsCfg{MaxTxs: 1, MaxGas: 1, MaxBatchResourcesPct: 1},
+			expectedResult: false,
+		},
+		{
+			name:                "Max txs reached",
+			limits:              L2BlockLimitsCfg{MaxTxs: 3},
+			txs:                 []*TxTracker{tx, tx, tx},
+			expectedResult:      true,
+			expectedCloseReason: l2BlockMaxTxsClosingReason,
+		},
+		{
+			name:           "Max txs not reached",
+			limits:         L2BlockLimitsCfg{MaxTxs: 3},
+			txs:            []*TxTracker{tx, tx},
+			expectedResult: false,
+		},
+		{
+			name:                "Max gas reached",
+			limits:              L2BlockLimitsCfg{MaxGas: 100000},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 100000}},
+			expectedResult:      true,
+			expectedCloseReason: l2BlockMaxGasClosingReason,
+		},
+		{
+			name:           "Max gas not reached",
+			limits:         L2BlockLimitsCfg{MaxGas: 100000},
+			txs:            []*TxTracker{tx},
+			usedResources:  state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 99999}},
+			expectedResult: false,
+		},
+		{
+			name:                "Max batch resources reached - Steps",
+			limits:              L2BlockLimitsCfg{MaxBatchResourcesPct: 50},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{ZKCounters: state.ZKCounters{Steps: bc.MaxSteps / 2}},
+			expectedResult:      true,
+			expectedCloseReason: l2BlockMaxBatchResourcesClosingReason,
+		},
+		{
+			name:                "Max batch resources reached - Bytes",
+			limits:              L2BlockLimitsCfg{MaxBatchResourcesPct: 50},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{Bytes: bc.MaxBatchBytesSize / 2},
+			expectedResult:      true,
+			expectedCloseReason: l2BlockMaxBatchResourcesClosingReason,
+		},
+		{
+			name:           "Max batch resources not reached",
+			limits:         L2BlockLimitsCfg{MaxBatchResourcesPct: 50},
+			txs:            []*TxTracker{tx},
+			usedResources:  state.BatchResources{ZKCounters: state.ZKCounters{Steps: bc.MaxSteps/2 - 1, KeccakHashes: bc.MaxKeccakHashes/2 - 1}},
+			expectedResult: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f = setupFinalizer(true)
+			f.cfg.L2BlockLimits = tc.limits
+			f.wipL2Block = &L2Block{transactions: tc.txs, usedResources: tc.usedResources}
+
+			// act
+			result, closeReason := f.checkIfFinalizeL2Block()
+
+			// assert
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedCloseReason, closeReason)
+		})
+	}
+}
+
+func TestFinalizer_wipL2BlockRemainingResources(t *testing.T) {
+	// arrange
+	tx := &TxTracker{}
+	batchRemaining := state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1000000, Steps: bc.MaxSteps / 2}, Bytes: bc.MaxBatchBytesSize / 2}
+	testCases := []struct {
+		name                string
+		limits              L2BlockLimitsCfg
+		txs                 []*TxTracker
+		usedResources       state.BatchResources
+		expectedResources   state.BatchResources
+		expectedCloseReason l2BlockClosingReason
+	}{
+		{
+			name:              "No limits",
+			txs:               []*TxTracker{tx},
+			usedResources:     state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1000000}},
+			expectedResources: batchRemaining,
+		},
+		{
+			name:              "Empty L2 block",
+			limits:            L2BlockLimitsCfg{MaxGas: 1, MaxBatchResourcesPct: 1},
+			expectedResources: batchRemaining,
+		},
+		{
+			name:                "Max gas narrows the gas",
+			limits:              L2BlockLimitsCfg{MaxGas: 100000},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 60000}},
+			expectedResources:   state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 40000, Steps: bc.MaxSteps / 2}, Bytes: bc.MaxBatchBytesSize / 2},
+			expectedCloseReason: l2BlockMaxGasClosingReason,
+		},
+		{
+			name:                "Max gas exceeded by the first tx",
+			limits:              L2BlockLimitsCfg{MaxGas: 100000},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 150000}},
+			expectedResources:   state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 0, Steps: bc.MaxSteps / 2}, Bytes: bc.MaxBatchBytesSize / 2},
+			expectedCloseReason: l2BlockMaxGasClosingReason,
+		},
+		{
+			name:                "Max batch resources narrows the steps",
+			limits:              L2BlockLimitsCfg{MaxBatchResourcesPct: 50},
+			txs:                 []*TxTracker{tx},
+			usedResources:       state.BatchResources{ZKCounters: state.ZKCounters{Steps: 1000}},
+			expectedResources:   state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1000000, Steps: bc.MaxSteps/2 - 1000}, Bytes: bc.MaxBatchBytesSize / 2},
+			expectedCloseReason: l2BlockMaxBatchResourcesClosingReason,
+		},
+		{
+			name:              "Budget greater than the batch remaining resources",
+			limits:            L2BlockLimitsCfg{MaxGas: 2000000, MaxBatchResourcesPct: 100},
+			txs:               []*TxTracker{tx},
+			usedResources:     state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1000}},
+			expectedResources: batchRemaining,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			f = setupFinalizer(true)
+			f.cfg.L2BlockLimits = tc.limits
+			f.wipBatch.imRemainingResources = batchRemaining
+			f.wipL2Block = &L2Block{transactions: tc.txs, usedResources: tc.usedResources}
+
+			// act
+			resources, closeReason := f.wipL2BlockRemainingResources()
+
+			// assert
+			assert.Equal(t, tc.expectedResources, resources)
+			assert.Equal(t, tc.expectedCloseReason, closeReason)
+		})
+	}
+}
+
+func TestFinalizer_planL2BlockNumber(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	f.lastPlannedL2BlockNumber = 10
+
+	// act and assert
+	assert.Equal(t, uint64(11), f.planL2BlockNumber(1))
+	assert.Equal(t, uint64(12), f.planL2BlockNumber(2))
+
+	// L2 block [1] has been stored as 12 instead of 11, so L2 block [3] will be 14 instead of 13
+	f.l2BlockNumberResync.Store(&l2BlockNumberResync{number: 12, trackingNum: 1})
+	assert.Equal(t, uint64(14), f.planL2BlockNumber(3))
+	assert.Equal(t, uint64(15), f.planL2BlockNumber(4))
+
+	// L2 block [2] stored as 13 doesn't shift again the numbers already resynced
+	f.l2BlockNumberResync.Store(&l2BlockNumberResync{number: 13, trackingNum: 2})
+	assert.Equal(t, uint64(16), f.planL2BlockNumber(5))
+}
+
 func TestFinalizer_setNextForcedBatchDeadline(t *testing.T) {
 	// arrange
 	f = setupFinalizer(false)
-	now = testNow
-	defer func() {
-		now = time.Now
-	}()
-	expected := now().Unix() + int64(f.cfg.ForcedBatchesTimeout.Duration.Seconds())
+	f.now = testNow
+	expected := f.now().Unix() + int64(f.cfg.ForcedBatchesTimeout.Duration.Seconds())
 
 	// act
 	f.setNextForcedBatchDeadline()
@@ -2075,6 +2244,35 @@ func TestFinalizer_setNextForcedBatchDeadline(t *testing.T) {
 	assert.Equal(t, expected, f.nextForcedBatchDeadline)
 }
 
+func TestFinalizer_openNewWIPBatchForkConstraints(t *testing.T) {
+	// arrange
+	f = setupFinalizer(false)
+	f.batchCfg.ForkConstraints = []state.ForkBatchConstraintsCfg{
+		{ForkID: state.FORKID_ELDERBERRY, Constraints: state.BatchConstraintsCfg{MaxSteps: bc.MaxSteps * 2}},
+	}
+	forkConstraints := bc
+	forkConstraints.MaxSteps = bc.MaxSteps * 2
+	stateMock.On("GetForkIDByBatchNumber", uint64(1)).Return(uint64(state.FORKID_ETROG)).Once()
+	stateMock.On("GetForkIDByBatchNumber", uint64(2)).Return(uint64(state.FORKID_ELDERBERRY)).Twice()
+	workerMock.On("SetBatchConstraints", forkConstraints).Return().Once()
+
+	// act and assert
+	batch := f.openNewWIPBatch(1, oldHash)
+	assert.Equal(t, bc, batch.constraints)
+	assert.Equal(t, bc.MaxSteps, batch.imRemainingResources.ZKCounters.Steps)
+
+	// the constraints are updated at the fork boundary
+	batch = f.openNewWIPBatch(2, oldHash)
+	assert.Equal(t, forkConstraints, f.batchConstraints)
+	assert.Equal(t, forkConstraints, batch.constraints)
+	assert.Equal(t, forkConstraints.MaxSteps, batch.finalRemainingResources.ZKCounters.Steps)
+
+	// the worker is only updated when the constraints change
+	f.openNewWIPBatch(2, oldHash)
+	stateMock.AssertExpectations(t)
+	workerMock.AssertExpectations(t)
+}
+
 func TestFinalizer_getConstraintThresholdUint64(t *testing.T) {
 	// arrange
 	f = setupFinalizer(false)
@@ -2200,9 +2398,10 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 			coinbase:             l2Coinbase,
 			initialStateRoot:     oldHash,
 			imStateRoot:          newHash,
-			timestamp:            now(),
+			timestamp:            time.Now(),
 			imRemainingResources: getMaxBatchResources(bc),
 			closingReason:        state.EmptyClosingReason,
+			constraints:          bc,
 		}
 	}
 	eventStorage, err := nileventstorage.NewNilEventStorage()
@@ -2219,6 +2418,7 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 		stateIntf:                  stateMock,
 		wipBatch:                   wipBatch,
 		sipBatch:                   wipBatch,
+		batchCfg:                   state.BatchConfig{Constraints: bc},
 		batchConstraints:           bc,
 		nextForcedBatches:          make([]state.ForcedBatch, 0),
 		nextForcedBatchDeadline:    0,
@@ -2234,5 +2434,6 @@ func setupFinalizer(withWipBatch bool) *finalizer {
 		proverID:                   "",
 		lastPendingFlushID:         0,
 		pendingFlushIDCond:         sync.NewCond(new(sync.Mutex)),
+		now:                        time.Now,
 	}
 }
@@ -227,7 +227,7 @@ func (f *finalizer) sortForcedBatches(fb []state.ForcedBatch) []state.ForcedBatc
 
 // setNextForcedBatchDeadline sets the next forced batch deadline
 func (f *finalizer) setNextForcedBatchDeadline() {
-	f.nextForcedBatchDeadline = now().Unix() + int64(f.cfg.ForcedBatchesTimeout.Duration.Seconds())
+	f.nextForcedBatchDeadline = f.now().Unix() + int64(f.cfg.ForcedBatchesTimeout.Duration.Seconds())
 }
 
 func (f *finalizer) checkForcedBatches(ctx context.Context) {
@@ -22,6 +22,9 @@ type txPool interface {
 	DeleteTransactionByHash(ctx context.Context, hash common.Hash) error
 	MarkWIPTxsAsPending(ctx context.Context) error
 	GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error)
+	GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error)
+	GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]pool.Transaction, error)
+	SubscribeNewTxs(ctx context.Context) <-chan common.Hash
 	UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error
 	GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error)
 	UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error
@@ -29,6 +32,9 @@ type txPool interface {
 	GetDefaultMinGasPriceAllowed() uint64
 	GetL1AndL2GasPrice() (uint64, uint64)
 	GetEarliestProcessedTx(ctx context.Context) (common.Hash, error)
+	AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error
+	RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64) ([]common.Hash, error)
+	IsSponsoredClaim(tx types.Transaction) bool
 }
 
 // ethermanInterface contains the methods required to interact with ethereum.
@@ -53,6 +59,8 @@ type stateInterface interface {
 	ProcessBatchV2(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, string, error)
 	CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error
 	CloseWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error
+	AddBatchClosingInfo(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx) error
+	AddL2BlockClosingInfo(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx) error
 	GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error)
 	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 	OpenBatch(ctx context.Context, processingContext state.ProcessingContext, dbTx pgx.Tx) error
@@ -81,10 +89,26 @@ type stateInterface interface {
 	GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error)
 	GetLatestBatchGlobalExitRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error)
 	GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*state.Batch, error)
+	AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error)
+	RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error
+	CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error
+	ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error
+	GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*state.SequencerControl, error)
+	SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
+}
+
+// finalizerControl contains the methods to control the finalizer at runtime.
+type finalizerControl interface {
+	Pause()
+	Resume()
+	SetHaltOnBatchNumber(batchNumber uint64)
+	CloseWIPL2Block()
+	CloseWIPBatch()
+	DrainAndStop()
 }
 
 type workerInterface interface {
-	GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, fistL2Block bool) (*TxTracker, []*TxTracker, error)
+	GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, fistL2Block bool) (*TxTracker, []*TxTracker, []*TxTracker, error)
 	UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker
 	UpdateTxZKCounters(txHash common.Hash, from common.Address, usedZKCounters state.ZKCounters, reservedZKCounters state.ZKCounters)
 	AddTxTracker(ctx context.Context, txTracker *TxTracker) (replacedTx *TxTracker, dropReason error)
@@ -96,4 +120,5 @@ type workerInterface interface {
 	AddForcedTx(txHash common.Hash, addr common.Address)
 	DeleteForcedTx(txHash common.Hash, addr common.Address)
 	RestoreTxsPendingToStore(ctx context.Context) ([]*TxTracker, []*TxTracker)
+	SetBatchConstraints(constraints state.BatchConstraintsCfg)
 }
@@ -9,15 +9,42 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqmetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	stateMetrics "github.com/0xPolygonHermez/zkevm-node/state/metrics"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
 )
 
+// l2BlockClosingReason is the reason why a wip L2 block is closed
+type l2BlockClosingReason string
+
+const (
+	// l2BlockMaxDeltaTimestampClosingReason is the closing reason when the L2 block time is reached
+	l2BlockMaxDeltaTimestampClosingReason l2BlockClosingReason = "max_delta_timestamp"
+	// l2BlockMaxTxsClosingReason is the closing reason when the L2 block reaches the max number of txs
+	l2BlockMaxTxsClosingReason l2BlockClosingReason = "max_txs"
+	// l2BlockMaxGasClosingReason is the closing reason when the L2 block reaches the max gas
+	l2BlockMaxGasClosingReason l2BlockClosingReason = "max_gas"
+	// l2BlockMaxBatchResourcesClosingReason is the closing reason when the L2 block reaches the max share of a batch resource
+	l2BlockMaxBatchResourcesClosingReason l2BlockClosingReason = "max_batch_resources"
+	// l2BlockBatchClosedClosingReason is the closing reason when the L2 block is closed because its batch is closed
+	l2BlockBatchClosedClosingReason l2BlockClosingReason = "batch_closed"
+	// l2BlockOperatorRequestClosingReason is the closing reason when the operator requests to close the L2 block
+	l2BlockOperatorRequestClosingReason l2BlockClosingReason = "operator_request"
+)
+
+// l2BlockNumberResync is the number a L2 block has been stored with when it differs from the number planned for it
+type l2BlockNumberResync struct {
+	number      uint64
+	trackingNum uint64
+}
+
 // L2Block represents a wip or processed L2 block
 type L2Block struct {
 	createdAt                 time.Time
 	trackingNum               uint64
+	number                    uint64 // planned number, the L2 block gets it when it's stored unless it is dropped by a reorg
 	timestamp                 uint64
 	deltaTimestamp            uint32
 	imStateRoot               common.Hash
@@ -27,6 +54,8 @@ type L2Block struct {
 	usedZKCountersOnNew       state.ZKCounters
 	reservedZKCountersOnNew   state.ZKCounters
 	highReservedZKCounters    state.ZKCounters
+	usedResources             state.BatchResources
+	closingReason             l2BlockClosingReason
 	transactions              []*TxTracker
 	batch                     *Batch
 	batchResponse             *state.ProcessBatchResponse
@@ -38,8 +67,9 @@ func (b *L2Block) isEmpty() bool {
 }
 
 // addTx adds a tx to the L2 block
-func (b *L2Block) addTx(tx *TxTracker) {
+func (b *L2Block) addTx(tx *TxTracker, usedResources state.BatchResources) {
 	b.transactions = append(b.transactions, tx)
+	b.usedResources.SumUp(usedResources)
 }
 
 // getL1InfoTreeIndex returns the L1InfoTreeIndex that must be used when processing/storing the block
@@ -67,6 +97,14 @@ func (f *finalizer) initWIPL2Block(ctx context.Context) {
 		log.Fatalf("failed to get last L2 block number, error: %v", err)
 	}
 
+	// All the closed L2 blocks have been stored, so the pre-confirmations of the next L2 blocks were published for
+	// L2 blocks dropped by a reorg or by a previous run (or sequencer) that stopped before storing them
+	f.lastPlannedL2BlockNumber = lastL2Block.NumberU64()
+	f.l2BlockNumberResync.Store(nil)
+	if f.preconfirmer != nil {
+		f.preconfirmer.rescind(ctx, f.lastPlannedL2BlockNumber+1)
+	}
+
 	f.openNewWIPL2Block(ctx, uint64(lastL2Block.ReceivedAt.Unix()), nil)
 }
 
@@ -397,6 +435,12 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		return retError
 	}
 
+	// Check the sequencer still holds the leader lease (high availability mode)
+	err = f.checkLeaderLease(ctx, dbTx)
+	if err != nil {
+		return rollbackOnError(fmt.Errorf("error storing L2 block %d [%d], error: %v", blockResponse.BlockNumber, l2Block.trackingNum, err))
+	}
+
 	if (f.sipBatch == nil) || (f.sipBatch.batchNumber != l2Block.batch.batchNumber) {
 		// We have l2 blocks to store from a new batch, therefore we insert this new batch in the statedb
 		// First we need to close the current sipBatch
@@ -428,6 +472,11 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		return rollbackOnError(fmt.Errorf("database error on storing L2 block %d [%d], error: %v", blockResponse.BlockNumber, l2Block.trackingNum, err))
 	}
 
+	err = f.addL2BlockClosingInfo(ctx, blockResponse.BlockNumber, l2Block, dbTx)
+	if err != nil {
+		return rollbackOnError(err)
+	}
+
 	// Now we need to update de BatchL2Data of the wip batch and also update the status of the L2 block txs in the pool
 
 	batch, err := f.stateIntf.GetBatchByNumber(ctx, l2Block.batch.batchNumber, dbTx)
@@ -489,6 +538,20 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 		}
 	}
 
+	// The pre-confirmations of the txs were published with the planned number, so we re-issue them with the stored number.
+	// They replace the previous ones of the txs and the pool notifies them to the subscribers
+	if f.preconfirmer != nil && blockResponse.BlockNumber != l2Block.number {
+		log.Warnf("L2 block %d [%d] has been stored with a different number than the planned %d, re-issuing its pre-confirmations",
+			blockResponse.BlockNumber, l2Block.trackingNum, l2Block.number)
+
+		for i, txResponse := range blockResponse.TransactionResponses {
+			f.preconfirmer.preconfirm(txResponse.TxHash, blockResponse.BlockNumber, uint64(i), txResponse.RomError != nil)
+		}
+
+		// The next L2 blocks are planned from the number this L2 block has been stored with
+		f.l2BlockNumberResync.Store(&l2BlockNumberResync{number: blockResponse.BlockNumber, trackingNum: l2Block.trackingNum})
+	}
+
 	// Send L2 block to data streamer
 	err = f.DSSendL2Block(ctx, l2Block.batch.batchNumber, blockResponse, l2Block.getL1InfoTreeIndex(), l2Block.timestamp, blockHash)
 	if err != nil {
@@ -508,23 +571,56 @@ func (f *finalizer) storeL2Block(ctx context.Context, l2Block *L2Block) error {
 	return nil
 }
 
+// addL2BlockClosingInfo stores in the state the report about the closing of a L2 block, used for capacity planning
+func (f *finalizer) addL2BlockClosingInfo(ctx context.Context, l2BlockNumber uint64, l2Block *L2Block, dbTx pgx.Tx) error {
+	m := l2Block.metrics
+	closingInfo := &state.L2BlockClosingInfo{
+		L2BlockNumber: l2BlockNumber,
+		BatchNumber:   l2Block.batch.batchNumber,
+		ClosingReason: string(l2Block.closingReason),
+		Txs:           uint64(len(l2Block.transactions)),
+		ProcessedTxs:  uint64(m.processedTxsCount),
+		UsedResources: l2Block.usedResources,
+		Times: state.L2BlockProcessTimes{
+			Total:               m.totalTime(),
+			Idle:                m.idleTime,
+			WaitL2Block:         m.waitl2BlockTime,
+			SequencerNewL2Block: m.newL2BlockTimes.sequencer,
+			SequencerTxs:        m.transactionsTimes.sequencer,
+			SequencerL2Block:    m.l2BlockTimes.sequencer,
+			ExecutorNewL2Block:  m.newL2BlockTimes.executor,
+			ExecutorTxs:         m.transactionsTimes.executor,
+			ExecutorL2Block:     m.l2BlockTimes.executor,
+		},
+	}
+
+	err := f.stateIntf.AddL2BlockClosingInfo(ctx, closingInfo, dbTx)
+	if err != nil {
+		return fmt.Errorf("error storing closing info of L2 block %d [%d], error: %v", l2BlockNumber, l2Block.trackingNum, err)
+	}
+
+	return nil
+}
+
 // finalizeWIPL2Block closes the wip L2 block and opens a new one
-func (f *finalizer) finalizeWIPL2Block(ctx context.Context) {
+func (f *finalizer) finalizeWIPL2Block(ctx context.Context, closeReason l2BlockClosingReason) {
 	log.Debugf("finalizing wip L2 block [%d]", f.wipL2Block.trackingNum)
 
 	prevTimestamp := f.wipL2Block.timestamp
 	prevL1InfoTreeIndex := f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex
 
-	f.closeWIPL2Block(ctx)
+	f.closeWIPL2Block(ctx, closeReason)
 
 	f.openNewWIPL2Block(ctx, prevTimestamp, &prevL1InfoTreeIndex)
 }
 
 // closeWIPL2Block closes the wip L2 block
-func (f *finalizer) closeWIPL2Block(ctx context.Context) {
-	log.Debugf("closing wip L2 block [%d]", f.wipL2Block.trackingNum)
+func (f *finalizer) closeWIPL2Block(ctx context.Context, closeReason l2BlockClosingReason) {
+	log.Debugf("closing wip L2 block [%d], reason: %s", f.wipL2Block.trackingNum, closeReason)
 
 	f.wipBatch.countOfL2Blocks++
+	f.wipL2Block.closingReason = closeReason
+	seqmetrics.L2BlockClosed(string(closeReason))
 
 	if f.cfg.SequentialProcessL2Block {
 		err := f.processL2Block(ctx, f.wipL2Block)
@@ -558,8 +654,8 @@ func (f *finalizer) closeWIPL2Block(ctx context.Context) {
 		l2BlockResourcesUsed.ZKCounters.SumUp(f.wipL2Block.usedZKCountersOnNew)
 		l2BlockResourcesReserved.ZKCounters.SumUp(f.wipL2Block.reservedZKCountersOnNew)
 
-		log.Infof("closed wip L2 block [%d], batch: %d, deltaTimestamp: %d, timestamp: %d, l1InfoTreeIndex: %d, l1InfoTreeIndexChanged: %v, txs: %d, used counters: %s, reserved counters: %s",
-			f.wipL2Block.trackingNum, f.wipL2Block.batch.batchNumber, f.wipL2Block.deltaTimestamp, f.wipL2Block.timestamp, f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex,
+		log.Infof("closed wip L2 block [%d], batch: %d, reason: %s, deltaTimestamp: %d, timestamp: %d, l1InfoTreeIndex: %d, l1InfoTreeIndexChanged: %v, txs: %d, used counters: %s, reserved counters: %s",
+			f.wipL2Block.trackingNum, f.wipL2Block.batch.batchNumber, closeReason, f.wipL2Block.deltaTimestamp, f.wipL2Block.timestamp, f.wipL2Block.l1InfoTreeExitRoot.L1InfoTreeIndex,
 			f.wipL2Block.l1InfoTreeExitRootChanged, len(f.wipL2Block.transactions), f.logZKCounters(l2BlockResourcesUsed.ZKCounters), f.logZKCounters(l2BlockResourcesReserved.ZKCounters))
 
 		if f.nextStateRootSync.Before(time.Now()) {
@@ -581,20 +677,157 @@ func (f *finalizer) closeWIPL2Block(ctx context.Context) {
 	f.wipL2Block = nil
 }
 
+// checkIfFinalizeL2Block checks if the wip L2 block must be closed because it reached one of the L2 block limits
+func (f *finalizer) checkIfFinalizeL2Block() (bool, l2BlockClosingReason) {
+	if f.wipL2Block.isEmpty() {
+		return false, ""
+	}
+
+	limits := f.cfg.L2BlockLimits
+
+	// Max txs per L2 block
+	if limits.MaxTxs > 0 && uint64(len(f.wipL2Block.transactions)) >= limits.MaxTxs {
+		log.Infof("closing wip L2 block [%d], because it reached the maximum number of txs", f.wipL2Block.trackingNum)
+		return true, l2BlockMaxTxsClosingReason
+	}
+
+	// Max gas per L2 block
+	if limits.MaxGas > 0 && f.wipL2Block.usedResources.ZKCounters.GasUsed >= limits.MaxGas {
+		log.Infof("closing wip L2 block [%d], because it reached the maximum gas", f.wipL2Block.trackingNum)
+		return true, l2BlockMaxGasClosingReason
+	}
+
+	// Max share of the batch resources per L2 block
+	if limits.MaxBatchResourcesPct > 0 {
+		if reached, resourceDesc := f.isL2BlockBatchResourcesLimitReached(f.wipL2Block.usedResources); reached {
+			log.Infof("closing wip L2 block [%d], because it reached the maximum share of %s batch resource", f.wipL2Block.trackingNum, resourceDesc)
+			return true, l2BlockMaxBatchResourcesClosingReason
+		}
+	}
+
+	return false, ""
+}
+
+// isL2BlockBatchResourcesLimitReached checks if the resources used by a L2 block reached the MaxBatchResourcesPct of any of the batch resources
+func (f *finalizer) isL2BlockBatchResourcesLimitReached(resources state.BatchResources) (bool, string) {
+	pct := uint64(f.cfg.L2BlockLimits.MaxBatchResourcesPct)
+	limit := func(input uint64) uint64 {
+		return input * pct / 100 //nolint:gomnd
+	}
+
+	zkCounters := resources.ZKCounters
+	switch {
+	case resources.Bytes >= limit(f.batchConstraints.MaxBatchBytesSize):
+		return true, "Bytes"
+	case uint64(zkCounters.Steps) >= limit(uint64(f.batchConstraints.MaxSteps)):
+		return true, "Steps"
+	case uint64(zkCounters.PoseidonPaddings) >= limit(uint64(f.batchConstraints.MaxPoseidonPaddings)):
+		return true, "PoseidonPaddings"
+	case uint64(zkCounters.PoseidonHashes) >= limit(uint64(f.batchConstraints.MaxPoseidonHashes)):
+		return true, "PoseidonHashes"
+	case uint64(zkCounters.Binaries) >= limit(uint64(f.batchConstraints.MaxBinaries)):
+		return true, "Binaries"
+	case uint64(zkCounters.KeccakHashes) >= limit(uint64(f.batchConstraints.MaxKeccakHashes)):
+		return true, "KeccakHashes"
+	case uint64(zkCounters.Arithmetics) >= limit(uint64(f.batchConstraints.MaxArithmetics)):
+		return true, "Arithmetics"
+	case uint64(zkCounters.MemAligns) >= limit(uint64(f.batchConstraints.MaxMemAligns)):
+		return true, "MemAligns"
+	case zkCounters.GasUsed >= limit(f.batchConstraints.MaxCumulativeGasUsed):
+		return true, "CumulativeGas"
+	case uint64(zkCounters.Sha256Hashes_V2) >= limit(uint64(f.batchConstraints.MaxSHA256Hashes)):
+		return true, "SHA256Hashes"
+	}
+
+	return false, ""
+}
+
+// wipL2BlockRemainingResources returns the remaining resources of the wip batch limited by the budget left in the wip L2 block by
+// the MaxGas and MaxBatchResourcesPct limits, and the closing reason of the limit that narrows them (empty if none does). The limits
+// don't apply to an empty L2 block, so a tx that exceeds them on its own is still selected for a new L2 block
+func (f *finalizer) wipL2BlockRemainingResources() (state.BatchResources, l2BlockClosingReason) {
+	remaining := f.wipBatch.imRemainingResources
+	limits := f.cfg.L2BlockLimits
+	if f.wipL2Block.isEmpty() || (limits.MaxGas == 0 && limits.MaxBatchResourcesPct == 0) {
+		return remaining, ""
+	}
+
+	var closeReason l2BlockClosingReason
+	narrowed := false
+	budget := func(remaining uint64, limit uint64, used uint64) uint64 {
+		left := uint64(0)
+		if used < limit {
+			left = limit - used
+		}
+		if left < remaining {
+			narrowed = true
+			return left
+		}
+		return remaining
+	}
+
+	used := f.wipL2Block.usedResources
+	zkCounters := &remaining.ZKCounters
+
+	if limits.MaxGas > 0 {
+		zkCounters.GasUsed = budget(zkCounters.GasUsed, limits.MaxGas, used.ZKCounters.GasUsed)
+		if narrowed {
+			closeReason = l2BlockMaxGasClosingReason
+		}
+	}
+
+	if limits.MaxBatchResourcesPct > 0 {
+		pct := uint64(limits.MaxBatchResourcesPct)
+		limit := func(input uint64) uint64 {
+			return input * pct / 100 //nolint:gomnd
+		}
+
+		narrowed = false
+		remaining.Bytes = budget(remaining.Bytes, limit(f.batchConstraints.MaxBatchBytesSize), used.Bytes)
+		zkCounters.Steps = uint32(budget(uint64(zkCounters.Steps), limit(uint64(f.batchConstraints.MaxSteps)), uint64(used.ZKCounters.Steps)))
+		zkCounters.PoseidonPaddings = uint32(budget(uint64(zkCounters.PoseidonPaddings), limit(uint64(f.batchConstraints.MaxPoseidonPaddings)), uint64(used.ZKCounters.PoseidonPaddings)))
+		zkCounters.PoseidonHashes = uint32(budget(uint64(zkCounters.PoseidonHashes), limit(uint64(f.batchConstraints.MaxPoseidonHashes)), uint64(used.ZKCounters.PoseidonHashes)))
+		zkCounters.Binaries = uint32(budget(uint64(zkCounters.Binaries), limit(uint64(f.batchConstraints.MaxBinaries)), uint64(used.ZKCounters.Binaries)))
+		zkCounters.KeccakHashes = uint32(budget(uint64(zkCounters.KeccakHashes), limit(uint64(f.batchConstraints.MaxKeccakHashes)), uint64(used.ZKCounters.KeccakHashes)))
+		zkCounters.Arithmetics = uint32(budget(uint64(zkCounters.Arithmetics), limit(uint64(f.batchConstraints.MaxArithmetics)), uint64(used.ZKCounters.Arithmetics)))
+		zkCounters.MemAligns = uint32(budget(uint64(zkCounters.MemAligns), limit(uint64(f.batchConstraints.MaxMemAligns)), uint64(used.ZKCounters.MemAligns)))
+		zkCounters.GasUsed = budget(zkCounters.GasUsed, limit(f.batchConstraints.MaxCumulativeGasUsed), used.ZKCounters.GasUsed)
+		zkCounters.Sha256Hashes_V2 = uint32(budget(uint64(zkCounters.Sha256Hashes_V2), limit(uint64(f.batchConstraints.MaxSHA256Hashes)), uint64(used.ZKCounters.Sha256Hashes_V2)))
+		if narrowed && closeReason == "" {
+			closeReason = l2BlockMaxBatchResourcesClosingReason
+		}
+	}
+
+	return remaining, closeReason
+}
+
+// planL2BlockNumber returns the number the L2 block with the given tracking number will have when it is stored. If a previous
+// L2 block has been stored with a different number than the planned one, the numbers are resynced from it since the L2 blocks
+// opened after it are stored with consecutive numbers
+func (f *finalizer) planL2BlockNumber(trackingNum uint64) uint64 {
+	if resync := f.l2BlockNumberResync.Swap(nil); resync != nil {
+		f.lastPlannedL2BlockNumber = resync.number + (trackingNum - resync.trackingNum) - 1
+	}
+	f.lastPlannedL2BlockNumber++
+	return f.lastPlannedL2BlockNumber
+}
+
 // openNewWIPL2Block opens a new wip L2 block
 func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64, prevL1InfoTreeIndex *uint32) {
 	processStart := time.Now()
 
 	newL2Block := &L2Block{}
-	now := time.Now()
-	newL2Block.createdAt = now
-	newL2Block.deltaTimestamp = uint32(uint64(now.Unix()) - prevTimestamp)
+	createdAt := f.now()
+	newL2Block.createdAt = createdAt
+	newL2Block.deltaTimestamp = uint32(uint64(createdAt.Unix()) - prevTimestamp)
 	newL2Block.timestamp = prevTimestamp + uint64(newL2Block.deltaTimestamp)
 
 	// Tracking number
 	f.l2BlockCounter++
 	newL2Block.trackingNum = f.l2BlockCounter
 
+	newL2Block.number = f.planL2BlockNumber(newL2Block.trackingNum)
+
 	newL2Block.transactions = []*TxTracker{}
 
 	f.lastL1InfoTreeMux.Lock()
@@ -665,6 +898,7 @@ func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64,
 	// If reserved WIP L2 block resources don't fit in the remaining batch resources (or we got an overflow when trying to subtract the used resources)
 	// we close the WIP batch and we create a new one
 	if !fits || subOverflow {
+		f.wipBatch.exhaustedResource = overflowResource
 		err := f.closeAndOpenNewWIPBatch(ctx, state.ResourceExhaustedClosingReason)
 		if err != nil {
 			f.Halt(ctx, fmt.Errorf("failed to create new wip batch [%d], error: %v", f.wipL2Block.trackingNum, err), true)
@@ -0,0 +1,163 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// leaseLostEventTimeout is the max time to wait for the event of the lost leader lease to be stored before exiting
+const leaseLostEventTimeout = 5 * time.Second
+
+// leaderLease is the leader lease held by the sequencer in high availability mode. The fencing token
+// changes each time the lease is acquired, so the data of a previous holder is rejected when stored
+type leaderLease struct {
+	holder       string
+	fencingToken uint64
+}
+
+// checkHACfg checks the high availability config is valid when it is enabled
+func checkHACfg(cfg HACfg) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.NodeID == "" {
+		return fmt.Errorf("the sequencer NodeID must be set when the high availability mode is enabled")
+	}
+	if cfg.RenewInterval.Duration <= 0 || cfg.RenewInterval.Duration >= cfg.LeaseDuration.Duration {
+		return fmt.Errorf("the leader lease RenewInterval %v must be greater than 0 and lower than LeaseDuration %v", cfg.RenewInterval.Duration, cfg.LeaseDuration.Duration)
+	}
+	return nil
+}
+
+// waitForLeadership blocks the standby sequencer until it acquires the leader lease, keeping its worker
+// updated with the pending txs of the pool meanwhile. It returns false if the context is done before
+func (s *Sequencer) waitForLeadership(ctx context.Context) bool {
+	log.Infof("sequencer %s running as standby, waiting to acquire the leader lease", s.cfg.HA.NodeID)
+
+	for {
+		fencingToken, err := s.stateIntf.AcquireSequencerLease(ctx, s.cfg.HA.NodeID, s.cfg.HA.LeaseDuration.Duration, nil)
+		if err == nil {
+			s.leaderLease = &leaderLease{holder: s.cfg.HA.NodeID, fencingToken: fencingToken}
+			s.logEvent(ctx, event.Level_Warning, event.EventID_SequencerLeadershipAcquired,
+				fmt.Sprintf("sequencer %s acquired the leader lease, fencing token: %d", s.cfg.HA.NodeID, fencingToken))
+			return true
+		} else if !errors.Is(err, state.ErrSequencerLeaseNotHeld) {
+			log.Errorf("failed to acquire the leader lease, error: %v", err)
+		}
+
+		s.updateStandbyWorker(ctx)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(s.cfg.HA.StandbyCheckInterval.Duration):
+		}
+	}
+}
+
+// keepLeadership renews the leader lease while the sequencer is running. If the lease is lost or can not be
+// renewed before it expires, the process exits, stopping the finalizer, so a standby sequencer takes over.
+// The in-memory state of the finalizer is not valid anymore, so the node must be restarted to run as standby
+func (s *Sequencer) keepLeadership(ctx context.Context) {
+	lastRenewal := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Release the lease so a standby sequencer takes over without waiting for it to expire
+			err := s.stateIntf.ReleaseSequencerLease(context.Background(), s.leaderLease.holder, s.leaderLease.fencingToken, nil)
+			if err != nil {
+				log.Errorf("failed to release the leader lease, error: %v", err)
+			}
+			return
+		case <-time.After(s.cfg.HA.RenewInterval.Duration):
+		}
+
+		err := s.stateIntf.RenewSequencerLease(ctx, s.leaderLease.holder, s.leaderLease.fencingToken, s.cfg.HA.LeaseDuration.Duration, nil)
+		if err == nil {
+			lastRenewal = time.Now()
+			continue
+		}
+
+		if errors.Is(err, state.ErrSequencerLeaseNotHeld) || time.Since(lastRenewal) >= s.cfg.HA.LeaseDuration.Duration {
+			// Stop selecting txs right away, the data stored from now on is rejected by the fencing token anyway
+			s.finalizer.haltFinalizer.Store(true)
+
+			// The event is logged with a deadline, as the lease is usually lost when the state db is unreachable
+			eventCtx, cancel := context.WithTimeout(ctx, leaseLostEventTimeout)
+			s.logEvent(eventCtx, event.Level_Critical, event.EventID_SequencerLeadershipLost,
+				fmt.Sprintf("sequencer %s lost the leader lease, fencing token: %d, error: %v", s.leaderLease.holder, s.leaderLease.fencingToken, err))
+			cancel()
+
+			log.Fatalf("sequencer %s lost the leader lease, exiting so a standby sequencer takes over, error: %v", s.leaderLease.holder, err)
+		}
+
+		log.Errorf("failed to renew the leader lease, error: %v", err)
+	}
+}
+
+// updateStandbyWorker loads into the worker of the standby sequencer the pending txs of the pool, including the
+// ones in process by the active sequencer, without changing their status in the pool. The txs that are no longer
+// pending or that have been processed are deleted from the worker
+func (s *Sequencer) updateStandbyWorker(ctx context.Context) {
+	poolTransactions, err := s.pool.GetPendingTxs(ctx, 0)
+	if err != nil && err != pool.ErrNotFound {
+		log.Errorf("error loading txs from pool, error: %v", err)
+		return
+	}
+
+	txHashes := make(map[common.Hash]struct{}, len(poolTransactions))
+	for _, tx := range poolTransactions {
+		txHashes[tx.Hash()] = struct{}{}
+	}
+	workerTxs := s.worker.RetainTxs(txHashes)
+
+	for _, tx := range poolTransactions {
+		if _, found := workerTxs[tx.Hash()]; found {
+			continue
+		}
+
+		txTracker, err := s.newTxTracker(tx)
+		if err != nil {
+			log.Errorf("error creating tx tracker for tx %s, error: %v", tx.Hash().String(), err)
+			continue
+		}
+		// The status of the dropped txs is updated in the pool by the active sequencer
+		_, dropReason := s.worker.AddTxTracker(ctx, txTracker)
+		if dropReason != nil {
+			log.Debugf("tx %s not added to the standby worker, reason: %v", txTracker.HashStr, dropReason)
+		}
+	}
+
+	err = s.worker.RefreshAddrQueues(ctx)
+	if err != nil {
+		log.Errorf("failed to refresh the worker addrQueues, error: %v", err)
+	}
+}
+
+// logEvent adds an event for runtime debugging
+func (s *Sequencer) logEvent(ctx context.Context, level event.Level, eventId event.EventID, description string) {
+	log.Info(description)
+
+	event := &event.Event{
+		ReceivedAt:  time.Now(),
+		Source:      event.Source_Node,
+		Component:   event.Component_Sequencer,
+		Level:       level,
+		EventID:     eventId,
+		Description: description,
+	}
+
+	eventErr := s.eventLog.LogEvent(ctx, event)
+	if eventErr != nil {
+		log.Errorf("error storing log event, error: %v", eventErr)
+	}
+}
@@ -0,0 +1,28 @@
+package sequencer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckHACfg(t *testing.T) {
+	validCfg := HACfg{
+		Enabled:       true,
+		NodeID:        "sequencer-1",
+		LeaseDuration: types.NewDuration(10 * time.Second),
+		RenewInterval: types.NewDuration(3 * time.Second),
+	}
+	assert.NoError(t, checkHACfg(validCfg))
+	assert.NoError(t, checkHACfg(HACfg{}))
+
+	noNodeIDCfg := validCfg
+	noNodeIDCfg.NodeID = ""
+	assert.Error(t, checkHACfg(noNodeIDCfg))
+
+	slowRenewalCfg := validCfg
+	slowRenewalCfg.RenewInterval = types.NewDuration(10 * time.Second)
+	assert.Error(t, checkHACfg(slowRenewalCfg))
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	prefix                  = "sequencer_"
+	txWaitTimeName          = prefix + "tx_wait_time"
+	txWaitTimeLaneLabelName = "lane"
+
+	l2BlockClosedName            = prefix + "l2block_closed"
+	l2BlockClosedReasonLabelName = "reason"
+)
+
+// Register the metrics for the sequencer package.
+func Register() {
+	histogramVecs := []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name:    txWaitTimeName,
+				Help:    "[SEQUENCER] Histogram for the time the txs wait in the worker until they are selected, by tx ordering lane",
+				Buckets: prometheus.ExponentialBuckets(0.1, 2, 15), //nolint:gomnd
+			},
+			Labels: []string{txWaitTimeLaneLabelName},
+		},
+	}
+
+	counterVecs := []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: l2BlockClosedName,
+				Help: "[SEQUENCER] Total count of closed wip L2 blocks, by closing reason",
+			},
+			Labels: []string{l2BlockClosedReasonLabelName},
+		},
+	}
+
+	metrics.RegisterHistogramVecs(histogramVecs...)
+	metrics.RegisterCounterVecs(counterVecs...)
+}
+
+// TxWaitTime observes (histogram) the time a tx waited in the worker until it was
+// selected, for the given tx ordering lane.
+func TxWaitTime(lane string, waitTime time.Duration) {
+	metrics.HistogramVecObserve(txWaitTimeName, lane, waitTime.Seconds())
+}
+
+// L2BlockClosed increments the counter of closed wip L2 blocks for the given closing reason.
+func L2BlockClosed(reason string) {
+	metrics.CounterVecInc(l2BlockClosedName, reason)
+}
@@ -14,6 +14,8 @@ import (
 	state "github.com/0xPolygonHermez/zkevm-node/state"
 
 	time "time"
+
+	types "github.com/ethereum/go-ethereum/core/types"
 )
 
 // PoolMock is an autogenerated mock type for the txPool type
@@ -21,6 +23,24 @@ type PoolMock struct {
 	mock.Mock
 }
 
+// AddPreconfirmation provides a mock function with given fields: ctx, preconfirmation
+func (_m *PoolMock) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	ret := _m.Called(ctx, preconfirmation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPreconfirmation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pool.Preconfirmation) error); ok {
+		r0 = rf(ctx, preconfirmation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteFailedTransactionsOlderThan provides a mock function with given fields: ctx, date
 func (_m *PoolMock) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
 	ret := _m.Called(ctx, date)
@@ -209,6 +229,66 @@ func (_m *PoolMock) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction
 	return r0, r1
 }
 
+// GetNonWIPPendingTxsByHashes provides a mock function with given fields: ctx, hashes
+func (_m *PoolMock) GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]pool.Transaction, error) {
+	ret := _m.Called(ctx, hashes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonWIPPendingTxsByHashes")
+	}
+
+	var r0 []pool.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) ([]pool.Transaction, error)); ok {
+		return rf(ctx, hashes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash) []pool.Transaction); ok {
+		r0 = rf(ctx, hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash) error); ok {
+		r1 = rf(ctx, hashes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingTxs provides a mock function with given fields: ctx, limit
+func (_m *PoolMock) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingTxs")
+	}
+
+	var r0 []pool.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]pool.Transaction, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []pool.Transaction); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTxZkCountersByHash provides a mock function with given fields: ctx, hash
 func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
 	ret := _m.Called(ctx, hash)
@@ -248,6 +328,24 @@ func (_m *PoolMock) GetTxZkCountersByHash(ctx context.Context, hash common.Hash)
 	return r0, r1, r2
 }
 
+// IsSponsoredClaim provides a mock function with given fields: tx
+func (_m *PoolMock) IsSponsoredClaim(tx types.Transaction) bool {
+	ret := _m.Called(tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsSponsoredClaim")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(types.Transaction) bool); ok {
+		r0 = rf(tx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // MarkWIPTxsAsPending provides a mock function with given fields: ctx
 func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -266,6 +364,56 @@ func (_m *PoolMock) MarkWIPTxsAsPending(ctx context.Context) error {
 	return r0
 }
 
+// RescindPreconfirmations provides a mock function with given fields: ctx, fromL2BlockNumber
+func (_m *PoolMock) RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64) ([]common.Hash, error) {
+	ret := _m.Called(ctx, fromL2BlockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescindPreconfirmations")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]common.Hash, error)); ok {
+		return rf(ctx, fromL2BlockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []common.Hash); ok {
+		r0 = rf(ctx, fromL2BlockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, fromL2BlockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeNewTxs provides a mock function with given fields: ctx
+func (_m *PoolMock) SubscribeNewTxs(ctx context.Context) <-chan common.Hash {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeNewTxs")
+	}
+
+	var r0 <-chan common.Hash
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan common.Hash); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan common.Hash)
+		}
+	}
+
+	return r0
+}
+
 // UpdateTxStatus provides a mock function with given fields: ctx, hash, newStatus, isWIP, failedReason
 func (_m *PoolMock) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error {
 	ret := _m.Called(ctx, hash, newStatus, isWIP, failedReason)
@@ -13,6 +13,8 @@ import (
 	pgx "github.com/jackc/pgx/v4"
 
 	state "github.com/0xPolygonHermez/zkevm-node/state"
+
+	time "time"
 )
 
 // StateMock is an autogenerated mock type for the stateInterface type
@@ -20,6 +22,70 @@ type StateMock struct {
 	mock.Mock
 }
 
+// AcquireSequencerLease provides a mock function with given fields: ctx, holder, duration, dbTx
+func (_m *StateMock) AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, holder, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, holder, duration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r1 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddBatchClosingInfo provides a mock function with given fields: ctx, closingInfo, dbTx
+func (_m *StateMock) AddBatchClosingInfo(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, closingInfo, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBatchClosingInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.BatchClosingInfo, pgx.Tx) error); ok {
+		r0 = rf(ctx, closingInfo, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddL2BlockClosingInfo provides a mock function with given fields: ctx, closingInfo, dbTx
+func (_m *StateMock) AddL2BlockClosingInfo(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, closingInfo, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddL2BlockClosingInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.L2BlockClosingInfo, pgx.Tx) error); ok {
+		r0 = rf(ctx, closingInfo, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BeginStateTransaction provides a mock function with given fields: ctx
 func (_m *StateMock) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
 	ret := _m.Called(ctx)
@@ -70,6 +136,24 @@ func (_m *StateMock) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex u
 	return r0
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, dbTx
+func (_m *StateMock) CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CloseBatch provides a mock function with given fields: ctx, receipt, dbTx
 func (_m *StateMock) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, receipt, dbTx)
@@ -820,6 +904,36 @@ func (_m *StateMock) GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*
 	return r0, r1
 }
 
+// GetSequencerControl provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*state.SequencerControl, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequencerControl")
+	}
+
+	var r0 *state.SequencerControl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.SequencerControl, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) *state.SequencerControl); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.SequencerControl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStorageAt provides a mock function with given fields: ctx, address, position, root
 func (_m *StateMock) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
 	ret := _m.Called(ctx, address, position, root)
@@ -1018,6 +1132,60 @@ func (_m *StateMock) ProcessBatchV2(ctx context.Context, request state.ProcessRe
 	return r0, r1, r2
 }
 
+// ReleaseSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, dbTx
+func (_m *StateMock) ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RenewSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, duration, dbTx
+func (_m *StateMock) RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, duration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetSequencerHaltOnBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSequencerHaltOnBatchNumber")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreL2Block provides a mock function with given fields: ctx, batchNumber, l2Block, txsEGPLog, dbTx
 func (_m *StateMock) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) (common.Hash, error) {
 	ret := _m.Called(ctx, batchNumber, l2Block, txsEGPLog, dbTx)
@@ -71,7 +71,7 @@ func (_m *WorkerMock) DeleteTxPendingToStore(txHash common.Hash, addr common.Add
 }
 
 // GetBestFittingTx provides a mock function with given fields: remainingResources, highReservedCounters, fistL2Block
-func (_m *WorkerMock) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, fistL2Block bool) (*TxTracker, []*TxTracker, error) {
+func (_m *WorkerMock) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, fistL2Block bool) (*TxTracker, []*TxTracker, []*TxTracker, error) {
 	ret := _m.Called(remainingResources, highReservedCounters, fistL2Block)
 
 	if len(ret) == 0 {
@@ -80,8 +80,9 @@ func (_m *WorkerMock) GetBestFittingTx(remainingResources state.BatchResources,
 
 	var r0 *TxTracker
 	var r1 []*TxTracker
-	var r2 error
-	if rf, ok := ret.Get(0).(func(state.BatchResources, state.ZKCounters, bool) (*TxTracker, []*TxTracker, error)); ok {
+	var r2 []*TxTracker
+	var r3 error
+	if rf, ok := ret.Get(0).(func(state.BatchResources, state.ZKCounters, bool) (*TxTracker, []*TxTracker, []*TxTracker, error)); ok {
 		return rf(remainingResources, highReservedCounters, fistL2Block)
 	}
 	if rf, ok := ret.Get(0).(func(state.BatchResources, state.ZKCounters, bool) *TxTracker); ok {
@@ -100,13 +101,21 @@ func (_m *WorkerMock) GetBestFittingTx(remainingResources state.BatchResources,
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(state.BatchResources, state.ZKCounters, bool) error); ok {
+	if rf, ok := ret.Get(2).(func(state.BatchResources, state.ZKCounters, bool) []*TxTracker); ok {
 		r2 = rf(remainingResources, highReservedCounters, fistL2Block)
 	} else {
-		r2 = ret.Error(2)
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).([]*TxTracker)
+		}
+	}
+
+	if rf, ok := ret.Get(3).(func(state.BatchResources, state.ZKCounters, bool) error); ok {
+		r3 = rf(remainingResources, highReservedCounters, fistL2Block)
+	} else {
+		r3 = ret.Error(3)
 	}
 
-	return r0, r1, r2
+	return r0, r1, r2, r3
 }
 
 // MoveTxPendingToStore provides a mock function with given fields: txHash, addr
@@ -196,6 +205,11 @@ func (_m *WorkerMock) RestoreTxsPendingToStore(ctx context.Context) ([]*TxTracke
 	return r0, r1
 }
 
+// SetBatchConstraints provides a mock function with given fields: constraints
+func (_m *WorkerMock) SetBatchConstraints(constraints state.BatchConstraintsCfg) {
+	_m.Called(constraints)
+}
+
 // UpdateAfterSingleSuccessfulTxExecution provides a mock function with given fields: from, touchedAddresses
 func (_m *WorkerMock) UpdateAfterSingleSuccessfulTxExecution(from common.Address, touchedAddresses map[common.Address]*state.InfoReadWrite) []*TxTracker {
 	ret := _m.Called(from, touchedAddresses)
@@ -0,0 +1,119 @@
+package sequencer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// preconfirmationsQueueSize is the number of pre-confirmations pending to be signed and published,
+	// the next ones are dropped so the finalizer is never blocked by the pool
+	preconfirmationsQueueSize = 4096
+)
+
+// preconfirmer signs the pre-confirmations of the txs executed in the wip L2 block and publishes them in
+// the pool. The requests are applied in order, so a rescind is never overtaken by a previous pre-confirmation
+type preconfirmer struct {
+	poolIntf   txPool
+	privateKey *ecdsa.PrivateKey
+	chainID    uint64
+	requests   chan preconfirmationRequest
+}
+
+// preconfirmationRequest is a request to publish a pre-confirmation or to rescind the ones of the L2 blocks not stored
+type preconfirmationRequest struct {
+	// preconfirmation to sign and publish, nil if the request is a rescind
+	preconfirmation *pool.Preconfirmation
+	// rescindFromL2BlockNumber is the first L2 block whose pre-confirmations are rescinded
+	rescindFromL2BlockNumber uint64
+}
+
+// newPreconfirmer creates the preconfirmer loading the signing key from its key store file
+func newPreconfirmer(cfg PreconfirmationsCfg, poolIntf txPool) (*preconfirmer, error) {
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(cfg.PrivateKey.Path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the pre-confirmations key store file, error: %v", err)
+	}
+	key, err := keystore.DecryptKey(keystoreEncrypted, cfg.PrivateKey.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the pre-confirmations key store file, error: %v", err)
+	}
+	log.Infof("pre-confirmations signed by address: %s", key.Address.String())
+
+	return &preconfirmer{
+		poolIntf:   poolIntf,
+		privateKey: key.PrivateKey,
+		chainID:    cfg.ChainID,
+		requests:   make(chan preconfirmationRequest, preconfirmationsQueueSize),
+	}, nil
+}
+
+// preconfirm queues the pre-confirmation of a tx executed in the wip L2 block
+func (p *preconfirmer) preconfirm(txHash common.Hash, l2BlockNumber uint64, index uint64, reverted bool) {
+	status := ethTypes.ReceiptStatusSuccessful
+	if reverted {
+		status = ethTypes.ReceiptStatusFailed
+	}
+
+	request := preconfirmationRequest{
+		preconfirmation: &pool.Preconfirmation{TxHash: txHash, L2BlockNumber: l2BlockNumber, Index: index, Status: status, CreatedAt: time.Now()},
+	}
+	select {
+	case p.requests <- request:
+	default:
+		log.Warnf("pre-confirmation of tx %s dropped, the pre-confirmations queue is full", txHash.String())
+	}
+}
+
+// rescind queues the rescind of the pre-confirmations of the L2 blocks from the given number, it blocks
+// if the queue is full since a rescind can not be dropped
+func (p *preconfirmer) rescind(ctx context.Context, fromL2BlockNumber uint64) {
+	select {
+	case p.requests <- preconfirmationRequest{rescindFromL2BlockNumber: fromL2BlockNumber}:
+	case <-ctx.Done():
+	}
+}
+
+// run signs and publishes the queued pre-confirmations until the context is done
+func (p *preconfirmer) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-p.requests:
+			if request.preconfirmation == nil {
+				hashes, err := p.poolIntf.RescindPreconfirmations(ctx, request.rescindFromL2BlockNumber)
+				if err != nil {
+					log.Errorf("failed to rescind pre-confirmations from L2 block %d, error: %v", request.rescindFromL2BlockNumber, err)
+				} else if len(hashes) > 0 {
+					log.Infof("rescinded %d pre-confirmations from L2 block %d", len(hashes), request.rescindFromL2BlockNumber)
+				}
+				continue
+			}
+
+			preconfirmation := request.preconfirmation
+			signature, err := crypto.Sign(preconfirmation.SigningHash(p.chainID).Bytes(), p.privateKey)
+			if err != nil {
+				log.Errorf("failed to sign pre-confirmation of tx %s, error: %v", preconfirmation.TxHash.String(), err)
+				continue
+			}
+			preconfirmation.Signature = signature
+
+			err = p.poolIntf.AddPreconfirmation(ctx, *preconfirmation)
+			if err != nil {
+				log.Errorf("failed to add pre-confirmation of tx %s, error: %v", preconfirmation.TxHash.String(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package sequencer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmer(t *testing.T) {
+	poolMock := NewPoolMock(t)
+	p, err := newPreconfirmer(PreconfirmationsCfg{
+		Enabled:    true,
+		PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "testonly"},
+		ChainID:    1001,
+	}, poolMock)
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(p.privateKey.PublicKey)
+
+	_, err = newPreconfirmer(PreconfirmationsCfg{
+		Enabled:    true,
+		PrivateKey: types.KeystoreFileConfig{Path: "../test/sequencer.keystore", Password: "wrong"},
+	}, poolMock)
+	assert.Error(t, err)
+
+	txHash := common.HexToHash("0x1")
+	done := make(chan struct{})
+	addCall := poolMock.On("AddPreconfirmation", mock.Anything, mock.MatchedBy(func(preconfirmation pool.Preconfirmation) bool {
+		preconfirmationSigner, err := preconfirmation.Signer(1001)
+		return err == nil && preconfirmationSigner == signer && preconfirmation.TxHash == txHash &&
+			preconfirmation.L2BlockNumber == 10 && preconfirmation.Index == 2 && preconfirmation.Status == 0
+	})).Return(nil).Once()
+	poolMock.On("RescindPreconfirmations", mock.Anything, uint64(10)).Return([]common.Hash{txHash}, nil).Once().
+		NotBefore(addCall).Run(func(args mock.Arguments) { close(done) })
+
+	p.preconfirm(txHash, 10, 2, true)
+	p.rescind(context.Background(), 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.run(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "pre-confirmation requests not applied")
+	}
+}
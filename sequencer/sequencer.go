@@ -10,6 +10,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqmetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/datastream"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,6 +34,10 @@ type Sequencer struct {
 	worker    *Worker
 	finalizer *finalizer
 
+	txOrderingPolicy TxOrderingPolicy
+	leaderLease      *leaderLease
+	preconfirmer     *preconfirmer
+
 	workerReadyTxsCond *timeoutCond
 
 	streamServer *datastreamer.StreamServer
@@ -43,6 +48,26 @@ type Sequencer struct {
 
 // New init sequencer
 func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txPool, stateIntf stateInterface, etherman ethermanInterface, eventLog *event.EventLog) (*Sequencer, error) {
+	txOrderingPolicy, err := NewTxOrderingPolicy(cfg.TxOrdering, batchCfg.Constraints)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkHACfg(cfg.HA)
+	if err != nil {
+		return nil, err
+	}
+
+	var preconfirmer *preconfirmer
+	if cfg.Preconfirmations.Enabled {
+		preconfirmer, err = newPreconfirmer(cfg.Preconfirmations, txPool)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seqmetrics.Register()
+
 	sequencer := &Sequencer{
 		cfg:       cfg,
 		batchCfg:  batchCfg,
@@ -51,6 +76,9 @@ func New(cfg Config, batchCfg state.BatchConfig, poolCfg pool.Config, txPool txP
 		stateIntf: stateIntf,
 		etherman:  etherman,
 		eventLog:  eventLog,
+
+		txOrderingPolicy: txOrderingPolicy,
+		preconfirmer:     preconfirmer,
 	}
 
 	sequencer.dataToStream = make(chan interface{}, datastreamChannelBufferSize)
@@ -65,6 +93,15 @@ func (s *Sequencer) Start(ctx context.Context) {
 		time.Sleep(time.Second)
 	}
 
+	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
+	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.poolCfg.PriceBump, s.txOrderingPolicy, s.workerReadyTxsCond)
+
+	// In high availability mode the sequencer waits as standby until it holds the leader lease
+	if s.cfg.HA.Enabled && !s.waitForLeadership(ctx) {
+		return
+	}
+
+	// The wip txs of the previous sequencer are marked as pending to be loaded again in the worker
 	err := s.pool.MarkWIPTxsAsPending(ctx)
 	if err != nil {
 		log.Fatalf("failed to mark wip txs as pending, error: %v", err)
@@ -89,9 +126,25 @@ func (s *Sequencer) Start(ctx context.Context) {
 		go s.sendDataToStreamer(s.cfg.StreamServer.ChainID, s.cfg.StreamServer.Version)
 	}
 
-	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
-	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
-	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.cfg.L2Coinbase, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.cfg.L2Coinbase, s.isSynced, s.batchCfg, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	s.finalizer.leaderLease = s.leaderLease
+	s.finalizer.preconfirmer = s.preconfirmer
+	if s.preconfirmer != nil {
+		go s.preconfirmer.run(ctx)
+	}
+	if s.leaderLease != nil {
+		go s.keepLeadership(ctx)
+	}
+
+	// The persistent control requests of the operator apply before the finalizer starts
+	control, err := s.stateIntf.GetSequencerControl(ctx, nil)
+	if err != nil {
+		log.Fatalf("failed to get sequencer control requests, error: %v", err)
+	}
+	applySequencerControl(s.finalizer, nil, control)
+	go s.loadControlRequests(ctx, s.finalizer, control)
+
+	// The finalizer resumes from the last wip batch and L2 block stored
 	go s.finalizer.Start(ctx)
 
 	go s.loadFromPool(ctx)
@@ -196,6 +249,13 @@ func (s *Sequencer) expireOldWorkerTxs(ctx context.Context) {
 
 // loadFromPool keeps loading transactions from the pool
 func (s *Sequencer) loadFromPool(ctx context.Context) {
+	var newTxs <-chan common.Hash
+	checkInterval := s.cfg.LoadPoolTxsCheckInterval.Duration
+	if s.cfg.LoadPoolTxsNotificationsEnabled {
+		newTxs = s.pool.SubscribeNewTxs(ctx)
+		checkInterval = s.cfg.LoadPoolTxsFallbackInterval.Duration
+	}
+
 	for {
 		if s.finalizer.haltFinalizer.Load() {
 			return
@@ -206,21 +266,79 @@ func (s *Sequencer) loadFromPool(ctx context.Context) {
 			log.Errorf("error loading txs from pool, error: %v", err)
 		}
 
-		for _, tx := range poolTransactions {
-			err := s.addTxToWorker(ctx, tx)
+		s.addTxsToWorker(ctx, poolTransactions)
+
+		if len(poolTransactions) == 0 {
+			s.loadNotifiedTxsFromPool(ctx, newTxs, checkInterval)
+		}
+	}
+}
+
+// loadNotifiedTxsFromPool loads the txs notified by the pool until checkInterval
+// elapses, when the whole pool is checked again in case a notification was missed
+func (s *Sequencer) loadNotifiedTxsFromPool(ctx context.Context, newTxs <-chan common.Hash, checkInterval time.Duration) {
+	timer := time.NewTimer(checkInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			return
+		case hash, ok := <-newTxs:
+			if !ok {
+				newTxs = nil
+				continue
+			}
+
+			// load in a single query the txs notified meanwhile
+			hashes := []common.Hash{hash}
+			for pending := len(newTxs); pending > 0; pending-- {
+				hash, ok := <-newTxs
+				if !ok {
+					newTxs = nil
+					break
+				}
+				hashes = append(hashes, hash)
+			}
+
+			poolTransactions, err := s.pool.GetNonWIPPendingTxsByHashes(ctx, hashes)
 			if err != nil {
-				log.Errorf("error adding transaction to worker, error: %v", err)
+				log.Errorf("error loading notified txs from pool, error: %v", err)
+				continue
+			}
+			s.addTxsToWorker(ctx, poolTransactions)
+
+			if s.finalizer.haltFinalizer.Load() {
+				return
 			}
 		}
+	}
+}
 
-		if len(poolTransactions) == 0 {
-			time.Sleep(s.cfg.LoadPoolTxsCheckInterval.Duration)
+func (s *Sequencer) addTxsToWorker(ctx context.Context, poolTransactions []pool.Transaction) {
+	for _, tx := range poolTransactions {
+		err := s.addTxToWorker(ctx, tx)
+		if err != nil {
+			log.Errorf("error adding transaction to worker, error: %v", err)
 		}
 	}
 }
 
-func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) error {
+// newTxTracker creates the TxTracker of a pool tx, keeping the time it was received by the pool
+func (s *Sequencer) newTxTracker(tx pool.Transaction) (*TxTracker, error) {
 	txTracker, err := s.worker.NewTxTracker(tx.Transaction, tx.ZKCounters, tx.ReservedZKCounters, tx.IP)
+	if err != nil {
+		return nil, err
+	}
+	txTracker.ReceivedAt = tx.ReceivedAt
+	txTracker.IsSponsoredClaim = s.pool.IsSponsoredClaim(tx.Transaction)
+	return txTracker, nil
+}
+
+func (s *Sequencer) addTxToWorker(ctx context.Context, tx pool.Transaction) error {
+	txTracker, err := s.newTxTracker(tx)
 	if err != nil {
 		return err
 	}
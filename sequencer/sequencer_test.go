@@ -0,0 +1,86 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNotifiedTxsFromPool(t *testing.T) {
+	ctx := context.Background()
+	txPoolMock := new(PoolMock)
+	txStateMock := new(StateMock)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1000))
+	require.NoError(t, err)
+	tx, err := auth.Signer(auth.From, types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil))
+	require.NoError(t, err)
+
+	s := &Sequencer{
+		pool:      txPoolMock,
+		worker:    NewWorker(txStateMock, state.BatchConstraintsCfg{}, 0, &gasPriceOrderingPolicy{}, newTimeoutCond(&sync.Mutex{})),
+		finalizer: &finalizer{},
+	}
+
+	// the notified txs are loaded from the pool in a single query
+	newTxs := make(chan common.Hash, 2)
+	newTxs <- tx.Hash()
+	newTxs <- common.HexToHash("0x1")
+	// the closed subscription is not read anymore until the interval elapses
+	close(newTxs)
+	txPoolMock.On("GetNonWIPPendingTxsByHashes", ctx, []common.Hash{tx.Hash(), common.HexToHash("0x1")}).
+		Return([]pool.Transaction{*pool.NewTransaction(*tx, "", false)}, nil).Once()
+
+	txPoolMock.On("IsSponsoredClaim", *tx).Return(false).Once()
+
+	// the worker fails to add the tx, so it is set as failed in the pool
+	txStateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{}, errors.New("state error")).Once()
+	txPoolMock.On("UpdateTxStatus", ctx, tx.Hash(), pool.TxStatusFailed, false, mock.Anything).Return(nil).Once()
+
+	start := time.Now()
+	s.loadNotifiedTxsFromPool(ctx, newTxs, 100*time.Millisecond)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	txPoolMock.AssertExpectations(t)
+	txStateMock.AssertExpectations(t)
+}
+
+func TestNewTxTrackerKeepsPoolReceivedAt(t *testing.T) {
+	txPoolMock := new(PoolMock)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1000))
+	require.NoError(t, err)
+	tx, err := auth.Signer(auth.From, types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(0), nil))
+	require.NoError(t, err)
+
+	s := &Sequencer{
+		pool:   txPoolMock,
+		worker: NewWorker(nil, state.BatchConstraintsCfg{}, 0, &fifoOrderingPolicy{}, newTimeoutCond(&sync.Mutex{})),
+	}
+
+	poolTx := pool.NewTransaction(*tx, "", false)
+	poolTx.ReceivedAt = time.Now().Add(-time.Hour)
+	txPoolMock.On("IsSponsoredClaim", *tx).Return(true).Once()
+
+	txTracker, err := s.newTxTracker(*poolTx)
+	require.NoError(t, err)
+	require.Equal(t, poolTx.ReceivedAt, txTracker.ReceivedAt)
+	require.True(t, txTracker.IsSponsoredClaim)
+	txPoolMock.AssertExpectations(t)
+}
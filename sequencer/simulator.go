@@ -0,0 +1,383 @@
+package sequencer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// simulationEndClosingReason is the closing reason of the wip batch closed when the simulation ends
+	simulationEndClosingReason state.ClosingReason = "Simulation end"
+)
+
+// SimulationTx is a pool tx replayed by the finalizer simulation
+type SimulationTx struct {
+	ReceivedAt         time.Time
+	Tx                 ethTypes.Transaction
+	UsedZKCounters     state.ZKCounters
+	ReservedZKCounters state.ZKCounters
+	IP                 string
+}
+
+// SimulationCfg is a candidate sequencer configuration evaluated by the finalizer simulation
+type SimulationCfg struct {
+	// Name identifies the candidate configuration in the simulation report
+	Name string `mapstructure:"Name"`
+
+	// Finalizer is the finalizer configuration. The L2 blocks and the batch sanity checks are always processed
+	// sequentially in the simulation (SequentialProcessL2Block and SequentialBatchSanityCheck) so it is deterministic
+	Finalizer FinalizerCfg `mapstructure:"Finalizer"`
+
+	// TxOrdering is the config of the order in which the ready txs are selected to be processed
+	TxOrdering TxOrderingCfg `mapstructure:"TxOrdering"`
+
+	// BatchConstraints are the resources constraints of the batches
+	BatchConstraints state.BatchConstraintsCfg `mapstructure:"BatchConstraints"`
+
+	// TxExecutionTime is the (simulated) time spent by the executor to process a tx
+	TxExecutionTime types.Duration `mapstructure:"TxExecutionTime"`
+
+	// L2BlockZKCounters are the counters used by the executor to open a new L2 block
+	L2BlockZKCounters state.ZKCounters `mapstructure:"L2BlockZKCounters"`
+}
+
+// SimulationLatency contains the percentiles of the time elapsed since a tx is received until its L2 block is closed
+type SimulationLatency struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// SimulationResourceUsage contains the percentage of a batch resource used by the closed batches
+type SimulationResourceUsage struct {
+	Resource string
+	AvgPct   float64
+	MaxPct   float64
+}
+
+// SimulationReport contains the results of the finalizer simulation for a candidate configuration
+type SimulationReport struct {
+	Name string
+	// Duration is the simulated time elapsed since the first tx is received until the simulation ends
+	Duration time.Duration
+	// Txs is the number of replayed txs
+	Txs uint64
+	// IncludedTxs is the number of txs stored in a L2 block
+	IncludedTxs uint64
+	// InvalidTxs is the number of txs set as invalid or failed by the finalizer
+	InvalidTxs uint64
+	// DroppedTxs is the number of txs rejected or replaced in the worker
+	DroppedTxs uint64
+	// PendingTxs is the number of txs still pending in the worker when the simulation ends
+	PendingTxs     uint64
+	Batches        uint64
+	L2Blocks       uint64
+	EmptyL2Blocks  uint64
+	ClosingReasons map[state.ClosingReason]uint64
+	TxLatency      SimulationLatency
+	Resources      []SimulationResourceUsage
+}
+
+// simulation replays the recorded txs through the finalizer and the worker. It implements the state, the
+// executor and the pool used by the finalizer, returning the recorded counters of the txs when they are executed
+type simulation struct {
+	ctx    context.Context
+	cfg    SimulationCfg
+	f      *finalizer
+	worker *Worker
+
+	// clock
+	clock    time.Time
+	clockMux sync.Mutex
+	start    time.Time
+
+	// replayed txs
+	txs           []SimulationTx
+	nextTx        int
+	txsByHash     map[common.Hash]*SimulationTx
+	senders       map[common.Hash]common.Address
+	initialNonces map[common.Address]uint64
+	balance       *big.Int
+	finished      bool
+
+	// state
+	mux               sync.Mutex
+	batches           map[uint64]*state.Batch
+	nonces            map[common.Address]uint64
+	lastL2BlockNumber uint64
+	invalidTxs        map[common.Hash]struct{}
+	latencies         []time.Duration
+	resourcesPct      [][]float64
+
+	report SimulationReport
+}
+
+// Simulate replays the txs through the finalizer and the worker with the candidate configuration, using a mocked executor
+// that returns the recorded counters of the txs. The simulated clock advances with the arrival time of the txs, the
+// NewTxsWaitInterval when there are no txs to process and the TxExecutionTime of each tx, so the results only depend
+// on the txs and the configuration
+func Simulate(ctx context.Context, cfg SimulationCfg, txs []SimulationTx) (*SimulationReport, error) {
+	if cfg.Finalizer.NewTxsWaitInterval.Duration <= 0 || cfg.Finalizer.L2BlockMaxDeltaTimestamp.Duration <= 0 {
+		return nil, fmt.Errorf("NewTxsWaitInterval and L2BlockMaxDeltaTimestamp must be greater than 0")
+	}
+
+	txOrderingPolicy, err := NewTxOrderingPolicy(cfg.TxOrdering, cfg.BatchConstraints)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newSimulation(ctx, cfg, txs)
+
+	finalizerCfg := cfg.Finalizer
+	finalizerCfg.SequentialProcessL2Block = true
+	finalizerCfg.SequentialBatchSanityCheck = true
+	finalizerCfg.HaltOnBatchNumber = 0
+	finalizerCfg.Metrics.EnableLog = false
+
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	if err != nil {
+		return nil, err
+	}
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	workerReadyTxsCond := newTimeoutCond(&sync.Mutex{})
+	s.worker = NewWorker(s, cfg.BatchConstraints, 0, txOrderingPolicy, workerReadyTxsCond)
+	s.f = newFinalizer(finalizerCfg, pool.Config{}, s.worker, s, s, nil, common.Address{}, func(ctx context.Context) bool { return true },
+		state.BatchConfig{Constraints: cfg.BatchConstraints}, eventLog, nil, workerReadyTxsCond, nil)
+	s.f.now = s.now
+	s.f.waitNewTxs = s.waitNewTxs
+	s.f.lastL1InfoTreeValid = true
+
+	// Store the L2 blocks in the order they are processed
+	storeErr := make(chan error, 1)
+	go func() {
+		var err error
+		for l2Block := range s.f.pendingL2BlocksToStore {
+			if err == nil {
+				err = s.f.storeL2Block(ctx, l2Block)
+			}
+			s.f.pendingL2BlocksToStoreWG.Done()
+		}
+		storeErr <- err
+	}()
+
+	s.f.initWIPBatch(ctx)
+	s.f.initWIPL2Block(ctx)
+
+	showNotFoundTxLog := true
+	for !s.finished && ctx.Err() == nil {
+		s.f.finalizeBatchesStep(ctx, &showNotFoundTxLog)
+	}
+
+	if !s.f.wipBatch.isEmpty() {
+		s.f.finalizeWIPBatch(ctx, simulationEndClosingReason)
+	}
+	s.f.waitPendingL2Blocks()
+	if s.f.sipBatch != nil {
+		err = s.f.finalizeSIPBatch(ctx)
+	}
+
+	close(s.f.pendingL2BlocksToStore)
+	if storeErr := <-storeErr; storeErr != nil {
+		return nil, fmt.Errorf("failed to store L2 block, error: %v", storeErr)
+	} else if err != nil {
+		return nil, err
+	} else if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.buildReport(), nil
+}
+
+// newSimulation creates the simulation of the txs sorted by arrival time
+func newSimulation(ctx context.Context, cfg SimulationCfg, txs []SimulationTx) *simulation {
+	s := &simulation{
+		ctx:           ctx,
+		cfg:           cfg,
+		txs:           make([]SimulationTx, len(txs)),
+		txsByHash:     make(map[common.Hash]*SimulationTx, len(txs)),
+		senders:       make(map[common.Hash]common.Address, len(txs)),
+		initialNonces: make(map[common.Address]uint64),
+		balance:       new(big.Int).Lsh(big.NewInt(1), 200), //nolint:gomnd
+		batches:       map[uint64]*state.Batch{0: {BatchNumber: 0, WIP: false}},
+		nonces:        make(map[common.Address]uint64),
+		invalidTxs:    make(map[common.Hash]struct{}),
+		resourcesPct:  make([][]float64, len(simulationResources)),
+		report: SimulationReport{
+			Name:           cfg.Name,
+			Txs:            uint64(len(txs)),
+			ClosingReasons: make(map[state.ClosingReason]uint64),
+		},
+	}
+
+	copy(s.txs, txs)
+	sort.SliceStable(s.txs, func(i, j int) bool { return s.txs[i].ReceivedAt.Before(s.txs[j].ReceivedAt) })
+
+	for i := range s.txs {
+		tx := &s.txs[i]
+		// Txs recorded without reserved counters reserve the counters they use
+		if tx.ReservedZKCounters == (state.ZKCounters{}) {
+			tx.ReservedZKCounters = tx.UsedZKCounters
+		}
+		s.txsByHash[tx.Tx.Hash()] = tx
+
+		sender, err := state.GetSender(tx.Tx)
+		if err != nil {
+			continue
+		}
+		s.senders[tx.Tx.Hash()] = sender
+		// The initial nonce of a sender is the lowest nonce of its txs
+		if nonce, found := s.initialNonces[sender]; !found || tx.Tx.Nonce() < nonce {
+			s.initialNonces[sender] = tx.Tx.Nonce()
+		}
+	}
+
+	if len(s.txs) > 0 {
+		s.clock = s.txs[0].ReceivedAt
+	} else {
+		s.clock = time.Unix(0, 0)
+	}
+	s.start = s.clock
+
+	return s
+}
+
+// now returns the simulated time
+func (s *simulation) now() time.Time {
+	s.clockMux.Lock()
+	defer s.clockMux.Unlock()
+	return s.clock
+}
+
+// advance moves forward the simulated clock and adds to the worker the txs received until the new time
+func (s *simulation) advance(d time.Duration) {
+	s.clockMux.Lock()
+	s.clock = s.clock.Add(d)
+	clock := s.clock
+	s.clockMux.Unlock()
+
+	for s.nextTx < len(s.txs) && !s.txs[s.nextTx].ReceivedAt.After(clock) {
+		s.addTx(&s.txs[s.nextTx])
+		s.nextTx++
+	}
+}
+
+// addTx adds a received tx to the worker
+func (s *simulation) addTx(tx *SimulationTx) {
+	dropped := false
+
+	txTracker, err := s.worker.NewTxTracker(tx.Tx, tx.UsedZKCounters, tx.ReservedZKCounters, tx.IP)
+	if err != nil {
+		dropped = true
+	} else {
+		txTracker.ReceivedAt = tx.ReceivedAt
+		replacedTx, dropReason := s.worker.AddTxTracker(s.ctx, txTracker)
+		dropped = dropReason != nil || replacedTx != nil
+	}
+
+	if dropped {
+		s.mux.Lock()
+		s.report.DroppedTxs++
+		s.mux.Unlock()
+	}
+}
+
+// waitNewTxs replaces the wait of the finalizer for new ready txs. It advances the clock until the next tx is
+// received or the NewTxsWaitInterval is reached, and ends the simulation when all the txs have been replayed
+// and the wip L2 block is empty
+func (s *simulation) waitNewTxs() {
+	if s.nextTx >= len(s.txs) && s.f.wipL2Block.isEmpty() {
+		s.finished = true
+		return
+	}
+
+	wait := s.cfg.Finalizer.NewTxsWaitInterval.Duration
+	if s.nextTx < len(s.txs) {
+		if untilNextTx := s.txs[s.nextTx].ReceivedAt.Sub(s.now()); untilNextTx < wait {
+			wait = untilNextTx
+		}
+	}
+
+	s.advance(wait)
+}
+
+// simulationResources are the names of the batch resources included in the simulation report
+var simulationResources = []string{"gas", "keccakHashes", "poseidonHashes", "poseidonPaddings", "memAligns", "arithmetics", "binaries", "steps", "sha256Hashes", "bytes"}
+
+// addClosedBatch adds to the report the closing reason and the resources used by a closed batch
+func (s *simulation) addClosedBatch(resources state.BatchResources, closingReason state.ClosingReason) {
+	pct := func(used uint64, max uint64) float64 {
+		if max == 0 {
+			return 0
+		}
+		return float64(used) * 100 / float64(max) //nolint:gomnd
+	}
+
+	constraints := s.cfg.BatchConstraints
+	usedPct := []float64{
+		pct(resources.ZKCounters.GasUsed, constraints.MaxCumulativeGasUsed),
+		pct(uint64(resources.ZKCounters.KeccakHashes), uint64(constraints.MaxKeccakHashes)),
+		pct(uint64(resources.ZKCounters.PoseidonHashes), uint64(constraints.MaxPoseidonHashes)),
+		pct(uint64(resources.ZKCounters.PoseidonPaddings), uint64(constraints.MaxPoseidonPaddings)),
+		pct(uint64(resources.ZKCounters.MemAligns), uint64(constraints.MaxMemAligns)),
+		pct(uint64(resources.ZKCounters.Arithmetics), uint64(constraints.MaxArithmetics)),
+		pct(uint64(resources.ZKCounters.Binaries), uint64(constraints.MaxBinaries)),
+		pct(uint64(resources.ZKCounters.Steps), uint64(constraints.MaxSteps)),
+		pct(uint64(resources.ZKCounters.Sha256Hashes_V2), uint64(constraints.MaxSHA256Hashes)),
+		pct(resources.Bytes, constraints.MaxBatchBytesSize),
+	}
+	for i, used := range usedPct {
+		s.resourcesPct[i] = append(s.resourcesPct[i], used)
+	}
+
+	s.report.Batches++
+	s.report.ClosingReasons[closingReason]++
+}
+
+// buildReport returns the report of the simulation
+func (s *simulation) buildReport() *SimulationReport {
+	report := s.report
+	report.Duration = s.now().Sub(s.start)
+	report.InvalidTxs = uint64(len(s.invalidTxs))
+	if processed := report.IncludedTxs + report.InvalidTxs + report.DroppedTxs; processed < report.Txs {
+		report.PendingTxs = report.Txs - processed
+	}
+
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+	percentile := func(p int) time.Duration {
+		if len(s.latencies) == 0 {
+			return 0
+		}
+		return s.latencies[(len(s.latencies)-1)*p/100] //nolint:gomnd
+	}
+	report.TxLatency = SimulationLatency{P50: percentile(50), P90: percentile(90), P99: percentile(99), Max: percentile(100)} //nolint:gomnd
+
+	for i, resource := range simulationResources {
+		usage := SimulationResourceUsage{Resource: resource}
+		for _, used := range s.resourcesPct[i] {
+			usage.AvgPct += used
+			if used > usage.MaxPct {
+				usage.MaxPct = used
+			}
+		}
+		if len(s.resourcesPct[i]) > 0 {
+			usage.AvgPct /= float64(len(s.resourcesPct[i]))
+		}
+		report.Resources = append(report.Resources, usage)
+	}
+
+	return &report
+}
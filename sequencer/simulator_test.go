@@ -0,0 +1,89 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	privateKeys := []string{
+		"0x28b2b0318721be8c8339199172cd7cc8f5e273800a35616ec893083a4b32c02e",
+		"0x45e17b0e5c8a43c7e4ce6bd2f0e0c8b8cc0c4f8a8d4e2d1e0f1a2b3c4d5e6f70",
+	}
+	signer := ethTypes.NewEIP155Signer(big.NewInt(1000))
+	receivedAt := time.Unix(1700000000, 0)
+
+	txs := []SimulationTx{}
+	for i := 0; i < 10; i++ {
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeys[i%2], "0x"))
+		require.NoError(t, err)
+		tx, err := ethTypes.SignTx(ethTypes.NewTransaction(uint64(i/2), common.HexToAddress("0x1"), big.NewInt(1), 21000, big.NewInt(1), nil), signer, privateKey)
+		require.NoError(t, err)
+
+		txs = append(txs, SimulationTx{
+			ReceivedAt:         receivedAt.Add(time.Duration(i) * 500 * time.Millisecond),
+			Tx:                 *tx,
+			UsedZKCounters:     state.ZKCounters{GasUsed: 21000, Steps: 1000},
+			ReservedZKCounters: state.ZKCounters{GasUsed: 21000, Steps: 1200},
+		})
+	}
+
+	cfg := SimulationCfg{
+		Name: "test",
+		Finalizer: FinalizerCfg{
+			NewTxsWaitInterval:         types.Duration{Duration: 100 * time.Millisecond},
+			ResourceExhaustedMarginPct: 10,
+			BatchMaxDeltaTimestamp:     types.Duration{Duration: 60 * time.Second},
+			L2BlockMaxDeltaTimestamp:   types.Duration{Duration: 3 * time.Second},
+		},
+		TxOrdering: TxOrderingCfg{Policy: TxOrderingGasPrice},
+		BatchConstraints: state.BatchConstraintsCfg{
+			MaxTxsPerBatch:       300,
+			MaxBatchBytesSize:    120000,
+			MaxCumulativeGasUsed: 1125899906842624,
+			MaxKeccakHashes:      2145,
+			MaxPoseidonHashes:    252357,
+			MaxPoseidonPaddings:  135191,
+			MaxMemAligns:         236585,
+			MaxArithmetics:       236585,
+			MaxBinaries:          473170,
+			MaxSteps:             3500,
+			MaxSHA256Hashes:      1596,
+		},
+		TxExecutionTime: types.Duration{Duration: 10 * time.Millisecond},
+	}
+
+	report, err := Simulate(context.Background(), cfg, txs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test", report.Name)
+	assert.Equal(t, uint64(10), report.Txs)
+	assert.Equal(t, uint64(10), report.IncludedTxs)
+	assert.Equal(t, uint64(0), report.InvalidTxs+report.DroppedTxs+report.PendingTxs)
+	// Only 3 txs fit in a batch because of the steps
+	assert.Equal(t, uint64(4), report.Batches)
+	assert.Equal(t, report.Batches, report.ClosingReasons[state.NoTxFitsClosingReason]+report.ClosingReasons[state.ResourceExhaustedClosingReason]+
+		report.ClosingReasons[state.ResourceMarginExhaustedClosingReason]+report.ClosingReasons[simulationEndClosingReason])
+	assert.Greater(t, report.L2Blocks, uint64(0))
+	assert.LessOrEqual(t, report.TxLatency.P50, report.TxLatency.Max)
+	assert.LessOrEqual(t, report.TxLatency.Max, cfg.Finalizer.L2BlockMaxDeltaTimestamp.Duration+time.Second)
+	require.Len(t, report.Resources, len(simulationResources))
+	assert.Equal(t, "steps", report.Resources[7].Resource)
+	assert.Greater(t, report.Resources[7].AvgPct, float64(50))
+
+	// The simulation is deterministic
+	report2, err := Simulate(context.Background(), cfg, txs)
+	require.NoError(t, err)
+	assert.Equal(t, report, report2)
+}
@@ -0,0 +1,497 @@
+package sequencer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// simulationDBTx is the db transaction used by the finalizer in the simulation, it does nothing
+type simulationDBTx struct {
+	pgx.Tx
+}
+
+// Commit commits the db transaction
+func (tx *simulationDBTx) Commit(ctx context.Context) error {
+	return nil
+}
+
+// Rollback rollbacks the db transaction
+func (tx *simulationDBTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+// ProcessBatchV2 executes the txs of the request returning the counters recorded for them. The simulated clock
+// advances the TxExecutionTime when a single tx is processed, and the latency of the txs is recorded when their
+// L2 block is processed
+func (s *simulation) ProcessBatchV2(ctx context.Context, request state.ProcessRequest, updateMerkleTree bool) (*state.ProcessBatchResponse, string, error) {
+	batchL2Data := request.Transactions
+	if request.SkipFirstChangeL2Block_V2 {
+		batchL2Data = append(s.BuildChangeL2Block(0, 0), batchL2Data...)
+	}
+
+	rawBatch, err := state.DecodeBatchV2(batchL2Data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	response := &state.ProcessBatchResponse{
+		ReadWriteAddresses: make(map[common.Address]*state.InfoReadWrite),
+	}
+	highReservedZKCounters := state.ZKCounters{}
+	txsReceivedAt := []int64{}
+
+	for _, rawL2Block := range rawBatch.Blocks {
+		if !request.SkipFirstChangeL2Block_V2 {
+			response.UsedZkCounters.SumUp(s.cfg.L2BlockZKCounters)
+		}
+
+		blockResponse := &state.ProcessBlockResponse{
+			Timestamp:            request.TimestampLimit_V2,
+			TransactionResponses: []*state.ProcessTransactionResponse{},
+		}
+		for _, rawTx := range rawL2Block.Transactions {
+			txHash := rawTx.Tx.Hash()
+			tx, found := s.txsByHash[txHash]
+			if !found {
+				return nil, "", fmt.Errorf("tx %s not found in the simulation txs", txHash)
+			}
+
+			response.UsedZkCounters.SumUp(tx.UsedZKCounters)
+			_, highReservedZKCounters = getNeededZKCounters(highReservedZKCounters, tx.UsedZKCounters, tx.ReservedZKCounters)
+			txsReceivedAt = append(txsReceivedAt, tx.ReceivedAt.UnixNano())
+
+			blockResponse.TransactionResponses = append(blockResponse.TransactionResponses, &state.ProcessTransactionResponse{
+				TxHash:              txHash,
+				Tx:                  rawTx.Tx,
+				GasUsed:             tx.UsedZKCounters.GasUsed,
+				EffectivePercentage: uint32(rawTx.EfficiencyPercentage),
+				ChangesStateRoot:    true,
+			})
+
+			sender := s.senders[txHash]
+			nonce := rawTx.Tx.Nonce() + 1
+			response.ReadWriteAddresses[sender] = &state.InfoReadWrite{Address: sender, Nonce: &nonce, Balance: s.balance}
+		}
+		response.BlockResponses = append(response.BlockResponses, blockResponse)
+	}
+
+	response.ReservedZkCounters = response.UsedZkCounters
+	response.ReservedZkCounters.SumUp(highReservedZKCounters)
+
+	if request.SkipFirstChangeL2Block_V2 {
+		s.advance(s.cfg.TxExecutionTime.Duration)
+	}
+
+	if updateMerkleTree {
+		processedAt := s.now().UnixNano()
+		s.mux.Lock()
+		for _, receivedAt := range txsReceivedAt {
+			s.latencies = append(s.latencies, time.Duration(processedAt-receivedAt))
+		}
+		s.mux.Unlock()
+	}
+
+	return response, "", nil
+}
+
+// BuildChangeL2Block returns a changeL2Block tx to use in the BatchL2Data
+func (s *simulation) BuildChangeL2Block(deltaTimestamp uint32, l1InfoTreeIndex uint32) []byte {
+	l2block := state.ChangeL2BlockHeader{
+		DeltaTimestamp:  deltaTimestamp,
+		IndexL1InfoTree: l1InfoTreeIndex,
+	}
+	return l2block.Encode(nil)
+}
+
+// GetForkIDByBatchNumber returns the fork id of a batch
+func (s *simulation) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	return state.FORKID_ELDERBERRY
+}
+
+// BeginStateTransaction starts a db transaction
+func (s *simulation) BeginStateTransaction(ctx context.Context) (pgx.Tx, error) {
+	return &simulationDBTx{}, nil
+}
+
+// GetLastStateRoot returns the last state root
+func (s *simulation) GetLastStateRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error) {
+	return state.ZeroHash, nil
+}
+
+// GetNonceByStateRoot returns the nonce of an address after its last stored tx
+func (s *simulation) GetNonceByStateRoot(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if nonce, found := s.nonces[address]; found {
+		return new(big.Int).SetUint64(nonce), nil
+	}
+	return new(big.Int).SetUint64(s.initialNonces[address]), nil
+}
+
+// GetBalanceByStateRoot returns the balance of an address, it is enough to pay for all the txs
+func (s *simulation) GetBalanceByStateRoot(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error) {
+	return s.balance, nil
+}
+
+// GetLastBatchNumber returns the number of the last batch
+func (s *simulation) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	lastBatchNumber := uint64(0)
+	for batchNumber := range s.batches {
+		if batchNumber > lastBatchNumber {
+			lastBatchNumber = batchNumber
+		}
+	}
+	return lastBatchNumber, nil
+}
+
+// GetBatchByNumber returns a batch
+func (s *simulation) GetBatchByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.Batch, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	batch, found := s.batches[batchNumber]
+	if !found {
+		return nil, state.ErrNotFound
+	}
+	batchCopy := *batch
+	return &batchCopy, nil
+}
+
+// OpenWIPBatch adds a new wip batch
+func (s *simulation) OpenWIPBatch(ctx context.Context, batch state.Batch, dbTx pgx.Tx) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	batch.WIP = true
+	s.batches[batch.BatchNumber] = &batch
+	return nil
+}
+
+// UpdateWIPBatch updates the data of a wip batch
+func (s *simulation) UpdateWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	batch, found := s.batches[receipt.BatchNumber]
+	if !found {
+		return state.ErrNotFound
+	}
+	batch.BatchL2Data = receipt.BatchL2Data
+	batch.Resources = receipt.BatchResources
+	batch.HighReservedZKCounters = receipt.HighReservedZKCounters
+	return nil
+}
+
+// CloseWIPBatch closes a wip batch and adds it to the simulation report
+func (s *simulation) CloseWIPBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	batch, found := s.batches[receipt.BatchNumber]
+	if !found {
+		return state.ErrNotFound
+	}
+	batch.WIP = false
+
+	s.addClosedBatch(receipt.BatchResources, receipt.ClosingReason)
+	return nil
+}
+
+// AddBatchClosingInfo stores the closing info of a batch, it is not needed by the simulation report
+func (s *simulation) AddBatchClosingInfo(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx) error {
+	return nil
+}
+
+// AddL2BlockClosingInfo stores the closing info of a L2 block, it is not needed by the simulation report
+func (s *simulation) AddL2BlockClosingInfo(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx) error {
+	return nil
+}
+
+// UpdateBatchAsChecked marks a batch as checked
+func (s *simulation) UpdateBatchAsChecked(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	return nil
+}
+
+// GetL1InfoTreeDataFromBatchL2Data returns the L1InfoTree data used by a batch
+func (s *simulation) GetL1InfoTreeDataFromBatchL2Data(ctx context.Context, batchL2Data []byte, dbTx pgx.Tx) (map[uint32]state.L1DataV2, common.Hash, common.Hash, error) {
+	return map[uint32]state.L1DataV2{}, state.ZeroHash, state.ZeroHash, nil
+}
+
+// GetLastL2Block returns the last L2 block, received when the simulation starts
+func (s *simulation) GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	l2Block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: new(big.Int).SetUint64(s.lastL2BlockNumber)}))
+	l2Block.ReceivedAt = s.start
+	return l2Block, nil
+}
+
+// GetLatestBatchGlobalExitRoot returns the last GER used by a batch
+func (s *simulation) GetLatestBatchGlobalExitRoot(ctx context.Context, dbTx pgx.Tx) (common.Hash, error) {
+	return state.ZeroHash, nil
+}
+
+// StoreL2Block stores a L2 block and adds it to the simulation report
+func (s *simulation) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *state.ProcessBlockResponse, txsEGPLog []*state.EffectiveGasPriceLog, dbTx pgx.Tx) (common.Hash, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.lastL2BlockNumber++
+	s.report.L2Blocks++
+	if len(l2Block.TransactionResponses) == 0 {
+		s.report.EmptyL2Blocks++
+	}
+
+	for _, txResponse := range l2Block.TransactionResponses {
+		s.report.IncludedTxs++
+		s.nonces[s.senders[txResponse.TxHash]] = txResponse.Tx.Nonce() + 1
+	}
+
+	return common.BigToHash(new(big.Int).SetUint64(s.lastL2BlockNumber)), nil
+}
+
+// UpdateTxStatus updates the status of a tx in the pool, the txs set as invalid or failed are added to the simulation report
+func (s *simulation) UpdateTxStatus(ctx context.Context, hash common.Hash, newStatus pool.TxStatus, isWIP bool, failedReason *string) error {
+	if newStatus == pool.TxStatusInvalid || newStatus == pool.TxStatusFailed {
+		s.mux.Lock()
+		s.invalidTxs[hash] = struct{}{}
+		s.mux.Unlock()
+	}
+	return nil
+}
+
+// GetL1AndL2GasPrice returns the L1 and L2 gas prices. The effective gas price is not simulated, but the
+// prices can't be zero to calculate it
+func (s *simulation) GetL1AndL2GasPrice() (uint64, uint64) {
+	return 1, 1
+}
+
+// The state and pool methods below are not used by the finalizer in the simulation, they return ErrNotSupportedBySimulation
+
+// GetTxsOlderThanNL1BlocksUntilTxHash is not supported by the simulation
+func (s *simulation) GetTxsOlderThanNL1BlocksUntilTxHash(ctx context.Context, nL1Blocks uint64, earliestTxHash common.Hash, dbTx pgx.Tx) ([]common.Hash, error) {
+	return nil, fmt.Errorf("GetTxsOlderThanNL1BlocksUntilTxHash: %w", ErrNotSupportedBySimulation)
+}
+
+// GetLastVirtualBatchNum is not supported by the simulation
+func (s *simulation) GetLastVirtualBatchNum(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return 0, fmt.Errorf("GetLastVirtualBatchNum: %w", ErrNotSupportedBySimulation)
+}
+
+// CloseBatch is not supported by the simulation
+func (s *simulation) CloseBatch(ctx context.Context, receipt state.ProcessingReceipt, dbTx pgx.Tx) error {
+	return fmt.Errorf("CloseBatch: %w", ErrNotSupportedBySimulation)
+}
+
+// GetForcedBatch is not supported by the simulation
+func (s *simulation) GetForcedBatch(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (*state.ForcedBatch, error) {
+	return nil, fmt.Errorf("GetForcedBatch: %w", ErrNotSupportedBySimulation)
+}
+
+// OpenBatch is not supported by the simulation
+func (s *simulation) OpenBatch(ctx context.Context, processingContext state.ProcessingContext, dbTx pgx.Tx) error {
+	return fmt.Errorf("OpenBatch: %w", ErrNotSupportedBySimulation)
+}
+
+// GetLastBlock is not supported by the simulation
+func (s *simulation) GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error) {
+	return nil, fmt.Errorf("GetLastBlock: %w", ErrNotSupportedBySimulation)
+}
+
+// GetForcedBatchesSince is not supported by the simulation
+func (s *simulation) GetForcedBatchesSince(ctx context.Context, forcedBatchNumber, maxBlockNumber uint64, dbTx pgx.Tx) ([]*state.ForcedBatch, error) {
+	return nil, fmt.Errorf("GetForcedBatchesSince: %w", ErrNotSupportedBySimulation)
+}
+
+// GetLastTrustedForcedBatchNumber is not supported by the simulation
+func (s *simulation) GetLastTrustedForcedBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return 0, fmt.Errorf("GetLastTrustedForcedBatchNumber: %w", ErrNotSupportedBySimulation)
+}
+
+// CountReorgs is not supported by the simulation
+func (s *simulation) CountReorgs(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return 0, fmt.Errorf("CountReorgs: %w", ErrNotSupportedBySimulation)
+}
+
+// GetLatestL1InfoRoot is not supported by the simulation
+func (s *simulation) GetLatestL1InfoRoot(ctx context.Context, maxBlockNumber uint64) (state.L1InfoTreeExitRootStorageEntry, error) {
+	return state.L1InfoTreeExitRootStorageEntry{}, fmt.Errorf("GetLatestL1InfoRoot: %w", ErrNotSupportedBySimulation)
+}
+
+// GetStoredFlushID is not supported by the simulation
+func (s *simulation) GetStoredFlushID(ctx context.Context) (uint64, string, error) {
+	return 0, "", fmt.Errorf("GetStoredFlushID: %w", ErrNotSupportedBySimulation)
+}
+
+// GetDSGenesisBlock is not supported by the simulation
+func (s *simulation) GetDSGenesisBlock(ctx context.Context, dbTx pgx.Tx) (*state.DSL2Block, error) {
+	return nil, fmt.Errorf("GetDSGenesisBlock: %w", ErrNotSupportedBySimulation)
+}
+
+// GetDSBatches is not supported by the simulation
+func (s *simulation) GetDSBatches(ctx context.Context, firstBatchNumber, lastBatchNumber uint64, readWIPBatch bool, dbTx pgx.Tx) ([]*state.DSBatch, error) {
+	return nil, fmt.Errorf("GetDSBatches: %w", ErrNotSupportedBySimulation)
+}
+
+// GetDSL2Blocks is not supported by the simulation
+func (s *simulation) GetDSL2Blocks(ctx context.Context, firstBatchNumber, lastBatchNumber uint64, dbTx pgx.Tx) ([]*state.DSL2Block, error) {
+	return nil, fmt.Errorf("GetDSL2Blocks: %w", ErrNotSupportedBySimulation)
+}
+
+// GetDSL2Transactions is not supported by the simulation
+func (s *simulation) GetDSL2Transactions(ctx context.Context, firstL2Block, lastL2Block uint64, dbTx pgx.Tx) ([]*state.DSL2Transaction, error) {
+	return nil, fmt.Errorf("GetDSL2Transactions: %w", ErrNotSupportedBySimulation)
+}
+
+// GetStorageAt is not supported by the simulation
+func (s *simulation) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
+	return nil, fmt.Errorf("GetStorageAt: %w", ErrNotSupportedBySimulation)
+}
+
+// GetBlockByNumber is not supported by the simulation
+func (s *simulation) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	return nil, fmt.Errorf("GetBlockByNumber: %w", ErrNotSupportedBySimulation)
+}
+
+// GetVirtualBatchParentHash is not supported by the simulation
+func (s *simulation) GetVirtualBatchParentHash(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (common.Hash, error) {
+	return common.Hash{}, fmt.Errorf("GetVirtualBatchParentHash: %w", ErrNotSupportedBySimulation)
+}
+
+// GetForcedBatchParentHash is not supported by the simulation
+func (s *simulation) GetForcedBatchParentHash(ctx context.Context, forcedBatchNumber uint64, dbTx pgx.Tx) (common.Hash, error) {
+	return common.Hash{}, fmt.Errorf("GetForcedBatchParentHash: %w", ErrNotSupportedBySimulation)
+}
+
+// GetL1InfoRootLeafByIndex is not supported by the simulation
+func (s *simulation) GetL1InfoRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (state.L1InfoTreeExitRootStorageEntry, error) {
+	return state.L1InfoTreeExitRootStorageEntry{}, fmt.Errorf("GetL1InfoRootLeafByIndex: %w", ErrNotSupportedBySimulation)
+}
+
+// GetNotCheckedBatches is not supported by the simulation
+func (s *simulation) GetNotCheckedBatches(ctx context.Context, dbTx pgx.Tx) ([]*state.Batch, error) {
+	return nil, fmt.Errorf("GetNotCheckedBatches: %w", ErrNotSupportedBySimulation)
+}
+
+// AcquireSequencerLease is not supported by the simulation
+func (s *simulation) AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	return 0, fmt.Errorf("AcquireSequencerLease: %w", ErrNotSupportedBySimulation)
+}
+
+// RenewSequencerLease is not supported by the simulation
+func (s *simulation) RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error {
+	return fmt.Errorf("RenewSequencerLease: %w", ErrNotSupportedBySimulation)
+}
+
+// CheckSequencerLease is not supported by the simulation
+func (s *simulation) CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	return fmt.Errorf("CheckSequencerLease: %w", ErrNotSupportedBySimulation)
+}
+
+// ReleaseSequencerLease is not supported by the simulation
+func (s *simulation) ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	return fmt.Errorf("ReleaseSequencerLease: %w", ErrNotSupportedBySimulation)
+}
+
+// GetSequencerControl is not supported by the simulation
+func (s *simulation) GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*state.SequencerControl, error) {
+	return nil, fmt.Errorf("GetSequencerControl: %w", ErrNotSupportedBySimulation)
+}
+
+// SetSequencerHaltOnBatchNumber is not supported by the simulation
+func (s *simulation) SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	return fmt.Errorf("SetSequencerHaltOnBatchNumber: %w", ErrNotSupportedBySimulation)
+}
+
+// DeleteTransactionsByHashes is not supported by the simulation
+func (s *simulation) DeleteTransactionsByHashes(ctx context.Context, hashes []common.Hash) error {
+	return fmt.Errorf("DeleteTransactionsByHashes: %w", ErrNotSupportedBySimulation)
+}
+
+// DeleteFailedTransactionsOlderThan is not supported by the simulation
+func (s *simulation) DeleteFailedTransactionsOlderThan(ctx context.Context, date time.Time) error {
+	return fmt.Errorf("DeleteFailedTransactionsOlderThan: %w", ErrNotSupportedBySimulation)
+}
+
+// DeleteTransactionByHash is not supported by the simulation
+func (s *simulation) DeleteTransactionByHash(ctx context.Context, hash common.Hash) error {
+	return fmt.Errorf("DeleteTransactionByHash: %w", ErrNotSupportedBySimulation)
+}
+
+// MarkWIPTxsAsPending is not supported by the simulation
+func (s *simulation) MarkWIPTxsAsPending(ctx context.Context) error {
+	return fmt.Errorf("MarkWIPTxsAsPending: %w", ErrNotSupportedBySimulation)
+}
+
+// GetNonWIPPendingTxs is not supported by the simulation
+func (s *simulation) GetNonWIPPendingTxs(ctx context.Context) ([]pool.Transaction, error) {
+	return nil, fmt.Errorf("GetNonWIPPendingTxs: %w", ErrNotSupportedBySimulation)
+}
+
+// GetPendingTxs is not supported by the simulation
+func (s *simulation) GetPendingTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	return nil, fmt.Errorf("GetPendingTxs: %w", ErrNotSupportedBySimulation)
+}
+
+// GetNonWIPPendingTxsByHashes is not supported by the simulation
+func (s *simulation) GetNonWIPPendingTxsByHashes(ctx context.Context, hashes []common.Hash) ([]pool.Transaction, error) {
+	return nil, fmt.Errorf("GetNonWIPPendingTxsByHashes: %w", ErrNotSupportedBySimulation)
+}
+
+// SubscribeNewTxs returns a nil channel, the simulation adds the txs to the worker when they are received
+func (s *simulation) SubscribeNewTxs(ctx context.Context) <-chan common.Hash {
+	return nil
+}
+
+// GetTxZkCountersByHash is not supported by the simulation
+func (s *simulation) GetTxZkCountersByHash(ctx context.Context, hash common.Hash) (*state.ZKCounters, *state.ZKCounters, error) {
+	return nil, nil, fmt.Errorf("GetTxZkCountersByHash: %w", ErrNotSupportedBySimulation)
+}
+
+// UpdateTxWIPStatus is not supported by the simulation
+func (s *simulation) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
+	return fmt.Errorf("UpdateTxWIPStatus: %w", ErrNotSupportedBySimulation)
+}
+
+// GetGasPrices is not supported by the simulation
+func (s *simulation) GetGasPrices(ctx context.Context) (pool.GasPrices, error) {
+	return pool.GasPrices{}, fmt.Errorf("GetGasPrices: %w", ErrNotSupportedBySimulation)
+}
+
+// GetDefaultMinGasPriceAllowed returns 0, the effective gas price is not simulated
+func (s *simulation) GetDefaultMinGasPriceAllowed() uint64 {
+	return 0
+}
+
+// GetEarliestProcessedTx is not supported by the simulation
+func (s *simulation) GetEarliestProcessedTx(ctx context.Context) (common.Hash, error) {
+	return common.Hash{}, fmt.Errorf("GetEarliestProcessedTx: %w", ErrNotSupportedBySimulation)
+}
+
+// AddPreconfirmation is not supported by the simulation
+func (s *simulation) AddPreconfirmation(ctx context.Context, preconfirmation pool.Preconfirmation) error {
+	return fmt.Errorf("AddPreconfirmation: %w", ErrNotSupportedBySimulation)
+}
+
+// RescindPreconfirmations is not supported by the simulation
+func (s *simulation) RescindPreconfirmations(ctx context.Context, fromL2BlockNumber uint64) ([]common.Hash, error) {
+	return nil, fmt.Errorf("RescindPreconfirmations: %w", ErrNotSupportedBySimulation)
+}
+
+// IsSponsoredClaim returns false, the sponsored claims are not simulated
+func (s *simulation) IsSponsoredClaim(tx ethTypes.Transaction) bool {
+	return false
+}
@@ -0,0 +1,320 @@
+package sequencer
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxOrderingPolicyType is the name of a policy to order the ready txs of the worker
+type TxOrderingPolicyType string
+
+const (
+	// TxOrderingGasPrice selects first the txs with the highest gas price
+	TxOrderingGasPrice TxOrderingPolicyType = "gasprice"
+	// TxOrderingFIFO selects first the txs received first by the sequencer
+	TxOrderingFIFO TxOrderingPolicyType = "fifo"
+	// TxOrderingRoundRobin selects one tx of each sender per round
+	TxOrderingRoundRobin TxOrderingPolicyType = "roundrobin"
+	// TxOrderingPriority selects first the txs of the priority addresses
+	TxOrderingPriority TxOrderingPolicyType = "priority"
+	// TxOrderingZKEfficiency selects first the txs paying the highest fee per unit of their scarcest batch resource
+	TxOrderingZKEfficiency TxOrderingPolicyType = "zkefficiency"
+
+	// priorityLane is the lane of the txs sent by the priority addresses
+	priorityLane = "priority"
+	// regularLane is the lane of the txs not sent by the priority addresses
+	regularLane = "regular"
+)
+
+// TxOrderingPolicy decides the order in which the worker offers the ready txs to the finalizer.
+// Its methods are called with the worker lock held
+type TxOrderingPolicy interface {
+	// Name returns the name of the policy
+	Name() TxOrderingPolicyType
+	// Lane returns the lane of the tx within the policy, used to label the wait time metrics
+	Lane(tx *TxTracker) string
+	// Ready is called when the tx becomes the ready tx of its sender, before it is sorted
+	Ready(tx *TxTracker)
+	// Selected is called when the tx is selected to be processed
+	Selected(tx *TxTracker)
+	// Compare returns a negative number if tx1 must be selected before tx2, a positive number
+	// if tx2 must be selected before tx1 and 0 if they have the same priority. It must not change
+	// while the txs are sorted
+	Compare(tx1 *TxTracker, tx2 *TxTracker) int
+	// SetBatchConstraints is called when the batch constraints change, like at a fork upgrade.
+	// It returns true if the order of the txs changes, so the sorted txs must be sorted again
+	SetBatchConstraints(constraints state.BatchConstraintsCfg) bool
+}
+
+// NewTxOrderingPolicy creates the tx ordering policy of the config
+func NewTxOrderingPolicy(cfg TxOrderingCfg, constraints state.BatchConstraintsCfg) (TxOrderingPolicy, error) {
+	switch cfg.Policy {
+	case TxOrderingGasPrice, "":
+		return &gasPriceOrderingPolicy{}, nil
+	case TxOrderingFIFO:
+		return &fifoOrderingPolicy{}, nil
+	case TxOrderingRoundRobin:
+		return &roundRobinOrderingPolicy{lastRounds: map[common.Address]senderRound{}}, nil
+	case TxOrderingPriority:
+		priorityAddresses := make(map[common.Address]struct{}, len(cfg.PriorityAddresses))
+		for _, address := range cfg.PriorityAddresses {
+			priorityAddresses[address] = struct{}{}
+		}
+		return &priorityOrderingPolicy{priorityAddresses: priorityAddresses}, nil
+	case TxOrderingZKEfficiency:
+		return &zkEfficiencyOrderingPolicy{constraints: constraints}, nil
+	default:
+		return nil, fmt.Errorf("unknown tx ordering policy %s", cfg.Policy)
+	}
+}
+
+// compareGasPrice sorts the txs by descending gas price
+func compareGasPrice(tx1 *TxTracker, tx2 *TxTracker) int {
+	return tx2.GasPrice.Cmp(tx1.GasPrice)
+}
+
+// compareReceivedAt sorts the txs by ascending received time
+func compareReceivedAt(tx1 *TxTracker, tx2 *TxTracker) int {
+	if tx1.ReceivedAt.Before(tx2.ReceivedAt) {
+		return -1
+	} else if tx1.ReceivedAt.After(tx2.ReceivedAt) {
+		return 1
+	}
+	return 0
+}
+
+// gasPriceOrderingPolicy selects first the txs with the highest gas price
+type gasPriceOrderingPolicy struct{}
+
+func (p *gasPriceOrderingPolicy) Name() TxOrderingPolicyType {
+	return TxOrderingGasPrice
+}
+
+func (p *gasPriceOrderingPolicy) Lane(tx *TxTracker) string {
+	return string(p.Name())
+}
+
+func (p *gasPriceOrderingPolicy) Ready(tx *TxTracker) {
+}
+
+func (p *gasPriceOrderingPolicy) Selected(tx *TxTracker) {
+}
+
+func (p *gasPriceOrderingPolicy) SetBatchConstraints(constraints state.BatchConstraintsCfg) bool {
+	return false
+}
+
+func (p *gasPriceOrderingPolicy) Compare(tx1, tx2 *TxTracker) int {
+	return compareGasPrice(tx1, tx2)
+}
+
+// fifoOrderingPolicy selects first the txs received first by the sequencer
+type fifoOrderingPolicy struct{}
+
+func (p *fifoOrderingPolicy) Name() TxOrderingPolicyType {
+	return TxOrderingFIFO
+}
+
+func (p *fifoOrderingPolicy) Lane(tx *TxTracker) string {
+	return string(p.Name())
+}
+
+func (p *fifoOrderingPolicy) Ready(tx *TxTracker) {
+}
+
+func (p *fifoOrderingPolicy) Selected(tx *TxTracker) {
+}
+
+func (p *fifoOrderingPolicy) SetBatchConstraints(constraints state.BatchConstraintsCfg) bool {
+	return false
+}
+
+func (p *fifoOrderingPolicy) Compare(tx1, tx2 *TxTracker) int {
+	return compareReceivedAt(tx1, tx2)
+}
+
+// senderRound is the round assigned to the last ready tx of a sender
+type senderRound struct {
+	round uint64
+	nonce uint64
+}
+
+// roundRobinOrderingPolicy selects one tx of each sender per round, the txs of the same
+// round are selected in arrival order. A sender joins the current round with its first
+// ready tx and each of its next ready txs goes to the following round, so the senders
+// with many txs can not delay the txs of the others
+type roundRobinOrderingPolicy struct {
+	currentRound uint64
+	lastRounds   map[common.Address]senderRound
+}
+
+func (p *roundRobinOrderingPolicy) Name() TxOrderingPolicyType {
+	return TxOrderingRoundRobin
+}
+
+func (p *roundRobinOrderingPolicy) Lane(tx *TxTracker) string {
+	return string(p.Name())
+}
+
+func (p *roundRobinOrderingPolicy) Ready(tx *TxTracker) {
+	round := p.currentRound
+	if last, found := p.lastRounds[tx.From]; found {
+		if last.nonce == tx.Nonce {
+			// the same tx moved back to ready or replaced, it keeps its round
+			round = last.round
+		} else if last.round+1 > round {
+			round = last.round + 1
+		}
+	}
+	tx.orderingRound = round
+	p.lastRounds[tx.From] = senderRound{round: round, nonce: tx.Nonce}
+}
+
+func (p *roundRobinOrderingPolicy) Selected(tx *TxTracker) {
+	if tx.orderingRound <= p.currentRound {
+		return
+	}
+	p.currentRound = tx.orderingRound
+
+	// the senders behind the current round join it with their next ready tx
+	for address, last := range p.lastRounds {
+		if last.round < p.currentRound {
+			delete(p.lastRounds, address)
+		}
+	}
+}
+
+func (p *roundRobinOrderingPolicy) SetBatchConstraints(constraints state.BatchConstraintsCfg) bool {
+	return false
+}
+
+func (p *roundRobinOrderingPolicy) Compare(tx1, tx2 *TxTracker) int {
+	if tx1.orderingRound < tx2.orderingRound {
+		return -1
+	} else if tx1.orderingRound > tx2.orderingRound {
+		return 1
+	}
+	return compareReceivedAt(tx1, tx2)
+}
+
+// priorityOrderingPolicy selects first the txs sent by the priority addresses, the
+// txs of the same lane are selected by descending gas price
+type priorityOrderingPolicy struct {
+	priorityAddresses map[common.Address]struct{}
+}
+
+func (p *priorityOrderingPolicy) Name() TxOrderingPolicyType {
+	return TxOrderingPriority
+}
+
+func (p *priorityOrderingPolicy) Ready(tx *TxTracker) {
+}
+
+func (p *priorityOrderingPolicy) Selected(tx *TxTracker) {
+}
+
+func (p *priorityOrderingPolicy) SetBatchConstraints(constraints state.BatchConstraintsCfg) bool {
+	return false
+}
+
+func (p *priorityOrderingPolicy) Lane(tx *TxTracker) string {
+	if p.isPriority(tx) {
+		return priorityLane
+	}
+	return regularLane
+}
+
+func (p *priorityOrderingPolicy) Compare(tx1, tx2 *TxTracker) int {
+	isPriority1, isPriority2 := p.isPriority(tx1), p.isPriority(tx2)
+	if isPriority1 && !isPriority2 {
+		return -1
+	} else if !isPriority1 && isPriority2 {
+		return 1
+	}
+	return compareGasPrice(tx1, tx2)
+}
+
+func (p *priorityOrderingPolicy) isPriority(tx *TxTracker) bool {
+	_, found := p.priorityAddresses[tx.From]
+	return found
+}
+
+// zkEfficiencyOrderingPolicy selects first the txs paying the highest fee per unit of
+// their scarcest batch resource, the one they use the most relative to the batch limits
+type zkEfficiencyOrderingPolicy struct {
+	constraints state.BatchConstraintsCfg
+}
+
+func (p *zkEfficiencyOrderingPolicy) Name() TxOrderingPolicyType {
+	return TxOrderingZKEfficiency
+}
+
+func (p *zkEfficiencyOrderingPolicy) Lane(tx *TxTracker) string {
+	return string(p.Name())
+}
+
+func (p *zkEfficiencyOrderingPolicy) Ready(tx *TxTracker) {
+}
+
+func (p *zkEfficiencyOrderingPolicy) Selected(tx *TxTracker) {
+}
+
+func (p *zkEfficiencyOrderingPolicy) SetBatchConstraints(constraints state.BatchConstraintsCfg) bool {
+	if constraints == p.constraints {
+		return false
+	}
+	p.constraints = constraints
+	return true
+}
+
+func (p *zkEfficiencyOrderingPolicy) Compare(tx1, tx2 *TxTracker) int {
+	efficiency1, efficiency2 := p.efficiency(tx1), p.efficiency(tx2)
+	if efficiency1 > efficiency2 {
+		return -1
+	} else if efficiency1 < efficiency2 {
+		return 1
+	}
+	return 0
+}
+
+// efficiency returns the fee of the tx divided by the fraction of the batch limit
+// of the resource it uses the most
+func (p *zkEfficiencyOrderingPolicy) efficiency(tx *TxTracker) float64 {
+	c := p.constraints
+	counters := tx.ReservedZKCounters
+	scarcest := math.Max(usage(counters.GasUsed, c.MaxCumulativeGasUsed), usage(tx.Bytes, c.MaxBatchBytesSize))
+	for _, u := range []float64{
+		usage(uint64(counters.KeccakHashes), uint64(c.MaxKeccakHashes)),
+		usage(uint64(counters.PoseidonHashes), uint64(c.MaxPoseidonHashes)),
+		usage(uint64(counters.PoseidonPaddings), uint64(c.MaxPoseidonPaddings)),
+		usage(uint64(counters.MemAligns), uint64(c.MaxMemAligns)),
+		usage(uint64(counters.Arithmetics), uint64(c.MaxArithmetics)),
+		usage(uint64(counters.Binaries), uint64(c.MaxBinaries)),
+		usage(uint64(counters.Steps), uint64(c.MaxSteps)),
+		usage(uint64(counters.Sha256Hashes_V2), uint64(c.MaxSHA256Hashes)),
+	} {
+		scarcest = math.Max(scarcest, u)
+	}
+
+	gas := tx.UsedZKCounters.GasUsed
+	if gas == 0 {
+		gas = tx.Gas
+	}
+	fee, _ := new(big.Float).Mul(new(big.Float).SetInt(tx.GasPrice), new(big.Float).SetUint64(gas)).Float64()
+	if scarcest == 0 {
+		return math.Inf(1)
+	}
+	return fee / scarcest
+}
+
+// usage returns the fraction of the limit used, 0 if there is no limit
+func usage(used uint64, limit uint64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return float64(used) / float64(limit)
+}
@@ -0,0 +1,151 @@
+package sequencer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sortedHashes(el *txSortedList) []string {
+	hashes := []string{}
+	for _, tx := range el.GetSorted() {
+		hashes = append(hashes, tx.HashStr)
+	}
+	return hashes
+}
+
+func TestNewTxOrderingPolicy(t *testing.T) {
+	for _, policyType := range []TxOrderingPolicyType{TxOrderingGasPrice, TxOrderingFIFO, TxOrderingRoundRobin, TxOrderingPriority, TxOrderingZKEfficiency} {
+		policy, err := NewTxOrderingPolicy(TxOrderingCfg{Policy: policyType}, state.BatchConstraintsCfg{})
+		require.NoError(t, err)
+		assert.Equal(t, policyType, policy.Name())
+	}
+
+	policy, err := NewTxOrderingPolicy(TxOrderingCfg{}, state.BatchConstraintsCfg{})
+	require.NoError(t, err)
+	assert.Equal(t, TxOrderingGasPrice, policy.Name())
+
+	_, err = NewTxOrderingPolicy(TxOrderingCfg{Policy: "unknown"}, state.BatchConstraintsCfg{})
+	assert.Error(t, err)
+}
+
+func TestTxOrderingFIFO(t *testing.T) {
+	now := time.Now()
+	el := newTxSortedList(&fifoOrderingPolicy{})
+	el.add(&TxTracker{HashStr: "0x01", GasPrice: big.NewInt(100), ReceivedAt: now.Add(2 * time.Second)})
+	el.add(&TxTracker{HashStr: "0x02", GasPrice: big.NewInt(10), ReceivedAt: now})
+	el.add(&TxTracker{HashStr: "0x03", GasPrice: big.NewInt(50), ReceivedAt: now.Add(time.Second)})
+	el.add(&TxTracker{HashStr: "0x04", GasPrice: big.NewInt(50), ReceivedAt: now})
+	assert.Equal(t, []string{"0x02", "0x04", "0x03", "0x01"}, sortedHashes(el))
+
+	require.True(t, el.delete(&TxTracker{HashStr: "0x04"}))
+	assert.Equal(t, []string{"0x02", "0x03", "0x01"}, sortedHashes(el))
+}
+
+func TestTxOrderingPriority(t *testing.T) {
+	oracle := common.HexToAddress("0x1")
+	policy, err := NewTxOrderingPolicy(TxOrderingCfg{Policy: TxOrderingPriority, PriorityAddresses: []common.Address{oracle}}, state.BatchConstraintsCfg{})
+	require.NoError(t, err)
+
+	el := newTxSortedList(policy)
+	el.add(&TxTracker{HashStr: "0x01", From: common.HexToAddress("0x2"), GasPrice: big.NewInt(100)})
+	el.add(&TxTracker{HashStr: "0x02", From: oracle, GasPrice: big.NewInt(1)})
+	el.add(&TxTracker{HashStr: "0x03", From: common.HexToAddress("0x3"), GasPrice: big.NewInt(50)})
+	el.add(&TxTracker{HashStr: "0x04", From: oracle, GasPrice: big.NewInt(2)})
+	assert.Equal(t, []string{"0x04", "0x02", "0x01", "0x03"}, sortedHashes(el))
+
+	assert.Equal(t, priorityLane, policy.Lane(el.getByIndex(0)))
+	assert.Equal(t, regularLane, policy.Lane(el.getByIndex(2)))
+}
+
+func TestTxOrderingZKEfficiency(t *testing.T) {
+	constraints := state.BatchConstraintsCfg{MaxCumulativeGasUsed: 1000000, MaxBatchBytesSize: 1000, MaxKeccakHashes: 100, MaxSteps: 1000000}
+	el := newTxSortedList(&zkEfficiencyOrderingPolicy{constraints: constraints})
+
+	// same fee, but 0x01 uses half of the keccak hashes of the batch
+	el.add(&TxTracker{HashStr: "0x01", GasPrice: big.NewInt(10), Gas: 21000, Bytes: 100, ReservedZKCounters: state.ZKCounters{GasUsed: 21000, KeccakHashes: 50}})
+	el.add(&TxTracker{HashStr: "0x02", GasPrice: big.NewInt(10), Gas: 21000, Bytes: 100, ReservedZKCounters: state.ZKCounters{GasUsed: 21000, KeccakHashes: 1}})
+	// higher gas price, but it uses most of the steps of the batch
+	el.add(&TxTracker{HashStr: "0x03", GasPrice: big.NewInt(15), Gas: 21000, Bytes: 100, ReservedZKCounters: state.ZKCounters{GasUsed: 21000, Steps: 900000}})
+	assert.Equal(t, []string{"0x02", "0x01", "0x03"}, sortedHashes(el))
+}
+
+func TestTxOrderingRoundRobin(t *testing.T) {
+	now := time.Now()
+	spammer := common.HexToAddress("0x1")
+	user := common.HexToAddress("0x2")
+	policy, err := NewTxOrderingPolicy(TxOrderingCfg{Policy: TxOrderingRoundRobin}, state.BatchConstraintsCfg{})
+	require.NoError(t, err)
+	el := newTxSortedList(policy)
+
+	ready := func(tx *TxTracker) {
+		policy.Ready(tx)
+		el.add(tx)
+	}
+	selectFirst := func() *TxTracker {
+		tx := el.getByIndex(0)
+		policy.Selected(tx)
+		require.True(t, el.delete(tx))
+		return tx
+	}
+
+	// the spammer sends its txs first, its ready txs are added one by one as they are processed
+	ready(&TxTracker{HashStr: "0x01", From: spammer, Nonce: 0, GasPrice: big.NewInt(100), ReceivedAt: now})
+	assert.Equal(t, "0x01", selectFirst().HashStr)
+	ready(&TxTracker{HashStr: "0x02", From: spammer, Nonce: 1, GasPrice: big.NewInt(100), ReceivedAt: now})
+
+	// the user joins the current round, so it goes before the next tx of the spammer
+	ready(&TxTracker{HashStr: "0x03", From: user, Nonce: 0, GasPrice: big.NewInt(1), ReceivedAt: now.Add(time.Second)})
+	assert.Equal(t, []string{"0x03", "0x02"}, sortedHashes(el))
+	assert.Equal(t, "0x03", selectFirst().HashStr)
+
+	// a replaced ready tx keeps its round
+	ready(&TxTracker{HashStr: "0x04", From: user, Nonce: 1, GasPrice: big.NewInt(1), ReceivedAt: now.Add(time.Second)})
+	assert.Equal(t, []string{"0x02", "0x04"}, sortedHashes(el))
+	require.True(t, el.delete(el.getByIndex(1)))
+	ready(&TxTracker{HashStr: "0x05", From: user, Nonce: 1, GasPrice: big.NewInt(2), ReceivedAt: now.Add(time.Second)})
+	assert.Equal(t, []string{"0x02", "0x05"}, sortedHashes(el))
+}
+
+func TestWorkerUpdateTxZKCountersResortsReadyTx(t *testing.T) {
+	constraints := state.BatchConstraintsCfg{MaxCumulativeGasUsed: 1000000, MaxSteps: 1000}
+	worker := NewWorker(nil, constraints, 0, &zkEfficiencyOrderingPolicy{constraints: constraints}, newTimeoutCond(&sync.Mutex{}))
+
+	from1, from2 := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+	hash1, hash2 := common.HexToHash("0x01"), common.HexToHash("0x02")
+	tx1 := &TxTracker{Hash: hash1, HashStr: hash1.String(), From: from1, FromStr: from1.String(), GasPrice: big.NewInt(10), Gas: 21000, Cost: big.NewInt(0), ReservedZKCounters: state.ZKCounters{Steps: 10}}
+	tx2 := &TxTracker{Hash: hash2, HashStr: hash2.String(), From: from2, FromStr: from2.String(), GasPrice: big.NewInt(10), Gas: 21000, Cost: big.NewInt(0), ReservedZKCounters: state.ZKCounters{Steps: 20}}
+	for _, tx := range []*TxTracker{tx1, tx2} {
+		addr := newAddrQueue(tx.From, 0, big.NewInt(1000), 0)
+		newReadyTx, _, _, err := addr.addTx(tx)
+		require.NoError(t, err)
+		worker.pool[tx.FromStr] = addr
+		worker.txSortedList.add(newReadyTx)
+	}
+	assert.Equal(t, []string{hash1.String(), hash2.String()}, sortedHashes(worker.txSortedList))
+
+	worker.UpdateTxZKCounters(tx1.Hash, from1, state.ZKCounters{}, state.ZKCounters{Steps: 500})
+	assert.Equal(t, []string{hash2.String(), hash1.String()}, sortedHashes(worker.txSortedList))
+}
+
+func TestWorkerSetBatchConstraintsResortsReadyTxs(t *testing.T) {
+	constraints := state.BatchConstraintsCfg{MaxCumulativeGasUsed: 1000000, MaxSteps: 1000, MaxKeccakHashes: 1000}
+	worker := NewWorker(nil, constraints, 0, &zkEfficiencyOrderingPolicy{constraints: constraints}, newTimeoutCond(&sync.Mutex{}))
+
+	// 0x01 uses more steps and 0x02 more keccak hashes
+	worker.txSortedList.add(&TxTracker{HashStr: "0x01", GasPrice: big.NewInt(10), Gas: 21000, ReservedZKCounters: state.ZKCounters{Steps: 100, KeccakHashes: 10}})
+	worker.txSortedList.add(&TxTracker{HashStr: "0x02", GasPrice: big.NewInt(10), Gas: 21000, ReservedZKCounters: state.ZKCounters{Steps: 10, KeccakHashes: 50}})
+	assert.Equal(t, []string{"0x02", "0x01"}, sortedHashes(worker.txSortedList))
+
+	// the keccak hashes become the scarcest resource of the new fork
+	constraints.MaxKeccakHashes = 100
+	worker.SetBatchConstraints(constraints)
+	assert.Equal(t, constraints, worker.batchConstraints)
+	assert.Equal(t, []string{"0x01", "0x02"}, sortedHashes(worker.txSortedList))
+}
@@ -18,6 +18,7 @@ type TxTracker struct {
 	Nonce              uint64
 	Gas                uint64 // To check if it fits into a batch
 	GasPrice           *big.Int
+	GasTipCap          *big.Int // To check if a tx with the same nonce bumps the price enough to replace it
 	Cost               *big.Int // Cost = Amount + Benefit
 	Bytes              uint64
 	UsedZKCounters     state.ZKCounters
@@ -32,6 +33,8 @@ type TxTracker struct {
 	EGPLog             state.EffectiveGasPriceLog
 	L1GasPrice         uint64
 	L2GasPrice         uint64
+	IsSponsoredClaim   bool   // IsSponsoredClaim is true if the tx is a bridge claim sponsored by the pool
+	orderingRound      uint64 // Round of the tx in the round robin ordering policy
 }
 
 // newTxTracker creates and inti a TxTracker
@@ -54,6 +57,7 @@ func newTxTracker(tx types.Transaction, usedZKCounters state.ZKCounters, reserve
 		Nonce:              tx.Nonce(),
 		Gas:                tx.Gas(),
 		GasPrice:           tx.GasPrice(),
+		GasTipCap:          tx.GasTipCap(),
 		Cost:               tx.Cost(),
 		Bytes:              uint64(len(rawTx)) + state.EfficiencyPercentageByteLength,
 		UsedZKCounters:     usedZKCounters,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
+	seqmetrics "github.com/0xPolygonHermez/zkevm-node/sequencer/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -26,18 +27,22 @@ type Worker struct {
 	batchConstraints state.BatchConstraintsCfg
 	readyTxsCond     *timeoutCond
 	wipTx            *TxTracker
+	priceBump        uint64
+	txOrderingPolicy TxOrderingPolicy
 }
 
 // NewWorker creates an init a worker
-func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, readyTxsCond *timeoutCond) *Worker {
+func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, priceBump uint64, txOrderingPolicy TxOrderingPolicy, readyTxsCond *timeoutCond) *Worker {
 	w := Worker{
 		pool:             make(map[string]*addrQueue),
 		workerMutex:      new(sync.Mutex),
-		txSortedList:     newTxSortedList(),
+		txSortedList:     newTxSortedList(txOrderingPolicy),
 		pendingToStore:   []*TxTracker{},
 		state:            state,
 		batchConstraints: constraints,
 		readyTxsCond:     readyTxsCond,
+		priceBump:        priceBump,
+		txOrderingPolicy: txOrderingPolicy,
 	}
 
 	return &w
@@ -100,7 +105,7 @@ func (w *Worker) addTxTracker(ctx context.Context, tx *TxTracker, mutex *sync.Mu
 			return nil, dropReason
 		}
 
-		addr = newAddrQueue(tx.From, nonce.Uint64(), balance)
+		addr = newAddrQueue(tx.From, nonce.Uint64(), balance, w.priceBump)
 
 		// Lock again the worker
 		mutexLock(mutex)
@@ -253,6 +258,18 @@ func (w *Worker) DeleteForcedTx(txHash common.Hash, addr common.Address) {
 	}
 }
 
+// SetBatchConstraints sets the batch constraints used to check the txs added to the worker
+// and to order them, sorting again the ready txs if the ordering policy depends on them
+func (w *Worker) SetBatchConstraints(constraints state.BatchConstraintsCfg) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.batchConstraints = constraints
+	if w.txOrderingPolicy.SetBatchConstraints(constraints) {
+		w.txSortedList.resort()
+	}
+}
+
 // UpdateTxZKCounters updates the ZKCounter of a tx
 func (w *Worker) UpdateTxZKCounters(txHash common.Hash, addr common.Address, usedZKCounters state.ZKCounters, reservedZKCounters state.ZKCounters) {
 	w.workerMutex.Lock()
@@ -273,7 +290,14 @@ func (w *Worker) UpdateTxZKCounters(txHash common.Hash, addr common.Address, use
 	addrQueue, found := w.pool[addr.String()]
 
 	if found {
-		addrQueue.UpdateTxZKCounters(txHash, usedZKCounters, reservedZKCounters)
+		// The ready tx is sorted again, as the tx ordering policy may depend on its counters
+		readyTx := addrQueue.readyTx
+		if readyTx != nil && readyTx.Hash == txHash && w.txSortedList.delete(readyTx) {
+			addrQueue.UpdateTxZKCounters(txHash, usedZKCounters, reservedZKCounters)
+			w.txSortedList.add(readyTx)
+		} else {
+			addrQueue.UpdateTxZKCounters(txHash, usedZKCounters, reservedZKCounters)
+		}
 	} else {
 		log.Warnf("addrQueue %s not found", addr.String())
 	}
@@ -404,8 +428,9 @@ func (w *Worker) DeleteTxPendingToStore(txHash common.Hash, addr common.Address)
 	}
 }
 
-// GetBestFittingTx gets the most efficient tx that fits in the available batch resources
-func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, isFistL2BlockAndEmpty bool) (*TxTracker, []*TxTracker, error) {
+// GetBestFittingTx gets the most efficient tx that fits in the available batch resources. It also returns the txs that will never
+// fit in an empty batch (ooc txs) and the txs skipped before the selected one because they didn't fit in the available resources
+func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highReservedCounters state.ZKCounters, isFistL2BlockAndEmpty bool) (*TxTracker, []*TxTracker, []*TxTracker, error) {
 	w.workerMutex.Lock()
 	defer w.workerMutex.Unlock()
 
@@ -417,7 +442,7 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 		w.reorgedTxs = w.reorgedTxs[1:]
 		if addrQueue, found := w.pool[reorgedTx.FromStr]; found {
 			if addrQueue.readyTx != nil && addrQueue.readyTx.Hash == reorgedTx.Hash {
-				return reorgedTx, nil, nil
+				return reorgedTx, nil, nil, nil
 			} else {
 				log.Warnf("reorged tx %s is not the ready tx for addrQueue %s, this shouldn't happen", reorgedTx.Hash, reorgedTx.From)
 			}
@@ -427,7 +452,7 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 	}
 
 	if w.txSortedList.len() == 0 {
-		return nil, nil, ErrTransactionsListEmpty
+		return nil, nil, nil, ErrTransactionsListEmpty
 	}
 
 	var (
@@ -435,6 +460,7 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 		foundMutex  sync.RWMutex
 		oocTxs      []*TxTracker
 		oocTxsMutex sync.Mutex
+		notFitting  = make(map[int]*TxTracker)
 	)
 
 	nGoRoutines := runtime.NumCPU()
@@ -461,11 +487,13 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 				if !fits {
 					// If we are looking for a tx for the first empty L2 block in the batch and this tx doesn't fits in the batch, then this tx will never fit in any batch.
 					// We add the tx to the oocTxs slice. That slice will be returned to set these txs as invalid (and delete them from the worker) from the finalizer code
+					oocTxsMutex.Lock()
 					if isFistL2BlockAndEmpty {
-						oocTxsMutex.Lock()
 						oocTxs = append(oocTxs, txCandidate)
-						oocTxsMutex.Unlock()
+					} else {
+						notFitting[i] = txCandidate
 					}
+					oocTxsMutex.Unlock()
 					// We continue looking for a tx that fits in the batch
 					continue
 				}
@@ -483,17 +511,27 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 	}
 	wg.Wait()
 
+	// The txs before the selected one are always checked, so the skipped txs don't depend on the scheduling of the go routines
+	skippedTxs := make([]*TxTracker, 0, len(notFitting))
+	for i, txCandidate := range notFitting {
+		if foundAt == -1 || i < foundAt {
+			skippedTxs = append(skippedTxs, txCandidate)
+		}
+	}
+
 	if foundAt != -1 {
 		log.Debugf("best fitting tx %s found at index %d with gasPrice %d", tx.HashStr, foundAt, tx.GasPrice)
 		w.wipTx = tx
-		return tx, oocTxs, nil
+		w.txOrderingPolicy.Selected(tx)
+		seqmetrics.TxWaitTime(w.txOrderingPolicy.Lane(tx), time.Since(tx.ReceivedAt))
+		return tx, oocTxs, skippedTxs, nil
 	} else {
 		// If the length of the oocTxs slice is equal to the length of the txSortedList this means that all the txs are ooc,
 		// therefore we need to return an error indicating that the list is empty
 		if w.txSortedList.len() == len(oocTxs) {
-			return nil, oocTxs, ErrTransactionsListEmpty
+			return nil, oocTxs, skippedTxs, ErrTransactionsListEmpty
 		} else {
-			return nil, oocTxs, ErrNoFittingTransaction
+			return nil, oocTxs, skippedTxs, ErrNoFittingTransaction
 		}
 	}
 }
@@ -523,7 +561,76 @@ func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 	return txs
 }
 
+// RetainTxs deletes the txs of the worker that are not in txHashes and returns the hashes of the txs kept.
+// It is used by a standby sequencer to drop the txs processed or discarded by the active sequencer
+func (w *Worker) RetainTxs(txHashes map[common.Hash]struct{}) map[common.Hash]struct{} {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	keptTxs := make(map[common.Hash]struct{})
+	for _, addrQueue := range w.pool {
+		for _, txTracker := range addrQueue.getTransactions() {
+			if _, found := txHashes[txTracker.Hash]; found {
+				keptTxs[txTracker.Hash] = struct{}{}
+				continue
+			}
+			w.deleteTx(txTracker.Hash, txTracker.From)
+		}
+	}
+
+	return keptTxs
+}
+
+// RefreshAddrQueues updates the nonce and balance of all the addrQueues with the ones of the last state root,
+// deleting the txs with a nonce lower than the current one and the addrQueues that become empty. It is used
+// by a standby sequencer to follow the txs processed by the active sequencer
+func (w *Worker) RefreshAddrQueues(ctx context.Context) error {
+	w.workerMutex.Lock()
+	addresses := make([]common.Address, 0, len(w.pool))
+	for _, addrQueue := range w.pool {
+		addresses = append(addresses, addrQueue.from)
+	}
+	w.workerMutex.Unlock()
+
+	// Get the nonces and balances without holding the worker lock
+	root, err := w.state.GetLastStateRoot(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error getting last state root from hashdb service, error: %v", err)
+	}
+	nonces := make(map[common.Address]uint64, len(addresses))
+	balances := make(map[common.Address]*big.Int, len(addresses))
+	for _, address := range addresses {
+		nonce, err := w.state.GetNonceByStateRoot(ctx, address, root)
+		if err != nil {
+			return fmt.Errorf("error getting nonce for address %s from hashdb service, error: %v", address, err)
+		}
+		balance, err := w.state.GetBalanceByStateRoot(ctx, address, root)
+		if err != nil {
+			return fmt.Errorf("error getting balance for address %s from hashdb service, error: %v", address, err)
+		}
+		nonces[address] = nonce.Uint64()
+		balances[address] = balance
+	}
+
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	for address, nonce := range nonces {
+		nonce := nonce
+		w.applyAddressUpdate(address, &nonce, balances[address])
+	}
+
+	for fromStr, addrQueue := range w.pool {
+		if addrQueue.IsEmpty() {
+			delete(w.pool, fromStr)
+		}
+	}
+
+	return nil
+}
+
 func (w *Worker) addTxToSortedList(readyTx *TxTracker) {
+	w.txOrderingPolicy.Ready(readyTx)
 	w.txSortedList.add(readyTx)
 	if w.txSortedList.len() == 1 {
 		// The txSortedList was empty before to add the new tx, we notify finalizer that we have new ready txs to process
@@ -258,7 +258,7 @@ func TestWorkerGetBestTx(t *testing.T) {
 	ct := 0
 
 	for {
-		tx, _, _ := worker.GetBestFittingTx(rc, state.ZKCounters{}, true)
+		tx, _, _, _ := worker.GetBestFittingTx(rc, state.ZKCounters{}, true)
 		if tx != nil {
 			if ct >= len(expectedGetBestTx) {
 				t.Fatalf("Error getting more best tx than expected. Expected=%d, Actual=%d", len(expectedGetBestTx), ct+1)
@@ -283,7 +283,129 @@ func TestWorkerGetBestTx(t *testing.T) {
 	}
 }
 
+func TestWorkerGetBestFittingTxSkippedTxs(t *testing.T) {
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr)
+
+	addrQueueInfo := []workerAddrQueueInfo{
+		{from: common.Address{1}, nonce: new(big.Int).SetInt64(1), balance: new(big.Int).SetInt64(10)},
+		{from: common.Address{2}, nonce: new(big.Int).SetInt64(1), balance: new(big.Int).SetInt64(10)},
+		{from: common.Address{3}, nonce: new(big.Int).SetInt64(1), balance: new(big.Int).SetInt64(10)},
+	}
+
+	for _, aq := range addrQueueInfo {
+		stateMock.On("GetNonceByStateRoot", ctx, aq.from, common.Hash{0}).Return(aq.nonce, nilErr)
+		stateMock.On("GetBalanceByStateRoot", ctx, aq.from, common.Hash{0}).Return(aq.balance, nilErr)
+	}
+
+	addTxsTC := []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x01/gp:100", from: common.Address{1}, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(100),
+			cost:                 new(big.Int).SetInt64(5),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 5, KeccakHashes: 5, PoseidonHashes: 5, PoseidonPaddings: 5, MemAligns: 5, Arithmetics: 5, Binaries: 5, Steps: 5, Sha256Hashes_V2: 5},
+			usedBytes:            5,
+			expectedTxSortedList: []common.Hash{{1}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x02/gp:50", from: common.Address{2}, txHash: common.Hash{2}, nonce: 1, gasPrice: new(big.Int).SetInt64(50),
+			cost:                 new(big.Int).SetInt64(5),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 2, KeccakHashes: 2, PoseidonHashes: 2, PoseidonPaddings: 2, MemAligns: 2, Arithmetics: 2, Binaries: 2, Steps: 2, Sha256Hashes_V2: 2},
+			usedBytes:            2,
+			expectedTxSortedList: []common.Hash{{1}, {2}},
+		},
+		{
+			name: "Adding from:0x03, tx:0x03/gp:10", from: common.Address{3}, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost:                 new(big.Int).SetInt64(5),
+			reservedZKCounters:   state.ZKCounters{GasUsed: 4, KeccakHashes: 4, PoseidonHashes: 4, PoseidonPaddings: 4, MemAligns: 4, Arithmetics: 4, Binaries: 4, Steps: 4, Sha256Hashes_V2: 4},
+			usedBytes:            4,
+			expectedTxSortedList: []common.Hash{{1}, {2}, {3}},
+		},
+	}
+
+	processWorkerAddTxTestCases(ctx, t, worker, addTxsTC)
+
+	// Only the txs before the selected one are skipped
+	rc := state.BatchResources{
+		ZKCounters: state.ZKCounters{GasUsed: 3, KeccakHashes: 3, PoseidonHashes: 3, PoseidonPaddings: 3, MemAligns: 3, Arithmetics: 3, Binaries: 3, Steps: 3, Sha256Hashes_V2: 3},
+		Bytes:      3,
+	}
+	tx, oocTxs, skippedTxs, err := worker.GetBestFittingTx(rc, state.ZKCounters{}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, common.Hash{2}, tx.Hash)
+	assert.Empty(t, oocTxs)
+	assert.Len(t, skippedTxs, 1)
+	assert.Equal(t, common.Hash{1}, skippedTxs[0].Hash)
+
+	// All the txs are skipped when none of them fits
+	rc = state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 1, KeccakHashes: 1, PoseidonHashes: 1, PoseidonPaddings: 1, MemAligns: 1, Arithmetics: 1, Binaries: 1, Steps: 1, Sha256Hashes_V2: 1}, Bytes: 1}
+	tx, oocTxs, skippedTxs, err = worker.GetBestFittingTx(rc, state.ZKCounters{}, false)
+	assert.ErrorIs(t, err, ErrNoFittingTransaction)
+	assert.Nil(t, tx)
+	assert.Empty(t, oocTxs)
+	assert.Len(t, skippedTxs, 3)
+}
+
 func initWorker(stateMock *StateMock, rcMax state.BatchConstraintsCfg) *Worker {
-	worker := NewWorker(stateMock, rcMax, newTimeoutCond(&sync.Mutex{}))
+	worker := NewWorker(stateMock, rcMax, 0, &gasPriceOrderingPolicy{}, newTimeoutCond(&sync.Mutex{}))
 	return worker
 }
+
+func TestWorkerStandbySync(t *testing.T) {
+	var nilErr error
+
+	stateMock := NewStateMock(t)
+	worker := initWorker(stateMock, rcMax)
+
+	ctx := context.Background()
+
+	from1, from2 := common.Address{1}, common.Address{2}
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, from1, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, from1, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr).Once()
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nilErr).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, from2, common.Hash{0}).Return(new(big.Int).SetInt64(1), nilErr).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, from2, common.Hash{0}).Return(new(big.Int).SetInt64(10), nilErr).Once()
+
+	processWorkerAddTxTestCases(ctx, t, worker, []workerAddTxTestCase{
+		{
+			name: "Adding from:0x01, tx:0x01/gp:10", from: from1, txHash: common.Hash{1}, nonce: 1, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(5), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}},
+		},
+		{
+			name: "Adding from:0x01, tx:0x02/gp:10", from: from1, txHash: common.Hash{2}, nonce: 2, gasPrice: new(big.Int).SetInt64(10),
+			cost: new(big.Int).SetInt64(5), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}},
+		},
+		{
+			name: "Adding from:0x02, tx:0x03/gp:5", from: from2, txHash: common.Hash{3}, nonce: 1, gasPrice: new(big.Int).SetInt64(5),
+			cost: new(big.Int).SetInt64(5), usedBytes: 1,
+			expectedTxSortedList: []common.Hash{{1}, {3}},
+		},
+	})
+
+	// tx 0x03 is no longer pending in the pool
+	keptTxs := worker.RetainTxs(map[common.Hash]struct{}{{1}: {}, {2}: {}})
+	assert.Equal(t, map[common.Hash]struct{}{{1}: {}, {2}: {}}, keptTxs)
+	assert.Equal(t, 1, worker.txSortedList.len())
+
+	// the active sequencer has processed tx 0x01
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{1}, nilErr).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, from1, common.Hash{1}).Return(new(big.Int).SetInt64(2), nilErr).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, from1, common.Hash{1}).Return(new(big.Int).SetInt64(5), nilErr).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, from2, common.Hash{1}).Return(new(big.Int).SetInt64(1), nilErr).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, from2, common.Hash{1}).Return(new(big.Int).SetInt64(10), nilErr).Once()
+
+	err := worker.RefreshAddrQueues(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, worker.txSortedList.len())
+	assert.Equal(t, common.Hash{2}, worker.txSortedList.getByIndex(0).Hash)
+
+	// the empty addrQueue of 0x02 is deleted
+	assert.Len(t, worker.pool, 1)
+	assert.Contains(t, worker.pool, from1.String())
+}
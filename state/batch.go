@@ -86,6 +86,8 @@ const (
 	NoTxFitsClosingReason ClosingReason = "No transaction fits"
 	// L2BlockReorgClonsingReason is the closing reason used when we have a L2 block reorg (unexpected error, like OOC, when processing L2 block)
 	L2BlockReorgClonsingReason ClosingReason = "L2 block reorg"
+	// OperatorRequestClosingReason is the closing reason used when the operator requests to close the batch
+	OperatorRequestClosingReason ClosingReason = "Operator request"
 
 	// Reason due Synchronizer
 	// ------------------------------------------------------------------------------------------
@@ -0,0 +1,62 @@
+package state
+
+import (
+	"time"
+)
+
+// BatchClosingInfo is the report of the sequencer about the closing of a batch
+type BatchClosingInfo struct {
+	BatchNumber uint64
+	// ClosingReason is the reason why the batch was closed
+	ClosingReason ClosingReason
+	// ExhaustedResource is the batch resource that caused the closing of the batch, empty if it was not closed due to a resource
+	ExhaustedResource string
+	// UsedResources are the resources used by the batch
+	UsedResources BatchResources
+	// HighReservedZKCounters are the max difference between the reserved and used counters of the txs of the batch
+	HighReservedZKCounters ZKCounters
+	// L2Blocks is the number of L2 blocks of the batch
+	L2Blocks uint64
+	// Txs is the number of txs of the batch
+	Txs uint64
+	// SkippedTxs is the number of txs not added to the batch because they didn't fit in its remaining resources when they were
+	// selected or processed
+	SkippedTxs uint64
+	// NodeOOCTxs is the number of txs set as invalid while the batch was open because they don't fit in an empty batch
+	NodeOOCTxs uint64
+	// OpenedAt is the time the batch was opened
+	OpenedAt time.Time
+	// ClosedAt is the time the batch was closed
+	ClosedAt time.Time
+	// L2BlocksClosingInfo are the reports about the closing of the L2 blocks of the batch
+	L2BlocksClosingInfo []L2BlockClosingInfo
+}
+
+// L2BlockClosingInfo is the report of the sequencer about the closing of a L2 block
+type L2BlockClosingInfo struct {
+	L2BlockNumber uint64
+	BatchNumber   uint64
+	// ClosingReason is the reason why the L2 block was closed
+	ClosingReason string
+	// Txs is the number of txs of the L2 block
+	Txs uint64
+	// ProcessedTxs is the number of txs executed while the L2 block was open, including the ones not added to it
+	ProcessedTxs uint64
+	// UsedResources are the resources used by the txs of the L2 block
+	UsedResources BatchResources
+	// Times is the time spent by the sequencer and the executor in the L2 block
+	Times L2BlockProcessTimes
+}
+
+// L2BlockProcessTimes is the time spent by the sequencer and the executor in a L2 block
+type L2BlockProcessTimes struct {
+	Total               time.Duration
+	Idle                time.Duration
+	WaitL2Block         time.Duration
+	SequencerNewL2Block time.Duration
+	SequencerTxs        time.Duration
+	SequencerL2Block    time.Duration
+	ExecutorNewL2Block  time.Duration
+	ExecutorTxs         time.Duration
+	ExecutorL2Block     time.Duration
+}
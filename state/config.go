@@ -1,6 +1,7 @@
 package state
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
@@ -44,6 +45,11 @@ type Config struct {
 	// logs in a single call to the state, if zero it means no limit
 	MaxLogsBlockRange uint64
 
+	// MaxIndexedLogsBlockRange is the same as MaxLogsBlockRange but applied to the queries
+	// filtering by address or topics, these queries use the log bloom index to skip the
+	// blocks without matching logs so they support wider ranges, if zero it means no limit
+	MaxIndexedLogsBlockRange uint64
+
 	// MaxNativeBlockHashBlockRange is a configuration to set the max range for block number when querying
 	// native block hashes in a single call to the state, if zero it means no limit
 	MaxNativeBlockHashBlockRange uint64
@@ -56,6 +62,72 @@ type Config struct {
 // BatchConfig represents the configuration of the batch constraints
 type BatchConfig struct {
 	Constraints BatchConstraintsCfg `mapstructure:"Constraints"`
+
+	// ForkConstraints are the batch constraints of the batches from a fork id. Each entry applies to
+	// the batches of its fork id and the next ones until the fork id of another entry, and the constraints
+	// set to zero in an entry take the value of Constraints
+	ForkConstraints []ForkBatchConstraintsCfg `mapstructure:"ForkConstraints"`
+}
+
+// ForkBatchConstraintsCfg represents the configuration of the batch constraints from a fork id
+type ForkBatchConstraintsCfg struct {
+	// ForkID is the first fork id that uses the constraints
+	ForkID      uint64              `mapstructure:"ForkID"`
+	Constraints BatchConstraintsCfg `mapstructure:"Constraints"`
+}
+
+// ConstraintsForForkID returns the batch constraints of the batches executed under a fork id
+func (c BatchConfig) ConstraintsForForkID(forkID uint64) BatchConstraintsCfg {
+	var forkConstraints *ForkBatchConstraintsCfg
+	for i := range c.ForkConstraints {
+		if c.ForkConstraints[i].ForkID <= forkID && (forkConstraints == nil || c.ForkConstraints[i].ForkID > forkConstraints.ForkID) {
+			forkConstraints = &c.ForkConstraints[i]
+		}
+	}
+	if forkConstraints == nil {
+		return c.Constraints
+	}
+	return c.Constraints.override(forkConstraints.Constraints)
+}
+
+// NextBatchConstraints returns the batch constraints of the fork the next batch will be executed under
+func (c BatchConfig) NextBatchConstraints(ctx context.Context, reader BatchForkIDReader) (BatchConstraintsCfg, error) {
+	if len(c.ForkConstraints) == 0 {
+		return c.Constraints, nil
+	}
+
+	_, nextForkID, err := GetLastAndNextBatchForkIDs(ctx, reader)
+	if err != nil {
+		return BatchConstraintsCfg{}, err
+	}
+	return c.ConstraintsForForkID(nextForkID), nil
+}
+
+// override returns the constraints with the values not set to zero in overrides
+func (c BatchConstraintsCfg) override(overrides BatchConstraintsCfg) BatchConstraintsCfg {
+	overrideUint64 := func(value *uint64, override uint64) {
+		if override != 0 {
+			*value = override
+		}
+	}
+	overrideUint32 := func(value *uint32, override uint32) {
+		if override != 0 {
+			*value = override
+		}
+	}
+
+	overrideUint64(&c.MaxTxsPerBatch, overrides.MaxTxsPerBatch)
+	overrideUint64(&c.MaxBatchBytesSize, overrides.MaxBatchBytesSize)
+	overrideUint64(&c.MaxCumulativeGasUsed, overrides.MaxCumulativeGasUsed)
+	overrideUint32(&c.MaxKeccakHashes, overrides.MaxKeccakHashes)
+	overrideUint32(&c.MaxPoseidonHashes, overrides.MaxPoseidonHashes)
+	overrideUint32(&c.MaxPoseidonPaddings, overrides.MaxPoseidonPaddings)
+	overrideUint32(&c.MaxMemAligns, overrides.MaxMemAligns)
+	overrideUint32(&c.MaxArithmetics, overrides.MaxArithmetics)
+	overrideUint32(&c.MaxBinaries, overrides.MaxBinaries)
+	overrideUint32(&c.MaxSteps, overrides.MaxSteps)
+	overrideUint32(&c.MaxSHA256Hashes, overrides.MaxSHA256Hashes)
+	return c
 }
 
 // BatchConstraintsCfg represents the configuration of the batch constraints
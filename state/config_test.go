@@ -0,0 +1,99 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintsForForkID(t *testing.T) {
+	constraints := state.BatchConstraintsCfg{
+		MaxTxsPerBatch:       300,
+		MaxBatchBytesSize:    120000,
+		MaxCumulativeGasUsed: 1125899906842624,
+		MaxKeccakHashes:      2145,
+		MaxPoseidonHashes:    252357,
+		MaxPoseidonPaddings:  135191,
+		MaxMemAligns:         236585,
+		MaxArithmetics:       236585,
+		MaxBinaries:          473170,
+		MaxSteps:             7570538,
+		MaxSHA256Hashes:      1596,
+	}
+	batchCfg := state.BatchConfig{
+		Constraints: constraints,
+		ForkConstraints: []state.ForkBatchConstraintsCfg{
+			{ForkID: state.FORKID_FEIJOA, Constraints: state.BatchConstraintsCfg{MaxBatchBytesSize: 240000, MaxSteps: 8388608}},
+			{ForkID: state.FORKID_ELDERBERRY, Constraints: state.BatchConstraintsCfg{MaxSHA256Hashes: 2000}},
+		},
+	}
+
+	// Before the first fork with its own constraints
+	assert.Equal(t, constraints, batchCfg.ConstraintsForForkID(state.FORKID_ETROG))
+
+	elderberryConstraints := constraints
+	elderberryConstraints.MaxSHA256Hashes = 2000
+	assert.Equal(t, elderberryConstraints, batchCfg.ConstraintsForForkID(state.FORKID_ELDERBERRY))
+
+	// The constraints of a fork apply to the next forks, without accumulating the previous ones
+	feijoaConstraints := constraints
+	feijoaConstraints.MaxBatchBytesSize = 240000
+	feijoaConstraints.MaxSteps = 8388608
+	assert.Equal(t, feijoaConstraints, batchCfg.ConstraintsForForkID(state.FORKID_FEIJOA))
+	assert.Equal(t, feijoaConstraints, batchCfg.ConstraintsForForkID(state.FORKID_FEIJOA+1))
+
+	require.Equal(t, constraints, state.BatchConfig{Constraints: constraints}.ConstraintsForForkID(state.FORKID_FEIJOA))
+}
+
+// batchForkIDReader returns a fixed last batch number and the fork id of the batches from forkIDs
+type batchForkIDReader struct {
+	lastBatchNumber uint64
+	forkIDs         map[uint64]uint64
+	err             error
+}
+
+func (r *batchForkIDReader) GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return r.lastBatchNumber, r.err
+}
+
+func (r *batchForkIDReader) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
+	return r.forkIDs[batchNumber]
+}
+
+func TestNextBatchConstraints(t *testing.T) {
+	ctx := context.Background()
+	constraints := state.BatchConstraintsCfg{MaxSteps: 7570538, MaxKeccakHashes: 2145}
+	batchCfg := state.BatchConfig{
+		Constraints: constraints,
+		ForkConstraints: []state.ForkBatchConstraintsCfg{
+			{ForkID: state.FORKID_ELDERBERRY, Constraints: state.BatchConstraintsCfg{MaxSteps: 8388608}},
+		},
+	}
+	elderberryConstraints := constraints
+	elderberryConstraints.MaxSteps = 8388608
+
+	// The fork upgrade is scheduled after the last batch, so the next batch uses the constraints of the new fork
+	reader := &batchForkIDReader{lastBatchNumber: 10, forkIDs: map[uint64]uint64{10: state.FORKID_ETROG, 11: state.FORKID_ELDERBERRY}}
+	lastForkID, nextForkID, err := state.GetLastAndNextBatchForkIDs(ctx, reader)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(state.FORKID_ETROG), lastForkID)
+	assert.Equal(t, uint64(state.FORKID_ELDERBERRY), nextForkID)
+
+	nextConstraints, err := batchCfg.NextBatchConstraints(ctx, reader)
+	require.NoError(t, err)
+	assert.Equal(t, elderberryConstraints, nextConstraints)
+
+	// Without fork constraints the state is not read
+	reader.err = errors.New("state error")
+	nextConstraints, err = state.BatchConfig{Constraints: constraints}.NextBatchConstraints(ctx, reader)
+	require.NoError(t, err)
+	assert.Equal(t, constraints, nextConstraints)
+
+	_, err = batchCfg.NextBatchConstraints(ctx, reader)
+	require.ErrorIs(t, err, reader.err)
+}
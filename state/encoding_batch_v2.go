@@ -138,6 +138,21 @@ func EncodeBatchV2(batch *BatchRawV2) ([]byte, error) {
 	return encoder.GetResult(), nil
 }
 
+// EncodeForcedBatchV2 encodes a forced batch V2 (Etrog) into a byte slice.
+// Is forbidden changeL2Block, so are just the set of transactions
+func EncodeForcedBatchV2(forcedBatch *ForcedBatchRawV2) ([]byte, error) {
+	if forcedBatch == nil || len(forcedBatch.Transactions) == 0 {
+		return nil, fmt.Errorf("a forced batch need minimum a tx: %w", ErrInvalidBatchV2)
+	}
+
+	encoder := NewBatchV2Encoder()
+	err := encoder.AddTransactions(forcedBatch.Transactions)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode tx: %w", err)
+	}
+	return encoder.GetResult(), nil
+}
+
 // BatchV2Encoder is a builder of the batchl2data used by EncodeBatchV2
 type BatchV2Encoder struct {
 	batchData []byte
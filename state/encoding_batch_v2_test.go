@@ -238,6 +238,19 @@ func TestDecodeForcedBatchV2(t *testing.T) {
 	require.Equal(t, 2, len(decodedBatch.Transactions))
 }
 
+func TestDecodeEncodeForcedBatchV2(t *testing.T) {
+	batchL2Data, err := hex.DecodeString(codedRLP2Txs1)
+	require.NoError(t, err)
+	decodedBatch, err := DecodeForcedBatchV2(batchL2Data)
+	require.NoError(t, err)
+	encodedBatch, err := EncodeForcedBatchV2(decodedBatch)
+	require.NoError(t, err)
+	require.Equal(t, batchL2Data, encodedBatch)
+
+	_, err = EncodeForcedBatchV2(&ForcedBatchRawV2{})
+	require.ErrorIs(t, err, ErrInvalidBatchV2)
+}
+
 func TestDecodeForcedBatchV2WithRegularBatch(t *testing.T) {
 	batchL2Data, err := hex.DecodeString(codedL2Block1)
 	require.NoError(t, err)
@@ -50,6 +50,8 @@ var (
 	ErrExecutorNil = errors.New("the method requires an executor that is not nil")
 	// ErrStateTreeNil indicates that the method requires a state tree that is not nil
 	ErrStateTreeNil = errors.New("the method requires a state tree that is not nil")
+	// ErrSequencerLeaseNotHeld indicates that the sequencer leader lease is held by another sequencer or has expired
+	ErrSequencerLeaseNotHeld = errors.New("the sequencer leader lease is not held")
 	// ErrUnsupportedDuration is returned if the provided unit for a time
 	// interval is not supported by our conversion mechanism.
 	ErrUnsupportedDuration = errors.New("unsupported time duration")
@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jackc/pgx/v4"
 )
@@ -51,3 +52,19 @@ func (s *State) GetForkIDByBatchNumber(batchNumber uint64) uint64 {
 func (s *State) GetForkIDByBlockNumber(blockNumber uint64) uint64 {
 	return s.storage.GetForkIDByBlockNumber(blockNumber)
 }
+
+// BatchForkIDReader reads the last batch number and the fork id of the batches
+type BatchForkIDReader interface {
+	GetLastBatchNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	GetForkIDByBatchNumber(batchNumber uint64) uint64
+}
+
+// GetLastAndNextBatchForkIDs returns the fork id of the last batch and the fork id the next batch will be executed under,
+// they are different when a fork upgrade is scheduled after the last batch
+func GetLastAndNextBatchForkIDs(ctx context.Context, reader BatchForkIDReader) (uint64, uint64, error) {
+	lastBatchNumber, err := reader.GetLastBatchNumber(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the last batch number, error: %w", err)
+	}
+	return reader.GetForkIDByBatchNumber(lastBatchNumber), reader.GetForkIDByBatchNumber(lastBatchNumber + 1), nil
+}
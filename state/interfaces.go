@@ -96,6 +96,7 @@ type storage interface {
 	IsL2BlockConsolidated(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
 	IsL2BlockVirtualized(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (bool, error)
 	GetLogs(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, blockHash *common.Hash, since *time.Time, dbTx pgx.Tx) ([]*types.Log, error)
+	GetLogsMaxBlockRange(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) (uint64, error)
 	AddReceipt(ctx context.Context, receipt *types.Receipt, imStateRoot common.Hash, dbTx pgx.Tx) error
 	AddLog(ctx context.Context, l *types.Log, dbTx pgx.Tx) error
 	GetExitRootByGlobalExitRoot(ctx context.Context, ger common.Hash, dbTx pgx.Tx) (*GlobalExitRoot, error)
@@ -137,6 +138,7 @@ type storage interface {
 	CloseBatchInStorage(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error
 	CloseWIPBatchInStorage(ctx context.Context, receipt ProcessingReceipt, dbTx pgx.Tx) error
 	GetLogsByBlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]*types.Log, error)
+	BackfillLogBloomIndex(ctx context.Context, maxBlocks uint64, dbTx pgx.Tx) (bool, error)
 	AddL1InfoRootToExitRoot(ctx context.Context, exitRoot *L1InfoTreeExitRootStorageEntry, dbTx pgx.Tx) error
 	GetAllL1InfoRootEntries(ctx context.Context, dbTx pgx.Tx) ([]L1InfoTreeExitRootStorageEntry, error)
 	GetLatestL1InfoRoot(ctx context.Context, maxBlockNumber uint64) (L1InfoTreeExitRootStorageEntry, error)
@@ -166,6 +168,17 @@ type storage interface {
 	GetAllL1InfoTreeRecursiveRootEntries(ctx context.Context, dbTx pgx.Tx) ([]L1InfoTreeRecursiveExitRootStorageEntry, error)
 	GetLatestL1InfoTreeRecursiveRoot(ctx context.Context, maxBlockNumber uint64, dbTx pgx.Tx) (L1InfoTreeRecursiveExitRootStorageEntry, error)
 	GetL1InfoRecursiveRootLeafByIndex(ctx context.Context, l1InfoTreeIndex uint32, dbTx pgx.Tx) (L1InfoTreeExitRootStorageEntry, error)
+	AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error)
+	RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error
+	CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error
+	ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error
+	GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*SequencerControl, error)
+	SetSequencerPaused(ctx context.Context, paused bool, dbTx pgx.Tx) error
+	SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error
+	AddSequencerControlRequest(ctx context.Context, request SequencerControlRequest, dbTx pgx.Tx) error
+	AddBatchClosingInfo(ctx context.Context, closingInfo *BatchClosingInfo, dbTx pgx.Tx) error
+	AddL2BlockClosingInfo(ctx context.Context, closingInfo *L2BlockClosingInfo, dbTx pgx.Tx) error
+	GetBatchClosingInfo(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*BatchClosingInfo, error)
 
 	storeblobsequences
 	storeblobinner
@@ -0,0 +1,129 @@
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// LogBloomSectionSize is the number of L2 blocks indexed by each section of the
+	// log bloom index, every bit of the logs bloom has a bit vector per section with
+	// one bit per L2 block in the section
+	LogBloomSectionSize = 4096
+
+	// LogBloomBitsPerValue is the number of bits of the logs bloom set by every
+	// address and topic
+	LogBloomBitsPerValue = 3
+
+	logBloomBitLength = types.BloomBitLength
+
+	// logBloomBackfillInterval is the time to wait between the backfills of the
+	// sections of the log bloom index, or after a failed backfill
+	logBloomBackfillInterval = time.Second
+)
+
+// CanUseLogBloomIndex checks if a logs filter by block range can be sped up
+// using the log bloom index, this requires filtering by address or topic
+func CanUseLogBloomIndex(addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		return true
+	}
+	for _, topicsInPosition := range topics {
+		if len(topicsInPosition) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LogBloomBits returns the positions of the bits set in the logs bloom, the
+// position of a bit is the value computed by LogBloomBitsOf for the data that
+// sets it
+func LogBloomBits(bloom types.Bloom) []uint16 {
+	bits := []uint16{}
+	for bit := uint16(0); bit < logBloomBitLength; bit++ {
+		byteIndex := types.BloomByteLength - 1 - int(bit/8) // nolint:gomnd
+		if bloom[byteIndex]&(1<<(bit%8)) != 0 {             // nolint:gomnd
+			bits = append(bits, bit)
+		}
+	}
+	return bits
+}
+
+// LogBloomBitsOf returns the positions of the bits that the provided data sets
+// in a logs bloom, following the same algorithm used by types.Bloom
+func LogBloomBitsOf(data []byte) [LogBloomBitsPerValue]uint16 {
+	hash := crypto.Keccak256(data)
+	var bits [LogBloomBitsPerValue]uint16
+	for i := 0; i < LogBloomBitsPerValue; i++ {
+		bits[i] = binary.BigEndian.Uint16(hash[2*i:]) & (logBloomBitLength - 1)
+	}
+	return bits
+}
+
+// LogFilterBloomBits converts a logs filter to groups of bloom bits, a block
+// matches the filter if for every group at least one of its items has all
+// the bits set in the block logs bloom. Empty filters are not included.
+func LogFilterBloomBits(addresses []common.Address, topics [][]common.Hash) [][][LogBloomBitsPerValue]uint16 {
+	groups := [][][LogBloomBitsPerValue]uint16{}
+	if len(addresses) > 0 {
+		group := make([][LogBloomBitsPerValue]uint16, 0, len(addresses))
+		for _, address := range addresses {
+			group = append(group, LogBloomBitsOf(address.Bytes()))
+		}
+		groups = append(groups, group)
+	}
+	for _, topicsInPosition := range topics {
+		if len(topicsInPosition) == 0 {
+			continue
+		}
+		group := make([][LogBloomBitsPerValue]uint16, 0, len(topicsInPosition))
+		for _, topic := range topicsInPosition {
+			group = append(group, LogBloomBitsOf(topic.Bytes()))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// StartLogBloomIndexBackfill indexes, a section per db tx, the logs bloom of the L2
+// blocks stored before the log bloom index was created. Until a block is
+// indexed the logs queries including it don't use the log bloom index
+func (s *State) StartLogBloomIndexBackfill(ctx context.Context) {
+	for {
+		pending, err := s.backfillLogBloomIndexSection(ctx)
+		if err != nil {
+			log.Errorf("failed to backfill the log bloom index: %v", err)
+		} else if !pending {
+			log.Info("log bloom index backfilled")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(logBloomBackfillInterval):
+		}
+	}
+}
+
+func (s *State) backfillLogBloomIndexSection(ctx context.Context) (bool, error) {
+	dbTx, err := s.BeginStateTransaction(ctx)
+	if err != nil {
+		return false, err
+	}
+	pending, err := s.BackfillLogBloomIndex(ctx, LogBloomSectionSize, dbTx)
+	if err != nil {
+		if rollbackErr := dbTx.Rollback(ctx); rollbackErr != nil {
+			log.Errorf("failed to rollback the log bloom index backfill: %v", rollbackErr)
+		}
+		return false, err
+	}
+	return pending, dbTx.Commit(ctx)
+}
@@ -0,0 +1,43 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogBloomBits(t *testing.T) {
+	address := common.HexToAddress("0x617b3a3528F9cDd6630fd3301B9c8911F7Bf063D")
+	topic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	var bloom types.Bloom
+	bloom.Add(address.Bytes())
+	bloom.Add(topic.Bytes())
+
+	bits := LogBloomBits(bloom)
+	for _, value := range [][]byte{address.Bytes(), topic.Bytes()} {
+		for _, bit := range LogBloomBitsOf(value) {
+			assert.Contains(t, bits, bit)
+		}
+	}
+
+	var rebuilt types.Bloom
+	for _, bit := range bits {
+		rebuilt[types.BloomByteLength-1-int(bit/8)] |= 1 << (bit % 8)
+	}
+	assert.Equal(t, bloom, rebuilt)
+}
+
+func TestCanUseLogBloomIndex(t *testing.T) {
+	assert.False(t, CanUseLogBloomIndex(nil, nil))
+	assert.False(t, CanUseLogBloomIndex(nil, [][]common.Hash{{}, {}}))
+	assert.True(t, CanUseLogBloomIndex([]common.Address{common.HexToAddress("0x1")}, nil))
+	assert.True(t, CanUseLogBloomIndex(nil, [][]common.Hash{{}, {common.HexToHash("0x1")}}))
+
+	groups := LogFilterBloomBits([]common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}, [][]common.Hash{{}, {common.HexToHash("0x1")}})
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[0], 2)
+	assert.Len(t, groups[1], 1)
+}
@@ -33,6 +33,65 @@ func (_m *StorageMock) EXPECT() *StorageMock_Expecter {
 	return &StorageMock_Expecter{mock: &_m.Mock}
 }
 
+// AcquireSequencerLease provides a mock function with given fields: ctx, holder, duration, dbTx
+func (_m *StorageMock) AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, holder, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireSequencerLease")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, holder, duration, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration, pgx.Tx) error); ok {
+		r1 = rf(ctx, holder, duration, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_AcquireSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireSequencerLease'
+type StorageMock_AcquireSequencerLease_Call struct {
+	*mock.Call
+}
+
+// AcquireSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - duration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AcquireSequencerLease(ctx interface{}, holder interface{}, duration interface{}, dbTx interface{}) *StorageMock_AcquireSequencerLease_Call {
+	return &StorageMock_AcquireSequencerLease_Call{Call: _e.mock.On("AcquireSequencerLease", ctx, holder, duration, dbTx)}
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) Run(run func(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx)) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) Return(_a0 uint64, _a1 error) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_AcquireSequencerLease_Call) RunAndReturn(run func(context.Context, string, time.Duration, pgx.Tx) (uint64, error)) *StorageMock_AcquireSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddAccumulatedInputHash provides a mock function with given fields: ctx, batchNum, accInputHash, dbTx
 func (_m *StorageMock) AddAccumulatedInputHash(ctx context.Context, batchNum uint64, accInputHash common.Hash, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNum, accInputHash, dbTx)
@@ -82,6 +141,54 @@ func (_c *StorageMock_AddAccumulatedInputHash_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// AddBatchClosingInfo provides a mock function with given fields: ctx, closingInfo, dbTx
+func (_m *StorageMock) AddBatchClosingInfo(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, closingInfo, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBatchClosingInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.BatchClosingInfo, pgx.Tx) error); ok {
+		r0 = rf(ctx, closingInfo, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddBatchClosingInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddBatchClosingInfo'
+type StorageMock_AddBatchClosingInfo_Call struct {
+	*mock.Call
+}
+
+// AddBatchClosingInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - closingInfo *state.BatchClosingInfo
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddBatchClosingInfo(ctx interface{}, closingInfo interface{}, dbTx interface{}) *StorageMock_AddBatchClosingInfo_Call {
+	return &StorageMock_AddBatchClosingInfo_Call{Call: _e.mock.On("AddBatchClosingInfo", ctx, closingInfo, dbTx)}
+}
+
+func (_c *StorageMock_AddBatchClosingInfo_Call) Run(run func(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx)) *StorageMock_AddBatchClosingInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*state.BatchClosingInfo), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddBatchClosingInfo_Call) Return(_a0 error) *StorageMock_AddBatchClosingInfo_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddBatchClosingInfo_Call) RunAndReturn(run func(context.Context, *state.BatchClosingInfo, pgx.Tx) error) *StorageMock_AddBatchClosingInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBatchProof provides a mock function with given fields: ctx, proof, dbTx
 func (_m *StorageMock) AddBatchProof(ctx context.Context, proof *state.Proof, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, proof, dbTx)
@@ -615,6 +722,54 @@ func (_c *StorageMock_AddL2Block_Call) RunAndReturn(run func(context.Context, ui
 	return _c
 }
 
+// AddL2BlockClosingInfo provides a mock function with given fields: ctx, closingInfo, dbTx
+func (_m *StorageMock) AddL2BlockClosingInfo(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, closingInfo, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddL2BlockClosingInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *state.L2BlockClosingInfo, pgx.Tx) error); ok {
+		r0 = rf(ctx, closingInfo, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddL2BlockClosingInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddL2BlockClosingInfo'
+type StorageMock_AddL2BlockClosingInfo_Call struct {
+	*mock.Call
+}
+
+// AddL2BlockClosingInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - closingInfo *state.L2BlockClosingInfo
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddL2BlockClosingInfo(ctx interface{}, closingInfo interface{}, dbTx interface{}) *StorageMock_AddL2BlockClosingInfo_Call {
+	return &StorageMock_AddL2BlockClosingInfo_Call{Call: _e.mock.On("AddL2BlockClosingInfo", ctx, closingInfo, dbTx)}
+}
+
+func (_c *StorageMock_AddL2BlockClosingInfo_Call) Run(run func(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx)) *StorageMock_AddL2BlockClosingInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*state.L2BlockClosingInfo), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddL2BlockClosingInfo_Call) Return(_a0 error) *StorageMock_AddL2BlockClosingInfo_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddL2BlockClosingInfo_Call) RunAndReturn(run func(context.Context, *state.L2BlockClosingInfo, pgx.Tx) error) *StorageMock_AddL2BlockClosingInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddLog provides a mock function with given fields: ctx, l, dbTx
 func (_m *StorageMock) AddLog(ctx context.Context, l *types.Log, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, l, dbTx)
@@ -760,6 +915,54 @@ func (_c *StorageMock_AddSequence_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// AddSequencerControlRequest provides a mock function with given fields: ctx, request, dbTx
+func (_m *StorageMock) AddSequencerControlRequest(ctx context.Context, request state.SequencerControlRequest, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, request, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSequencerControlRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, state.SequencerControlRequest, pgx.Tx) error); ok {
+		r0 = rf(ctx, request, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_AddSequencerControlRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSequencerControlRequest'
+type StorageMock_AddSequencerControlRequest_Call struct {
+	*mock.Call
+}
+
+// AddSequencerControlRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request state.SequencerControlRequest
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) AddSequencerControlRequest(ctx interface{}, request interface{}, dbTx interface{}) *StorageMock_AddSequencerControlRequest_Call {
+	return &StorageMock_AddSequencerControlRequest_Call{Call: _e.mock.On("AddSequencerControlRequest", ctx, request, dbTx)}
+}
+
+func (_c *StorageMock_AddSequencerControlRequest_Call) Run(run func(ctx context.Context, request state.SequencerControlRequest, dbTx pgx.Tx)) *StorageMock_AddSequencerControlRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(state.SequencerControlRequest), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_AddSequencerControlRequest_Call) Return(_a0 error) *StorageMock_AddSequencerControlRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_AddSequencerControlRequest_Call) RunAndReturn(run func(context.Context, state.SequencerControlRequest, pgx.Tx) error) *StorageMock_AddSequencerControlRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddTrustedReorg provides a mock function with given fields: ctx, reorg, dbTx
 func (_m *StorageMock) AddTrustedReorg(ctx context.Context, reorg *state.TrustedReorg, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, reorg, dbTx)
@@ -904,6 +1107,64 @@ func (_c *StorageMock_AddVirtualBatch_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// BackfillLogBloomIndex provides a mock function with given fields: ctx, maxBlocks, dbTx
+func (_m *StorageMock) BackfillLogBloomIndex(ctx context.Context, maxBlocks uint64, dbTx pgx.Tx) (bool, error) {
+	ret := _m.Called(ctx, maxBlocks, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillLogBloomIndex")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (bool, error)); ok {
+		return rf(ctx, maxBlocks, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) bool); ok {
+		r0 = rf(ctx, maxBlocks, dbTx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, maxBlocks, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_BackfillLogBloomIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BackfillLogBloomIndex'
+type StorageMock_BackfillLogBloomIndex_Call struct {
+	*mock.Call
+}
+
+// BackfillLogBloomIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - maxBlocks uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) BackfillLogBloomIndex(ctx interface{}, maxBlocks interface{}, dbTx interface{}) *StorageMock_BackfillLogBloomIndex_Call {
+	return &StorageMock_BackfillLogBloomIndex_Call{Call: _e.mock.On("BackfillLogBloomIndex", ctx, maxBlocks, dbTx)}
+}
+
+func (_c *StorageMock_BackfillLogBloomIndex_Call) Run(run func(ctx context.Context, maxBlocks uint64, dbTx pgx.Tx)) *StorageMock_BackfillLogBloomIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_BackfillLogBloomIndex_Call) Return(_a0 bool, _a1 error) *StorageMock_BackfillLogBloomIndex_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_BackfillLogBloomIndex_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (bool, error)) *StorageMock_BackfillLogBloomIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BatchNumberByL2BlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StorageMock) BatchNumberByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -1078,6 +1339,55 @@ func (_c *StorageMock_CheckProofContainsCompleteSequences_Call) RunAndReturn(run
 	return _c
 }
 
+// CheckSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, dbTx
+func (_m *StorageMock) CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_CheckSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckSequencerLease'
+type StorageMock_CheckSequencerLease_Call struct {
+	*mock.Call
+}
+
+// CheckSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - fencingToken uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) CheckSequencerLease(ctx interface{}, holder interface{}, fencingToken interface{}, dbTx interface{}) *StorageMock_CheckSequencerLease_Call {
+	return &StorageMock_CheckSequencerLease_Call{Call: _e.mock.On("CheckSequencerLease", ctx, holder, fencingToken, dbTx)}
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Run(run func(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx)) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) Return(_a0 error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_CheckSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, pgx.Tx) error) *StorageMock_CheckSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanupBatchProofs provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) CleanupBatchProofs(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -1861,6 +2171,66 @@ func (_c *StorageMock_GetBatchByTxHash_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// GetBatchClosingInfo provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) GetBatchClosingInfo(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.BatchClosingInfo, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchClosingInfo")
+	}
+
+	var r0 *state.BatchClosingInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.BatchClosingInfo, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.BatchClosingInfo); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.BatchClosingInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetBatchClosingInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatchClosingInfo'
+type StorageMock_GetBatchClosingInfo_Call struct {
+	*mock.Call
+}
+
+// GetBatchClosingInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetBatchClosingInfo(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_GetBatchClosingInfo_Call {
+	return &StorageMock_GetBatchClosingInfo_Call{Call: _e.mock.On("GetBatchClosingInfo", ctx, batchNumber, dbTx)}
+}
+
+func (_c *StorageMock_GetBatchClosingInfo_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_GetBatchClosingInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetBatchClosingInfo_Call) Return(_a0 *state.BatchClosingInfo, _a1 error) *StorageMock_GetBatchClosingInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetBatchClosingInfo_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) (*state.BatchClosingInfo, error)) *StorageMock_GetBatchClosingInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBatchNumberOfL2Block provides a mock function with given fields: ctx, blockNumber, dbTx
 func (_m *StorageMock) GetBatchNumberOfL2Block(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	ret := _m.Called(ctx, blockNumber, dbTx)
@@ -5649,6 +6019,67 @@ func (_c *StorageMock_GetLogsByBlockNumber_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// GetLogsMaxBlockRange provides a mock function with given fields: ctx, fromBlock, toBlock, addresses, topics, dbTx
+func (_m *StorageMock) GetLogsMaxBlockRange(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogsMaxBlockRange")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, []common.Address, [][]common.Hash, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, []common.Address, [][]common.Hash, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, []common.Address, [][]common.Hash, pgx.Tx) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetLogsMaxBlockRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogsMaxBlockRange'
+type StorageMock_GetLogsMaxBlockRange_Call struct {
+	*mock.Call
+}
+
+// GetLogsMaxBlockRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - addresses []common.Address
+//   - topics [][]common.Hash
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetLogsMaxBlockRange(ctx interface{}, fromBlock interface{}, toBlock interface{}, addresses interface{}, topics interface{}, dbTx interface{}) *StorageMock_GetLogsMaxBlockRange_Call {
+	return &StorageMock_GetLogsMaxBlockRange_Call{Call: _e.mock.On("GetLogsMaxBlockRange", ctx, fromBlock, toBlock, addresses, topics, dbTx)}
+}
+
+func (_c *StorageMock_GetLogsMaxBlockRange_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx)) *StorageMock_GetLogsMaxBlockRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].([]common.Address), args[4].([][]common.Hash), args[5].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetLogsMaxBlockRange_Call) Return(_a0 uint64, _a1 error) *StorageMock_GetLogsMaxBlockRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetLogsMaxBlockRange_Call) RunAndReturn(run func(context.Context, uint64, uint64, []common.Address, [][]common.Hash, pgx.Tx) (uint64, error)) *StorageMock_GetLogsMaxBlockRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetNativeBlockHashesInRange provides a mock function with given fields: ctx, fromBlock, toBlock, dbTx
 func (_m *StorageMock) GetNativeBlockHashesInRange(ctx context.Context, fromBlock uint64, toBlock uint64, dbTx pgx.Tx) ([]common.Hash, error) {
 	ret := _m.Called(ctx, fromBlock, toBlock, dbTx)
@@ -6255,6 +6686,65 @@ func (_c *StorageMock_GetReorgedTransactions_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetSequencerControl provides a mock function with given fields: ctx, dbTx
+func (_m *StorageMock) GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*state.SequencerControl, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequencerControl")
+	}
+
+	var r0 *state.SequencerControl
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (*state.SequencerControl, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) *state.SequencerControl); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.SequencerControl)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageMock_GetSequencerControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSequencerControl'
+type StorageMock_GetSequencerControl_Call struct {
+	*mock.Call
+}
+
+// GetSequencerControl is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) GetSequencerControl(ctx interface{}, dbTx interface{}) *StorageMock_GetSequencerControl_Call {
+	return &StorageMock_GetSequencerControl_Call{Call: _e.mock.On("GetSequencerControl", ctx, dbTx)}
+}
+
+func (_c *StorageMock_GetSequencerControl_Call) Run(run func(ctx context.Context, dbTx pgx.Tx)) *StorageMock_GetSequencerControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_GetSequencerControl_Call) Return(_a0 *state.SequencerControl, _a1 error) *StorageMock_GetSequencerControl_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StorageMock_GetSequencerControl_Call) RunAndReturn(run func(context.Context, pgx.Tx) (*state.SequencerControl, error)) *StorageMock_GetSequencerControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSequences provides a mock function with given fields: ctx, lastVerifiedBatchNumber, dbTx
 func (_m *StorageMock) GetSequences(ctx context.Context, lastVerifiedBatchNumber uint64, dbTx pgx.Tx) ([]state.Sequence, error) {
 	ret := _m.Called(ctx, lastVerifiedBatchNumber, dbTx)
@@ -8275,6 +8765,105 @@ func (_c *StorageMock_QueryRow_Call) RunAndReturn(run func(context.Context, stri
 	return _c
 }
 
+// ReleaseSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, dbTx
+func (_m *StorageMock) ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_ReleaseSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseSequencerLease'
+type StorageMock_ReleaseSequencerLease_Call struct {
+	*mock.Call
+}
+
+// ReleaseSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - fencingToken uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) ReleaseSequencerLease(ctx interface{}, holder interface{}, fencingToken interface{}, dbTx interface{}) *StorageMock_ReleaseSequencerLease_Call {
+	return &StorageMock_ReleaseSequencerLease_Call{Call: _e.mock.On("ReleaseSequencerLease", ctx, holder, fencingToken, dbTx)}
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) Run(run func(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx)) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) Return(_a0 error) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_ReleaseSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, pgx.Tx) error) *StorageMock_ReleaseSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenewSequencerLease provides a mock function with given fields: ctx, holder, fencingToken, duration, dbTx
+func (_m *StorageMock) RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, holder, fencingToken, duration, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenewSequencerLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, time.Duration, pgx.Tx) error); ok {
+		r0 = rf(ctx, holder, fencingToken, duration, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_RenewSequencerLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenewSequencerLease'
+type StorageMock_RenewSequencerLease_Call struct {
+	*mock.Call
+}
+
+// RenewSequencerLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holder string
+//   - fencingToken uint64
+//   - duration time.Duration
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) RenewSequencerLease(ctx interface{}, holder interface{}, fencingToken interface{}, duration interface{}, dbTx interface{}) *StorageMock_RenewSequencerLease_Call {
+	return &StorageMock_RenewSequencerLease_Call{Call: _e.mock.On("RenewSequencerLease", ctx, holder, fencingToken, duration, dbTx)}
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Run(run func(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx)) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(time.Duration), args[4].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) Return(_a0 error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_RenewSequencerLease_Call) RunAndReturn(run func(context.Context, string, uint64, time.Duration, pgx.Tx) error) *StorageMock_RenewSequencerLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResetForkID provides a mock function with given fields: ctx, batchNumber, dbTx
 func (_m *StorageMock) ResetForkID(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batchNumber, dbTx)
@@ -8516,6 +9105,102 @@ func (_c *StorageMock_SetLastBatchInfoSeenOnEthereum_Call) RunAndReturn(run func
 	return _c
 }
 
+// SetSequencerHaltOnBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StorageMock) SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSequencerHaltOnBatchNumber")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_SetSequencerHaltOnBatchNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSequencerHaltOnBatchNumber'
+type StorageMock_SetSequencerHaltOnBatchNumber_Call struct {
+	*mock.Call
+}
+
+// SetSequencerHaltOnBatchNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchNumber uint64
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) SetSequencerHaltOnBatchNumber(ctx interface{}, batchNumber interface{}, dbTx interface{}) *StorageMock_SetSequencerHaltOnBatchNumber_Call {
+	return &StorageMock_SetSequencerHaltOnBatchNumber_Call{Call: _e.mock.On("SetSequencerHaltOnBatchNumber", ctx, batchNumber, dbTx)}
+}
+
+func (_c *StorageMock_SetSequencerHaltOnBatchNumber_Call) Run(run func(ctx context.Context, batchNumber uint64, dbTx pgx.Tx)) *StorageMock_SetSequencerHaltOnBatchNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_SetSequencerHaltOnBatchNumber_Call) Return(_a0 error) *StorageMock_SetSequencerHaltOnBatchNumber_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_SetSequencerHaltOnBatchNumber_Call) RunAndReturn(run func(context.Context, uint64, pgx.Tx) error) *StorageMock_SetSequencerHaltOnBatchNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSequencerPaused provides a mock function with given fields: ctx, paused, dbTx
+func (_m *StorageMock) SetSequencerPaused(ctx context.Context, paused bool, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, paused, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSequencerPaused")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, pgx.Tx) error); ok {
+		r0 = rf(ctx, paused, dbTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StorageMock_SetSequencerPaused_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSequencerPaused'
+type StorageMock_SetSequencerPaused_Call struct {
+	*mock.Call
+}
+
+// SetSequencerPaused is a helper method to define mock.On call
+//   - ctx context.Context
+//   - paused bool
+//   - dbTx pgx.Tx
+func (_e *StorageMock_Expecter) SetSequencerPaused(ctx interface{}, paused interface{}, dbTx interface{}) *StorageMock_SetSequencerPaused_Call {
+	return &StorageMock_SetSequencerPaused_Call{Call: _e.mock.On("SetSequencerPaused", ctx, paused, dbTx)}
+}
+
+func (_c *StorageMock_SetSequencerPaused_Call) Run(run func(ctx context.Context, paused bool, dbTx pgx.Tx)) *StorageMock_SetSequencerPaused_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bool), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *StorageMock_SetSequencerPaused_Call) Return(_a0 error) *StorageMock_SetSequencerPaused_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StorageMock_SetSequencerPaused_Call) RunAndReturn(run func(context.Context, bool, pgx.Tx) error) *StorageMock_SetSequencerPaused_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StoreGenesisBatch provides a mock function with given fields: ctx, batch, closingReason, dbTx
 func (_m *StorageMock) StoreGenesisBatch(ctx context.Context, batch state.Batch, closingReason string, dbTx pgx.Tx) error {
 	ret := _m.Called(ctx, batch, closingReason, dbTx)
@@ -0,0 +1,124 @@
+package pgstatestorage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddBatchClosingInfo stores the report of the sequencer about the closing of a batch
+func (p *PostgresStorage) AddBatchClosingInfo(ctx context.Context, closingInfo *state.BatchClosingInfo, dbTx pgx.Tx) error {
+	const addBatchClosingInfoSQL = `
+        INSERT INTO state.batch_closing_info (batch_num, exhausted_resource, l2_blocks, txs, skipped_txs, node_ooc_txs, closed_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addBatchClosingInfoSQL, closingInfo.BatchNumber, closingInfo.ExhaustedResource, closingInfo.L2Blocks,
+		closingInfo.Txs, closingInfo.SkippedTxs, closingInfo.NodeOOCTxs, closingInfo.ClosedAt)
+	return err
+}
+
+// AddL2BlockClosingInfo stores the report of the sequencer about the closing of a L2 block
+func (p *PostgresStorage) AddL2BlockClosingInfo(ctx context.Context, closingInfo *state.L2BlockClosingInfo, dbTx pgx.Tx) error {
+	const addL2BlockClosingInfoSQL = `
+        INSERT INTO state.l2block_closing_info (block_num, batch_num, closing_reason, txs, processed_txs, used_resources, times)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	usedResources, err := json.Marshal(closingInfo.UsedResources)
+	if err != nil {
+		return err
+	}
+	times, err := json.Marshal(closingInfo.Times)
+	if err != nil {
+		return err
+	}
+
+	e := p.getExecQuerier(dbTx)
+	_, err = e.Exec(ctx, addL2BlockClosingInfoSQL, closingInfo.L2BlockNumber, closingInfo.BatchNumber, closingInfo.ClosingReason,
+		closingInfo.Txs, closingInfo.ProcessedTxs, string(usedResources), string(times))
+	return err
+}
+
+// GetBatchClosingInfo returns the report of the sequencer about the closing of a batch, including the reports of its L2 blocks
+func (p *PostgresStorage) GetBatchClosingInfo(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (*state.BatchClosingInfo, error) {
+	const getBatchClosingInfoSQL = `
+        SELECT b.batch_num, COALESCE(b.closing_reason, ''), COALESCE(c.exhausted_resource, ''), b.batch_resources, b.high_reserved_counters,
+               c.l2_blocks, c.txs, c.skipped_txs, c.node_ooc_txs, b.timestamp, c.closed_at
+          FROM state.batch_closing_info c
+          JOIN state.batch b ON b.batch_num = c.batch_num
+         WHERE c.batch_num = $1`
+
+	const getL2BlocksClosingInfoSQL = `
+        SELECT block_num, batch_num, closing_reason, txs, processed_txs, used_resources, times
+          FROM state.l2block_closing_info
+         WHERE batch_num = $1
+         ORDER BY block_num ASC`
+
+	var (
+		closingInfo          state.BatchClosingInfo
+		closingReason        string
+		usedResources        []byte
+		highReservedCounters []byte
+	)
+
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getBatchClosingInfoSQL, batchNumber).Scan(&closingInfo.BatchNumber, &closingReason, &closingInfo.ExhaustedResource,
+		&usedResources, &highReservedCounters, &closingInfo.L2Blocks, &closingInfo.Txs, &closingInfo.SkippedTxs, &closingInfo.NodeOOCTxs,
+		&closingInfo.OpenedAt, &closingInfo.ClosedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, state.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	closingInfo.ClosingReason = state.ClosingReason(closingReason)
+
+	if usedResources != nil {
+		if err := json.Unmarshal(usedResources, &closingInfo.UsedResources); err != nil {
+			return nil, err
+		}
+	}
+	if highReservedCounters != nil {
+		if err := json.Unmarshal(highReservedCounters, &closingInfo.HighReservedZKCounters); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := e.Query(ctx, getL2BlocksClosingInfoSQL, batchNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closingInfo.L2BlocksClosingInfo = []state.L2BlockClosingInfo{}
+	for rows.Next() {
+		var (
+			l2BlockClosingInfo state.L2BlockClosingInfo
+			usedResources      []byte
+			times              []byte
+		)
+		err := rows.Scan(&l2BlockClosingInfo.L2BlockNumber, &l2BlockClosingInfo.BatchNumber, &l2BlockClosingInfo.ClosingReason,
+			&l2BlockClosingInfo.Txs, &l2BlockClosingInfo.ProcessedTxs, &usedResources, &times)
+		if err != nil {
+			return nil, err
+		}
+		if usedResources != nil {
+			if err := json.Unmarshal(usedResources, &l2BlockClosingInfo.UsedResources); err != nil {
+				return nil, err
+			}
+		}
+		if times != nil {
+			if err := json.Unmarshal(times, &l2BlockClosingInfo.Times); err != nil {
+				return nil, err
+			}
+		}
+		closingInfo.L2BlocksClosingInfo = append(closingInfo.L2BlocksClosingInfo, l2BlockClosingInfo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &closingInfo, nil
+}
@@ -264,6 +264,10 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 		p.AddLogs(ctx, logs, dbTx)
 	}
 
+	if err := p.addL2BlockLogBloom(ctx, l2blockNumber, l2Block.Bloom(), dbTx); err != nil {
+		return err
+	}
+
 	log.Debugf("[AddL2Block] added L2 block %d, time: %v\n%s", l2Block.NumberU64(), time.Since(start), logTxsL2Hash)
 	return nil
 }
@@ -0,0 +1,244 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// addL2BlockLogBloom sets the bit of the L2 block in the log bloom index
+// vectors of all the bits set in the logs bloom of the L2 block.
+//
+// Bits are never unset, if an L2 block is removed by a reorg its bits remain
+// in the index and can only produce false positives, which are discarded
+// when the logs are filtered.
+func (p *PostgresStorage) addL2BlockLogBloom(ctx context.Context, blockNumber uint64, bloom types.Bloom, dbTx pgx.Tx) error {
+	bits := state.LogBloomBits(bloom)
+	if len(bits) == 0 {
+		return nil
+	}
+
+	const addLogBloomBitsSQL = `
+        INSERT INTO state.log_bloom_bits (section, bit, vector)
+        SELECT $1, b, B'1'::BIT(4096) >> $2
+          FROM unnest($3::SMALLINT[]) AS b
+            ON CONFLICT (section, bit) DO UPDATE
+           SET vector = state.log_bloom_bits.vector | EXCLUDED.vector`
+
+	section := blockNumber / state.LogBloomSectionSize
+	offset := int(blockNumber % state.LogBloomSectionSize)
+
+	bitsArg := make([]int16, 0, len(bits))
+	for _, bit := range bits {
+		bitsArg = append(bitsArg, int16(bit))
+	}
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addLogBloomBitsSQL, section, offset, bitsArg)
+	return err
+}
+
+// BackfillLogBloomIndex indexes the logs bloom of the next maxBlocks L2 blocks
+// stored before the log bloom index was created, returns true if there are
+// still blocks pending to be indexed
+func (p *PostgresStorage) BackfillLogBloomIndex(ctx context.Context, maxBlocks uint64, dbTx pgx.Tx) (bool, error) {
+	const getBackfillSQL = "SELECT next_block, end_block FROM state.log_bloom_backfill FOR UPDATE"
+	const backfillSQL = `
+        INSERT INTO state.log_bloom_bits (section, bit, vector)
+        SELECT b.block_num / 4096
+             , bits.bit
+             , bit_or(B'1'::BIT(4096) >> (b.block_num % 4096)::INT)
+          FROM state.l2block b
+         CROSS JOIN LATERAL (
+               SELECT decode(substring(b.header->>'logsBloom' FROM 3), 'hex') AS bloom
+         ) h
+         CROSS JOIN LATERAL (
+               SELECT i AS bit
+                 FROM generate_series(0, 2047) AS i
+                WHERE get_bit(h.bloom, (255 - i / 8) * 8 + i % 8) = 1
+         ) bits
+         WHERE b.block_num >= $1 AND b.block_num < $2
+           AND b.header->>'logsBloom' IS NOT NULL
+           AND length(b.header->>'logsBloom') = 514
+           AND b.header->>'logsBloom' != '0x' || repeat('0', 512)
+         GROUP BY 1, 2
+            ON CONFLICT (section, bit) DO UPDATE
+           SET vector = state.log_bloom_bits.vector | EXCLUDED.vector`
+	const updateBackfillSQL = "UPDATE state.log_bloom_backfill SET next_block = $1"
+
+	e := p.getExecQuerier(dbTx)
+	var nextBlock, endBlock uint64
+	err := e.QueryRow(ctx, getBackfillSQL).Scan(&nextBlock, &endBlock)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if nextBlock >= endBlock {
+		return false, nil
+	}
+
+	toBlock := nextBlock + maxBlocks
+	if toBlock > endBlock {
+		toBlock = endBlock
+	}
+	if _, err := e.Exec(ctx, backfillSQL, nextBlock, toBlock); err != nil {
+		return false, err
+	}
+	if _, err := e.Exec(ctx, updateBackfillSQL, toBlock); err != nil {
+		return false, err
+	}
+	return toBlock < endBlock, nil
+}
+
+// GetLogsMaxBlockRange returns the max block range GetLogs allows for the filter
+// in the block range, MaxIndexedLogsBlockRange if it can use the log bloom index
+// or MaxLogsBlockRange otherwise
+func (p *PostgresStorage) GetLogsMaxBlockRange(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) (uint64, error) {
+	useLogBloomIndex, err := p.canUseLogBloomIndex(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+	if err != nil {
+		return 0, err
+	}
+	if useLogBloomIndex {
+		return p.cfg.MaxIndexedLogsBlockRange, nil
+	}
+	return p.cfg.MaxLogsBlockRange, nil
+}
+
+// canUseLogBloomIndex checks if the log bloom index can be used to get the logs
+// of the filter in the block range, the filter must be by address or topics and
+// the logs bloom of all the blocks in the range must be backfilled
+func (p *PostgresStorage) canUseLogBloomIndex(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) (bool, error) {
+	if !state.CanUseLogBloomIndex(addresses, topics) {
+		return false, nil
+	}
+	return p.isLogBloomIndexed(ctx, fromBlock, toBlock, dbTx)
+}
+
+// isLogBloomIndexed checks if the logs bloom of all the L2 blocks in the range
+// are in the log bloom index, the blocks pending to be backfilled are not
+func (p *PostgresStorage) isLogBloomIndexed(ctx context.Context, fromBlock, toBlock uint64, dbTx pgx.Tx) (bool, error) {
+	const isPendingSQL = `
+        SELECT EXISTS (
+               SELECT 1
+                 FROM state.log_bloom_backfill
+                WHERE next_block < end_block
+                  AND next_block <= $2
+                  AND end_block > $1)`
+
+	var pending bool
+	q := p.getExecQuerier(dbTx)
+	if err := q.QueryRow(ctx, isPendingSQL, fromBlock, toBlock).Scan(&pending); err != nil {
+		return false, err
+	}
+	return !pending, nil
+}
+
+// getL2BlockNumbersByLogFilter uses the log bloom index to return the L2 block
+// numbers in the range that may contain logs matching the addresses and topics
+func (p *PostgresStorage) getL2BlockNumbersByLogFilter(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address, topics [][]common.Hash, dbTx pgx.Tx) ([]uint64, error) {
+	groups := state.LogFilterBloomBits(addresses, topics)
+
+	// load only the vectors of the bits used by the filter
+	bitsArg := []int16{}
+	for _, group := range groups {
+		for _, item := range group {
+			for _, bit := range item {
+				bitsArg = append(bitsArg, int16(bit))
+			}
+		}
+	}
+
+	const getLogBloomBitsSQL = `
+        SELECT section, bit, vector
+          FROM state.log_bloom_bits
+         WHERE section BETWEEN $1 AND $2
+           AND bit = any($3)`
+
+	fromSection := fromBlock / state.LogBloomSectionSize
+	toSection := toBlock / state.LogBloomSectionSize
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getLogBloomBitsSQL, fromSection, toSection, bitsArg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vectors := map[uint64]map[uint16][]byte{}
+	for rows.Next() {
+		var section uint64
+		var bit int16
+		var vector pgtype.Bit
+		if err := rows.Scan(&section, &bit, &vector); err != nil {
+			return nil, err
+		}
+		if _, found := vectors[section]; !found {
+			vectors[section] = map[uint16][]byte{}
+		}
+		vectors[section][uint16(bit)] = vector.Bytes
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	blockNumbers := []uint64{}
+	for section := fromSection; section <= toSection; section++ {
+		sectionVectors, found := vectors[section]
+		if !found {
+			continue
+		}
+		matches := matchLogBloomSection(sectionVectors, groups)
+		for offset := uint64(0); offset < state.LogBloomSectionSize; offset++ {
+			if matches[offset/8]&(0x80>>(offset%8)) == 0 { // nolint:gomnd
+				continue
+			}
+			blockNumber := section*state.LogBloomSectionSize + offset
+			if blockNumber >= fromBlock && blockNumber <= toBlock {
+				blockNumbers = append(blockNumbers, blockNumber)
+			}
+		}
+	}
+
+	return blockNumbers, nil
+}
+
+// matchLogBloomSection returns a vector with the bits of the L2 blocks of the
+// section that match all the groups of the filter
+func matchLogBloomSection(vectors map[uint16][]byte, groups [][][state.LogBloomBitsPerValue]uint16) []byte {
+	const vectorLength = state.LogBloomSectionSize / 8
+
+	result := make([]byte, vectorLength)
+	for i := range result {
+		result[i] = 0xff
+	}
+
+	for _, group := range groups {
+		groupResult := make([]byte, vectorLength)
+		for _, item := range group {
+			itemResult := make([]byte, vectorLength)
+			copy(itemResult, result)
+			for _, bit := range item {
+				vector := vectors[bit]
+				for i := range itemResult {
+					if i < len(vector) {
+						itemResult[i] &= vector[i]
+					} else {
+						itemResult[i] = 0
+					}
+				}
+			}
+			for i := range groupResult {
+				groupResult[i] |= itemResult[i]
+			}
+		}
+		result = groupResult
+	}
+
+	return result
+}
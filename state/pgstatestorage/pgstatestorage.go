@@ -157,7 +157,7 @@ func (p *PostgresStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock
          AND (b.created_at >= $6 OR $6 IS NULL) `
 
 	const queryFilterByBlockHash = `AND b.block_hash = $7 `
-	const queryFilterByBlockNumbers = `AND b.block_num BETWEEN $7 AND $8 `
+	const queryFilterByBlockNumbers = `AND b.block_num BETWEEN $7 AND $8 AND (b.block_num = any($9) OR $9 IS NULL) `
 
 	const queryOrder = `ORDER BY b.block_num ASC, r.tx_index ASC, l.log_index ASC`
 
@@ -216,12 +216,35 @@ func (p *PostgresStorage) GetLogs(ctx context.Context, fromBlock uint64, toBlock
 			return nil, state.ErrInvalidBlockRange
 		}
 
+		useLogBloomIndex, err := p.canUseLogBloomIndex(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		maxBlockRange := p.cfg.MaxLogsBlockRange
+		if useLogBloomIndex {
+			maxBlockRange = p.cfg.MaxIndexedLogsBlockRange
+		}
+
 		blockRange := toBlock - fromBlock
-		if p.cfg.MaxLogsBlockRange > 0 && blockRange > p.cfg.MaxLogsBlockRange {
+		if maxBlockRange > 0 && blockRange > maxBlockRange {
 			return nil, state.ErrMaxLogsBlockRangeLimitExceeded
 		}
 
 		args = append(args, fromBlock, toBlock)
+
+		// prune the blocks that can't contain logs matching the filter
+		if useLogBloomIndex {
+			blockNumbers, err := p.getL2BlockNumbersByLogFilter(ctx, fromBlock, toBlock, addresses, topics, dbTx)
+			if err != nil {
+				return nil, err
+			}
+			if len(blockNumbers) == 0 {
+				return []*types.Log{}, nil
+			}
+			args = append(args, blockNumbers)
+		} else {
+			args = append(args, nil)
+		}
 		queryToCount = queryToCountLogsByBlockNumbers
 		queryToSelect = queryToSelectLogsByBlockNumbers
 	}
@@ -1766,3 +1766,113 @@ func TestGetUncheckedBlocks(t *testing.T) {
 	require.Equal(t, uint64(blockNumber+1), blocks[0].BlockNumber)
 	require.Equal(t, uint64(blockNumber+3), blocks[1].BlockNumber)
 }
+
+func TestSequencerLease(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	leaseDuration := time.Minute
+
+	// the first sequencer acquires the free lease
+	token1, err := testState.AcquireSequencerLease(ctx, "sequencer-1", leaseDuration, nil)
+	require.NoError(t, err)
+	require.NoError(t, testState.RenewSequencerLease(ctx, "sequencer-1", token1, leaseDuration, nil))
+	require.NoError(t, testState.CheckSequencerLease(ctx, "sequencer-1", token1, nil))
+
+	// the lease is not acquired by another sequencer until it expires
+	_, err = testState.AcquireSequencerLease(ctx, "sequencer-2", leaseDuration, nil)
+	require.ErrorIs(t, err, state.ErrSequencerLeaseNotHeld)
+
+	require.NoError(t, testState.ReleaseSequencerLease(ctx, "sequencer-1", token1, nil))
+	token2, err := testState.AcquireSequencerLease(ctx, "sequencer-2", leaseDuration, nil)
+	require.NoError(t, err)
+	require.Greater(t, token2, token1)
+
+	// the previous holder is fenced off
+	require.ErrorIs(t, testState.RenewSequencerLease(ctx, "sequencer-1", token1, leaseDuration, nil), state.ErrSequencerLeaseNotHeld)
+	require.ErrorIs(t, testState.CheckSequencerLease(ctx, "sequencer-1", token1, nil), state.ErrSequencerLeaseNotHeld)
+	require.NoError(t, testState.CheckSequencerLease(ctx, "sequencer-2", token2, nil))
+}
+
+func TestSequencerControl(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+
+	require.NoError(t, testState.SetSequencerPaused(ctx, true, nil))
+	require.NoError(t, testState.SetSequencerHaltOnBatchNumber(ctx, 100, nil))
+	require.NoError(t, testState.AddSequencerControlRequest(ctx, state.CloseBatchRequest, nil))
+	require.NoError(t, testState.AddSequencerControlRequest(ctx, state.CloseBatchRequest, nil))
+	require.NoError(t, testState.AddSequencerControlRequest(ctx, state.StopRequest, nil))
+
+	control, err := testState.GetSequencerControl(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, control.Paused)
+	assert.Equal(t, uint64(100), control.HaltOnBatchNumber)
+	assert.Equal(t, uint64(0), control.CloseL2BlockRequests)
+	assert.Equal(t, uint64(2), control.CloseBatchRequests)
+	assert.Equal(t, uint64(1), control.StopRequests)
+}
+
+func TestBatchClosingInfo(t *testing.T) {
+	initOrResetDB()
+	ctx := context.Background()
+	dbTx, err := testState.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, dbTx.Commit(ctx)) }()
+
+	const batchNumber = uint64(1)
+	_, err = testState.GetBatchClosingInfo(ctx, batchNumber, dbTx)
+	require.ErrorIs(t, err, state.ErrNotFound)
+
+	// add batch and l2 blocks
+	err = testState.AddBlock(ctx, state.NewBlock(1), dbTx)
+	require.NoError(t, err)
+	_, err = testState.Exec(ctx, "INSERT INTO state.batch (batch_num, closing_reason, batch_resources, wip) VALUES ($1, $2, $3, FALSE)",
+		batchNumber, state.ResourceMarginExhaustedClosingReason, `{"Bytes": 1000, "ZKCounters": {"Steps": 7000000}}`)
+	require.NoError(t, err)
+	for _, l2BlockNumber := range []uint64{2, 1} {
+		l2Block := state.NewL2BlockWithHeader(state.NewL2Header(&types.Header{Number: new(big.Int).SetUint64(l2BlockNumber)}))
+		err = testState.AddL2Block(ctx, batchNumber, l2Block, []*types.Receipt{}, []common.Hash{}, []state.StoreTxEGPData{}, []common.Hash{}, dbTx)
+		require.NoError(t, err)
+
+		err = testState.AddL2BlockClosingInfo(ctx, &state.L2BlockClosingInfo{
+			L2BlockNumber: l2BlockNumber,
+			BatchNumber:   batchNumber,
+			ClosingReason: "max_txs",
+			Txs:           10,
+			ProcessedTxs:  12,
+			UsedResources: state.BatchResources{Bytes: 500, ZKCounters: state.ZKCounters{Steps: 3500000}},
+			Times:         state.L2BlockProcessTimes{Total: 3 * time.Second, ExecutorTxs: time.Second},
+		}, dbTx)
+		require.NoError(t, err)
+	}
+
+	closedAt := time.Now().UTC().Truncate(time.Second)
+	err = testState.AddBatchClosingInfo(ctx, &state.BatchClosingInfo{
+		BatchNumber:       batchNumber,
+		ExhaustedResource: "Steps",
+		L2Blocks:          2,
+		Txs:               20,
+		SkippedTxs:        3,
+		NodeOOCTxs:        1,
+		ClosedAt:          closedAt,
+	}, dbTx)
+	require.NoError(t, err)
+
+	closingInfo, err := testState.GetBatchClosingInfo(ctx, batchNumber, dbTx)
+	require.NoError(t, err)
+	assert.Equal(t, batchNumber, closingInfo.BatchNumber)
+	assert.Equal(t, state.ResourceMarginExhaustedClosingReason, closingInfo.ClosingReason)
+	assert.Equal(t, "Steps", closingInfo.ExhaustedResource)
+	assert.Equal(t, uint32(7000000), closingInfo.UsedResources.ZKCounters.Steps)
+	assert.Equal(t, uint64(20), closingInfo.Txs)
+	assert.Equal(t, uint64(3), closingInfo.SkippedTxs)
+	assert.Equal(t, uint64(1), closingInfo.NodeOOCTxs)
+	assert.Equal(t, closedAt, closingInfo.ClosedAt.UTC())
+	require.Len(t, closingInfo.L2BlocksClosingInfo, 2)
+	assert.Equal(t, uint64(1), closingInfo.L2BlocksClosingInfo[0].L2BlockNumber)
+	assert.Equal(t, uint64(2), closingInfo.L2BlocksClosingInfo[1].L2BlockNumber)
+	assert.Equal(t, "max_txs", closingInfo.L2BlocksClosingInfo[0].ClosingReason)
+	assert.Equal(t, uint64(12), closingInfo.L2BlocksClosingInfo[0].ProcessedTxs)
+	assert.Equal(t, uint32(3500000), closingInfo.L2BlocksClosingInfo[0].UsedResources.ZKCounters.Steps)
+	assert.Equal(t, 3*time.Second, closingInfo.L2BlocksClosingInfo[0].Times.Total)
+}
@@ -0,0 +1,65 @@
+package pgstatestorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetSequencerControl returns the runtime control of the sequencer requested by the operator
+func (p *PostgresStorage) GetSequencerControl(ctx context.Context, dbTx pgx.Tx) (*state.SequencerControl, error) {
+	const getSequencerControlSQL = `
+        SELECT paused, halt_on_batch_num, close_l2block_requests, close_batch_requests, stop_requests, updated_at
+          FROM state.sequencer_control
+         WHERE id = 1`
+
+	control := &state.SequencerControl{}
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, getSequencerControlSQL).Scan(&control.Paused, &control.HaltOnBatchNumber,
+		&control.CloseL2BlockRequests, &control.CloseBatchRequests, &control.StopRequests, &control.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return control, nil
+}
+
+// SetSequencerPaused pauses or resumes the selection of new txs by the sequencer
+func (p *PostgresStorage) SetSequencerPaused(ctx context.Context, paused bool, dbTx pgx.Tx) error {
+	const setSequencerPausedSQL = "UPDATE state.sequencer_control SET paused = $1, updated_at = NOW() WHERE id = 1"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, setSequencerPausedSQL, paused)
+	return err
+}
+
+// SetSequencerHaltOnBatchNumber sets the batch number where the sequencer halts, 0 to unset it
+func (p *PostgresStorage) SetSequencerHaltOnBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) error {
+	const setSequencerHaltOnBatchNumberSQL = "UPDATE state.sequencer_control SET halt_on_batch_num = $1, updated_at = NOW() WHERE id = 1"
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, setSequencerHaltOnBatchNumberSQL, batchNumber)
+	return err
+}
+
+// AddSequencerControlRequest adds a one-time request of the operator to the sequencer
+func (p *PostgresStorage) AddSequencerControlRequest(ctx context.Context, request state.SequencerControlRequest, dbTx pgx.Tx) error {
+	var column string
+	switch request {
+	case state.CloseL2BlockRequest:
+		column = "close_l2block_requests"
+	case state.CloseBatchRequest:
+		column = "close_batch_requests"
+	case state.StopRequest:
+		column = "stop_requests"
+	default:
+		return fmt.Errorf("unknown sequencer control request %s", request)
+	}
+
+	addSequencerControlRequestSQL := fmt.Sprintf("UPDATE state.sequencer_control SET %[1]s = %[1]s + 1, updated_at = NOW() WHERE id = 1", column)
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, addSequencerControlRequestSQL)
+	return err
+}
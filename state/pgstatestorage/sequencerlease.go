@@ -0,0 +1,86 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/jackc/pgx/v4"
+)
+
+// AcquireSequencerLease acquires the sequencer leader lease for the holder if it is free,
+// has expired or was already held by the holder, and returns the new fencing token.
+// It returns state.ErrSequencerLeaseNotHeld if the lease is held by another sequencer
+func (p *PostgresStorage) AcquireSequencerLease(ctx context.Context, holder string, duration time.Duration, dbTx pgx.Tx) (uint64, error) {
+	const acquireSequencerLeaseSQL = `
+        INSERT INTO state.sequencer_lease (id, holder, fencing_token, expires_at)
+        VALUES (1, $1, 1, NOW() + $2 * INTERVAL '1 millisecond')
+            ON CONFLICT (id) DO UPDATE
+           SET holder = EXCLUDED.holder
+             , fencing_token = state.sequencer_lease.fencing_token + 1
+             , expires_at = EXCLUDED.expires_at
+         WHERE state.sequencer_lease.holder = EXCLUDED.holder
+            OR state.sequencer_lease.expires_at <= NOW()
+     RETURNING fencing_token`
+
+	var fencingToken uint64
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, acquireSequencerLeaseSQL, holder, duration.Milliseconds()).Scan(&fencingToken)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, state.ErrSequencerLeaseNotHeld
+	} else if err != nil {
+		return 0, err
+	}
+	return fencingToken, nil
+}
+
+// RenewSequencerLease extends the sequencer leader lease of the holder. It returns
+// state.ErrSequencerLeaseNotHeld if the lease has expired or the fencing token has changed
+func (p *PostgresStorage) RenewSequencerLease(ctx context.Context, holder string, fencingToken uint64, duration time.Duration, dbTx pgx.Tx) error {
+	const renewSequencerLeaseSQL = `
+        UPDATE state.sequencer_lease
+           SET expires_at = NOW() + $3 * INTERVAL '1 millisecond'
+         WHERE holder = $1 AND fencing_token = $2 AND expires_at > NOW()`
+
+	e := p.getExecQuerier(dbTx)
+	result, err := e.Exec(ctx, renewSequencerLeaseSQL, holder, fencingToken, duration.Milliseconds())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return state.ErrSequencerLeaseNotHeld
+	}
+	return nil
+}
+
+// CheckSequencerLease checks the holder still holds the sequencer leader lease with the fencing
+// token. When called within the db tx storing the sequencer data, the lease row is locked so it
+// can not change hands until the db tx ends
+func (p *PostgresStorage) CheckSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	const checkSequencerLeaseSQL = `
+        SELECT 1 FROM state.sequencer_lease
+         WHERE holder = $1 AND fencing_token = $2 AND expires_at > NOW()
+           FOR SHARE`
+
+	var found int
+	e := p.getExecQuerier(dbTx)
+	err := e.QueryRow(ctx, checkSequencerLeaseSQL, holder, fencingToken).Scan(&found)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return state.ErrSequencerLeaseNotHeld
+	}
+	return err
+}
+
+// ReleaseSequencerLease expires the sequencer leader lease if it is held by the holder with the
+// fencing token, so a standby sequencer can acquire it without waiting for it to expire
+func (p *PostgresStorage) ReleaseSequencerLease(ctx context.Context, holder string, fencingToken uint64, dbTx pgx.Tx) error {
+	const releaseSequencerLeaseSQL = `
+        UPDATE state.sequencer_lease
+           SET expires_at = NOW()
+         WHERE holder = $1 AND fencing_token = $2`
+
+	e := p.getExecQuerier(dbTx)
+	_, err := e.Exec(ctx, releaseSequencerLeaseSQL, holder, fencingToken)
+	return err
+}
@@ -0,0 +1,44 @@
+package state
+
+import (
+	"fmt"
+	"time"
+)
+
+// SequencerControl is the runtime control of the sequencer requested by the operator
+type SequencerControl struct {
+	// Paused is true if the sequencer must not select new txs
+	Paused bool
+	// HaltOnBatchNumber is the batch number where the sequencer halts, 0 if it is not set
+	HaltOnBatchNumber uint64
+	// CloseL2BlockRequests is the number of requests to close the wip L2 block
+	CloseL2BlockRequests uint64
+	// CloseBatchRequests is the number of requests to close the wip batch
+	CloseBatchRequests uint64
+	// StopRequests is the number of requests to drain and stop the sequencer
+	StopRequests uint64
+	// UpdatedAt is the time of the last request
+	UpdatedAt time.Time
+}
+
+// SequencerControlRequest is a one-time request of the operator to the sequencer
+type SequencerControlRequest string
+
+const (
+	// CloseL2BlockRequest requests the sequencer to close the wip L2 block
+	CloseL2BlockRequest SequencerControlRequest = "close-l2block"
+	// CloseBatchRequest requests the sequencer to close the wip batch
+	CloseBatchRequest SequencerControlRequest = "close-batch"
+	// StopRequest requests the sequencer to close the wip batch, store the pending L2 blocks and halt
+	StopRequest SequencerControlRequest = "stop"
+)
+
+// ParseSequencerControlRequest returns the sequencer control request with the given name
+func ParseSequencerControlRequest(name string) (SequencerControlRequest, error) {
+	switch request := SequencerControlRequest(name); request {
+	case CloseL2BlockRequest, CloseBatchRequest, StopRequest:
+		return request, nil
+	default:
+		return "", fmt.Errorf("unknown sequencer control request %s", name)
+	}
+}
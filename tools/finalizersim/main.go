@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/sequencer"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+	"github.com/urfave/cli/v2"
+)
+
+// recordedTx is a pool tx stored in the recording file
+type recordedTx struct {
+	ReceivedAt         time.Time        `json:"receivedAt"`
+	Encoded            string           `json:"encoded"`
+	UsedZKCounters     state.ZKCounters `json:"usedZKCounters"`
+	ReservedZKCounters state.ZKCounters `json:"reservedZKCounters"`
+	IP                 string           `json:"ip"`
+}
+
+func main() {
+	// Create CLI app
+	app := cli.NewApp()
+	app.Usage = "Replay recorded pool txs through the sequencer finalizer to tune its configuration"
+	app.Commands = []*cli.Command{
+		{
+			Name:   "record",
+			Usage:  "Record the txs received by the pool in a time range",
+			Action: record,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "db",
+					Usage:    "Pool DB connection string: \"host=xxx port=xxx user=xxx dbname=xxx password=xxx\"",
+					Required: true,
+				},
+				&cli.TimestampFlag{
+					Name:     "from",
+					Usage:    "record the txs received from this time onwards (RFC3339)",
+					Layout:   time.RFC3339,
+					Required: true,
+				},
+				&cli.TimestampFlag{
+					Name:   "to",
+					Usage:  "record the txs received until this time (RFC3339, optional)",
+					Layout: time.RFC3339,
+				},
+				&cli.StringFlag{
+					Name:     "output",
+					Aliases:  []string{"o"},
+					Usage:    "recording file",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "run",
+			Usage:  "Simulate the finalizer with the recorded txs for each candidate configuration",
+			Action: run,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "input",
+					Aliases:  []string{"i"},
+					Usage:    "recording file",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:     "cfg",
+					Aliases:  []string{"c"},
+					Usage:    "candidate configuration file, it can be set several times to compare them",
+					Required: true,
+				},
+			},
+		},
+	}
+
+	// Run CLI app
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// record stores in the recording file the txs received by the pool in the time range
+func record(ctx *cli.Context) error {
+	from := ctx.Timestamp("from")
+	to := ctx.Timestamp("to")
+	if to == nil {
+		now := time.Now()
+		to = &now
+	}
+
+	// Connect to DB
+	config, err := pgx.ParseConfig(ctx.String("db") + " sslmode=disable")
+	if err != nil {
+		return fmt.Errorf("error setting connection to db: %v", err)
+	}
+	conn, err := pgx.ConnectConfig(context.Background(), config)
+	if err != nil {
+		return fmt.Errorf("error connecting to db: %v", err)
+	}
+	defer conn.Close(context.Background())
+
+	// Query data
+	const query = `
+		SELECT encoded, received_at, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings,
+			used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, reserved_zkcounters
+		FROM pool.transaction
+		WHERE received_at >= $1 AND received_at <= $2
+		ORDER BY received_at`
+
+	rows, err := conn.Query(context.Background(), query, *from, *to)
+	if err != nil {
+		return fmt.Errorf("error executing query: %v", err)
+	}
+	defer rows.Close()
+
+	txs := []recordedTx{}
+	for rows.Next() {
+		var tx recordedTx
+		used := &tx.UsedZKCounters
+		err = rows.Scan(&tx.Encoded, &tx.ReceivedAt, &tx.IP, &used.GasUsed, &used.KeccakHashes, &used.PoseidonHashes, &used.PoseidonPaddings,
+			&used.MemAligns, &used.Arithmetics, &used.Binaries, &used.Steps, &used.Sha256Hashes_V2, &tx.ReservedZKCounters)
+		if err != nil {
+			return fmt.Errorf("error fetching row: %v", err)
+		}
+		txs = append(txs, tx)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error fetching rows: %v", err)
+	}
+
+	data, err := json.MarshalIndent(txs, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(ctx.String("output"), data, 0644) // nolint:gomnd
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Recorded %d txs received from %v to %v in %s\n", len(txs), *from, *to, ctx.String("output"))
+	return nil
+}
+
+// run simulates the finalizer with the recorded txs for each candidate configuration and prints the reports
+func run(ctx *cli.Context) error {
+	// The simulation is run by the sequencer code, only the errors are logged
+	log.Init(log.Config{Environment: "production", Level: "error", Outputs: []string{"stderr"}})
+
+	txs, err := loadRecording(ctx.String("input"))
+	if err != nil {
+		return err
+	}
+
+	for _, cfgFile := range ctx.StringSlice("cfg") {
+		cfg, err := loadConfig(cfgFile)
+		if err != nil {
+			return fmt.Errorf("error loading config file %s: %v", cfgFile, err)
+		}
+
+		report, err := sequencer.Simulate(ctx.Context, *cfg, txs)
+		if err != nil {
+			return fmt.Errorf("error simulating config %s: %v", cfg.Name, err)
+		}
+		printReport(report)
+	}
+
+	return nil
+}
+
+// loadRecording loads the txs of the recording file
+func loadRecording(fileName string) ([]sequencer.SimulationTx, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	recordedTxs := []recordedTx{}
+	err = json.Unmarshal(data, &recordedTxs)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding recording file %s: %v", fileName, err)
+	}
+
+	txs := make([]sequencer.SimulationTx, 0, len(recordedTxs))
+	for _, recordedTx := range recordedTxs {
+		b, err := hex.DecodeHex(recordedTx.Encoded)
+		if err != nil {
+			return nil, err
+		}
+		var tx ethTypes.Transaction
+		if err := tx.UnmarshalBinary(b); err != nil {
+			return nil, fmt.Errorf("error decoding tx %s: %v", recordedTx.Encoded, err)
+		}
+
+		txs = append(txs, sequencer.SimulationTx{
+			ReceivedAt:         recordedTx.ReceivedAt,
+			Tx:                 tx,
+			UsedZKCounters:     recordedTx.UsedZKCounters,
+			ReservedZKCounters: recordedTx.ReservedZKCounters,
+			IP:                 recordedTx.IP,
+		})
+	}
+
+	return txs, nil
+}
+
+// defaultConfig returns the default values of a candidate configuration, they are the node defaults
+func defaultConfig() sequencer.SimulationCfg {
+	return sequencer.SimulationCfg{
+		Finalizer: sequencer.FinalizerCfg{
+			NewTxsWaitInterval:         types.NewDuration(100 * time.Millisecond), // nolint:gomnd
+			BatchMaxDeltaTimestamp:     types.NewDuration(1800 * time.Second),     // nolint:gomnd
+			L2BlockMaxDeltaTimestamp:   types.NewDuration(3 * time.Second),        // nolint:gomnd
+			ResourceExhaustedMarginPct: 10,                                        // nolint:gomnd
+		},
+		TxOrdering: sequencer.TxOrderingCfg{
+			Policy: sequencer.TxOrderingGasPrice,
+		},
+		BatchConstraints: state.BatchConstraintsCfg{
+			MaxTxsPerBatch:       300,              // nolint:gomnd
+			MaxBatchBytesSize:    120000,           // nolint:gomnd
+			MaxCumulativeGasUsed: 1125899906842624, // nolint:gomnd
+			MaxKeccakHashes:      2145,             // nolint:gomnd
+			MaxPoseidonHashes:    252357,           // nolint:gomnd
+			MaxPoseidonPaddings:  135191,           // nolint:gomnd
+			MaxMemAligns:         236585,           // nolint:gomnd
+			MaxArithmetics:       236585,           // nolint:gomnd
+			MaxBinaries:          473170,           // nolint:gomnd
+			MaxSteps:             7570538,          // nolint:gomnd
+			MaxSHA256Hashes:      1596,             // nolint:gomnd
+		},
+		TxExecutionTime: types.NewDuration(20 * time.Millisecond), // nolint:gomnd
+	}
+}
+
+// loadConfig loads a candidate configuration file, the values not set in the file take the default value
+func loadConfig(configFilePath string) (*sequencer.SimulationCfg, error) {
+	cfg := defaultConfig()
+
+	dirName, fileName := filepath.Split(configFilePath)
+	fileExtension := strings.TrimPrefix(filepath.Ext(fileName), ".")
+	fileNameWithoutExtension := strings.TrimSuffix(fileName, "."+fileExtension)
+
+	v := viper.New()
+	v.AddConfigPath(dirName)
+	v.SetConfigName(fileNameWithoutExtension)
+	v.SetConfigType(fileExtension)
+
+	err := v.ReadInConfig()
+	if err != nil {
+		_, ok := err.(viper.ConfigFileNotFoundError)
+		if ok {
+			return nil, errors.New("config file not found")
+		}
+		return nil, err
+	}
+
+	decodeHooks := []viper.DecoderConfigOption{
+		// this allows arrays to be decoded from env var separated by ",", example: MY_VAR="value1,value2,value3"
+		viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(","))),
+	}
+
+	err = v.Unmarshal(&cfg, decodeHooks...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Name == "" {
+		cfg.Name = fileNameWithoutExtension
+	}
+
+	return &cfg, nil
+}
+
+// printReport prints the simulation report of a candidate configuration
+func printReport(report *sequencer.SimulationReport) {
+	fmt.Printf("\nSIMULATION [%s]:\n", report.Name)
+	fmt.Printf("Duration.........: %v\n", report.Duration)
+	fmt.Printf("Txs..............: [%d]\n", report.Txs)
+	fmt.Printf("  Included.......: [%d] (%.2f%%)\n", report.IncludedTxs, pct(report.IncludedTxs, report.Txs))
+	fmt.Printf("  Invalid........: [%d] (%.2f%%)\n", report.InvalidTxs, pct(report.InvalidTxs, report.Txs))
+	fmt.Printf("  Dropped........: [%d] (%.2f%%)\n", report.DroppedTxs, pct(report.DroppedTxs, report.Txs))
+	fmt.Printf("  Pending........: [%d] (%.2f%%)\n", report.PendingTxs, pct(report.PendingTxs, report.Txs))
+	fmt.Printf("Batches..........: [%d]\n", report.Batches)
+
+	closingReasons := make([]string, 0, len(report.ClosingReasons))
+	for closingReason := range report.ClosingReasons {
+		closingReasons = append(closingReasons, string(closingReason))
+	}
+	sort.Strings(closingReasons)
+	for _, closingReason := range closingReasons {
+		count := report.ClosingReasons[state.ClosingReason(closingReason)]
+		fmt.Printf("  %s: [%d] (%.2f%%)\n", closingReason, count, pct(count, report.Batches))
+	}
+
+	fmt.Printf("L2 blocks........: [%d]\n", report.L2Blocks)
+	fmt.Printf("  Empty..........: [%d] (%.2f%%)\n", report.EmptyL2Blocks, pct(report.EmptyL2Blocks, report.L2Blocks))
+	fmt.Printf("Tx latency.......: p50 %v, p90 %v, p99 %v, max %v\n", report.TxLatency.P50, report.TxLatency.P90, report.TxLatency.P99, report.TxLatency.Max)
+	fmt.Printf("Batch resources..:\n")
+	for _, resource := range report.Resources {
+		fmt.Printf("  %-18s avg %6.2f%%, max %6.2f%%\n", resource.Resource, resource.AvgPct, resource.MaxPct)
+	}
+}
+
+// pct returns the percentage of value in total
+func pct(value, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) * 100 / float64(total) // nolint:gomnd
+}